@@ -13,10 +13,13 @@ import (
 
 	"github.com/gardener/gardener/cmd/gardenadm/app"
 	"github.com/gardener/gardener/cmd/utils"
+	"github.com/gardener/gardener/pkg/gardenlet/features"
 )
 
 func main() {
 	utils.DeduplicateWarnings()
+	// gardenadm reuses the component deployers of gardenlet which evaluate its feature gates.
+	features.RegisterFeatureGates()
 
 	if err := app.NewCommand().ExecuteContext(signals.SetupSignalHandler()); err != nil {
 		os.Exit(1)
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"maps"
 	"net"
 	"net/http"
 	"os"
@@ -48,6 +49,7 @@ import (
 	"github.com/gardener/gardener/pkg/nodeagent/bootstrap"
 	"github.com/gardener/gardener/pkg/nodeagent/controller"
 	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+	"github.com/gardener/gardener/pkg/nodeagent/debug"
 	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
 )
 
@@ -76,7 +78,7 @@ func NewCommand() *cobra.Command {
 	verflag.AddFlags(flags)
 	opts.addFlags(flags)
 
-	cmd.AddCommand(getBootstrapCommand(opts))
+	cmd.AddCommand(getBootstrapCommand(opts), getPlanCommand(opts))
 	return cmd
 }
 
@@ -115,14 +117,22 @@ func run(ctx context.Context, cancel context.CancelFunc, log logr.Logger, cfg *n
 		return fmt.Errorf("failed getting REST config: %w", err)
 	}
 
-	var extraHandlers map[string]http.Handler
+	extraHandlers := map[string]http.Handler{}
 	if cfg.Debugging != nil && ptr.Deref(cfg.Debugging.EnableProfiling, false) {
-		extraHandlers = routes.ProfilingHandlers
+		maps.Copy(extraHandlers, routes.ProfilingHandlers)
 		if ptr.Deref(cfg.Debugging.EnableContentionProfiling, false) {
 			goruntime.SetBlockProfileRate(1)
 		}
 	}
 
+	// The debug endpoints are served by the metrics server. They are registered at the mux when the controllers are
+	// added to the manager.
+	var debugMux *http.ServeMux
+	if ptr.Deref(cfg.Server.EnableDebugHandlers, false) {
+		debugMux = http.NewServeMux()
+		extraHandlers[debug.PathPrefix+"/"] = debugMux
+	}
+
 	log.Info("Fetching hostname")
 	hostName, err := nodeagent.GetHostName()
 	if err != nil {
@@ -203,7 +213,7 @@ func run(ctx context.Context, cancel context.CancelFunc, log logr.Logger, cfg *n
 		},
 		ActualRunnables: []manager.Runnable{
 			manager.RunnableFunc(func(ctx context.Context) error {
-				return controller.AddToManager(ctx, cancel, mgr, cfg, hostName, machineName, nodeName)
+				return controller.AddToManager(ctx, cancel, mgr, cfg, hostName, machineName, nodeName, debugMux)
 			}),
 		},
 	}); err != nil {
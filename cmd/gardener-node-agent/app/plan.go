@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/component-base/version/verflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener/cmd/utils/initrun"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+)
+
+var outputFormats = []string{outputFormatText, outputFormatJSON, outputFormatYAML}
+
+type planOptions struct {
+	*options
+	output string
+}
+
+var _ initrun.Options = &planOptions{}
+
+func (o *planOptions) addFlags(fs *pflag.FlagSet) {
+	o.options.addFlags(fs)
+	fs.StringVarP(&o.output, "output", "o", outputFormatText, fmt.Sprintf("Output format of the plan, one of %v.", outputFormats))
+}
+
+func (o *planOptions) Validate() error {
+	if !slices.Contains(outputFormats, o.output) {
+		return fmt.Errorf("unsupported output format %q, must be one of %v", o.output, outputFormats)
+	}
+	return o.options.Validate()
+}
+
+func getPlanCommand(opts *options) *cobra.Command {
+	planOpts := &planOptions{options: opts}
+
+	planCmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Show the changes the " + Name + " would apply to this node",
+		Long: "Compute the changes of files, units, unit commands and containerd registries between the last applied " +
+			"operating system config on disk and the current one in the cluster, without touching the node.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			log, err := initrun.InitRun(cmd, planOpts, Name)
+			if err != nil {
+				return err
+			}
+
+			if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
+				planOpts.config.ClientConnection.Kubeconfig = kubeconfig
+			}
+
+			fs := afero.Afero{Fs: afero.NewOsFs()}
+			log.Info("Getting rest config")
+			restConfig, err := getReadOnlyRESTConfig(fs, planOpts.config)
+			if err != nil {
+				return fmt.Errorf("failed getting REST config: %w", err)
+			}
+
+			return plan(cmd.Context(), cmd.OutOrStdout(), fs, restConfig, planOpts.config, planOpts.output)
+		},
+	}
+
+	flags := planCmd.Flags()
+	verflag.AddFlags(flags)
+	planOpts.addFlags(flags)
+
+	return planCmd
+}
+
+func plan(ctx context.Context, w io.Writer, fs afero.Afero, restConfig *rest.Config, cfg *nodeagentconfigv1alpha1.NodeAgentConfiguration, output string) error {
+	c, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return fmt.Errorf("unable to create client: %w", err)
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: cfg.Controllers.OperatingSystemConfig.SecretName, Namespace: metav1.NamespaceSystem}}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+		return fmt.Errorf("failed reading operating system config secret %s: %w", client.ObjectKeyFromObject(secret), err)
+	}
+
+	p, err := operatingsystemconfig.ComputePlan(fs, secret)
+	if err != nil {
+		return err
+	}
+
+	return writePlan(w, p, output)
+}
+
+func writePlan(w io.Writer, p *operatingsystemconfig.Plan, output string) error {
+	switch output {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	case outputFormatYAML:
+		out, err := yaml.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("failed marshalling plan into YAML: %w", err)
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return p.WriteText(w)
+	}
+}
+
+// getReadOnlyRESTConfig returns a REST config based on the existing credentials on the node. In contrast to
+// getRESTConfig, it neither requests nor migrates credentials.
+func getReadOnlyRESTConfig(fs afero.Afero, cfg *nodeagentconfigv1alpha1.NodeAgentConfiguration) (*rest.Config, error) {
+	if len(cfg.ClientConnection.Kubeconfig) > 0 {
+		return kubernetes.RESTConfigFromClientConnectionConfiguration(&cfg.ClientConnection, nil, kubernetes.AuthTokenFile)
+	}
+
+	if kubeconfigExists, err := fs.Exists(nodeagentconfigv1alpha1.KubeconfigFilePath); err != nil {
+		return nil, fmt.Errorf("failed checking whether kubeconfig file %q exists: %w", nodeagentconfigv1alpha1.KubeconfigFilePath, err)
+	} else if kubeconfigExists {
+		kubeconfig, err := fs.ReadFile(nodeagentconfigv1alpha1.KubeconfigFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading kubeconfig file %q: %w", nodeagentconfigv1alpha1.KubeconfigFilePath, err)
+		}
+		return kubernetes.RESTConfigFromKubeconfig(kubeconfig)
+	}
+
+	if tokenExists, err := fs.Exists(nodeagentconfigv1alpha1.TokenFilePath); err != nil {
+		return nil, fmt.Errorf("failed checking whether token file %q exists: %w", nodeagentconfigv1alpha1.TokenFilePath, err)
+	} else if tokenExists {
+		return &rest.Config{
+			Burst: int(cfg.ClientConnection.Burst),
+			QPS:   cfg.ClientConnection.QPS,
+			ContentConfig: rest.ContentConfig{
+				AcceptContentTypes: cfg.ClientConnection.AcceptContentTypes,
+				ContentType:        cfg.ClientConnection.ContentType,
+			},
+			Host:            cfg.APIServer.Server,
+			TLSClientConfig: rest.TLSClientConfig{CAData: cfg.APIServer.CABundle},
+			BearerTokenFile: nodeagentconfigv1alpha1.TokenFilePath,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unable to construct REST config (neither kubeconfig file %q nor token file %q exist)", nodeagentconfigv1alpha1.KubeconfigFilePath, nodeagentconfigv1alpha1.TokenFilePath)
+}
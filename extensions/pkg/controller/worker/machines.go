@@ -101,21 +101,27 @@ func WorkerPoolHash(pool extensionsv1alpha1.WorkerPool, cluster *extensionscontr
 }
 
 // WorkerPoolHashV1 returns a hash value for a given worker pool and a given cluster resource.
+// The Kubernetes version is not considered for worker pools whose Kubernetes version is updated in-place, see
+// v1beta1constants.KubernetesUpdateStrategyInPlace.
 func WorkerPoolHashV1(pool extensionsv1alpha1.WorkerPool, cluster *extensionscontroller.Cluster, additionalData ...string) (string, error) {
-	kubernetesVersion := cluster.Shoot.Spec.Kubernetes.Version
-	if pool.KubernetesVersion != nil {
-		kubernetesVersion = *pool.KubernetesVersion
-	}
-	shootVersionMajorMinor, err := util.VersionMajorMinor(kubernetesVersion)
-	if err != nil {
-		return "", err
+	var data []string
+
+	if !isKubernetesInPlaceUpdateEnabled(pool.Name, cluster) {
+		kubernetesVersion := cluster.Shoot.Spec.Kubernetes.Version
+		if pool.KubernetesVersion != nil {
+			kubernetesVersion = *pool.KubernetesVersion
+		}
+		shootVersionMajorMinor, err := util.VersionMajorMinor(kubernetesVersion)
+		if err != nil {
+			return "", err
+		}
+		data = append(data, shootVersionMajorMinor)
 	}
 
-	data := []string{
-		shootVersionMajorMinor,
+	data = append(data,
 		pool.MachineType,
-		pool.MachineImage.Name + pool.MachineImage.Version,
-	}
+		pool.MachineImage.Name+pool.MachineImage.Version,
+	)
 
 	if pool.Volume != nil {
 		data = append(data, pool.Volume.Size)
@@ -160,6 +166,15 @@ func WorkerPoolHashV1(pool extensionsv1alpha1.WorkerPool, cluster *extensionscon
 	return utils.ComputeSHA256Hex([]byte(result))[:5], nil
 }
 
+func isKubernetesInPlaceUpdateEnabled(poolName string, cluster *extensionscontroller.Cluster) bool {
+	for _, worker := range cluster.Shoot.Spec.Provider.Workers {
+		if worker.Name == poolName {
+			return helper.IsKubernetesInPlaceUpdateEnabled(&worker)
+		}
+	}
+	return false
+}
+
 // WorkerPoolHashV2 returns a hash value for a given nodeAgentSecretName and additional data.
 func WorkerPoolHashV2(nodeAgentSecretName string, additionalData ...string) (string, error) {
 	data := []string{nodeAgentSecretName}
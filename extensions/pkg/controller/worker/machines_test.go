@@ -191,6 +191,17 @@ var _ = Describe("Machines", func() {
 			It("when changing additional data for V2", func() {
 				additionalDataV2 = []string{"test"}
 			})
+
+			It("when changing the kubernetes major/minor version of a worker pool updated in-place", func() {
+				c.Shoot.Spec.Provider = gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{
+					{Name: "test-worker", Annotations: map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "InPlace"}}}}
+				var err error
+				hash, err = WorkerPoolHash(p, c, additionalDataV1, additionalDataV2)
+				Expect(err).NotTo(HaveOccurred())
+
+				p.KubernetesVersion = ptr.To("1.3.3")
+				c.Shoot.Spec.Kubernetes.Version = "1.3.3"
+			})
 		})
 
 		Context("hash value should change", func() {
@@ -242,6 +253,11 @@ var _ = Describe("Machines", func() {
 					{Name: "test-worker", CRI: &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD}}}}
 			})
 
+			It("when switching the worker pool to in-place Kubernetes updates", func() {
+				c.Shoot.Spec.Provider = gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{
+					{Name: "test-worker", Annotations: map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "InPlace"}}}}
+			})
+
 			It("when a shoot CA rotation is triggered", func() {
 				newRotationTime := metav1.Time{Time: lastCARotationInitiation.Add(time.Hour)}
 				c.Shoot.Status.Credentials.Rotation.CertificateAuthorities.LastInitiationTime = &newRotationTime
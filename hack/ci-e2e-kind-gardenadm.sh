@@ -20,6 +20,9 @@ trap "
   ( make kind-down )
 " EXIT
 
+# the garden cluster is required for connecting autonomous shoot clusters with `gardenadm connect`
+make gardener-up
 make gardenadm-high-touch-up gardenadm-medium-touch-up
 make test-e2e-local-gardenadm
 make gardenadm-high-touch-down gardenadm-medium-touch-down
+make gardener-down
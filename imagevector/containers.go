@@ -29,6 +29,8 @@ const (
 	ContainerImageNameCortex = "cortex"
 	// ContainerImageNameDependencyWatchdog is a constant for an image in the image vector with name 'dependency-watchdog'.
 	ContainerImageNameDependencyWatchdog = "dependency-watchdog"
+	// ContainerImageNameEtcd is a constant for an image in the image vector with name 'etcd'.
+	ContainerImageNameEtcd = "etcd"
 	// ContainerImageNameEtcdDruid is a constant for an image in the image vector with name 'etcd-druid'.
 	ContainerImageNameEtcdDruid = "etcd-druid"
 	// ContainerImageNameEventLogger is a constant for an image in the image vector with name 'event-logger'.
@@ -41,6 +43,8 @@ const (
 	ContainerImageNameFluentBitPluginInstaller = "fluent-bit-plugin-installer"
 	// ContainerImageNameFluentOperator is a constant for an image in the image vector with name 'fluent-operator'.
 	ContainerImageNameFluentOperator = "fluent-operator"
+	// ContainerImageNameGardenadm is a constant for an image in the image vector with name 'gardenadm'.
+	ContainerImageNameGardenadm = "gardenadm"
 	// ContainerImageNameGardenerAdmissionController is a constant for an image in the image vector with name 'gardener-admission-controller'.
 	ContainerImageNameGardenerAdmissionController = "gardener-admission-controller"
 	// ContainerImageNameGardenerApiserver is a constant for an image in the image vector with name 'gardener-apiserver'.
@@ -724,6 +724,25 @@ const (
 	LabelWorkerPoolSystemComponents = "worker.gardener.cloud/system-components"
 	// LabelWorkerPoolGardenerNodeAgentSecretName is the name of the secret used by the gardener node agent
 	LabelWorkerPoolGardenerNodeAgentSecretName = "worker.gardener.cloud/gardener-node-agent-secret-name"
+	// AnnotationWorkerKubernetesUpdateStrategy is a constant for an annotation on a worker pool in the Shoot
+	// specification which controls how Kubernetes version updates are rolled out to the nodes of the pool.
+	AnnotationWorkerKubernetesUpdateStrategy = "worker.gardener.cloud/kubernetes-update-strategy"
+	// KubernetesUpdateStrategyInPlace is a value for the AnnotationWorkerKubernetesUpdateStrategy annotation which
+	// indicates that gardener-node-agent updates the Kubernetes version of existing nodes in-place instead of rolling
+	// the machines. Hence, the Kubernetes version is not part of the worker pool hash and the name of the operating
+	// system config secret of such pools. This way, the machines of such pools are not rolled when the Kubernetes
+	// version changes. Note that adding or removing the annotation changes the worker pool hash, i.e., the machines of
+	// the pool are rolled once.
+	KubernetesUpdateStrategyInPlace = "InPlace"
+	// AnnotationWorkerCoordinatedReboots is a constant for an annotation on a worker pool in the Shoot specification
+	// which enables gardener-node-agent to cordon, drain and reboot the nodes of the pool in coordination with the other
+	// nodes of the pool if changes to the operating system config require a reboot. It requires the NodeAgentAuthorizer
+	// feature gate to be enabled.
+	AnnotationWorkerCoordinatedReboots = "worker.gardener.cloud/coordinated-reboots"
+	// AnnotationWorkerDirectImagePull is a constant for an annotation on a worker pool in the Shoot specification which
+	// enables gardener-node-agent to pull the images of files with an image reference directly from the registry
+	// instead of via containerd. The image references must be pinned by a digest.
+	AnnotationWorkerDirectImagePull = "worker.gardener.cloud/direct-image-pull"
 
 	// EventResourceReferenced indicates that the resource deletion is in waiting mode because the resource is still
 	// being referenced by at least one other resource (e.g. a SecretBinding is still referenced by a Shoot)
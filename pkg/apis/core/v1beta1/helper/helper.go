@@ -495,6 +495,24 @@ func SystemComponentsAllowed(worker *gardencorev1beta1.Worker) bool {
 	return worker.SystemComponents == nil || worker.SystemComponents.Allow
 }
 
+// IsKubernetesInPlaceUpdateEnabled checks if the Kubernetes version of the nodes of the given worker pool is updated
+// in-place by gardener-node-agent instead of rolling the machines.
+func IsKubernetesInPlaceUpdateEnabled(worker *gardencorev1beta1.Worker) bool {
+	return worker.Annotations[v1beta1constants.AnnotationWorkerKubernetesUpdateStrategy] == v1beta1constants.KubernetesUpdateStrategyInPlace
+}
+
+// IsCoordinatedRebootEnabled checks if gardener-node-agent reboots the nodes of the given worker pool in coordination
+// with the other nodes of the pool if changes to the operating system config require a reboot.
+func IsCoordinatedRebootEnabled(worker *gardencorev1beta1.Worker) bool {
+	return worker.Annotations[v1beta1constants.AnnotationWorkerCoordinatedReboots] == "true"
+}
+
+// IsDirectImagePullEnabled checks if gardener-node-agent pulls the images of files with an image reference directly
+// from the registry for the nodes of the given worker pool.
+func IsDirectImagePullEnabled(worker *gardencorev1beta1.Worker) bool {
+	return worker.Annotations[v1beta1constants.AnnotationWorkerDirectImagePull] == "true"
+}
+
 // KubernetesVersionExistsInCloudProfile checks if the given Kubernetes version exists in the CloudProfile
 func KubernetesVersionExistsInCloudProfile(cloudProfile *gardencorev1beta1.CloudProfile, currentVersion string) (bool, gardencorev1beta1.ExpirableVersion, error) {
 	for _, version := range cloudProfile.Spec.Kubernetes.Versions {
@@ -527,6 +527,33 @@ var _ = Describe("Helper", func() {
 		Entry("systemComponents.allowed = true", &gardencorev1beta1.Worker{SystemComponents: &gardencorev1beta1.WorkerSystemComponents{Allow: true}}, true),
 	)
 
+	DescribeTable("#IsKubernetesInPlaceUpdateEnabled",
+		func(annotations map[string]string, enabled bool) {
+			Expect(IsKubernetesInPlaceUpdateEnabled(&gardencorev1beta1.Worker{Annotations: annotations})).To(Equal(enabled))
+		},
+		Entry("no annotations", nil, false),
+		Entry("other update strategy", map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "Rolling"}, false),
+		Entry("in-place update strategy", map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "InPlace"}, true),
+	)
+
+	DescribeTable("#IsCoordinatedRebootEnabled",
+		func(annotations map[string]string, enabled bool) {
+			Expect(IsCoordinatedRebootEnabled(&gardencorev1beta1.Worker{Annotations: annotations})).To(Equal(enabled))
+		},
+		Entry("no annotations", nil, false),
+		Entry("coordinated reboots disabled", map[string]string{"worker.gardener.cloud/coordinated-reboots": "false"}, false),
+		Entry("coordinated reboots enabled", map[string]string{"worker.gardener.cloud/coordinated-reboots": "true"}, true),
+	)
+
+	DescribeTable("#IsDirectImagePullEnabled",
+		func(annotations map[string]string, enabled bool) {
+			Expect(IsDirectImagePullEnabled(&gardencorev1beta1.Worker{Annotations: annotations})).To(Equal(enabled))
+		},
+		Entry("no annotations", nil, false),
+		Entry("direct image pull disabled", map[string]string{"worker.gardener.cloud/direct-image-pull": "false"}, false),
+		Entry("direct image pull enabled", map[string]string{"worker.gardener.cloud/direct-image-pull": "true"}, true),
+	)
+
 	DescribeTable("#HibernationIsEnabled",
 		func(shoot *gardencorev1beta1.Shoot, hibernated bool) {
 			Expect(HibernationIsEnabled(shoot)).To(Equal(hibernated))
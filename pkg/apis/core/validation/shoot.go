@@ -1711,6 +1711,14 @@ func ValidateWorker(worker core.Worker, kubernetes core.Kubernetes, fldPath *fie
 
 	allErrs = append(allErrs, metav1validation.ValidateLabels(worker.Labels, fldPath.Child("labels"))...)
 	allErrs = append(allErrs, apivalidation.ValidateAnnotations(worker.Annotations, fldPath.Child("annotations"))...)
+	if strategy, ok := worker.Annotations[v1beta1constants.AnnotationWorkerKubernetesUpdateStrategy]; ok && strategy != v1beta1constants.KubernetesUpdateStrategyInPlace {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("annotations").Key(v1beta1constants.AnnotationWorkerKubernetesUpdateStrategy), strategy, []string{v1beta1constants.KubernetesUpdateStrategyInPlace}))
+	}
+	for _, annotation := range []string{v1beta1constants.AnnotationWorkerCoordinatedReboots, v1beta1constants.AnnotationWorkerDirectImagePull} {
+		if value, ok := worker.Annotations[annotation]; ok && value != "true" && value != "false" {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("annotations").Key(annotation), value, []string{"true", "false"}))
+		}
+	}
 	if len(worker.Taints) > 0 {
 		allErrs = append(allErrs, validateTaints(worker.Taints, fldPath.Child("taints"))...)
 	}
@@ -5646,6 +5646,9 @@ var _ = Describe("Shoot Validation Tests", func() {
 
 			// invalid value
 			Entry("too long", map[string]string{"foo": strings.Repeat("a", 262142)}, field.ErrorTypeTooLong),
+			Entry("unsupported Kubernetes update strategy", map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "Rolling"}, field.ErrorTypeNotSupported),
+			Entry("unsupported coordinated reboots value", map[string]string{"worker.gardener.cloud/coordinated-reboots": "yes"}, field.ErrorTypeNotSupported),
+			Entry("unsupported direct image pull value", map[string]string{"worker.gardener.cloud/direct-image-pull": "yes"}, field.ErrorTypeNotSupported),
 		)
 
 		DescribeTable("reject when taints are invalid",
@@ -108,6 +108,10 @@ type Unit struct {
 	// FilePaths is a list of files the unit depends on. If any file changes a restart of the dependent unit will be
 	// triggered. For each FilePath there must exist a File with matching Path in OperatingSystemConfig.Spec.Files.
 	FilePaths []string `json:"filePaths,omitempty"`
+	// RequiresReboot specifies whether the node must be rebooted when the unit (or one of its drop-ins) changes or is
+	// removed. The reboot is coordinated with the other nodes of the worker pool. Defaults to false.
+	// +optional
+	RequiresReboot *bool `json:"requiresReboot,omitempty"`
 }
 
 // UnitCommand is a string alias.
@@ -141,6 +145,10 @@ type File struct {
 	Permissions *uint32 `json:"permissions,omitempty"`
 	// Content describe the file's content.
 	Content FileContent `json:"content"`
+	// RequiresReboot specifies whether the node must be rebooted when the file changes or is removed. The reboot is
+	// coordinated with the other nodes of the worker pool. Defaults to false.
+	// +optional
+	RequiresReboot *bool `json:"requiresReboot,omitempty"`
 }
 
 // FileContent can either reference a secret or contain inline configuration.
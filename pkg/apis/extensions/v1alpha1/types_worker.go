@@ -242,6 +242,14 @@ type WorkerStatus struct {
 	// MachineDeploymentsLastUpdateTime is the timestamp when the status.MachineDeployments slice was last updated.
 	// +optional
 	MachineDeploymentsLastUpdateTime *metav1.Time `json:"machineDeploymentsLastUpdateTime,omitempty"`
+	// OperatingSystemConfigs contains the status of applying the latest operating system configurations on the nodes of
+	// the worker pools. It is maintained by gardenlet based on the apply status reported by gardener-node-agent.
+	// In contrast to the other fields of the status, it is owned by gardenlet, hence extension controllers must not
+	// modify it and must patch the status instead of updating it.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +optional
+	OperatingSystemConfigs []WorkerPoolOperatingSystemConfigStatus `json:"operatingSystemConfigs,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 }
 
 // MachineDeployment is a created machine deployment.
@@ -253,3 +261,35 @@ type MachineDeployment struct {
 	// Maximum is the maximum number for this machine deployment.
 	Maximum int32 `json:"maximum"`
 }
+
+// WorkerPoolOperatingSystemConfigStatus contains the status of applying the latest operating system configuration on
+// the nodes of a worker pool.
+type WorkerPoolOperatingSystemConfigStatus struct {
+	// Name is the name of the worker pool.
+	Name string `json:"name"`
+	// Checksum is the checksum of the latest operating system configuration of the worker pool.
+	Checksum string `json:"checksum"`
+	// Nodes is the number of nodes in the worker pool.
+	Nodes int32 `json:"nodes"`
+	// UpToDateNodes is the number of nodes which have applied the latest operating system configuration.
+	UpToDateNodes int32 `json:"upToDateNodes"`
+	// FailedNodes is the number of nodes which failed applying the latest operating system configuration.
+	FailedNodes int32 `json:"failedNodes"`
+	// Failures contains the failed attempts of the nodes to apply the latest operating system configuration. It is
+	// limited to a few nodes to keep the size of the status bounded.
+	// +optional
+	Failures []NodeOperatingSystemConfigApplyFailure `json:"failures,omitempty"`
+}
+
+// NodeOperatingSystemConfigApplyFailure is a failed attempt of a node to apply an operating system configuration.
+type NodeOperatingSystemConfigApplyFailure struct {
+	// NodeName is the name of the node.
+	NodeName string `json:"nodeName"`
+	// FailedStep is the step in which applying the operating system configuration failed.
+	// +optional
+	FailedStep string `json:"failedStep,omitempty"`
+	// Message is the error of the failed attempt.
+	Message string `json:"message"`
+	// LastAttemptTime is the time when the failed attempt finished.
+	LastAttemptTime metav1.Time `json:"lastAttemptTime"`
+}
@@ -1101,6 +1101,11 @@ func (in *File) DeepCopyInto(out *File) {
 		**out = **in
 	}
 	in.Content.DeepCopyInto(&out.Content)
+	if in.RequiresReboot != nil {
+		in, out := &in.RequiresReboot, &out.RequiresReboot
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1477,6 +1482,23 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeOperatingSystemConfigApplyFailure) DeepCopyInto(out *NodeOperatingSystemConfigApplyFailure) {
+	*out = *in
+	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeOperatingSystemConfigApplyFailure.
+func (in *NodeOperatingSystemConfigApplyFailure) DeepCopy() *NodeOperatingSystemConfigApplyFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeOperatingSystemConfigApplyFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeTemplate) DeepCopyInto(out *NodeTemplate) {
 	*out = *in
@@ -1751,6 +1773,11 @@ func (in *Unit) DeepCopyInto(out *Unit) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RequiresReboot != nil {
+		in, out := &in.RequiresReboot, &out.RequiresReboot
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1954,6 +1981,29 @@ func (in *WorkerPool) DeepCopy() *WorkerPool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPoolOperatingSystemConfigStatus) DeepCopyInto(out *WorkerPoolOperatingSystemConfigStatus) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]NodeOperatingSystemConfigApplyFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPoolOperatingSystemConfigStatus.
+func (in *WorkerPoolOperatingSystemConfigStatus) DeepCopy() *WorkerPoolOperatingSystemConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPoolOperatingSystemConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerSpec) DeepCopyInto(out *WorkerSpec) {
 	*out = *in
@@ -2002,6 +2052,13 @@ func (in *WorkerStatus) DeepCopyInto(out *WorkerStatus) {
 		in, out := &in.MachineDeploymentsLastUpdateTime, &out.MachineDeploymentsLastUpdateTime
 		*out = (*in).DeepCopy()
 	}
+	if in.OperatingSystemConfigs != nil {
+		in, out := &in.OperatingSystemConfigs, &out.OperatingSystemConfigs
+		*out = make([]WorkerPoolOperatingSystemConfigStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+)
+
+// UpdatedObjectCondition returns a copy of the given object condition with the given status, reason and message. The
+// last transition time is only updated if the status changes.
+func UpdatedObjectCondition(clock clock.Clock, condition *resourcesv1alpha1.ObjectCondition, status gardencorev1beta1.ConditionStatus, reason, message string) *resourcesv1alpha1.ObjectCondition {
+	newCondition := &resourcesv1alpha1.ObjectCondition{
+		Status:             status,
+		LastTransitionTime: metav1.NewTime(clock.Now()),
+		Reason:             reason,
+		Message:            message,
+	}
+
+	if condition != nil && condition.Status == status {
+		newCondition.LastTransitionTime = condition.LastTransitionTime
+	}
+
+	return newCondition
+}
+
+// HealthSummary returns a summary of the health of the given objects, e.g. `3/5 healthy, 1 progressing, 1 unknown`.
+// Objects which have not been checked yet are considered unknown.
+func HealthSummary(objects []resourcesv1alpha1.ObjectReference) string {
+	if len(objects) == 0 {
+		return ""
+	}
+
+	var healthy, progressing, unknown int
+	for _, obj := range objects {
+		switch {
+		case obj.Healthy == nil || obj.Healthy.Status == gardencorev1beta1.ConditionUnknown:
+			unknown++
+		case obj.Healthy.Status == gardencorev1beta1.ConditionTrue:
+			healthy++
+		}
+
+		if obj.Progressing != nil && obj.Progressing.Status == gardencorev1beta1.ConditionTrue {
+			progressing++
+		}
+	}
+
+	summary := fmt.Sprintf("%d/%d healthy", healthy, len(objects))
+	if progressing > 0 {
+		summary += fmt.Sprintf(", %d progressing", progressing)
+	}
+	if unknown > 0 {
+		summary += fmt.Sprintf(", %d unknown", unknown)
+	}
+	return summary
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclock "k8s.io/utils/clock/testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	. "github.com/gardener/gardener/pkg/apis/resources/v1alpha1/helper"
+)
+
+var _ = Describe("Health", func() {
+	Describe("#UpdatedObjectCondition", func() {
+		var (
+			fakeClock *testclock.FakeClock
+			old       *resourcesv1alpha1.ObjectCondition
+		)
+
+		BeforeEach(func() {
+			fakeClock = testclock.NewFakeClock(time.Now().Round(time.Second))
+			old = &resourcesv1alpha1.ObjectCondition{
+				Status:             gardencorev1beta1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-time.Hour)),
+				Reason:             "DeploymentUnhealthy",
+				Message:            "foo",
+			}
+		})
+
+		It("should initialize the condition", func() {
+			Expect(UpdatedObjectCondition(fakeClock, nil, gardencorev1beta1.ConditionTrue, "", "")).To(Equal(&resourcesv1alpha1.ObjectCondition{
+				Status:             gardencorev1beta1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+			}))
+		})
+
+		It("should keep the last transition time if the status does not change", func() {
+			Expect(UpdatedObjectCondition(fakeClock, old, gardencorev1beta1.ConditionFalse, "DeploymentMissing", "bar")).To(Equal(&resourcesv1alpha1.ObjectCondition{
+				Status:             gardencorev1beta1.ConditionFalse,
+				LastTransitionTime: old.LastTransitionTime,
+				Reason:             "DeploymentMissing",
+				Message:            "bar",
+			}))
+		})
+
+		It("should update the last transition time if the status changes", func() {
+			Expect(UpdatedObjectCondition(fakeClock, old, gardencorev1beta1.ConditionTrue, "", "")).To(Equal(&resourcesv1alpha1.ObjectCondition{
+				Status:             gardencorev1beta1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+			}))
+		})
+	})
+
+	Describe("#HealthSummary", func() {
+		objectWith := func(healthy, progressing *gardencorev1beta1.ConditionStatus) resourcesv1alpha1.ObjectReference {
+			obj := resourcesv1alpha1.ObjectReference{}
+			if healthy != nil {
+				obj.Healthy = &resourcesv1alpha1.ObjectCondition{Status: *healthy}
+			}
+			if progressing != nil {
+				obj.Progressing = &resourcesv1alpha1.ObjectCondition{Status: *progressing}
+			}
+			return obj
+		}
+
+		var (
+			conditionTrue    = gardencorev1beta1.ConditionTrue
+			conditionFalse   = gardencorev1beta1.ConditionFalse
+			conditionUnknown = gardencorev1beta1.ConditionUnknown
+		)
+
+		It("should return an empty summary if there are no objects", func() {
+			Expect(HealthSummary(nil)).To(BeEmpty())
+		})
+
+		It("should only mention the healthy objects if everything is fine", func() {
+			Expect(HealthSummary([]resourcesv1alpha1.ObjectReference{
+				objectWith(&conditionTrue, &conditionFalse),
+				objectWith(&conditionTrue, nil),
+			})).To(Equal("2/2 healthy"))
+		})
+
+		It("should summarize unhealthy, progressing and unknown objects", func() {
+			Expect(HealthSummary([]resourcesv1alpha1.ObjectReference{
+				objectWith(&conditionTrue, &conditionTrue),
+				objectWith(&conditionFalse, nil),
+				objectWith(&conditionUnknown, nil),
+				objectWith(nil, nil),
+				objectWith(&conditionTrue, nil),
+			})).To(Equal("2/5 healthy, 1 progressing, 2 unknown"))
+		})
+	})
+})
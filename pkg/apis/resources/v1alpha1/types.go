@@ -17,6 +17,10 @@ const (
 	Ignore = "resources.gardener.cloud/ignore"
 	// SkipHealthCheck is an annotation that dictates whether a resource should be ignored during health check.
 	SkipHealthCheck = "resources.gardener.cloud/skip-health-check"
+	// ApplyPhase is a constant for an annotation on a resource managed by a ManagedResource. It states the phase in
+	// which the resource is applied (an integer, defaults to 0). The resources of a phase are only applied after all
+	// resources of the previous phases are ready.
+	ApplyPhase = "resources.gardener.cloud/apply-phase"
 	// DeleteOnInvalidUpdate is a constant for an annotation on a resource managed by a ManagedResource. If set to
 	// true then the controller will delete the object in case it faces an "Invalid" response during an update operation.
 	DeleteOnInvalidUpdate = "resources.gardener.cloud/delete-on-invalid-update"
@@ -31,6 +35,10 @@ const (
 	// Reconciliation in ignore mode removes the resource from the ManagedResource status and does not
 	// perform any action on the cluster.
 	ModeIgnore = "Ignore"
+	// ModeServerSideApply is a constant for the value of the mode annotation describing a server-side apply mode.
+	// Reconciliation in server-side apply mode applies the resource with server-side apply, i.e., only the fields which
+	// are part of the desired state are owned and other fields are left to other managers.
+	ModeServerSideApply = "ServerSideApply"
 	// PreserveReplicas is a constant for an annotation on a resource managed by a ManagedResource. If set to
 	// true then the controller will keep the `spec.replicas` field's value during updates to the resource.
 	PreserveReplicas = "resources.gardener.cloud/preserve-replicas"
@@ -38,6 +46,10 @@ const (
 	// true then the controller will keep the resource requests and limits in Pod templates (e.g. in a
 	// DeploymentSpec) during updates to the resource. This applies for all containers.
 	PreserveResources = "resources.gardener.cloud/preserve-resources"
+	// Preview is a constant for an annotation on a ManagedResource. If set to true then the controller does not apply
+	// the resources to the target cluster but computes the changes which would be made and writes them to the
+	// `.status.preview` field of the ManagedResource.
+	Preview = "resources.gardener.cloud/preview"
 	// OriginAnnotation is a constant for an annotation on a resource managed by a ManagedResource.
 	// It is set by the ManagedResource controller to the key of the owning ManagedResource, optionally prefixed with the
 	// clusterID.
@@ -56,6 +68,9 @@ const (
 
 	// GardenerManager is a constant for the default value of the 'ManagedBy' label.
 	GardenerManager = "gardener"
+	// ManagedResourceSource is a constant for a label on a ConfigMap which is referenced by a ManagedResource. Only
+	// ConfigMaps with this label set to "true" are watched and read by the ManagedResource controller.
+	ManagedResourceSource = "resources.gardener.cloud/managed-resource-source"
 
 	// StaticTokenSkip is a constant for a label on a ServiceAccount which indicates that this ServiceAccount should not
 	// be considered by this controller.
@@ -207,6 +222,7 @@ const (
 // +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=`.status.conditions[?(@.type=="ResourcesApplied")].status`,description=" Indicates whether all resources have been applied."
 // +kubebuilder:printcolumn:name="Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="ResourcesHealthy")].status`,description="Indicates whether all resources are healthy."
 // +kubebuilder:printcolumn:name="Progressing",type=string,JSONPath=`.status.conditions[?(@.type=="ResourcesProgressing")].status`,description="Indicates whether some resources are still progressing to be rolled out."
+// +kubebuilder:printcolumn:name="Objects",type=string,JSONPath=`.status.healthSummary`,description="Summarizes the health of the managed objects."
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="creation timestamp"
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -239,7 +255,16 @@ type ManagedResourceSpec struct {
 	// +optional
 	Class *string `json:"class,omitempty"`
 	// SecretRefs is a list of secret references.
+	// +optional
 	SecretRefs []corev1.LocalObjectReference `json:"secretRefs"`
+	// ConfigMapRefs is a list of config map references. ConfigMaps can be used instead of secrets for resources which do
+	// not contain sensitive data. The ConfigMaps must be labeled with `resources.gardener.cloud/managed-resource-source=true`.
+	// +optional
+	ConfigMapRefs []corev1.LocalObjectReference `json:"configMapRefs,omitempty"`
+	// OCIArtifacts is a list of references to OCI artifacts containing resources. This is useful for large static
+	// bundles which should not be stored in the cluster for every ManagedResource.
+	// +optional
+	OCIArtifacts []OCIArtifactReference `json:"ociArtifacts,omitempty"`
 	// InjectLabels injects the provided labels into every resource that is part of the referenced secrets.
 	// +optional
 	InjectLabels map[string]string `json:"injectLabels,omitempty"`
@@ -262,6 +287,16 @@ type ManagedResourceSpec struct {
 	DeletePersistentVolumeClaims *bool `json:"deletePersistentVolumeClaims,omitempty"`
 }
 
+// OCIArtifactReference is a reference to an OCI artifact containing resources. All layers of the artifact with media
+// type `application/vnd.gardener.cloud.manifests.v1+yaml` are read.
+type OCIArtifactReference struct {
+	// Repository is the repository of the artifact, e.g. `europe-docker.pkg.dev/gardener-project/releases/dashboards`.
+	Repository string `json:"repository"`
+	// Digest of the artifact, e.g. `sha256:...`. Artifacts must be pinned by their digest, so that the resources cannot
+	// change without a change of the ManagedResource.
+	Digest string `json:"digest"`
+}
+
 // ManagedResourceStatus is the status of a managed resource.
 type ManagedResourceStatus struct {
 	Conditions []gardencorev1beta1.Condition `json:"conditions,omitempty"`
@@ -270,11 +305,66 @@ type ManagedResourceStatus struct {
 	// Resources is a list of objects that have been created.
 	// +optional
 	Resources []ObjectReference `json:"resources,omitempty"`
-	// SecretsDataChecksum is the checksum of referenced secrets data.
+	// HealthSummary summarizes the health of the objects in Resources, e.g. `3/5 healthy, 1 progressing, 1 unknown`.
+	// +optional
+	HealthSummary string `json:"healthSummary,omitempty"`
+	// SecretsDataChecksum is the checksum of the data of the referenced secrets, config maps and OCI artifacts.
 	// +optional
 	SecretsDataChecksum *string `json:"secretsDataChecksum,omitempty"`
+	// Preview contains the changes which would be made to the target cluster if the resources were applied. It is only
+	// set if the ManagedResource is annotated with `resources.gardener.cloud/preview=true`.
+	// +optional
+	Preview *ManagedResourcePreview `json:"preview,omitempty"`
 }
 
+// ManagedResourcePreview contains the changes which would be made to the target cluster if the resources of a
+// ManagedResource were applied.
+type ManagedResourcePreview struct {
+	// SecretsDataChecksum is the checksum of the referenced secrets data the preview was computed for.
+	SecretsDataChecksum string `json:"secretsDataChecksum"`
+	// LastUpdateTime is the time when the preview was computed.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// Summary is a human-readable summary of the changes.
+	Summary string `json:"summary"`
+	// Changes is a list of objects which would be created, updated, or deleted.
+	// +optional
+	Changes []ObjectChange `json:"changes,omitempty"`
+}
+
+// ObjectChange describes a change of an object in the target cluster.
+type ObjectChange struct {
+	// APIVersion is the API version of the object.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+	// Operation is the operation which would be performed on the object.
+	Operation ObjectChangeOperation `json:"operation"`
+	// Fields is a list of paths of the fields which would be changed by an update of the object. Their values are not
+	// contained since they might be confidential.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+	// Error is the error which occurred while computing the change, if any.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ObjectChangeOperation is the operation which would be performed on an object.
+type ObjectChangeOperation string
+
+const (
+	// ObjectChangeOperationCreate indicates that the object would be created.
+	ObjectChangeOperationCreate ObjectChangeOperation = "Create"
+	// ObjectChangeOperationUpdate indicates that the object would be updated.
+	ObjectChangeOperationUpdate ObjectChangeOperation = "Update"
+	// ObjectChangeOperationDelete indicates that the object would be deleted.
+	ObjectChangeOperationDelete ObjectChangeOperation = "Delete"
+)
+
 // ObjectReference is a reference to another object.
 type ObjectReference struct {
 	corev1.ObjectReference `json:",inline"`
@@ -282,6 +372,27 @@ type ObjectReference struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// Annotations is a map of annotations that were used during last update of the resource.
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// Healthy describes whether the resource is healthy as determined by the last health check.
+	// +optional
+	Healthy *ObjectCondition `json:"healthy,omitempty"`
+	// Progressing describes whether the rollout of the resource is progressing as determined by the last progressing
+	// check. It is only set for resources which are subject to progressing checks.
+	// +optional
+	Progressing *ObjectCondition `json:"progressing,omitempty"`
+}
+
+// ObjectCondition describes the state of a single resource managed by a ManagedResource.
+type ObjectCondition struct {
+	// Status of the condition, one of True, False, Unknown.
+	Status gardencorev1beta1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the status transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason is a brief, machine-readable reason for the condition's status.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about the condition's status.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 const (
@@ -301,12 +412,18 @@ const (
 	// ConditionApplyFailed indicates that the `ResourcesApplied` condition is `False`,
 	// because applying the resources failed.
 	ConditionApplyFailed = "ApplyFailed"
+	// ConditionApplyConflict indicates that the `ResourcesApplied` condition is `False`,
+	// because applying a resource with server-side apply conflicts with fields owned by other managers.
+	ConditionApplyConflict = "ApplyConflict"
 	// ConditionDecodingFailed indicates that the `ResourcesApplied` condition is `False`,
 	// because decoding the resources of the ManagedResource failed.
 	ConditionDecodingFailed = "DecodingFailed"
 	// ConditionApplyProgressing indicates that the `ResourcesApplied` condition is `Progressing`,
 	// because the resources are currently being reconciled.
 	ConditionApplyProgressing = "ApplyProgressing"
+	// ConditionApplyPhasePending indicates that the `ResourcesApplied` condition is `Progressing`,
+	// because the resources of an apply phase are not ready yet, so that the resources of the next phase are not applied.
+	ConditionApplyPhasePending = "ApplyPhasePending"
 	// ConditionDeletionFailed indicates that the `ResourcesApplied` condition is `False`,
 	// because deleting the resources failed.
 	ConditionDeletionFailed = "DeletionFailed"
@@ -77,6 +77,30 @@ func (in *ManagedResourceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedResourcePreview) DeepCopyInto(out *ManagedResourcePreview) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ObjectChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedResourcePreview.
+func (in *ManagedResourcePreview) DeepCopy() *ManagedResourcePreview {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedResourcePreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedResourceSpec) DeepCopyInto(out *ManagedResourceSpec) {
 	*out = *in
@@ -90,6 +114,16 @@ func (in *ManagedResourceSpec) DeepCopyInto(out *ManagedResourceSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigMapRefs != nil {
+		in, out := &in.ConfigMapRefs, &out.ConfigMapRefs
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.OCIArtifacts != nil {
+		in, out := &in.OCIArtifacts, &out.OCIArtifacts
+		*out = make([]OCIArtifactReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.InjectLabels != nil {
 		in, out := &in.InjectLabels, &out.InjectLabels
 		*out = make(map[string]string, len(*in))
@@ -163,6 +197,11 @@ func (in *ManagedResourceStatus) DeepCopyInto(out *ManagedResourceStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = new(ManagedResourcePreview)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -176,6 +215,60 @@ func (in *ManagedResourceStatus) DeepCopy() *ManagedResourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIArtifactReference) DeepCopyInto(out *OCIArtifactReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifactReference.
+func (in *OCIArtifactReference) DeepCopy() *OCIArtifactReference {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIArtifactReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectChange) DeepCopyInto(out *ObjectChange) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectChange.
+func (in *ObjectChange) DeepCopy() *ObjectChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectCondition) DeepCopyInto(out *ObjectCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectCondition.
+func (in *ObjectCondition) DeepCopy() *ObjectCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -194,6 +287,16 @@ func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 			(*out)[key] = val
 		}
 	}
+	if in.Healthy != nil {
+		in, out := &in.Healthy, &out.Healthy
+		*out = new(ObjectCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progressing != nil {
+		in, out := &in.Progressing, &out.Progressing
+		*out = new(ObjectCondition)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
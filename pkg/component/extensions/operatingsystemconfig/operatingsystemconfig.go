@@ -17,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -855,6 +856,10 @@ func (d *deployer) deploy(ctx context.Context, operation string) (extensionsv1al
 		Sysctls:                 d.worker.Sysctls,
 		PreferIPv6:              d.primaryIPFamily == gardencorev1beta1.IPFamilyIPv6,
 		Taints:                  d.taints,
+		CoordinatedReboots:      v1beta1helper.IsCoordinatedRebootEnabled(&d.worker),
+		MaxConcurrentReboots:    maxConcurrentReboots(d.worker),
+		InPlaceKubernetesUpdate: v1beta1helper.IsKubernetesInPlaceUpdateEnabled(&d.worker),
+		DirectImagePull:         v1beta1helper.IsDirectImagePullEnabled(&d.worker),
 	}
 
 	switch d.purpose {
@@ -957,7 +962,7 @@ func calculateKeyForVersion(
 	switch version {
 	case 1:
 		// TODO(MichaelEischer): Remove KeyV1 after support for Kubernetes 1.30 is dropped
-		return KeyV1(worker.Name, kubernetesVersion, worker.CRI), nil
+		return KeyV1(worker, kubernetesVersion), nil
 	case 2:
 		return KeyV2(kubernetesVersion, values.CredentialsRotationStatus, worker, values.NodeLocalDNSEnabled, kubeletConfiguration), nil
 	default:
@@ -967,26 +972,31 @@ func calculateKeyForVersion(
 
 // KeyV1 returns the key that can be used as secret name based on the provided worker name, Kubernetes version and CRI
 // configuration.
-func KeyV1(workerPoolName string, kubernetesVersion *semver.Version, criConfig *gardencorev1beta1.CRI) string {
+// The Kubernetes version is not considered for worker pools whose Kubernetes version is updated in-place, see
+// v1beta1constants.KubernetesUpdateStrategyInPlace.
+func KeyV1(worker *gardencorev1beta1.Worker, kubernetesVersion *semver.Version) string {
 	if kubernetesVersion == nil {
 		return ""
 	}
 
-	var (
+	var kubernetesMajorMinorVersion string
+	if !v1beta1helper.IsKubernetesInPlaceUpdateEnabled(worker) {
 		kubernetesMajorMinorVersion = fmt.Sprintf("%d.%d", kubernetesVersion.Major(), kubernetesVersion.Minor())
-		criName                     gardencorev1beta1.CRIName
-	)
+	}
 
-	if criConfig != nil {
-		criName = criConfig.Name
+	var criName gardencorev1beta1.CRIName
+	if worker.CRI != nil {
+		criName = worker.CRI.Name
 	}
 
-	return fmt.Sprintf("gardener-node-agent-%s-%s", workerPoolName, utils.ComputeSHA256Hex([]byte(kubernetesMajorMinorVersion + string(criName)))[:5])
+	return fmt.Sprintf("gardener-node-agent-%s-%s", worker.Name, utils.ComputeSHA256Hex([]byte(kubernetesMajorMinorVersion + string(criName)))[:5])
 }
 
 // KeyV2 returns the key that can be used as secret name based on the provided worker name,
 // Kubernetes version, machine type, image, worker volume, CRI, credentials rotation, node local dns
 // and kubelet configuration.
+// The Kubernetes version is not considered for worker pools whose Kubernetes version is updated in-place, see
+// v1beta1constants.KubernetesUpdateStrategyInPlace.
 func KeyV2(
 	kubernetesVersion *semver.Version,
 	credentialsRotation *gardencorev1beta1.ShootCredentialsRotation,
@@ -998,13 +1008,14 @@ func KeyV2(
 		return ""
 	}
 
-	kubernetesMajorMinorVersion := fmt.Sprintf("%d.%d", kubernetesVersion.Major(), kubernetesVersion.Minor())
-
-	data := []string{
-		kubernetesMajorMinorVersion,
-		worker.Machine.Type,
-		worker.Machine.Image.Name + *worker.Machine.Image.Version,
+	var data []string
+	if !v1beta1helper.IsKubernetesInPlaceUpdateEnabled(worker) {
+		data = append(data, fmt.Sprintf("%d.%d", kubernetesVersion.Major(), kubernetesVersion.Minor()))
 	}
+	data = append(data,
+		worker.Machine.Type,
+		worker.Machine.Image.Name+*worker.Machine.Image.Version,
+	)
 
 	if worker.Volume != nil {
 		data = append(data, worker.Volume.VolumeSize)
@@ -1070,3 +1081,14 @@ func keySuffix(version int, machineImageName string, purpose extensionsv1alpha1.
 	}
 	return ""
 }
+
+// maxConcurrentReboots returns the number of nodes of the given worker pool which may be rebooted at the same time by
+// gardener-node-agent. It is derived from the maxUnavailable setting of the worker pool, but at least one node may be
+// rebooted.
+func maxConcurrentReboots(worker gardencorev1beta1.Worker) int32 {
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(ptr.To(ptr.Deref(worker.MaxUnavailable, intstr.FromInt32(0))), int(worker.Maximum), false)
+	if err != nil || maxUnavailable < 1 {
+		return 1
+	}
+	return int32(maxUnavailable) // #nosec G115 -- maxUnavailable is scaled against the maximum of the worker pool which is an int32.
+}
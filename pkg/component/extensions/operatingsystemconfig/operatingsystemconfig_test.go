@@ -197,7 +197,7 @@ var _ = Describe("OperatingSystemConfig", func() {
 					}
 				}
 
-				key := KeyV1(worker.Name, k8sVersion, worker.CRI)
+				key := KeyV1(&worker, k8sVersion)
 
 				imagesCopy := make(map[string]*imagevector.Image, len(images))
 				for imageName, image := range images {
@@ -512,7 +512,7 @@ var _ = Describe("OperatingSystemConfig", func() {
 			) (string, error) {
 				switch oscVersion {
 				case 1:
-					return KeyV1(worker.Name, kubernetesVersion, worker.CRI), nil
+					return KeyV1(worker, kubernetesVersion), nil
 				case 2:
 					return worker.Name + "-version2", nil
 				default:
@@ -643,7 +643,7 @@ var _ = Describe("OperatingSystemConfig", func() {
 					if worker.Kubernetes != nil && worker.Kubernetes.Version != nil {
 						k8sVersion = semver.MustParse(*worker.Kubernetes.Version)
 					}
-					key := KeyV1(worker.Name, k8sVersion, worker.CRI)
+					key := KeyV1(&worker, k8sVersion)
 
 					extensions = append(extensions,
 						gardencorev1beta1.ExtensionResourceState{
@@ -1169,19 +1169,30 @@ var _ = Describe("OperatingSystemConfig", func() {
 		)
 
 		It("should return an empty string", func() {
-			Expect(KeyV1(workerName, nil, nil)).To(BeEmpty())
+			Expect(KeyV1(&gardencorev1beta1.Worker{Name: workerName}, nil)).To(BeEmpty())
 		})
 
 		It("should return the expected key", func() {
-			Expect(KeyV1(workerName, semver.MustParse(kubernetesVersion), nil)).To(Equal("gardener-node-agent-" + workerName + "-77ac3"))
+			Expect(KeyV1(&gardencorev1beta1.Worker{Name: workerName}, semver.MustParse(kubernetesVersion))).To(Equal("gardener-node-agent-" + workerName + "-77ac3"))
 		})
 
 		It("is different for different worker.cri configurations", func() {
-			containerDKey := KeyV1(workerName, semver.MustParse("1.2.3"), &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD})
-			otherKey := KeyV1(workerName, semver.MustParse("1.2.3"), &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRIName("other")})
+			containerDKey := KeyV1(&gardencorev1beta1.Worker{Name: workerName, CRI: &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD}}, semver.MustParse("1.2.3"))
+			otherKey := KeyV1(&gardencorev1beta1.Worker{Name: workerName, CRI: &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRIName("other")}}, semver.MustParse("1.2.3"))
 			Expect(containerDKey).NotTo(Equal(otherKey))
 		})
 
+		It("is different for different Kubernetes minor versions", func() {
+			worker := &gardencorev1beta1.Worker{Name: workerName}
+			Expect(KeyV1(worker, semver.MustParse("1.2.3"))).NotTo(Equal(KeyV1(worker, semver.MustParse("1.3.3"))))
+		})
+
+		It("is the same for different Kubernetes minor versions if the worker pool is updated in-place", func() {
+			worker := &gardencorev1beta1.Worker{Name: workerName, Annotations: map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "InPlace"}}
+			Expect(KeyV1(worker, semver.MustParse("1.2.3"))).To(Equal(KeyV1(worker, semver.MustParse("1.3.3"))))
+			Expect(KeyV1(worker, semver.MustParse("1.2.3"))).NotTo(Equal(KeyV1(&gardencorev1beta1.Worker{Name: workerName}, semver.MustParse("1.2.3"))))
+		})
+
 		It("should return the expected key for version 1", func() {
 			key, err := CalculateKeyForVersion(1, semver.MustParse(kubernetesVersion), nil,
 				&gardencorev1beta1.Worker{
@@ -1323,6 +1334,16 @@ var _ = Describe("OperatingSystemConfig", func() {
 				kubernetesVersion = semver.MustParse("1.2.4")
 			})
 
+			It("when changing the kubernetes major/minor version of a worker pool which is updated in-place", func() {
+				p.Annotations = map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "InPlace"}
+
+				var err error
+				hash, err = CalculateKeyForVersion(2, kubernetesVersion, values, p, kubeletConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				kubernetesVersion = semver.MustParse("1.3.3")
+			})
+
 			It("when systemReserved is empty", func() {
 				kubeletConfig.SystemReserved = &gardencorev1beta1.KubeletConfigReserved{}
 			})
@@ -1429,6 +1450,10 @@ var _ = Describe("OperatingSystemConfig", func() {
 				p.CRI = &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD}
 			})
 
+			It("when enabling in-place Kubernetes version updates", func() {
+				p.Annotations = map[string]string{"worker.gardener.cloud/kubernetes-update-strategy": "InPlace"}
+			})
+
 			It("when a shoot CA rotation is triggered", func() {
 				newRotationTime := metav1.Time{Time: lastCARotationInitiation.Add(time.Hour)}
 				values.CredentialsRotationStatus.CertificateAuthorities.LastInitiationTime = &newRotationTime
@@ -45,4 +45,8 @@ type Context struct {
 	Sysctls                 map[string]string
 	PreferIPv6              bool
 	Taints                  []corev1.Taint
+	CoordinatedReboots      bool
+	MaxConcurrentReboots    int32
+	InPlaceKubernetesUpdate bool
+	DirectImagePull         bool
 }
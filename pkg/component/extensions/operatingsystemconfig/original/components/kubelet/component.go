@@ -34,6 +34,8 @@ const (
 	// PathKubeconfigReal is the path for the kubelet's real kubeconfig (with client certificate after the TLS
 	// bootstrapping process finished).
 	PathKubeconfigReal = PathKubeletDirectory + "/kubeconfig-real"
+	// PathKubeletBinary is the path for the kubelet binary.
+	PathKubeletBinary = v1beta1constants.OperatingSystemConfigFilePathBinaries + "/kubelet"
 	// PathKubeletCACert is the path for the kubelet's certificate authority.
 	PathKubeletCACert = PathKubeletDirectory + "/ca.crt"
 	// PathKubeletConfig is the path for the kubelet's config file.
@@ -80,7 +82,7 @@ func (component) Config(ctx components.Context) ([]extensionsv1alpha1.Unit, []ex
 			},
 		},
 		{
-			Path:        v1beta1constants.OperatingSystemConfigFilePathBinaries + "/kubelet",
+			Path:        PathKubeletBinary,
 			Permissions: ptr.To[uint32](0755),
 			Content: extensionsv1alpha1.FileContent{
 				ImageRef: &extensionsv1alpha1.FileContentImageRef{
@@ -111,7 +113,7 @@ RestartSec=5
 Environment="HTTP2_READ_IDLE_TIMEOUT_SECONDS=` + strconv.Itoa(http2ReadIdleTimeSeconds) + `" "HTTP2_PING_TIMEOUT_SECONDS=` + strconv.Itoa(http2PingTimeSeconds) + `"
 EnvironmentFile=/etc/environment
 EnvironmentFile=-/var/lib/kubelet/extra_args
-ExecStart=` + v1beta1constants.OperatingSystemConfigFilePathBinaries + `/kubelet \
+ExecStart=` + PathKubeletBinary + ` \
     ` + utils.Indent(strings.Join(cliFlags, " \\\n"), 4) + ` $KUBELET_EXTRA_ARGS`),
 		FilePaths: append(extensionsv1alpha1helper.FilePathsFrom(kubeletFiles), rootcertificates.PathLocalSSLRootCerts),
 	}
@@ -69,7 +69,22 @@ func (component) Config(ctx components.Context) ([]extensionsv1alpha1.Unit, []ex
 		})
 	}
 
-	files, err := Files(ComponentConfig(ctx.Key, ctx.KubernetesVersion, ctx.APIServerURL, caBundle, additionalTokenSyncConfigs))
+	config := ComponentConfig(ctx.Key, ctx.KubernetesVersion, ctx.APIServerURL, caBundle, additionalTokenSyncConfigs)
+	// Draining nodes requires listing and evicting their pods. The node agent authorizer restricts these permissions to
+	// the pods running on the node, hence reboots are only enabled together with the NodeAgentAuthorizer feature gate.
+	if (ctx.CoordinatedReboots || ctx.InPlaceKubernetesUpdate) && features.DefaultFeatureGate.Enabled(features.NodeAgentAuthorizer) {
+		config.Controllers.OperatingSystemConfig.Reboot = &nodeagentconfigv1alpha1.RebootConfig{
+			MaxConcurrentReboots: ptr.To(max(ctx.MaxConcurrentReboots, 1)),
+		}
+	}
+	if ctx.InPlaceKubernetesUpdate {
+		config.Controllers.OperatingSystemConfig.InPlaceUpdate = &nodeagentconfigv1alpha1.InPlaceUpdateConfig{}
+	}
+	if ctx.DirectImagePull {
+		config.Controllers.OperatingSystemConfig.DirectImagePull = &nodeagentconfigv1alpha1.DirectImagePullConfig{}
+	}
+
+	files, err := Files(config)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed generating files: %w", err)
 	}
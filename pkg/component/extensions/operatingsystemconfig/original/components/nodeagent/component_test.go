@@ -49,11 +49,12 @@ var _ = Describe("Component", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			units, files, err := component.Config(components.Context{
-				Key:               key,
-				KubernetesVersion: kubernetesVersion,
-				APIServerURL:      apiServerURL,
-				CABundle:          ptr.To(string(caBundle)),
-				Images:            map[string]*imagevectorutils.Image{"gardener-node-agent": {Repository: ptr.To("gardener-node-agent"), Tag: ptr.To("v1")}},
+				Key:                  key,
+				KubernetesVersion:    kubernetesVersion,
+				APIServerURL:         apiServerURL,
+				CABundle:             ptr.To(string(caBundle)),
+				Images:               map[string]*imagevectorutils.Image{"gardener-node-agent": {Repository: ptr.To("gardener-node-agent"), Tag: ptr.To("v1")}},
+				MaxConcurrentReboots: 3,
 			})
 
 			expectedFiles = append(expectedFiles, extensionsv1alpha1.File{
@@ -89,6 +90,88 @@ WantedBy=multi-user.target`),
 			))
 			Expect(files).To(ConsistOf(expectedFiles))
 		})
+
+		Context("coordinated reboots", func() {
+			var ctx components.Context
+
+			BeforeEach(func() {
+				ctx = components.Context{
+					Key:                  "key",
+					KubernetesVersion:    kubernetesVersion,
+					APIServerURL:         apiServerURL,
+					CABundle:             ptr.To(string(caBundle)),
+					Images:               map[string]*imagevectorutils.Image{"gardener-node-agent": {Repository: ptr.To("gardener-node-agent"), Tag: ptr.To("v1")}},
+					CoordinatedReboots:   true,
+					MaxConcurrentReboots: 3,
+				}
+			})
+
+			It("should enable coordinated reboots if the worker pool opted in", func() {
+				DeferCleanup(test.WithFeatureGate(features.DefaultFeatureGate, features.NodeAgentAuthorizer, true))
+
+				config := ComponentConfig(ctx.Key, kubernetesVersion, apiServerURL, caBundle, nil)
+				config.Controllers.OperatingSystemConfig.Reboot = &nodeagentconfigv1alpha1.RebootConfig{MaxConcurrentReboots: ptr.To[int32](3)}
+				expectedFiles, err := Files(config)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, files, err := component.Config(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(ContainElements(expectedFiles))
+			})
+
+			It("should not enable coordinated reboots if the NodeAgentAuthorizer feature gate is disabled", func() {
+				expectedFiles, err := Files(ComponentConfig(ctx.Key, kubernetesVersion, apiServerURL, caBundle, nil))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, files, err := component.Config(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(ContainElements(expectedFiles))
+			})
+		})
+
+		It("should enable in-place updates if the Kubernetes version is updated in-place", func() {
+			DeferCleanup(test.WithFeatureGate(features.DefaultFeatureGate, features.NodeAgentAuthorizer, true))
+			key := "key"
+
+			config := ComponentConfig(key, kubernetesVersion, apiServerURL, caBundle, nil)
+			config.Controllers.OperatingSystemConfig.Reboot = &nodeagentconfigv1alpha1.RebootConfig{MaxConcurrentReboots: ptr.To[int32](1)}
+			config.Controllers.OperatingSystemConfig.InPlaceUpdate = &nodeagentconfigv1alpha1.InPlaceUpdateConfig{}
+			expectedFiles, err := Files(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, files, err := component.Config(components.Context{
+				Key:                     key,
+				KubernetesVersion:       kubernetesVersion,
+				APIServerURL:            apiServerURL,
+				CABundle:                ptr.To(string(caBundle)),
+				Images:                  map[string]*imagevectorutils.Image{"gardener-node-agent": {Repository: ptr.To("gardener-node-agent"), Tag: ptr.To("v1")}},
+				InPlaceKubernetesUpdate: true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(ContainElements(expectedFiles))
+		})
+
+		It("should enable direct image pulls if configured", func() {
+			key := "key"
+
+			config := ComponentConfig(key, kubernetesVersion, apiServerURL, caBundle, nil)
+			config.Controllers.OperatingSystemConfig.DirectImagePull = &nodeagentconfigv1alpha1.DirectImagePullConfig{}
+			expectedFiles, err := Files(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, files, err := component.Config(components.Context{
+				Key:               key,
+				KubernetesVersion: kubernetesVersion,
+				APIServerURL:      apiServerURL,
+				CABundle:          ptr.To(string(caBundle)),
+				Images:            map[string]*imagevectorutils.Image{"gardener-node-agent": {Repository: ptr.To("gardener-node-agent"), Tag: ptr.To("v1")}},
+				DirectImagePull:   true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(ContainElements(expectedFiles))
+		})
 	})
 
 	Describe("#UnitContent", func() {
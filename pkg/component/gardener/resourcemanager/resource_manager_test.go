@@ -166,6 +166,11 @@ var _ = Describe("ResourceManager", func() {
 				Resources: []string{"secrets"},
 				Verbs:     []string{"get", "list", "watch", "update", "patch"},
 			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
 			{
 				APIGroups: []string{""},
 				Resources: []string{"configmaps", "events"},
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/component/autoscaling/vpa"
+	extensionscrds "github.com/gardener/gardener/pkg/component/extensions/crds"
+	"github.com/gardener/gardener/pkg/component/extensions/infrastructure"
+	"github.com/gardener/gardener/pkg/component/extensions/worker"
+	"github.com/gardener/gardener/pkg/component/gardener/resourcemanager"
+	"github.com/gardener/gardener/pkg/component/nodemanagement/machinecontrollermanager"
+	"github.com/gardener/gardener/pkg/component/observability/monitoring/prometheusoperator"
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"github.com/gardener/gardener/pkg/extensions"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+)
+
+// BootstrapSeedName is the name of the Seed which is passed to the extension controllers running in the bootstrap
+// cluster. The Seed is not registered anywhere, it only describes the bootstrap cluster.
+const BootstrapSeedName = "gardenadm-bootstrap"
+
+// BootstrapBotanist is a botanist for bootstrapping the infrastructure and machines of an autonomous shoot cluster.
+// Similar to gardenlet in a seed cluster, it deploys the extension controllers into a throw-away bootstrap cluster
+// (e.g., a KinD cluster) and reconciles the extension resources (Infrastructure, OperatingSystemConfig, Worker,
+// DNSRecord) there.
+type BootstrapBotanist struct {
+	// Logger is the logger.
+	Logger logr.Logger
+	// Clock is the clock.
+	Clock clock.Clock
+	// Resources are the Gardener resources describing the autonomous shoot cluster.
+	Resources *Resources
+	// ClientSet is the client set for the bootstrap cluster.
+	ClientSet kubernetes.Interface
+	// SecretsManager is the secrets manager which manages the secrets in the shoot namespace of the bootstrap cluster.
+	SecretsManager secretsmanager.Interface
+
+	// Namespace is the shoot namespace in the bootstrap cluster.
+	Namespace string
+	// Seed describes the bootstrap cluster for the extension controllers.
+	Seed *gardencorev1beta1.Seed
+	// KubernetesVersion is the Kubernetes version of the autonomous shoot cluster.
+	KubernetesVersion *semver.Version
+
+	infrastructure           infrastructure.Interface
+	worker                   worker.Interface
+	machineControllerManager machinecontrollermanager.Interface
+}
+
+// NewBootstrapBotanist creates a new BootstrapBotanist for the given resources and bootstrap cluster.
+func NewBootstrapBotanist(ctx context.Context, log logr.Logger, clock clock.Clock, resources *Resources, clientSet kubernetes.Interface) (*BootstrapBotanist, error) {
+	kubernetesVersion, err := semver.NewVersion(resources.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing Kubernetes version %q: %w", resources.Shoot.Spec.Kubernetes.Version, err)
+	}
+
+	projectName := strings.TrimPrefix(resources.Shoot.Namespace, gardenerutils.ProjectNamespacePrefix)
+
+	b := &BootstrapBotanist{
+		Logger:            log,
+		Clock:             clock,
+		Resources:         resources,
+		ClientSet:         clientSet,
+		Namespace:         gardenerutils.ComputeTechnicalID(projectName, resources.Shoot),
+		KubernetesVersion: kubernetesVersion,
+		Seed: &gardencorev1beta1.Seed{
+			ObjectMeta: metav1.ObjectMeta{Name: BootstrapSeedName},
+			Spec: gardencorev1beta1.SeedSpec{
+				Provider: gardencorev1beta1.SeedProvider{
+					Type:   resources.Shoot.Spec.Provider.Type,
+					Region: resources.Shoot.Spec.Region,
+				},
+			},
+		},
+	}
+
+	b.SecretsManager, err = secretsmanager.New(
+		ctx,
+		log.WithName("secretsmanager"),
+		clock,
+		clientSet.Client(),
+		b.Namespace,
+		"gardenadm",
+		secretsmanager.Config{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating secrets manager: %w", err)
+	}
+
+	b.infrastructure = b.defaultInfrastructure()
+	b.worker = b.defaultWorker()
+	b.machineControllerManager, err = b.defaultMachineControllerManager()
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// DeployCustomResourceDefinitions deploys the custom resource definitions which are required by the extension
+// controllers and the machine-controller-manager into the bootstrap cluster.
+func (b *BootstrapBotanist) DeployCustomResourceDefinitions(ctx context.Context) error {
+	applier := b.ClientSet.Applier()
+
+	prometheusOperatorCRDs, err := prometheusoperator.NewCRDs(b.ClientSet.Client(), applier)
+	if err != nil {
+		return fmt.Errorf("failed creating deployer for prometheus-operator CRDs: %w", err)
+	}
+
+	for name, deployer := range map[string]interface{ Deploy(context.Context) error }{
+		"extensions":                 extensionscrds.NewCRD(applier, true, true),
+		"machine-controller-manager": machinecontrollermanager.NewCRD(b.ClientSet.Client(), applier),
+		"vertical-pod-autoscaler":    vpa.NewCRD(applier, nil),
+		"prometheus-operator":        prometheusOperatorCRDs,
+	} {
+		if err := deployer.Deploy(ctx); err != nil {
+			return fmt.Errorf("failed deploying %s CRDs: %w", name, err)
+		}
+	}
+
+	if err := applier.ApplyManifest(ctx, kubernetes.NewManifestReader([]byte(resourcemanager.CRD)), kubernetes.DefaultMergeFuncs); err != nil {
+		return fmt.Errorf("failed deploying ManagedResource CRD: %w", err)
+	}
+
+	return nil
+}
+
+// bootstrapPriorityClasses are the PriorityClasses used by the extension controllers and the
+// machine-controller-manager. In seed clusters, they are managed by gardenlet.
+var bootstrapPriorityClasses = map[string]int32{
+	v1beta1constants.PriorityClassNameSeedSystem900:        999998900,
+	v1beta1constants.PriorityClassNameShootControlPlane300: 999998300,
+}
+
+// DeployPriorityClasses deploys the PriorityClasses used by the extension controllers and the
+// machine-controller-manager into the bootstrap cluster.
+func (b *BootstrapBotanist) DeployPriorityClasses(ctx context.Context) error {
+	for name, value := range bootstrapPriorityClasses {
+		priorityClass := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, b.ClientSet.Client(), priorityClass, func() error {
+			priorityClass.Value = value
+			priorityClass.Description = "PriorityClass for components deployed by gardenadm bootstrap"
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed deploying PriorityClass %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// DeployNamespace deploys the shoot namespace into the bootstrap cluster. It is labeled like shoot namespaces in seed
+// clusters so that the webhooks of the extensions consider it. The garden namespace is deployed as well since it
+// contains the seed-wide resources of the machine-controller-manager.
+func (b *BootstrapBotanist) DeployNamespace(ctx context.Context) error {
+	gardenNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.GardenNamespace}}
+	if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, b.ClientSet.Client(), gardenNamespace, func() error {
+		metav1.SetMetaDataLabel(&gardenNamespace.ObjectMeta, v1beta1constants.GardenRole, v1beta1constants.GardenRoleGarden)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: b.Namespace}}
+
+	_, err := controllerutils.GetAndCreateOrMergePatch(ctx, b.ClientSet.Client(), namespace, func() error {
+		metav1.SetMetaDataLabel(&namespace.ObjectMeta, v1beta1constants.GardenRole, v1beta1constants.GardenRoleShoot)
+		metav1.SetMetaDataLabel(&namespace.ObjectMeta, v1beta1constants.LabelSeedProvider, b.Seed.Spec.Provider.Type)
+		metav1.SetMetaDataLabel(&namespace.ObjectMeta, v1beta1constants.LabelShootProvider, b.Resources.Shoot.Spec.Provider.Type)
+		if networking := b.Resources.Shoot.Spec.Networking; networking != nil && networking.Type != nil {
+			metav1.SetMetaDataLabel(&namespace.ObjectMeta, v1beta1constants.LabelNetworkingProvider, *networking.Type)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	b.machineControllerManager.SetNamespaceUID(namespace.UID)
+	return nil
+}
+
+// DeployCloudProviderSecret deploys the secret containing the infrastructure credentials into the shoot namespace of
+// the bootstrap cluster. The credentials are taken from the secret in the config directory whose name equals the
+// secret binding name of the Shoot. If there is no such secret, an empty secret is deployed which is sufficient for
+// providers not requiring credentials (e.g., provider-local).
+func (b *BootstrapBotanist) DeployCloudProviderSecret(ctx context.Context) error {
+	var data map[string][]byte
+	if name := b.Resources.Shoot.Spec.SecretBindingName; name != nil {
+		if secret := b.Resources.Secret(*name); secret != nil {
+			data = secret.Data
+		}
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.SecretNameCloudProvider, Namespace: b.Namespace}}
+	_, err := controllerutils.GetAndCreateOrMergePatch(ctx, b.ClientSet.Client(), secret, func() error {
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = data
+		return nil
+	})
+	return err
+}
+
+// DeployCluster deploys the extensions Cluster resource for the shoot namespace into the bootstrap cluster.
+func (b *BootstrapBotanist) DeployCluster(ctx context.Context) error {
+	shoot := b.Resources.Shoot.DeepCopy()
+	shoot.Spec.SeedName = ptr.To(b.Seed.Name)
+
+	return extensions.SyncClusterResourceToSeed(ctx, b.ClientSet.Client(), b.Namespace, shoot, b.Resources.CloudProfile, b.Seed)
+}
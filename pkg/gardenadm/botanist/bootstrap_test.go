@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
+	. "github.com/gardener/gardener/pkg/gardenadm/botanist"
+)
+
+var _ = Describe("BootstrapBotanist", func() {
+	var (
+		ctx        = context.Background()
+		fakeClient client.Client
+		resources  *Resources
+		b          *BootstrapBotanist
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).Build()
+
+		resources = &Resources{
+			Shoot: &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "root", Namespace: "garden-local"},
+				Spec: gardencorev1beta1.ShootSpec{
+					Kubernetes:        gardencorev1beta1.Kubernetes{Version: "1.31.1"},
+					Networking:        &gardencorev1beta1.Networking{Type: ptr.To("calico")},
+					Provider:          gardencorev1beta1.Provider{Type: "local"},
+					Region:            "local",
+					SecretBindingName: ptr.To("local"),
+				},
+			},
+			CloudProfile: &gardencorev1beta1.CloudProfile{ObjectMeta: metav1.ObjectMeta{Name: "local"}},
+			Secrets: []*corev1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "garden-local"},
+				Data:       map[string][]byte{"foo": []byte("bar")},
+			}},
+		}
+
+		var err error
+		b, err = NewBootstrapBotanist(ctx, logr.Discard(), testclock.NewFakeClock(metav1.Now().Time), resources,
+			fakekubernetes.NewClientSetBuilder().WithClient(fakeClient).WithVersion("1.31.0").Build())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("#NewBootstrapBotanist", func() {
+		It("should compute the shoot namespace and the seed", func() {
+			Expect(b.Namespace).To(Equal("shoot--local--root"))
+			Expect(b.Seed.Name).To(Equal(BootstrapSeedName))
+			Expect(b.Seed.Spec.Provider.Type).To(Equal("local"))
+			Expect(b.Seed.Spec.Provider.Region).To(Equal("local"))
+		})
+
+		It("should fail for an invalid Kubernetes version", func() {
+			resources.Shoot.Spec.Kubernetes.Version = "foo"
+
+			_, err := NewBootstrapBotanist(ctx, logr.Discard(), testclock.NewFakeClock(metav1.Now().Time), resources, fakekubernetes.NewClientSetBuilder().WithClient(fakeClient).WithVersion("1.31.0").Build())
+			Expect(err).To(MatchError(ContainSubstring("failed parsing Kubernetes version")))
+		})
+	})
+
+	Describe("#DeployPriorityClasses", func() {
+		It("should deploy the priority classes", func() {
+			Expect(b.DeployPriorityClasses(ctx)).To(Succeed())
+
+			priorityClassList := &schedulingv1.PriorityClassList{}
+			Expect(fakeClient.List(ctx, priorityClassList)).To(Succeed())
+			Expect(priorityClassList.Items).To(HaveLen(2))
+		})
+	})
+
+	Describe("#DeployNamespace", func() {
+		It("should deploy the garden and the shoot namespace", func() {
+			Expect(b.DeployNamespace(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "garden"}, &corev1.Namespace{})).To(Succeed())
+
+			namespace := &corev1.Namespace{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "shoot--local--root"}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(Equal(map[string]string{
+				"gardener.cloud/role":                      "shoot",
+				"seed.gardener.cloud/provider":             "local",
+				"shoot.gardener.cloud/provider":            "local",
+				"networking.shoot.gardener.cloud/provider": "calico",
+			}))
+		})
+	})
+
+	Describe("#DeployCloudProviderSecret", func() {
+		It("should deploy the secret with the data of the referenced secret", func() {
+			Expect(b.DeployCloudProviderSecret(ctx)).To(Succeed())
+
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "cloudprovider", Namespace: "shoot--local--root"}, secret)).To(Succeed())
+			Expect(secret.Data).To(Equal(map[string][]byte{"foo": []byte("bar")}))
+		})
+
+		It("should deploy an empty secret if the referenced secret does not exist", func() {
+			resources.Secrets = nil
+
+			Expect(b.DeployCloudProviderSecret(ctx)).To(Succeed())
+
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "cloudprovider", Namespace: "shoot--local--root"}, secret)).To(Succeed())
+			Expect(secret.Data).To(BeEmpty())
+		})
+	})
+})
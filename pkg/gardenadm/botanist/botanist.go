@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
+	kubeapiserver "github.com/gardener/gardener/pkg/component/kubernetes/apiserver"
+	shootpkg "github.com/gardener/gardener/pkg/gardenlet/operation/shoot"
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+)
+
+const (
+	// BaseDirectory is the directory on the control plane node which contains the state of gardenadm.
+	BaseDirectory = "/var/lib/gardenadm"
+	// SecretsDirectory is the directory on the control plane node which contains the secrets generated by gardenadm.
+	// They are persisted so that subsequent runs of 'gardenadm init' reuse the same certificate authorities and keys.
+	SecretsDirectory = BaseDirectory + "/secrets"
+	// PathAdminKubeconfig is the path of the kubeconfig with cluster-admin privileges written by 'gardenadm init'.
+	PathAdminKubeconfig = "/etc/kubernetes/admin.conf"
+)
+
+// AutonomousBotanist is a botanist for autonomous shoot clusters whose control plane runs as static pods on the
+// machine gardenadm is executed on. The control plane components are deployed with the regular component deployers
+// into an in-memory client and then translated into static pod manifests and files which are applied by
+// gardener-node-agent's reconciliation logic.
+type AutonomousBotanist struct {
+	// Logger is the logger.
+	Logger logr.Logger
+	// FS is the file system of the machine.
+	FS afero.Afero
+	// DBus is the interface for interacting with systemd.
+	DBus dbus.DBus
+	// Clock is the clock.
+	Clock clock.Clock
+	// Resources are the Gardener resources describing the autonomous shoot cluster.
+	Resources *Resources
+	// HostName is the host name of the machine, it is used as name of the Node object.
+	HostName string
+
+	// SeedClientSet is an in-memory client set which is the target of the component deployers.
+	SeedClientSet kubernetes.Interface
+	// SecretsManager is the secrets manager which manages the secrets in the in-memory client.
+	SecretsManager secretsmanager.Interface
+	// Namespace is the namespace in the in-memory client the control plane components are deployed to.
+	Namespace string
+	// ShootClientSet is the client set for the autonomous shoot cluster. It is initialized once the kube-apiserver is
+	// ready.
+	ShootClientSet kubernetes.Interface
+
+	// KubernetesVersion is the Kubernetes version of the autonomous shoot cluster.
+	KubernetesVersion *semver.Version
+	// Networks contains the networks of the autonomous shoot cluster.
+	Networks *shootpkg.Networks
+
+	kubeAPIServer kubeapiserver.Interface
+
+	filesMutex sync.Mutex
+	files      []extensionsv1alpha1.File
+}
+
+// NewAutonomousBotanist creates a new AutonomousBotanist for the given resources. The secrets persisted by previous
+// runs are loaded from the file system and adopted by the secrets manager.
+func NewAutonomousBotanist(ctx context.Context, log logr.Logger, fs afero.Afero, dbus dbus.DBus, clock clock.Clock, resources *Resources, hostName string) (*AutonomousBotanist, error) {
+	kubernetesVersion, err := semver.NewVersion(resources.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing Kubernetes version %q: %w", resources.Shoot.Spec.Kubernetes.Version, err)
+	}
+
+	networks, err := shootpkg.ToNetworks(resources.Shoot, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed computing networks of shoot: %w", err)
+	}
+
+	b := &AutonomousBotanist{
+		Logger:            log,
+		FS:                fs,
+		DBus:              dbus,
+		Clock:             clock,
+		Resources:         resources,
+		HostName:          strings.ToLower(hostName),
+		Namespace:         metav1.NamespaceSystem,
+		KubernetesVersion: kubernetesVersion,
+		Networks:          networks,
+		SeedClientSet: fakekubernetes.NewClientSetBuilder().
+			WithClient(fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).Build()).
+			WithVersion(kubernetesVersion.String()).
+			Build(),
+	}
+
+	if err := b.loadSecrets(ctx); err != nil {
+		return nil, err
+	}
+
+	b.SecretsManager, err = secretsmanager.New(
+		ctx,
+		log.WithName("secretsmanager"),
+		clock,
+		b.SeedClientSet.Client(),
+		b.Namespace,
+		// Use the same identity as gardenlet so that the secrets can be adopted once the cluster is connected to a garden.
+		v1beta1constants.SecretManagerIdentityGardenlet,
+		secretsmanager.Config{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating secrets manager: %w", err)
+	}
+
+	return b, nil
+}
+
+// APIServerAddress returns the address of the kube-apiserver of the autonomous shoot cluster as seen from outside of
+// the control plane node. If the Shoot specifies a DNS domain then its API server domain is used, otherwise the host
+// name of the machine.
+func (b *AutonomousBotanist) APIServerAddress() string {
+	if dns := b.Resources.Shoot.Spec.DNS; dns != nil && dns.Domain != nil {
+		return gardenerutils.GetAPIServerDomain(*dns.Domain)
+	}
+	return b.HostName
+}
+
+// Files returns the files (static pod manifests, volume contents, kubeconfigs) computed by the deploy functions so far.
+func (b *AutonomousBotanist) Files() []extensionsv1alpha1.File {
+	b.filesMutex.Lock()
+	defer b.filesMutex.Unlock()
+
+	return append([]extensionsv1alpha1.File{}, b.files...)
+}
+
+func (b *AutonomousBotanist) addFiles(files ...extensionsv1alpha1.File) {
+	b.filesMutex.Lock()
+	defer b.filesMutex.Unlock()
+
+	b.files = append(b.files, files...)
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener/imagevector"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	"github.com/gardener/gardener/pkg/component/apiserver"
+	kubeapiserver "github.com/gardener/gardener/pkg/component/kubernetes/apiserver"
+	kubecontrollermanager "github.com/gardener/gardener/pkg/component/kubernetes/controllermanager"
+	kubescheduler "github.com/gardener/gardener/pkg/component/kubernetes/scheduler"
+	"github.com/gardener/gardener/pkg/component/shared"
+	"github.com/gardener/gardener/pkg/gardenadm/staticpod"
+	imagevectorutils "github.com/gardener/gardener/pkg/utils/imagevector"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+)
+
+const (
+	secretNameKubeControllerManagerKubeconfig = "gardenadm-kube-controller-manager-kubeconfig"
+	secretNameKubeSchedulerKubeconfig         = "gardenadm-kube-scheduler-kubeconfig"
+
+	// volumeNameGenericKubeconfig is the name of the projected volume injected by
+	// gardenerutils.InjectGenericKubeconfig. It is replaced by a secret volume containing a certificate-based kubeconfig
+	// since there is no gardener-resource-manager which could provide the shoot access token.
+	volumeNameGenericKubeconfig = "kubeconfig"
+)
+
+// InterfaceAddrs returns the addresses of the network interfaces of the machine. Exposed for testing.
+var InterfaceAddrs = net.InterfaceAddrs
+
+// DeployKubeAPIServer deploys the kube-apiserver into the in-memory client and computes its static pod manifest.
+func (b *AutonomousBotanist) DeployKubeAPIServer(ctx context.Context) error {
+	shoot := b.Resources.Shoot
+
+	kubeAPIServer, err := shared.NewKubeAPIServer(
+		ctx,
+		b.SeedClientSet,
+		b.SeedClientSet.Client(),
+		b.Namespace,
+		shoot.ObjectMeta,
+		b.KubernetesVersion,
+		b.KubernetesVersion,
+		b.SecretsManager,
+		"",
+		shoot.Spec.Kubernetes.KubeAPIServer,
+		apiserver.AutoscalingConfig{
+			APIServerResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("500Mi"),
+				},
+			},
+			MinReplicas: 1,
+			MaxReplicas: 1,
+		},
+		kubeapiserver.VPNConfig{Enabled: false},
+		v1beta1constants.PriorityClassNameShootControlPlane500,
+		false,
+		shoot.Spec.Kubernetes.EnableStaticTokenKubeconfig,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	b.kubeAPIServer = kubeAPIServer
+
+	machineIPs, err := machineIPAddresses()
+	if err != nil {
+		return err
+	}
+
+	var (
+		externalHostname     = b.APIServerAddress()
+		serviceAccountConfig *gardencorev1beta1.ServiceAccountConfig
+		resourcesToEncrypt   []string
+	)
+
+	if shoot.Spec.Kubernetes.KubeAPIServer != nil {
+		serviceAccountConfig = shoot.Spec.Kubernetes.KubeAPIServer.ServiceAccountConfig
+		resourcesToEncrypt = shared.NormalizeResources(shared.GetResourcesForEncryptionFromConfig(shoot.Spec.Kubernetes.KubeAPIServer.EncryptionConfig))
+	}
+
+	if err := shared.DeployKubeAPIServer(
+		ctx,
+		b.SeedClientSet.Client(),
+		b.Namespace,
+		kubeAPIServer,
+		kubeapiserver.ComputeKubeAPIServerServiceAccountConfig(serviceAccountConfig, externalHostname, v1beta1helper.GetShootServiceAccountKeyRotationPhase(shoot.Status.Credentials)),
+		kubeapiserver.ServerCertificateConfig{
+			ExtraIPAddresses: append(append([]net.IP{net.ParseIP("127.0.0.1")}, b.Networks.APIServer...), machineIPs...),
+			ExtraDNSNames:    []string{"localhost", b.HostName, externalHostname},
+		},
+		kubeapiserver.SNIConfig{},
+		externalHostname,
+		externalHostname,
+		b.Networks.Nodes,
+		b.Networks.Services,
+		b.Networks.Pods,
+		resourcesToEncrypt,
+		shared.NormalizeResources(shoot.Status.EncryptedResources),
+		v1beta1helper.GetShootETCDEncryptionKeyRotationPhase(shoot.Status.Credentials),
+		false,
+	); err != nil {
+		return err
+	}
+
+	return b.translateDeployment(ctx, v1beta1constants.DeploymentNameKubeAPIServer, func(deployment *appsv1.Deployment) {
+		deployment.Spec.Template.Spec.HostAliases = append(deployment.Spec.Template.Spec.HostAliases, corev1.HostAlias{
+			IP:        "127.0.0.1",
+			Hostnames: etcdHostNames,
+		})
+	})
+}
+
+// machineIPAddresses returns the global unicast IP addresses of the machine. They are added to the serving certificate
+// of kube-apiserver so that further nodes can join the cluster via the IP address of the control plane node.
+func machineIPAddresses() ([]net.IP, error) {
+	addresses, err := InterfaceAddrs()
+	if err != nil {
+		return nil, fmt.Errorf("failed listing addresses of network interfaces: %w", err)
+	}
+
+	var ips []net.IP
+	for _, address := range addresses {
+		ipNet, ok := address.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+		ips = append(ips, ipNet.IP)
+	}
+
+	return ips, nil
+}
+
+// DeployKubeControllerManager deploys the kube-controller-manager into the in-memory client and computes its static pod
+// manifest.
+func (b *AutonomousBotanist) DeployKubeControllerManager(ctx context.Context) error {
+	kubeControllerManager, err := shared.NewKubeControllerManager(
+		b.Logger,
+		b.SeedClientSet,
+		b.Namespace,
+		b.KubernetesVersion,
+		b.KubernetesVersion,
+		b.SecretsManager,
+		"",
+		b.Resources.Shoot.Spec.Kubernetes.KubeControllerManager,
+		v1beta1constants.PriorityClassNameShootControlPlane300,
+		false,
+		false,
+		nil,
+		kubecontrollermanager.ControllerWorkers{},
+		kubecontrollermanager.ControllerSyncPeriods{},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	kubeControllerManager.SetReplicaCount(1)
+	if b.kubeAPIServer != nil {
+		kubeControllerManager.SetRuntimeConfig(b.kubeAPIServer.GetValues().RuntimeConfig)
+	}
+	kubeControllerManager.SetServiceNetworks(b.Networks.Services)
+	kubeControllerManager.SetPodNetworks(b.Networks.Pods)
+
+	if err := kubeControllerManager.Deploy(ctx); err != nil {
+		return err
+	}
+
+	kubeconfigSecret, err := b.generateKubeconfig(ctx, secretNameKubeControllerManagerKubeconfig, user.KubeControllerManager, nil)
+	if err != nil {
+		return fmt.Errorf("failed generating kubeconfig for kube-controller-manager: %w", err)
+	}
+
+	return b.translateDeployment(ctx, v1beta1constants.DeploymentNameKubeControllerManager, replaceGenericKubeconfig(kubeconfigSecret.Name))
+}
+
+// DeployKubeScheduler deploys the kube-scheduler into the in-memory client and computes its static pod manifest.
+func (b *AutonomousBotanist) DeployKubeScheduler(ctx context.Context) error {
+	image, err := imagevector.Containers().FindImage(imagevector.ContainerImageNameKubeScheduler, imagevectorutils.RuntimeVersion(b.KubernetesVersion.String()), imagevectorutils.TargetVersion(b.KubernetesVersion.String()))
+	if err != nil {
+		return err
+	}
+
+	if err := kubescheduler.New(
+		b.SeedClientSet.Client(),
+		b.Namespace,
+		b.SecretsManager,
+		b.KubernetesVersion,
+		b.KubernetesVersion,
+		image.String(),
+		1,
+		b.Resources.Shoot.Spec.Kubernetes.KubeScheduler,
+	).Deploy(ctx); err != nil {
+		return err
+	}
+
+	kubeconfigSecret, err := b.generateKubeconfig(ctx, secretNameKubeSchedulerKubeconfig, user.KubeScheduler, nil)
+	if err != nil {
+		return fmt.Errorf("failed generating kubeconfig for kube-scheduler: %w", err)
+	}
+
+	return b.translateDeployment(ctx, v1beta1constants.DeploymentNameKubeScheduler, replaceGenericKubeconfig(kubeconfigSecret.Name))
+}
+
+// translateDeployment reads the deployment with the given name from the in-memory client, applies the given mutation
+// and translates it into a static pod manifest.
+func (b *AutonomousBotanist) translateDeployment(ctx context.Context, name string, mutate func(*appsv1.Deployment)) error {
+	deployment := &appsv1.Deployment{}
+	if err := b.SeedClientSet.Client().Get(ctx, client.ObjectKey{Namespace: b.Namespace, Name: name}, deployment); err != nil {
+		return fmt.Errorf("failed reading deployment %s: %w", name, err)
+	}
+
+	if mutate != nil {
+		mutate(deployment)
+	}
+
+	files, err := staticpod.Translate(ctx, b.SeedClientSet.Client(), deployment)
+	if err != nil {
+		return fmt.Errorf("failed translating deployment %s into static pod: %w", name, err)
+	}
+
+	b.addFiles(files...)
+	return nil
+}
+
+func replaceGenericKubeconfig(secretName string) func(*appsv1.Deployment) {
+	return func(deployment *appsv1.Deployment) {
+		for i, volume := range deployment.Spec.Template.Spec.Volumes {
+			if volume.Name != volumeNameGenericKubeconfig {
+				continue
+			}
+
+			deployment.Spec.Template.Spec.Volumes[i].VolumeSource = corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  secretName,
+					Items:       []corev1.KeyToPath{{Key: secretsutils.DataKeyKubeconfig, Path: secretsutils.DataKeyKubeconfig}},
+					DefaultMode: ptr.To[int32](0640),
+				},
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/gardener/imagevector"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/component/etcd/etcd"
+	etcdconstants "github.com/gardener/gardener/pkg/component/etcd/etcd/constants"
+	"github.com/gardener/gardener/pkg/gardenadm/staticpod"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+)
+
+const (
+	// DirectoryEtcdData is the directory on the control plane node which contains the etcd data.
+	DirectoryEtcdData = BaseDirectory + "/etcd"
+
+	etcdName                   = "etcd"
+	etcdSecretNameServer       = "etcd-server-" + v1beta1constants.ETCDRoleMain
+	etcdPortMetrics      int32 = 2381
+
+	etcdVolumeNameCA     = "ca"
+	etcdVolumeNameServer = "server"
+	etcdVolumeNameData   = "data"
+
+	etcdVolumeMountPathCA     = "/var/etcd/ssl/ca"
+	etcdVolumeMountPathServer = "/var/etcd/ssl/server"
+	etcdVolumeMountPathData   = "/var/etcd/data"
+)
+
+// etcdHostNames are the host names of the etcd services which are used by kube-apiserver. A single etcd static pod
+// serves both the 'main' and 'events' roles, hence both names are mapped to the loopback address.
+var etcdHostNames = []string{
+	etcdconstants.ServiceName(v1beta1constants.ETCDRoleMain),
+	etcdconstants.ServiceName(v1beta1constants.ETCDRoleEvents),
+}
+
+// DeployEtcd computes the static pod manifest for a single-member etcd which listens on the loopback interface only.
+// The certificates are generated by the secrets manager, similar to what the etcd component does for regular shoots.
+func (b *AutonomousBotanist) DeployEtcd(ctx context.Context) error {
+	image, err := imagevector.Containers().FindImage(imagevector.ContainerImageNameEtcd)
+	if err != nil {
+		return err
+	}
+
+	caSecret, found := b.SecretsManager.Get(v1beta1constants.SecretNameCAETCD)
+	if !found {
+		return fmt.Errorf("secret %q not found", v1beta1constants.SecretNameCAETCD)
+	}
+
+	serverSecret, err := b.SecretsManager.Generate(ctx, &secretsutils.CertificateSecretConfig{
+		Name:                        etcdSecretNameServer,
+		CommonName:                  "etcd-server",
+		DNSNames:                    append([]string{"localhost"}, etcdHostNames...),
+		IPAddresses:                 []net.IP{net.ParseIP("127.0.0.1")},
+		CertType:                    secretsutils.ServerClientCert,
+		SkipPublishingCACertificate: true,
+	}, secretsmanager.SignedByCA(v1beta1constants.SecretNameCAETCD), secretsmanager.Rotate(secretsmanager.InPlace))
+	if err != nil {
+		return err
+	}
+
+	if _, err := b.SecretsManager.Generate(ctx, &secretsutils.CertificateSecretConfig{
+		Name:                        etcd.SecretNameClient,
+		CommonName:                  "etcd-client",
+		CertType:                    secretsutils.ClientCert,
+		SkipPublishingCACertificate: true,
+	}, secretsmanager.SignedByCA(v1beta1constants.SecretNameCAETCD), secretsmanager.Rotate(secretsmanager.InPlace)); err != nil {
+		return err
+	}
+
+	var (
+		clientURL  = fmt.Sprintf("https://127.0.0.1:%d", etcdconstants.PortEtcdClient)
+		peerURL    = fmt.Sprintf("http://127.0.0.1:%d", etcdconstants.PortEtcdPeer)
+		metricsURL = fmt.Sprintf("http://127.0.0.1:%d", etcdPortMetrics)
+	)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdName,
+			Namespace: b.Namespace,
+			Labels: map[string]string{
+				v1beta1constants.LabelApp:  etcdName,
+				v1beta1constants.LabelRole: v1beta1constants.ETCDRoleMain,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:            etcdName,
+				Image:           image.String(),
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command: []string{
+					"etcd",
+					"--name=" + b.HostName,
+					"--data-dir=" + etcdVolumeMountPathData,
+					"--listen-client-urls=" + clientURL,
+					"--advertise-client-urls=" + clientURL,
+					"--listen-peer-urls=" + peerURL,
+					"--initial-advertise-peer-urls=" + peerURL,
+					"--initial-cluster=" + b.HostName + "=" + peerURL,
+					"--listen-metrics-urls=" + metricsURL,
+					"--client-cert-auth=true",
+					fmt.Sprintf("--trusted-ca-file=%s/%s", etcdVolumeMountPathCA, secretsutils.DataKeyCertificateBundle),
+					fmt.Sprintf("--cert-file=%s/%s", etcdVolumeMountPathServer, secretsutils.DataKeyCertificate),
+					fmt.Sprintf("--key-file=%s/%s", etcdVolumeMountPathServer, secretsutils.DataKeyPrivateKey),
+					"--snapshot-count=10000",
+					"--auto-compaction-mode=periodic",
+					"--auto-compaction-retention=30m",
+				},
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Host:   "127.0.0.1",
+							Path:   "/health",
+							Port:   intstr.FromInt32(etcdPortMetrics),
+							Scheme: corev1.URISchemeHTTP,
+						},
+					},
+					InitialDelaySeconds: 10,
+					PeriodSeconds:       10,
+					TimeoutSeconds:      15,
+					FailureThreshold:    8,
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: etcdVolumeNameCA, MountPath: etcdVolumeMountPathCA},
+					{Name: etcdVolumeNameServer, MountPath: etcdVolumeMountPathServer},
+					{Name: etcdVolumeNameData, MountPath: etcdVolumeMountPathData},
+				},
+			}},
+			Volumes: []corev1.Volume{
+				{
+					Name: etcdVolumeNameCA,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: caSecret.Name, DefaultMode: ptr.To[int32](0640)},
+					},
+				},
+				{
+					Name: etcdVolumeNameServer,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: serverSecret.Name, DefaultMode: ptr.To[int32](0640)},
+					},
+				},
+				{
+					Name: etcdVolumeNameData,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: DirectoryEtcdData, Type: ptr.To(corev1.HostPathDirectoryOrCreate)},
+					},
+				},
+			},
+		},
+	}
+
+	files, err := staticpod.Translate(ctx, b.SeedClientSet.Client(), pod)
+	if err != nil {
+		return fmt.Errorf("failed translating etcd into static pod: %w", err)
+	}
+
+	b.addFiles(files...)
+	return nil
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/component-base/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"github.com/gardener/gardener/pkg/features"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	"github.com/gardener/gardener/pkg/utils/oci"
+	"github.com/gardener/gardener/pkg/utils/retry"
+)
+
+var (
+	// IntervalWaitForExtensionControllers is the interval for waiting until the extension controllers are ready.
+	// Exposed for testing.
+	IntervalWaitForExtensionControllers = 5 * time.Second
+	// TimeoutWaitForExtensionControllers is the timeout for waiting until the extension controllers are ready. Exposed
+	// for testing.
+	TimeoutWaitForExtensionControllers = 5 * time.Minute
+
+	// PullHelmChart pulls the Helm chart archive from the given OCI repository. Exposed for testing.
+	PullHelmChart = func(ctx context.Context, repository *gardencorev1.OCIRepository) ([]byte, error) {
+		registry, err := oci.NewHelmRegistry()
+		if err != nil {
+			return nil, err
+		}
+		return registry.Pull(ctx, repository)
+	}
+)
+
+// DeployExtensionControllers deploys the extension controllers described by the ControllerRegistrations and
+// ControllerDeployments into the bootstrap cluster. Similar to gardenlet's ControllerInstallation controller, the Helm
+// charts are rendered with the standard values for the seed. The rendered objects are applied directly since
+// gardener-resource-manager is not running in the bootstrap cluster.
+func (b *BootstrapBotanist) DeployExtensionControllers(ctx context.Context) error {
+	for _, controllerRegistration := range b.Resources.ControllerRegistrations {
+		controllerDeployment, err := b.controllerDeploymentFor(controllerRegistration)
+		if err != nil {
+			return err
+		}
+		if controllerDeployment == nil {
+			b.Logger.Info("Skipping ControllerRegistration without ControllerDeployment", "controllerRegistration", controllerRegistration.Name)
+			continue
+		}
+
+		if err := b.deployExtensionController(ctx, controllerRegistration, controllerDeployment); err != nil {
+			return fmt.Errorf("failed deploying extension controller for ControllerRegistration %q: %w", controllerRegistration.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// WaitUntilExtensionControllersReady waits until the deployments of all extension controllers are healthy.
+func (b *BootstrapBotanist) WaitUntilExtensionControllersReady(ctx context.Context) error {
+	return retry.UntilTimeout(ctx, IntervalWaitForExtensionControllers, TimeoutWaitForExtensionControllers, func(ctx context.Context) (bool, error) {
+		for _, controllerRegistration := range b.Resources.ControllerRegistrations {
+			deploymentList := &appsv1.DeploymentList{}
+			if err := b.ClientSet.Client().List(ctx, deploymentList, client.InNamespace(extensionNamespace(controllerRegistration.Name))); err != nil {
+				return retry.SevereError(fmt.Errorf("failed listing deployments of extension %q: %w", controllerRegistration.Name, err))
+			}
+
+			for _, deployment := range deploymentList.Items {
+				if err := health.CheckDeployment(&deployment); err != nil {
+					b.Logger.Info("Waiting for extension controller to become ready", "deployment", client.ObjectKeyFromObject(&deployment), "reason", err.Error())
+					return retry.MinorError(fmt.Errorf("deployment %s is unhealthy: %w", client.ObjectKeyFromObject(&deployment), err))
+				}
+			}
+		}
+
+		return retry.Ok()
+	})
+}
+
+func (b *BootstrapBotanist) controllerDeploymentFor(controllerRegistration *gardencorev1beta1.ControllerRegistration) (*gardencorev1beta1.ControllerDeployment, error) {
+	if controllerRegistration.Spec.Deployment == nil || len(controllerRegistration.Spec.Deployment.DeploymentRefs) == 0 {
+		return nil, nil
+	}
+
+	name := controllerRegistration.Spec.Deployment.DeploymentRefs[0].Name
+	for _, controllerDeployment := range b.Resources.ControllerDeployments {
+		if controllerDeployment.Name == name {
+			return controllerDeployment, nil
+		}
+	}
+
+	return nil, fmt.Errorf("ControllerDeployment %q referenced by ControllerRegistration %q not found", name, controllerRegistration.Name)
+}
+
+func (b *BootstrapBotanist) deployExtensionController(ctx context.Context, controllerRegistration *gardencorev1beta1.ControllerRegistration, controllerDeployment *gardencorev1beta1.ControllerDeployment) error {
+	if controllerDeployment.Type != gardencorev1beta1.ControllerDeploymentTypeHelm {
+		return fmt.Errorf("unsupported type %q of ControllerDeployment %q, only %q is supported", controllerDeployment.Type, controllerDeployment.Name, gardencorev1beta1.ControllerDeploymentTypeHelm)
+	}
+
+	helmDeployment := &gardencorev1beta1.HelmControllerDeployment{}
+	if len(controllerDeployment.ProviderConfig.Raw) > 0 {
+		if err := json.Unmarshal(controllerDeployment.ProviderConfig.Raw, helmDeployment); err != nil {
+			return fmt.Errorf("failed decoding Helm deployment of ControllerDeployment %q: %w", controllerDeployment.Name, err)
+		}
+	}
+
+	var helmValues map[string]any
+	if helmDeployment.Values != nil {
+		if err := json.Unmarshal(helmDeployment.Values.Raw, &helmValues); err != nil {
+			return fmt.Errorf("failed decoding chart values of ControllerDeployment %q: %w", controllerDeployment.Name, err)
+		}
+	}
+
+	archive := helmDeployment.Chart
+	if len(archive) == 0 {
+		if helmDeployment.OCIRepository == nil {
+			return fmt.Errorf("ControllerDeployment %q neither contains a chart nor an OCI repository", controllerDeployment.Name)
+		}
+
+		var err error
+		archive, err = PullHelmChart(ctx, &gardencorev1.OCIRepository{
+			Ref:        helmDeployment.OCIRepository.Ref,
+			Repository: helmDeployment.OCIRepository.Repository,
+			Tag:        helmDeployment.OCIRepository.Tag,
+			Digest:     helmDeployment.OCIRepository.Digest,
+		})
+		if err != nil {
+			return fmt.Errorf("failed pulling chart of ControllerDeployment %q: %w", controllerDeployment.Name, err)
+		}
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: extensionNamespace(controllerRegistration.Name)}}
+	if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, b.ClientSet.Client(), namespace, func() error {
+		metav1.SetMetaDataLabel(&namespace.ObjectMeta, v1beta1constants.GardenRole, v1beta1constants.GardenRoleExtension)
+		metav1.SetMetaDataLabel(&namespace.ObjectMeta, v1beta1constants.LabelControllerRegistrationName, controllerRegistration.Name)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed deploying namespace %q: %w", namespace.Name, err)
+	}
+
+	b.Logger.Info("Deploying extension controller", "controllerRegistration", controllerRegistration.Name, "namespace", namespace.Name)
+	return b.ClientSet.ChartApplier().ApplyFromArchive(ctx, archive, namespace.Name, controllerRegistration.Name, kubernetes.Values(utils.MergeMaps(helmValues, b.gardenerValues())))
+}
+
+// gardenerValues returns the standard values which are passed to the charts of the extension controllers, see
+// gardenlet's ControllerInstallation controller.
+func (b *BootstrapBotanist) gardenerValues() map[string]any {
+	featureToEnabled := make(map[featuregate.Feature]bool)
+	for feature := range features.DefaultFeatureGate.GetAll() {
+		featureToEnabled[feature] = features.DefaultFeatureGate.Enabled(feature)
+	}
+
+	return map[string]any{
+		"gardener": map[string]any{
+			"version": version.Get().GitVersion,
+			"seed": map[string]any{
+				"name":     b.Seed.Name,
+				"provider": b.Seed.Spec.Provider.Type,
+				"region":   b.Seed.Spec.Provider.Region,
+				"spec":     b.Seed.Spec,
+			},
+			"gardenlet": map[string]any{
+				"featureGates": featureToEnabled,
+			},
+		},
+	}
+}
+
+func extensionNamespace(controllerRegistrationName string) string {
+	return "extension-" + controllerRegistrationName
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/utils/ptr"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/kubelet"
+	"github.com/gardener/gardener/pkg/utils"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+)
+
+const (
+	secretNameAdminKubeconfig   = "gardenadm-admin-kubeconfig"
+	secretNameKubeletKubeconfig = "gardenadm-kubelet-kubeconfig"
+)
+
+// generateKubeconfig generates a secret containing a kubeconfig with a client certificate signed by the client CA for
+// the given user. The kube-apiserver is addressed via the loopback interface.
+func (b *AutonomousBotanist) generateKubeconfig(ctx context.Context, name, commonName string, organization []string) (*corev1.Secret, error) {
+	caBundleSecret, found := b.SecretsManager.Get(v1beta1constants.SecretNameCACluster)
+	if !found {
+		return nil, fmt.Errorf("secret %q not found", v1beta1constants.SecretNameCACluster)
+	}
+
+	return b.SecretsManager.Generate(ctx, &secretsutils.ControlPlaneSecretConfig{
+		Name: name,
+		CertificateSecretConfig: &secretsutils.CertificateSecretConfig{
+			CommonName:                  commonName,
+			Organization:                organization,
+			CertType:                    secretsutils.ClientCert,
+			SkipPublishingCACertificate: true,
+		},
+		KubeConfigRequests: []secretsutils.KubeConfigRequest{{
+			ClusterName:   b.Resources.Shoot.Name,
+			APIServerHost: "localhost",
+			CAData:        caBundleSecret.Data[secretsutils.DataKeyCertificateBundle],
+		}},
+	}, secretsmanager.SignedByCA(v1beta1constants.SecretNameCAClient), secretsmanager.Rotate(secretsmanager.InPlace))
+}
+
+// DeployKubeconfigs computes the files for the kubeconfig with cluster-admin privileges and for the kubeconfig of the
+// kubelet. The latter is written directly to the path of the kubelet's real kubeconfig so that no TLS bootstrapping is
+// required for the control plane node.
+func (b *AutonomousBotanist) DeployKubeconfigs(ctx context.Context) error {
+	adminKubeconfig, err := b.generateKubeconfig(ctx, secretNameAdminKubeconfig, "gardenadm:admin", []string{user.SystemPrivilegedGroup})
+	if err != nil {
+		return fmt.Errorf("failed generating admin kubeconfig: %w", err)
+	}
+
+	kubeletKubeconfig, err := b.generateKubeconfig(ctx, secretNameKubeletKubeconfig, "system:node:"+b.HostName, []string{user.NodesGroup})
+	if err != nil {
+		return fmt.Errorf("failed generating kubelet kubeconfig: %w", err)
+	}
+
+	b.addFiles(
+		kubeconfigFile(PathAdminKubeconfig, adminKubeconfig),
+		kubeconfigFile(kubelet.PathKubeconfigReal, kubeletKubeconfig),
+	)
+	return nil
+}
+
+func kubeconfigFile(path string, secret *corev1.Secret) extensionsv1alpha1.File {
+	return extensionsv1alpha1.File{
+		Path:        path,
+		Permissions: ptr.To[uint32](0600),
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Encoding: "b64",
+				Data:     utils.EncodeBase64(secret.Data[secretsutils.DataKeyKubeconfig]),
+			},
+		},
+	}
+}
@@ -0,0 +1,392 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener/imagevector"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/component/extensions/dnsrecord"
+	"github.com/gardener/gardener/pkg/component/extensions/infrastructure"
+	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig"
+	"github.com/gardener/gardener/pkg/component/extensions/worker"
+	"github.com/gardener/gardener/pkg/component/nodemanagement/machinecontrollermanager"
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"github.com/gardener/gardener/pkg/extensions"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	imagevectorutils "github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/gardener/gardener/pkg/utils/retry"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+)
+
+var (
+	// IntervalWaitForMachines is the interval for waiting until the machines are created. Exposed for testing.
+	IntervalWaitForMachines = 5 * time.Second
+	// TimeoutWaitForMachines is the timeout for waiting until the machines are created. Exposed for testing.
+	TimeoutWaitForMachines = 10 * time.Minute
+)
+
+func (b *BootstrapBotanist) defaultInfrastructure() infrastructure.Interface {
+	return infrastructure.New(
+		b.Logger,
+		b.ClientSet.Client(),
+		&infrastructure.Values{
+			Namespace:      b.Namespace,
+			Name:           b.Resources.Shoot.Name,
+			Type:           b.Resources.Shoot.Spec.Provider.Type,
+			ProviderConfig: b.Resources.Shoot.Spec.Provider.InfrastructureConfig,
+			Region:         b.Resources.Shoot.Spec.Region,
+		},
+		infrastructure.DefaultInterval,
+		infrastructure.DefaultSevereThreshold,
+		infrastructure.DefaultTimeout,
+	)
+}
+
+func (b *BootstrapBotanist) defaultWorker() worker.Interface {
+	return worker.New(
+		b.Logger,
+		b.ClientSet.Client(),
+		&worker.Values{
+			Namespace:         b.Namespace,
+			Name:              b.Resources.Shoot.Name,
+			Type:              b.Resources.Shoot.Spec.Provider.Type,
+			Region:            b.Resources.Shoot.Spec.Region,
+			Workers:           b.Resources.Shoot.Spec.Provider.Workers,
+			KubernetesVersion: b.KubernetesVersion,
+			MachineTypes:      b.Resources.CloudProfile.Spec.MachineTypes,
+		},
+		worker.DefaultInterval,
+		worker.DefaultSevereThreshold,
+		worker.DefaultTimeout,
+	)
+}
+
+func (b *BootstrapBotanist) defaultMachineControllerManager() (machinecontrollermanager.Interface, error) {
+	image, err := imagevector.Containers().FindImage(imagevector.ContainerImageNameMachineControllerManager, imagevectorutils.RuntimeVersion(b.ClientSet.Version()), imagevectorutils.TargetVersion(b.KubernetesVersion.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeVersion, err := semver.NewVersion(b.ClientSet.Version())
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing Kubernetes version of bootstrap cluster: %w", err)
+	}
+
+	return machinecontrollermanager.New(
+		b.ClientSet.Client(),
+		b.Namespace,
+		b.SecretsManager,
+		machinecontrollermanager.Values{
+			Image:                    image.String(),
+			Replicas:                 1,
+			RuntimeKubernetesVersion: runtimeVersion,
+		},
+	), nil
+}
+
+// DeployInfrastructure deploys the Infrastructure resource into the bootstrap cluster.
+func (b *BootstrapBotanist) DeployInfrastructure(ctx context.Context) error {
+	return b.infrastructure.Deploy(ctx)
+}
+
+// WaitForInfrastructure waits until the Infrastructure resource has been reconciled and passes its provider status to
+// the Worker.
+func (b *BootstrapBotanist) WaitForInfrastructure(ctx context.Context) error {
+	if err := b.infrastructure.Wait(ctx); err != nil {
+		return err
+	}
+
+	b.worker.SetInfrastructureProviderStatus(b.infrastructure.ProviderStatus())
+	return nil
+}
+
+// DeployOperatingSystemConfigs deploys an OperatingSystemConfig resource with purpose 'provision' for each worker
+// pool into the bootstrap cluster, waits until the operating system extensions have generated the user data and
+// passes the user data secrets to the Worker. The machines are not supposed to join any cluster on their own, hence
+// the OperatingSystemConfigs do not contain any units or files. Instead, 'gardenadm init' or 'gardenadm join' is
+// executed on the machines after they have been created.
+func (b *BootstrapBotanist) DeployOperatingSystemConfigs(ctx context.Context) error {
+	workerPoolNameToOperatingSystemConfigs := make(map[string]*operatingsystemconfig.OperatingSystemConfigs, len(b.Resources.Shoot.Spec.Provider.Workers))
+
+	for _, pool := range b.Resources.Shoot.Spec.Provider.Workers {
+		if pool.Machine.Image == nil {
+			return fmt.Errorf("worker pool %q does not specify a machine image", pool.Name)
+		}
+
+		osc := &extensionsv1alpha1.OperatingSystemConfig{ObjectMeta: metav1.ObjectMeta{Name: "gardenadm-bootstrap-" + pool.Name, Namespace: b.Namespace}}
+		if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, b.ClientSet.Client(), osc, func() error {
+			metav1.SetMetaDataAnnotation(&osc.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)
+			metav1.SetMetaDataAnnotation(&osc.ObjectMeta, v1beta1constants.GardenerTimestamp, b.Clock.Now().UTC().Format(time.RFC3339Nano))
+			metav1.SetMetaDataLabel(&osc.ObjectMeta, v1beta1constants.LabelWorkerPool, pool.Name)
+
+			osc.Spec.Type = pool.Machine.Image.Name
+			osc.Spec.ProviderConfig = pool.Machine.Image.ProviderConfig
+			osc.Spec.Purpose = extensionsv1alpha1.OperatingSystemConfigPurposeProvision
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed deploying OperatingSystemConfig %s: %w", client.ObjectKeyFromObject(osc), err)
+		}
+
+		if err := extensions.WaitUntilExtensionObjectReady(
+			ctx,
+			b.ClientSet.Client(),
+			b.Logger,
+			osc,
+			extensionsv1alpha1.OperatingSystemConfigResource,
+			operatingsystemconfig.DefaultInterval,
+			operatingsystemconfig.DefaultSevereThreshold,
+			operatingsystemconfig.DefaultTimeout,
+			nil,
+		); err != nil {
+			return err
+		}
+
+		if osc.Status.CloudConfig == nil {
+			return fmt.Errorf("OperatingSystemConfig %s does not reference a user data secret", client.ObjectKeyFromObject(osc))
+		}
+
+		workerPoolNameToOperatingSystemConfigs[pool.Name] = &operatingsystemconfig.OperatingSystemConfigs{
+			Init: operatingsystemconfig.Data{
+				Object:     osc,
+				SecretName: ptr.To(osc.Status.CloudConfig.SecretRef.Name),
+			},
+		}
+	}
+
+	b.worker.SetWorkerPoolNameToOperatingSystemConfigsMap(workerPoolNameToOperatingSystemConfigs)
+	return nil
+}
+
+// DeployMachineControllerManager deploys the machine-controller-manager into the shoot namespace of the bootstrap
+// cluster. As there is no shoot cluster yet, the bootstrap cluster itself is used as target cluster of the
+// machine-controller-manager. Its RBAC resources and access token are provided by this function since
+// gardener-resource-manager is not running in the bootstrap cluster.
+func (b *BootstrapBotanist) DeployMachineControllerManager(ctx context.Context) error {
+	if err := b.generateGenericTokenKubeconfig(ctx); err != nil {
+		return err
+	}
+
+	if err := machinecontrollermanager.NewBootstrapper(b.ClientSet.Client(), v1beta1constants.GardenNamespace).Deploy(ctx); err != nil {
+		return fmt.Errorf("failed deploying machine-controller-manager bootstrap resources: %w", err)
+	}
+
+	if err := b.machineControllerManager.Deploy(ctx); err != nil {
+		return fmt.Errorf("failed deploying machine-controller-manager: %w", err)
+	}
+
+	for _, namespace := range []string{v1beta1constants.GardenNamespace, b.Namespace} {
+		if err := applyManagedResources(ctx, b.Logger, b.ClientSet.Client(), b.ClientSet.Client(), namespace, func(managedResource *resourcesv1alpha1.ManagedResource) bool {
+			return strings.Contains(managedResource.Name, v1beta1constants.DeploymentNameMachineControllerManager)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := b.requestAccessTokens(ctx); err != nil {
+		return err
+	}
+
+	return b.machineControllerManager.Wait(ctx)
+}
+
+// ScaleDownMachineControllerManager scales down the machine-controller-manager once the machines have been created.
+// The machines never join the bootstrap cluster as nodes, hence machine-controller-manager would replace them after
+// the machine creation timeout.
+func (b *BootstrapBotanist) ScaleDownMachineControllerManager(ctx context.Context) error {
+	return kubernetes.ScaleDeployment(ctx, b.ClientSet.Client(), client.ObjectKey{Namespace: b.Namespace, Name: v1beta1constants.DeploymentNameMachineControllerManager}, 0)
+}
+
+// DeployWorker deploys the Worker resource into the bootstrap cluster.
+func (b *BootstrapBotanist) DeployWorker(ctx context.Context) error {
+	return b.worker.Deploy(ctx)
+}
+
+// WaitUntilWorkerMachineDeploymentsCreated waits until the provider extension has created the MachineDeployments for
+// the Worker. Waiting until the Worker is ready is not possible since the machines never join the bootstrap cluster.
+func (b *BootstrapBotanist) WaitUntilWorkerMachineDeploymentsCreated(ctx context.Context) error {
+	return b.worker.WaitUntilWorkerStatusMachineDeploymentsUpdated(ctx)
+}
+
+// WaitUntilMachinesCreated waits until the machines of all MachineDeployments have been created at the provider and
+// returns them sorted by name.
+func (b *BootstrapBotanist) WaitUntilMachinesCreated(ctx context.Context) ([]machinev1alpha1.Machine, error) {
+	var machines []machinev1alpha1.Machine
+
+	if err := retry.UntilTimeout(ctx, IntervalWaitForMachines, TimeoutWaitForMachines, func(ctx context.Context) (bool, error) {
+		machineDeploymentList := &machinev1alpha1.MachineDeploymentList{}
+		if err := b.ClientSet.Client().List(ctx, machineDeploymentList, client.InNamespace(b.Namespace)); err != nil {
+			return retry.SevereError(fmt.Errorf("failed listing MachineDeployments: %w", err))
+		}
+
+		var desiredReplicas int32
+		for _, machineDeployment := range machineDeploymentList.Items {
+			desiredReplicas += machineDeployment.Spec.Replicas
+		}
+
+		machineList := &machinev1alpha1.MachineList{}
+		if err := b.ClientSet.Client().List(ctx, machineList, client.InNamespace(b.Namespace)); err != nil {
+			return retry.SevereError(fmt.Errorf("failed listing Machines: %w", err))
+		}
+
+		var createdMachines []machinev1alpha1.Machine
+		for _, machine := range machineList.Items {
+			if machine.Spec.ProviderID != "" {
+				createdMachines = append(createdMachines, machine)
+			}
+		}
+
+		if desiredReplicas == 0 || int32(len(createdMachines)) < desiredReplicas { // #nosec G115 -- The number of machines is small.
+			b.Logger.Info("Waiting until machines are created", "desired", desiredReplicas, "created", len(createdMachines))
+			return retry.MinorError(fmt.Errorf("only %d/%d machines are created", len(createdMachines), desiredReplicas))
+		}
+
+		slices.SortFunc(createdMachines, func(a, b machinev1alpha1.Machine) int { return strings.Compare(a.Name, b.Name) })
+		machines = createdMachines
+		return retry.Ok()
+	}); err != nil {
+		return nil, err
+	}
+
+	return machines, nil
+}
+
+// DeployDNSRecord deploys the DNSRecord for the kube-apiserver domain of the autonomous shoot cluster pointing to the
+// given address and waits until it has been reconciled. It is a no-op if the Shoot does not specify a DNS domain and a
+// primary DNS provider.
+func (b *BootstrapBotanist) DeployDNSRecord(ctx context.Context, address string) error {
+	dns := b.Resources.Shoot.Spec.DNS
+	if dns == nil || dns.Domain == nil {
+		return nil
+	}
+
+	provider := v1beta1helper.FindPrimaryDNSProvider(dns.Providers)
+	if provider == nil || provider.Type == nil {
+		return nil
+	}
+
+	secretData := map[string][]byte{}
+	if provider.SecretName != nil {
+		secret := b.Resources.Secret(*provider.SecretName)
+		if secret == nil {
+			return fmt.Errorf("secret %q of primary DNS provider not found", *provider.SecretName)
+		}
+		secretData = secret.Data
+	}
+
+	var zone *string
+	if provider.Zones != nil && len(provider.Zones.Include) == 1 {
+		zone = &provider.Zones.Include[0]
+	}
+
+	deployer := dnsrecord.New(
+		b.Logger,
+		b.ClientSet.Client(),
+		&dnsrecord.Values{
+			Name:       b.Resources.Shoot.Name + "-" + v1beta1constants.DNSRecordExternalName,
+			SecretName: "dnsrecord-" + b.Resources.Shoot.Name + "-" + v1beta1constants.DNSRecordExternalName,
+			Namespace:  b.Namespace,
+			Type:       *provider.Type,
+			SecretData: secretData,
+			Zone:       zone,
+			DNSName:    gardenerutils.GetAPIServerDomain(*dns.Domain),
+			RecordType: extensionsv1alpha1helper.GetDNSRecordType(address),
+			Values:     []string{address},
+			IPStack:    gardenerutils.GetIPStackForShoot(b.Resources.Shoot),
+		},
+		dnsrecord.DefaultInterval,
+		dnsrecord.DefaultSevereThreshold,
+		dnsrecord.DefaultTimeout,
+	)
+
+	if err := deployer.Deploy(ctx); err != nil {
+		return err
+	}
+	return deployer.Wait(ctx)
+}
+
+// generateGenericTokenKubeconfig generates the generic token kubeconfig which is used by the machine-controller-manager
+// for accessing its target cluster, i.e., the bootstrap cluster itself.
+func (b *BootstrapBotanist) generateGenericTokenKubeconfig(ctx context.Context) error {
+	_, err := b.SecretsManager.Generate(ctx, &secretsutils.KubeconfigSecretConfig{
+		Name:        v1beta1constants.SecretNameGenericTokenKubeconfig,
+		ContextName: b.Namespace,
+		Cluster: clientcmdv1.Cluster{
+			Server:                   "https://kubernetes.default.svc",
+			CertificateAuthorityData: b.ClientSet.RESTConfig().CAData,
+		},
+		AuthInfo: clientcmdv1.AuthInfo{
+			TokenFile: gardenerutils.PathShootToken,
+		},
+	})
+	return err
+}
+
+// requestAccessTokens requests tokens for the service accounts referenced by the shoot access secrets in the shoot
+// namespace and writes them into the secrets. Usually, this is done by the token-requestor controller of
+// gardener-resource-manager.
+func (b *BootstrapBotanist) requestAccessTokens(ctx context.Context) error {
+	secretList := &corev1.SecretList{}
+	if err := b.ClientSet.Client().List(ctx, secretList, client.InNamespace(b.Namespace), client.MatchingLabels{
+		resourcesv1alpha1.ResourceManagerPurpose: resourcesv1alpha1.LabelPurposeTokenRequest,
+		resourcesv1alpha1.ResourceManagerClass:   resourcesv1alpha1.ResourceManagerClassShoot,
+	}); err != nil {
+		return fmt.Errorf("failed listing access secrets: %w", err)
+	}
+
+	for _, secret := range secretList.Items {
+		serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Annotations[resourcesv1alpha1.ServiceAccountName],
+			Namespace: secret.Annotations[resourcesv1alpha1.ServiceAccountNamespace],
+		}}
+
+		if err := b.ClientSet.Client().Get(ctx, client.ObjectKeyFromObject(serviceAccount), serviceAccount); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed reading service account %s: %w", client.ObjectKeyFromObject(serviceAccount), err)
+			}
+			if err := b.ClientSet.Client().Create(ctx, serviceAccount); err != nil {
+				return fmt.Errorf("failed creating service account %s: %w", client.ObjectKeyFromObject(serviceAccount), err)
+			}
+		}
+
+		tokenRequest, err := b.ClientSet.Kubernetes().CoreV1().ServiceAccounts(serviceAccount.Namespace).CreateToken(ctx, serviceAccount.Name, &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: ptr.To[int64](int64((24 * time.Hour).Seconds()))},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed requesting token for service account %s: %w", client.ObjectKeyFromObject(serviceAccount), err)
+		}
+
+		patch := client.MergeFrom(secret.DeepCopy())
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[resourcesv1alpha1.DataKeyToken] = []byte(tokenRequest.Status.Token)
+		if err := b.ClientSet.Client().Patch(ctx, &secret, patch); err != nil {
+			return fmt.Errorf("failed writing token to access secret %s: %w", client.ObjectKeyFromObject(&secret), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,367 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/version"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/gardener/imagevector"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original"
+	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components"
+	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/kubelet"
+	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/nodeagent"
+	oscutils "github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/utils"
+	"github.com/gardener/gardener/pkg/features"
+	"github.com/gardener/gardener/pkg/gardenadm/staticpod"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	operatingsystemconfigcontroller "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	"github.com/gardener/gardener/pkg/nodeagent/registry"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	imagevectorutils "github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/gardener/gardener/pkg/utils/managedresources"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+)
+
+const (
+	// OperatingSystemConfigSecretName is the name of the secret containing the OperatingSystemConfig which is applied
+	// by gardener-node-agent's reconciliation logic.
+	OperatingSystemConfigSecretName = "gardenadm-operating-system-config"
+	// GardenerNodeAgentManagedResourceName is the name of the ManagedResource containing the operating system config
+	// secrets of the worker pools and the RBAC resources for gardener-node-agent.
+	GardenerNodeAgentManagedResourceName = "shoot-gardener-node-agent"
+
+	gardenerNodeAgentSecretNamePrefix = "gardener-node-agent-"
+
+	defaultNodeMonitorGracePeriod = 40 * time.Second
+)
+
+var decoder runtime.Decoder
+
+func init() {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(nodeagentconfigv1alpha1.AddToScheme(scheme))
+	decoder = serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
+
+// ComputeOperatingSystemConfig computes the OperatingSystemConfig for the control plane node. It contains the units and
+// files of the original components (without gardener-node-agent, since gardenadm applies the configuration itself) as
+// well as the static pod manifests and kubeconfig files computed by the other deploy functions.
+func (b *AutonomousBotanist) ComputeOperatingSystemConfig(ctx context.Context) (*extensionsv1alpha1.OperatingSystemConfig, error) {
+	// gardenadm applies the configuration itself, gardener-node-agent is only installed once the autonomous shoot
+	// cluster is connected to a garden.
+	osc, err := b.computeOperatingSystemConfig(ctx, b.Resources.Shoot.Spec.Provider.Workers[0], OperatingSystemConfigSecretName, "https://localhost", false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enableStaticPods(osc.Spec.Files); err != nil {
+		return nil, err
+	}
+	osc.Spec.Files = append(osc.Spec.Files, b.Files()...)
+
+	return osc, nil
+}
+
+// DeployGardenerNodeAgentResources computes the OperatingSystemConfigs for the worker pools of the autonomous shoot
+// cluster (all pools except the first one which hosts the control plane). The secrets containing them as well as the
+// RBAC resources for gardener-node-agent are deployed as ManagedResource into the in-memory client, so that they are
+// applied to the shoot by ApplyManagedResources. 'gardenadm join' fetches the secrets for joining worker nodes.
+func (b *AutonomousBotanist) DeployGardenerNodeAgentResources(ctx context.Context) error {
+	var (
+		secrets     []client.Object
+		secretNames []string
+	)
+
+	for _, worker := range b.Resources.Shoot.Spec.Provider.Workers[1:] {
+		secretName := gardenerNodeAgentSecretNamePrefix + worker.Name
+
+		osc, err := b.computeOperatingSystemConfig(ctx, worker, secretName, "https://"+b.APIServerAddress(), true)
+		if err != nil {
+			return fmt.Errorf("failed computing operating system config for worker pool %q: %w", worker.Name, err)
+		}
+
+		if err := enableNodeAgentAuthorizer(osc.Spec.Files); err != nil {
+			return err
+		}
+
+		secret, err := nodeagent.OperatingSystemConfigSecret(ctx, b.SeedClientSet.Client(), osc, secretName, worker.Name)
+		if err != nil {
+			return fmt.Errorf("failed computing operating system config secret for worker pool %q: %w", worker.Name, err)
+		}
+
+		secrets = append(secrets, secret)
+		secretNames = append(secretNames, secretName)
+	}
+
+	secretsData, err := managedresources.NewRegistry(kubernetes.ShootScheme, kubernetes.ShootCodec, kubernetes.ShootSerializer).AddAllAndSerialize(secrets...)
+	if err != nil {
+		return err
+	}
+
+	rbacResourcesData, err := nodeagent.RBACResourcesData(secretNames)
+	if err != nil {
+		return err
+	}
+
+	managedResource := managedresources.NewForShoot(b.SeedClientSet.Client(), b.Namespace, GardenerNodeAgentManagedResourceName, managedresources.LabelValueGardener, false)
+	for _, managedResourceSecret := range []struct {
+		name string
+		data map[string][]byte
+	}{
+		{name: GardenerNodeAgentManagedResourceName, data: secretsData},
+		{name: GardenerNodeAgentManagedResourceName + "-rbac", data: rbacResourcesData},
+	} {
+		secretName, secret := managedresources.NewSecret(b.SeedClientSet.Client(), b.Namespace, managedResourceSecret.name, managedResourceSecret.data, true)
+		if err := secret.Reconcile(ctx); err != nil {
+			return err
+		}
+		managedResource.WithSecretRef(secretName)
+	}
+
+	return managedResource.Reconcile(ctx)
+}
+
+// computeOperatingSystemConfig computes an OperatingSystemConfig containing the units and files of the original
+// components for the given worker pool. The gardener-node-agent component is only included if withNodeAgent is true.
+func (b *AutonomousBotanist) computeOperatingSystemConfig(_ context.Context, worker gardencorev1beta1.Worker, secretName, apiServerURL string, withNodeAgent bool) (*extensionsv1alpha1.OperatingSystemConfig, error) {
+	shoot := b.Resources.Shoot
+
+	kubernetesVersion, err := v1beta1helper.CalculateEffectiveKubernetesVersion(b.KubernetesVersion, worker.Kubernetes)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := imagevectorutils.FindImages(imagevector.Containers(), []string{imagevector.ContainerImageNameHyperkube, imagevector.ContainerImageNamePauseContainer, imagevector.ContainerImageNameValitail}, imagevectorutils.RuntimeVersion(kubernetesVersion.String()), imagevectorutils.TargetVersion(kubernetesVersion.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	if withNodeAgent {
+		images[imagevector.ContainerImageNameGardenerNodeAgent], err = imagevector.Containers().FindImage(imagevector.ContainerImageNameGardenerNodeAgent)
+		if err != nil {
+			return nil, fmt.Errorf("failed finding image %q: %w", imagevector.ContainerImageNameGardenerNodeAgent, err)
+		}
+		images[imagevector.ContainerImageNameGardenerNodeAgent].WithOptionalTag(version.Get().GitVersion)
+	}
+
+	kubeletCASecret, found := b.SecretsManager.Get(v1beta1constants.SecretNameCAKubelet)
+	if !found {
+		return nil, fmt.Errorf("secret %q not found", v1beta1constants.SecretNameCAKubelet)
+	}
+
+	criName := extensionsv1alpha1.CRINameContainerD
+	if worker.CRI != nil {
+		criName = extensionsv1alpha1.CRIName(worker.CRI.Name)
+	}
+
+	var clusterDNSAddresses []string
+	for _, ip := range b.Networks.CoreDNS {
+		clusterDNSAddresses = append(clusterDNSAddresses, ip.String())
+	}
+
+	nodeMonitorGracePeriod := metav1.Duration{Duration: defaultNodeMonitorGracePeriod}
+	if kcm := shoot.Spec.Kubernetes.KubeControllerManager; kcm != nil && kcm.NodeMonitorGracePeriod != nil {
+		nodeMonitorGracePeriod = *kcm.NodeMonitorGracePeriod
+	}
+
+	kubeletConfig := v1beta1helper.CalculateEffectiveKubeletConfiguration(shoot.Spec.Kubernetes.Kubelet, worker.Kubernetes)
+
+	componentsContext := components.Context{
+		Key:                     secretName,
+		CABundle:                b.Resources.CloudProfile.Spec.CABundle,
+		ClusterDNSAddresses:     clusterDNSAddresses,
+		ClusterDomain:           gardencorev1beta1.DefaultDomain,
+		CRIName:                 criName,
+		Images:                  images,
+		NodeLabels:              gardenerutils.NodeLabelsForWorkerPool(worker, v1beta1helper.IsNodeLocalDNSEnabled(shoot.Spec.SystemComponents), secretName),
+		NodeMonitorGracePeriod:  nodeMonitorGracePeriod,
+		KubeletCABundle:         kubeletCASecret.Data[secretsutils.DataKeyCertificateBundle],
+		KubeletConfigParameters: components.KubeletConfigParametersFromCoreV1beta1KubeletConfig(kubeletConfig),
+		KubeletCLIFlags:         components.KubeletCLIFlagsFromCoreV1beta1KubeletConfig(kubeletConfig),
+		KubeletDataVolumeName:   worker.KubeletDataVolumeName,
+		KubeProxyEnabled:        v1beta1helper.KubeProxyEnabled(shoot.Spec.Kubernetes.KubeProxy),
+		KubernetesVersion:       kubernetesVersion,
+		SSHAccessEnabled:        v1beta1helper.ShootEnablesSSHAccess(shoot),
+		ValitailEnabled:         false,
+		APIServerURL:            apiServerURL,
+		Sysctls:                 worker.Sysctls,
+		PreferIPv6:              len(shoot.Spec.Networking.IPFamilies) > 0 && shoot.Spec.Networking.IPFamilies[0] == gardencorev1beta1.IPFamilyIPv6,
+		Taints:                  worker.Taints,
+		DirectImagePull:         v1beta1helper.IsDirectImagePullEnabled(&worker),
+	}
+
+	var (
+		units []extensionsv1alpha1.Unit
+		files []extensionsv1alpha1.File
+	)
+
+	nodeAgentComponentName := nodeagent.New().Name()
+	for _, component := range original.Components(componentsContext.SSHAccessEnabled) {
+		if component.Name() == nodeAgentComponentName && !withNodeAgent {
+			continue
+		}
+
+		u, f, err := component.Config(componentsContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed computing units and files for component %q: %w", component.Name(), err)
+		}
+
+		units = append(units, u...)
+		files = append(files, f...)
+	}
+
+	osc := &extensionsv1alpha1.OperatingSystemConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      worker.Name,
+			Namespace: b.Namespace,
+		},
+		Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+			Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+			Units:   units,
+			Files:   files,
+			CRIConfig: &extensionsv1alpha1.CRIConfig{
+				Name: criName,
+			},
+		},
+	}
+
+	if criName == extensionsv1alpha1.CRINameContainerD {
+		osc.Spec.CRIConfig.Containerd = &extensionsv1alpha1.ContainerdConfig{
+			SandboxImage: images[imagevector.ContainerImageNamePauseContainer].String(),
+		}
+
+		if versionutils.ConstraintK8sGreaterEqual131.Check(kubernetesVersion) {
+			osc.Spec.CRIConfig.CgroupDriver = ptr.To(extensionsv1alpha1.CgroupDriverSystemd)
+		}
+	}
+
+	return osc, nil
+}
+
+// enableStaticPods configures the static pod path in the kubelet configuration file and removes the taint for critical
+// components, since there is no gardener-resource-manager which would remove it once the node is ready.
+func enableStaticPods(files []extensionsv1alpha1.File) error {
+	configCodec := kubelet.NewConfigCodec(oscutils.NewFileContentInlineCodec())
+
+	for i, file := range files {
+		if file.Path != kubelet.PathKubeletConfig || file.Content.Inline == nil {
+			continue
+		}
+
+		kubeletConfig, err := configCodec.Decode(file.Content.Inline)
+		if err != nil {
+			return fmt.Errorf("failed decoding kubelet configuration: %w", err)
+		}
+
+		kubeletConfig.StaticPodPath = staticpod.ManifestsDirectory
+		kubeletConfig.RegisterWithTaints = slices.DeleteFunc(kubeletConfig.RegisterWithTaints, func(taint corev1.Taint) bool {
+			return taint.Key == v1beta1constants.TaintNodeCriticalComponentsNotReady
+		})
+
+		content, err := configCodec.Encode(kubeletConfig, file.Content.Inline.Encoding)
+		if err != nil {
+			return fmt.Errorf("failed encoding kubelet configuration: %w", err)
+		}
+		files[i].Content.Inline = content
+
+		return nil
+	}
+
+	return fmt.Errorf("kubelet configuration file %q not found", kubelet.PathKubeletConfig)
+}
+
+// enableNodeAgentAuthorizer configures gardener-node-agent to authenticate with the client certificate requested during
+// 'gardenadm join' instead of syncing an access token, since there is no gardener-resource-manager which would provide
+// the access token. Without the node agent authorizer webhook, the legacy RBAC resources grant the permissions to the
+// certificate's group.
+func enableNodeAgentAuthorizer(files []extensionsv1alpha1.File) error {
+	for i, file := range files {
+		if file.Path != nodeagentconfigv1alpha1.ConfigFilePath || file.Content.Inline == nil {
+			continue
+		}
+
+		configRaw, err := oscutils.NewFileContentInlineCodec().Decode(file.Content.Inline)
+		if err != nil {
+			return fmt.Errorf("failed decoding gardener-node-agent configuration file: %w", err)
+		}
+
+		config := &nodeagentconfigv1alpha1.NodeAgentConfiguration{}
+		if err := runtime.DecodeInto(decoder, configRaw, config); err != nil {
+			return fmt.Errorf("failed decoding gardener-node-agent configuration: %w", err)
+		}
+
+		if config.FeatureGates == nil {
+			config.FeatureGates = make(map[string]bool)
+		}
+		config.FeatureGates[string(features.NodeAgentAuthorizer)] = true
+		config.Controllers.Token.SyncConfigs = slices.DeleteFunc(config.Controllers.Token.SyncConfigs, func(syncConfig nodeagentconfigv1alpha1.TokenSecretSyncConfig) bool {
+			return syncConfig.SecretName == nodeagentconfigv1alpha1.AccessSecretName
+		})
+
+		configFiles, err := nodeagent.Files(config)
+		if err != nil {
+			return err
+		}
+		files[i].Content.Inline = configFiles[0].Content.Inline
+
+		return nil
+	}
+
+	return fmt.Errorf("gardener-node-agent configuration file %q not found", nodeagentconfigv1alpha1.ConfigFilePath)
+}
+
+// ApplyOperatingSystemConfig applies the given OperatingSystemConfig to the machine by running the reconciliation logic
+// of gardener-node-agent against an in-memory client.
+func (b *AutonomousBotanist) ApplyOperatingSystemConfig(ctx context.Context, osc *extensionsv1alpha1.OperatingSystemConfig) error {
+	secret, err := nodeagent.OperatingSystemConfigSecret(ctx, b.SeedClientSet.Client(), osc, OperatingSystemConfigSecretName, osc.Name)
+	if err != nil {
+		return fmt.Errorf("failed computing operating system config secret: %w", err)
+	}
+
+	reconciler := &operatingsystemconfigcontroller.Reconciler{
+		Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.ShootScheme).WithObjects(secret).Build(),
+		Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+			SecretName:        secret.Name,
+			KubernetesVersion: b.KubernetesVersion,
+		},
+		Recorder:      &record.FakeRecorder{},
+		DBus:          b.DBus,
+		FS:            b.FS,
+		Extractor:     registry.NewExtractor(),
+		CancelContext: func() {},
+		HostName:      b.HostName,
+		Clock:         b.Clock,
+	}
+
+	// The reconciler requeues until the Node is registered, however the Node is not visible to the in-memory client.
+	// Hence, the requeue is expected and the configuration has been applied once the reconciler returns without error.
+	if _, err := reconciler.Reconcile(logf.IntoContext(ctx, b.Logger.WithName("operatingsystemconfig")), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}); err != nil {
+		return fmt.Errorf("failed applying operating system config: %w", err)
+	}
+
+	return nil
+}
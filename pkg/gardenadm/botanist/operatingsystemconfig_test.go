@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist_test
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
+	oscutils "github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/utils"
+	. "github.com/gardener/gardener/pkg/gardenadm/botanist"
+	shootpkg "github.com/gardener/gardener/pkg/gardenlet/operation/shoot"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	"github.com/gardener/gardener/pkg/utils/managedresources"
+	fakesecretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager/fake"
+)
+
+var decoder runtime.Decoder
+
+func init() {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(extensionsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(nodeagentconfigv1alpha1.AddToScheme(scheme))
+	decoder = serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
+
+var _ = Describe("OperatingSystemConfig", func() {
+	var (
+		ctx = context.Background()
+
+		fakeFS   afero.Afero
+		fakeDBus *fakedbus.DBus
+		b        *AutonomousBotanist
+	)
+
+	BeforeEach(func() {
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		fakeDBus = fakedbus.New()
+
+		b = &AutonomousBotanist{
+			Logger:            logr.Discard(),
+			FS:                fakeFS,
+			DBus:              fakeDBus,
+			Clock:             testclock.NewFakeClock(time.Now()),
+			HostName:          "machine-0",
+			KubernetesVersion: semver.MustParse("1.31.1"),
+			SeedClientSet: fakekubernetes.NewClientSetBuilder().
+				WithClient(fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).Build()).
+				Build(),
+		}
+	})
+
+	Describe("#DeployGardenerNodeAgentResources", func() {
+		BeforeEach(func() {
+			b.Namespace = "kube-system"
+			b.Networks = &shootpkg.Networks{CoreDNS: []net.IP{net.ParseIP("100.64.0.10")}}
+			b.Resources = &Resources{
+				CloudProfile: &gardencorev1beta1.CloudProfile{},
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						DNS:        &gardencorev1beta1.DNS{Domain: ptr.To("shoot.example.com")},
+						Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.31.1"},
+						Networking: &gardencorev1beta1.Networking{},
+						Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{
+							{Name: "control-plane", Machine: gardencorev1beta1.Machine{Architecture: ptr.To("amd64")}},
+							{Name: "worker", Machine: gardencorev1beta1.Machine{Architecture: ptr.To("amd64")}},
+						}},
+					},
+				},
+			}
+			b.SecretsManager = fakesecretsmanager.New(b.SeedClientSet.Client(), b.Namespace)
+
+			Expect(b.SeedClientSet.Client().Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-kubelet", Namespace: b.Namespace}})).To(Succeed())
+		})
+
+		It("should deploy the operating system config secrets of the worker pools and the RBAC resources", func() {
+			Expect(b.DeployGardenerNodeAgentResources(ctx)).To(Succeed())
+
+			objects, err := managedresources.GetObjects(ctx, b.SeedClientSet.Client(), b.Namespace, "shoot-gardener-node-agent")
+			Expect(err).NotTo(HaveOccurred())
+
+			var secret *corev1.Secret
+			for _, obj := range objects {
+				if s, ok := obj.(*corev1.Secret); ok {
+					Expect(secret).To(BeNil(), "expected only one operating system config secret")
+					secret = s
+				}
+			}
+			Expect(objects).To(ContainElement(And(
+				BeAssignableToTypeOf(&rbacv1.Role{}),
+				HaveField("ObjectMeta.Name", "gardener-node-agent"),
+			)))
+
+			Expect(secret).NotTo(BeNil())
+			Expect(secret.Name).To(Equal("gardener-node-agent-worker"))
+			Expect(secret.Labels).To(Equal(map[string]string{
+				"gardener.cloud/role":        "operating-system-config",
+				"worker.gardener.cloud/pool": "worker",
+			}))
+
+			osc := &extensionsv1alpha1.OperatingSystemConfig{}
+			Expect(runtime.DecodeInto(decoder, secret.Data["osc.yaml"], osc)).To(Succeed())
+
+			var nodeAgentConfigFile *extensionsv1alpha1.File
+			for _, file := range osc.Spec.Files {
+				if file.Path == "/var/lib/gardener-node-agent/config.yaml" {
+					nodeAgentConfigFile = &file
+				}
+			}
+			Expect(nodeAgentConfigFile).NotTo(BeNil())
+
+			configRaw, err := oscutils.NewFileContentInlineCodec().Decode(nodeAgentConfigFile.Content.Inline)
+			Expect(err).NotTo(HaveOccurred())
+			nodeAgentConfig := &nodeagentconfigv1alpha1.NodeAgentConfiguration{}
+			Expect(runtime.DecodeInto(decoder, configRaw, nodeAgentConfig)).To(Succeed())
+
+			Expect(nodeAgentConfig.APIServer.Server).To(Equal("https://api.shoot.example.com"))
+			Expect(nodeAgentConfig.Controllers.OperatingSystemConfig.SecretName).To(Equal("gardener-node-agent-worker"))
+			Expect(nodeAgentConfig.FeatureGates).To(HaveKeyWithValue("NodeAgentAuthorizer", true))
+			Expect(nodeAgentConfig.Controllers.Token.SyncConfigs).To(BeEmpty())
+		})
+	})
+
+	Describe("#ApplyOperatingSystemConfig", func() {
+		It("should apply the files and units with the reconciliation logic of gardener-node-agent", func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: "kube-system"},
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{{
+						Path:    "/etc/foo",
+						Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "foo"}},
+					}},
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Command: ptr.To(extensionsv1alpha1.CommandStart),
+						Enable:  ptr.To(true),
+						Content: ptr.To("[Service]"),
+					}},
+				},
+			}
+
+			Expect(b.ApplyOperatingSystemConfig(ctx, osc)).To(Succeed())
+
+			Expect(fakeFS.ReadFile("/etc/foo")).To(Equal([]byte("foo")))
+			Expect(fakeFS.ReadFile("/etc/systemd/system/foo.service")).To(Equal([]byte("[Service]")))
+			Expect(fakeDBus.Actions).To(ContainElement(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"foo.service"}}))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+)
+
+// Resources contains the Gardener resources which are read from the configuration directory and which describe the
+// autonomous shoot cluster.
+type Resources struct {
+	// Shoot is the Shoot resource describing the autonomous shoot cluster.
+	Shoot *gardencorev1beta1.Shoot
+	// CloudProfile is the CloudProfile referenced by the Shoot.
+	CloudProfile *gardencorev1beta1.CloudProfile
+	// NamespacedCloudProfile is the NamespacedCloudProfile referenced by the Shoot (optional).
+	NamespacedCloudProfile *gardencorev1beta1.NamespacedCloudProfile
+	// ControllerRegistrations are the ControllerRegistrations of the extensions required by the Shoot.
+	ControllerRegistrations []*gardencorev1beta1.ControllerRegistration
+	// ControllerDeployments are the ControllerDeployments of the extensions required by the Shoot.
+	ControllerDeployments []*gardencorev1beta1.ControllerDeployment
+	// Gardenlet describes the gardenlet which is deployed when connecting the autonomous shoot cluster to a garden
+	// (optional).
+	Gardenlet *seedmanagementv1alpha1.Gardenlet
+	// Secrets are the secrets containing the credentials for the infrastructure and DNS providers (optional). They are
+	// only needed by 'gardenadm bootstrap' which runs the provider extensions.
+	Secrets []*corev1.Secret
+}
+
+// NewResources sorts the given objects into a new Resources struct. It returns an error if the objects do not contain
+// exactly one Shoot, or if the CloudProfile referenced by the Shoot is missing.
+func NewResources(objects []client.Object) (*Resources, error) {
+	resources := &Resources{}
+
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *gardencorev1beta1.Shoot:
+			if resources.Shoot != nil {
+				return nil, fmt.Errorf("found more than one Shoot: %s and %s", client.ObjectKeyFromObject(resources.Shoot), client.ObjectKeyFromObject(o))
+			}
+			resources.Shoot = o
+		case *gardencorev1beta1.CloudProfile:
+			if resources.CloudProfile != nil {
+				return nil, fmt.Errorf("found more than one CloudProfile: %s and %s", resources.CloudProfile.Name, o.Name)
+			}
+			resources.CloudProfile = o
+		case *gardencorev1beta1.NamespacedCloudProfile:
+			if resources.NamespacedCloudProfile != nil {
+				return nil, fmt.Errorf("found more than one NamespacedCloudProfile: %s and %s", client.ObjectKeyFromObject(resources.NamespacedCloudProfile), client.ObjectKeyFromObject(o))
+			}
+			resources.NamespacedCloudProfile = o
+		case *gardencorev1beta1.ControllerRegistration:
+			resources.ControllerRegistrations = append(resources.ControllerRegistrations, o)
+		case *gardencorev1beta1.ControllerDeployment:
+			resources.ControllerDeployments = append(resources.ControllerDeployments, o)
+		case *seedmanagementv1alpha1.Gardenlet:
+			if resources.Gardenlet != nil {
+				return nil, fmt.Errorf("found more than one Gardenlet: %s and %s", client.ObjectKeyFromObject(resources.Gardenlet), client.ObjectKeyFromObject(o))
+			}
+			resources.Gardenlet = o
+		case *corev1.Secret:
+			resources.Secrets = append(resources.Secrets, o)
+		default:
+			return nil, fmt.Errorf("unsupported object %s of type %T", client.ObjectKeyFromObject(obj), obj)
+		}
+	}
+
+	if resources.Shoot == nil {
+		return nil, fmt.Errorf("no Shoot found")
+	}
+	if resources.CloudProfile == nil {
+		return nil, fmt.Errorf("no CloudProfile found")
+	}
+	if v1beta1helper.IsWorkerless(resources.Shoot) {
+		return nil, fmt.Errorf("shoot %s must have at least one worker pool, workerless shoots are not supported", client.ObjectKeyFromObject(resources.Shoot))
+	}
+
+	return resources, nil
+}
+
+// Secret returns the secret with the given name, or nil if it is not part of the resources.
+func (r *Resources) Secret(name string) *corev1.Secret {
+	for _, secret := range r.Secrets {
+		if secret.Name == name {
+			return secret
+		}
+	}
+	return nil
+}
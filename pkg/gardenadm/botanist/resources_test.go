@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+	. "github.com/gardener/gardener/pkg/gardenadm/botanist"
+)
+
+var _ = Describe("Resources", func() {
+	Describe("#NewResources", func() {
+		var (
+			shoot                  *gardencorev1beta1.Shoot
+			cloudProfile           *gardencorev1beta1.CloudProfile
+			namespacedCloudProfile *gardencorev1beta1.NamespacedCloudProfile
+			controllerRegistration *gardencorev1beta1.ControllerRegistration
+			controllerDeployment   *gardencorev1beta1.ControllerDeployment
+			gardenlet              *seedmanagementv1alpha1.Gardenlet
+			secret                 *corev1.Secret
+		)
+
+		BeforeEach(func() {
+			shoot = &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden"},
+				Spec: gardencorev1beta1.ShootSpec{
+					Provider: gardencorev1beta1.Provider{
+						Workers: []gardencorev1beta1.Worker{{Name: "control-plane"}},
+					},
+				},
+			}
+			cloudProfile = &gardencorev1beta1.CloudProfile{ObjectMeta: metav1.ObjectMeta{Name: "cloudprofile"}}
+			namespacedCloudProfile = &gardencorev1beta1.NamespacedCloudProfile{ObjectMeta: metav1.ObjectMeta{Name: "namespacedcloudprofile", Namespace: "garden"}}
+			controllerRegistration = &gardencorev1beta1.ControllerRegistration{ObjectMeta: metav1.ObjectMeta{Name: "provider-local"}}
+			controllerDeployment = &gardencorev1beta1.ControllerDeployment{ObjectMeta: metav1.ObjectMeta{Name: "provider-local"}}
+			gardenlet = &seedmanagementv1alpha1.Gardenlet{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden"}}
+			secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "garden"}}
+		})
+
+		It("should sort the objects into the resources", func() {
+			resources, err := NewResources([]client.Object{shoot, cloudProfile, namespacedCloudProfile, controllerRegistration, controllerDeployment, gardenlet, secret})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources).To(Equal(&Resources{
+				Shoot:                   shoot,
+				CloudProfile:            cloudProfile,
+				NamespacedCloudProfile:  namespacedCloudProfile,
+				ControllerRegistrations: []*gardencorev1beta1.ControllerRegistration{controllerRegistration},
+				ControllerDeployments:   []*gardencorev1beta1.ControllerDeployment{controllerDeployment},
+				Gardenlet:               gardenlet,
+				Secrets:                 []*corev1.Secret{secret},
+			}))
+			Expect(resources.Secret("local")).To(Equal(secret))
+			Expect(resources.Secret("foo")).To(BeNil())
+		})
+
+		It("should fail if there is no Shoot", func() {
+			_, err := NewResources([]client.Object{cloudProfile})
+			Expect(err).To(MatchError("no Shoot found"))
+		})
+
+		It("should fail if there is more than one Shoot", func() {
+			otherShoot := shoot.DeepCopy()
+			otherShoot.Name = "other"
+
+			_, err := NewResources([]client.Object{shoot, otherShoot, cloudProfile})
+			Expect(err).To(MatchError(ContainSubstring("found more than one Shoot")))
+		})
+
+		It("should fail if there is no CloudProfile", func() {
+			_, err := NewResources([]client.Object{shoot})
+			Expect(err).To(MatchError("no CloudProfile found"))
+		})
+
+		It("should fail if the Shoot is workerless", func() {
+			shoot.Spec.Provider.Workers = nil
+
+			_, err := NewResources([]client.Object{shoot, cloudProfile})
+			Expect(err).To(MatchError(ContainSubstring("workerless shoots are not supported")))
+		})
+
+		It("should fail for unsupported objects", func() {
+			_, err := NewResources([]client.Object{shoot, cloudProfile, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}}})
+			Expect(err).To(MatchError(ContainSubstring("unsupported object bar/foo")))
+		})
+	})
+})
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/utils/gardener/tokenrequest"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+)
+
+func caCertConfigurations() []secretsutils.ConfigInterface {
+	return []secretsutils.ConfigInterface{
+		&secretsutils.CertificateSecretConfig{Name: v1beta1constants.SecretNameCACluster, CommonName: "kubernetes", CertType: secretsutils.CACert},
+		&secretsutils.CertificateSecretConfig{Name: v1beta1constants.SecretNameCAClient, CommonName: "kubernetes-client", CertType: secretsutils.CACert},
+		&secretsutils.CertificateSecretConfig{Name: v1beta1constants.SecretNameCAETCD, CommonName: "etcd", CertType: secretsutils.CACert},
+		&secretsutils.CertificateSecretConfig{Name: v1beta1constants.SecretNameCAFrontProxy, CommonName: "front-proxy", CertType: secretsutils.CACert},
+		&secretsutils.CertificateSecretConfig{Name: v1beta1constants.SecretNameCAKubelet, CommonName: "kubelet", CertType: secretsutils.CACert},
+	}
+}
+
+// InitializeSecretsManagement generates the certificate authorities and the generic token kubeconfig which are
+// required by the control plane components.
+func (b *AutonomousBotanist) InitializeSecretsManagement(ctx context.Context) error {
+	for _, config := range caCertConfigurations() {
+		if _, err := b.SecretsManager.Generate(ctx, config, secretsmanager.Persist(), secretsmanager.Rotate(secretsmanager.KeepOld)); err != nil {
+			return fmt.Errorf("failed generating certificate authority %q: %w", config.GetName(), err)
+		}
+	}
+
+	if _, err := tokenrequest.GenerateGenericTokenKubeconfig(ctx, b.SecretsManager, b.Namespace, "localhost"); err != nil {
+		return fmt.Errorf("failed generating generic token kubeconfig: %w", err)
+	}
+
+	return nil
+}
+
+// loadSecrets reads the secrets persisted by previous runs and creates them in the in-memory client so that they are
+// adopted by the secrets manager.
+func (b *AutonomousBotanist) loadSecrets(ctx context.Context) error {
+	entries, err := b.FS.ReadDir(SecretsDirectory)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed reading secrets directory %q: %w", SecretsDirectory, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(SecretsDirectory, entry.Name())
+		data, err := b.FS.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed reading secret file %q: %w", path, err)
+		}
+
+		secret := &corev1.Secret{}
+		if err := yaml.Unmarshal(data, secret); err != nil {
+			return fmt.Errorf("failed decoding secret file %q: %w", path, err)
+		}
+		secret.Namespace = b.Namespace
+
+		if err := b.SeedClientSet.Client().Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed creating secret %s from file %q: %w", client.ObjectKeyFromObject(secret), path, err)
+		}
+	}
+
+	return nil
+}
+
+// PersistSecrets writes all secrets managed by the secrets manager to the file system so that they are reused by
+// subsequent runs.
+func (b *AutonomousBotanist) PersistSecrets(ctx context.Context) error {
+	secretList := &corev1.SecretList{}
+	if err := b.SeedClientSet.Client().List(ctx, secretList, client.InNamespace(b.Namespace), client.MatchingLabels{
+		secretsmanager.LabelKeyManagedBy: secretsmanager.LabelValueSecretsManager,
+	}); err != nil {
+		return fmt.Errorf("failed listing secrets: %w", err)
+	}
+
+	if err := b.FS.MkdirAll(SecretsDirectory, 0700); err != nil {
+		return fmt.Errorf("failed creating secrets directory %q: %w", SecretsDirectory, err)
+	}
+
+	for _, secret := range secretList.Items {
+		secret.ResourceVersion = ""
+		secret.ManagedFields = nil
+
+		data, err := yaml.Marshal(secret)
+		if err != nil {
+			return fmt.Errorf("failed encoding secret %s: %w", client.ObjectKeyFromObject(&secret), err)
+		}
+
+		path := filepath.Join(SecretsDirectory, secret.Name+".yaml")
+		if err := b.FS.WriteFile(path, data, 0600); err != nil {
+			return fmt.Errorf("failed writing secret file %q: %w", path, err)
+		}
+	}
+
+	return nil
+}
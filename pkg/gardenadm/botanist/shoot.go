@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/managedresources"
+	"github.com/gardener/gardener/pkg/utils/retry"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+)
+
+var (
+	// IntervalWaitForKubeAPIServer is the interval for waiting until the kube-apiserver is ready. Exposed for testing.
+	IntervalWaitForKubeAPIServer = 5 * time.Second
+	// TimeoutWaitForKubeAPIServer is the timeout for waiting until the kube-apiserver is ready. Exposed for testing.
+	TimeoutWaitForKubeAPIServer = 10 * time.Minute
+
+	// NewShootClientSetFromBytes creates a new client set for the autonomous shoot cluster. Exposed for testing.
+	NewShootClientSetFromBytes = func(kubeconfig []byte) (kubernetes.Interface, error) {
+		return kubernetes.NewClientFromBytes(
+			kubeconfig,
+			kubernetes.WithClientOptions(client.Options{Scheme: kubernetes.ShootScheme}),
+			kubernetes.WithDisabledCachedClient(),
+		)
+	}
+)
+
+// WaitUntilKubeAPIServerReady waits until the kube-apiserver static pod is ready to serve requests and initializes the
+// ShootClientSet.
+func (b *AutonomousBotanist) WaitUntilKubeAPIServerReady(ctx context.Context) error {
+	adminKubeconfig, found := b.SecretsManager.Get(secretNameAdminKubeconfig)
+	if !found {
+		return fmt.Errorf("secret %q not found", secretNameAdminKubeconfig)
+	}
+
+	shootClientSet, err := NewShootClientSetFromBytes(adminKubeconfig.Data[secretsutils.DataKeyKubeconfig])
+	if err != nil {
+		return fmt.Errorf("failed creating client set for shoot: %w", err)
+	}
+
+	if err := retry.UntilTimeout(ctx, IntervalWaitForKubeAPIServer, TimeoutWaitForKubeAPIServer, func(ctx context.Context) (bool, error) {
+		if err := shootClientSet.Kubernetes().Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).Error(); err != nil {
+			b.Logger.Info("Waiting for kube-apiserver to become ready", "reason", err.Error())
+			return retry.MinorError(err)
+		}
+		return retry.Ok()
+	}); err != nil {
+		return fmt.Errorf("failed waiting for kube-apiserver to become ready: %w", err)
+	}
+
+	b.ShootClientSet = shootClientSet
+	return nil
+}
+
+// ApplyManagedResources applies the objects of all ManagedResources for the shoot cluster (i.e., with class unset)
+// which were created by the component deployers in the in-memory client. Usually, gardener-resource-manager takes care
+// of this, however it is not available before the autonomous shoot cluster is fully set up.
+func (b *AutonomousBotanist) ApplyManagedResources(ctx context.Context) error {
+	return applyManagedResources(ctx, b.Logger, b.SeedClientSet.Client(), b.ShootClientSet.Client(), b.Namespace, func(managedResource *resourcesv1alpha1.ManagedResource) bool {
+		return managedResource.Spec.Class == nil
+	})
+}
+
+// applyManagedResources applies the objects of the ManagedResources in the given namespace which match the given
+// predicate to the target cluster.
+func applyManagedResources(ctx context.Context, log logr.Logger, sourceClient, targetClient client.Client, namespace string, predicate func(*resourcesv1alpha1.ManagedResource) bool) error {
+	managedResourceList := &resourcesv1alpha1.ManagedResourceList{}
+	if err := sourceClient.List(ctx, managedResourceList, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed listing ManagedResources: %w", err)
+	}
+
+	for _, managedResource := range managedResourceList.Items {
+		if !predicate(&managedResource) {
+			continue
+		}
+
+		objects, err := managedresources.GetObjects(ctx, sourceClient, managedResource.Namespace, managedResource.Name)
+		if err != nil {
+			return fmt.Errorf("failed reading objects of ManagedResource %s: %w", client.ObjectKeyFromObject(&managedResource), err)
+		}
+
+		log.Info("Applying objects of ManagedResource", "managedResource", client.ObjectKeyFromObject(&managedResource), "objects", len(objects))
+		for _, obj := range objects {
+			if err := createOrUpdate(ctx, targetClient, obj); err != nil {
+				return fmt.Errorf("failed applying object %s of ManagedResource %s: %w", client.ObjectKeyFromObject(obj), client.ObjectKeyFromObject(&managedResource), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func createOrUpdate(ctx context.Context, c client.Client, obj client.Object) error {
+	if err := c.Create(ctx, obj); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+
+		current, ok := obj.DeepCopyObject().(client.Object)
+		if !ok {
+			return fmt.Errorf("failed copying object %s", client.ObjectKeyFromObject(obj))
+		}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+			return err
+		}
+
+		obj.SetResourceVersion(current.GetResourceVersion())
+		return c.Update(ctx, obj)
+	}
+
+	return nil
+}
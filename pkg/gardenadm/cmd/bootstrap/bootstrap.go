@@ -5,11 +5,69 @@
 package bootstrap
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"path/filepath"
 
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/component-base/version"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener/imagevector"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/gardenadm/botanist"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/logger"
+	"github.com/gardener/gardener/pkg/utils/flow"
+)
+
+const (
+	// providerTypeLocal is the provider type of provider-local. Its machines are pods in the bootstrap cluster, hence
+	// gardenadm can access them directly.
+	providerTypeLocal = "local"
+	// machineContainerName is the name of the container of provider-local machine pods.
+	machineContainerName = "node"
+	// machineConfigDir is the directory on the machine to which the manifests are copied.
+	machineConfigDir = "/etc/gardenadm"
+	// machineGardenadmPath is the path on the machine to which the gardenadm binary is copied.
+	machineGardenadmPath = "/opt/bin/gardenadm"
+
+	// installGardenadmScript pulls the gardenadm image with containerd on the machine and copies the gardenadm binary
+	// from the image to the given path. Images built with ko contain the binary at /ko-app/gardenadm, release images at
+	// /gardenadm.
+	installGardenadmScript = `set -o errexit
+set -o nounset
+set -o pipefail
+
+image="$1"
+path="$2"
+
+tmp_dir="$(mktemp -d)"
+unmount() {
+  ctr images unmount "$tmp_dir" && rm -rf "$tmp_dir"
+}
+trap unmount EXIT
+
+ctr images pull --hosts-dir /etc/containerd/certs.d "$image" >/dev/null
+ctr images mount "$image" "$tmp_dir" >/dev/null
+
+binary="$tmp_dir/gardenadm"
+if [ -f "$tmp_dir/ko-app/gardenadm" ]; then
+  binary="$tmp_dir/ko-app/gardenadm"
+fi
+
+mkdir -p "$(dirname "$path")"
+cp -f "$binary" "$path"
+chmod 0700 "$path"
+`
 )
 
 // NewCommand creates a new cobra.Command.
@@ -19,10 +77,21 @@ func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "bootstrap",
 		Short: "Bootstrap the infrastructure for an Autonomous Shoot Cluster",
-		Long:  "Bootstrap the infrastructure for an Autonomous Shoot Cluster (networks, machines, etc.)",
+		Long: "Bootstrap the infrastructure for an Autonomous Shoot Cluster (networks, machines, etc.). " +
+			"The Gardener configuration resources (Shoot, CloudProfile, ControllerRegistrations, ControllerDeployments, etc.) are read from the config directory. " +
+			"A throw-away KinD cluster is created as bootstrap cluster (unless the kubeconfig of an existing cluster is given). " +
+			"The extension controllers are deployed into the bootstrap cluster and create the infrastructure and machines via the Infrastructure, OperatingSystemConfig and Worker resources. " +
+			"Afterwards, the DNS record for the API server is created via the DNSRecord resource, pointing to the first machine. " +
+			"Finally, 'gardenadm init' is executed on the first machine with the gardenadm binary from the release image (currently only supported for provider-local).",
 
 		Example: `# Bootstrap the infrastructure
-gardenadm bootstrap --kubeconfig ~/.kube/config`,
+gardenadm bootstrap
+
+# Bootstrap the infrastructure with the configuration from a specific directory
+gardenadm bootstrap --config-dir ./manifests
+
+# Bootstrap the infrastructure using an existing bootstrap cluster
+gardenadm bootstrap --kubeconfig ~/.kube/config --config-dir ./manifests`,
 
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := opts.Complete(); err != nil {
@@ -42,7 +111,246 @@ gardenadm bootstrap --kubeconfig ~/.kube/config`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented as well")
+func run(ctx context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	objects, err := cmdutils.ReadManifests(opts.ConfigDir, kubernetes.GardenScheme)
+	if err != nil {
+		return fmt.Errorf("failed reading manifests from config directory %q: %w", opts.ConfigDir, err)
+	}
+
+	for _, obj := range objects {
+		kubernetes.GardenScheme.Default(obj)
+	}
+
+	resources, err := botanist.NewResources(objects)
+	if err != nil {
+		return err
+	}
+
+	log := logger.MustNewZapLogger(logger.InfoLevel, logger.FormatText, logzap.WriteTo(ioStreams.ErrOut))
+
+	image, err := gardenadmImage(opts)
+	if err != nil {
+		return err
+	}
+
+	clientSet, err := newBootstrapClientSet(ctx, log, opts)
+	if err != nil {
+		return fmt.Errorf("failed creating client for bootstrap cluster: %w", err)
+	}
+
+	b, err := botanist.NewBootstrapBotanist(ctx, log, clock.RealClock{}, resources, clientSet)
+	if err != nil {
+		return fmt.Errorf("failed creating botanist: %w", err)
+	}
+
+	var (
+		machines []machinev1alpha1.Machine
+		g        = flow.NewGraph("bootstrap")
+
+		deployCustomResourceDefinitions = g.Add(flow.Task{
+			Name: "Deploying custom resource definitions",
+			Fn:   b.DeployCustomResourceDefinitions,
+		})
+		deployPriorityClasses = g.Add(flow.Task{
+			Name: "Deploying priority classes",
+			Fn:   b.DeployPriorityClasses,
+		})
+		deployExtensionControllers = g.Add(flow.Task{
+			Name:         "Deploying extension controllers",
+			Fn:           b.DeployExtensionControllers,
+			Dependencies: flow.NewTaskIDs(deployCustomResourceDefinitions, deployPriorityClasses),
+		})
+		waitUntilExtensionControllersReady = g.Add(flow.Task{
+			Name:         "Waiting until extension controllers are ready",
+			Fn:           b.WaitUntilExtensionControllersReady,
+			Dependencies: flow.NewTaskIDs(deployExtensionControllers),
+		})
+		deployNamespace = g.Add(flow.Task{
+			Name: "Deploying shoot namespace",
+			Fn:   b.DeployNamespace,
+		})
+		deployCloudProviderSecret = g.Add(flow.Task{
+			Name:         "Deploying cloud provider secret",
+			Fn:           b.DeployCloudProviderSecret,
+			Dependencies: flow.NewTaskIDs(deployNamespace),
+		})
+		deployCluster = g.Add(flow.Task{
+			Name:         "Deploying cluster resource",
+			Fn:           b.DeployCluster,
+			Dependencies: flow.NewTaskIDs(deployCustomResourceDefinitions, deployNamespace),
+		})
+		syncPoint = flow.NewTaskIDs(waitUntilExtensionControllersReady, deployCloudProviderSecret, deployCluster)
+
+		deployInfrastructure = g.Add(flow.Task{
+			Name:         "Deploying infrastructure",
+			Fn:           b.DeployInfrastructure,
+			Dependencies: flow.NewTaskIDs(syncPoint),
+		})
+		waitForInfrastructure = g.Add(flow.Task{
+			Name:         "Waiting until infrastructure has been reconciled",
+			Fn:           b.WaitForInfrastructure,
+			Dependencies: flow.NewTaskIDs(deployInfrastructure),
+		})
+		deployOperatingSystemConfigs = g.Add(flow.Task{
+			Name:         "Deploying operating system configs for provisioning the machines",
+			Fn:           b.DeployOperatingSystemConfigs,
+			Dependencies: flow.NewTaskIDs(syncPoint),
+		})
+		deployMachineControllerManager = g.Add(flow.Task{
+			Name:         "Deploying machine-controller-manager",
+			Fn:           b.DeployMachineControllerManager,
+			Dependencies: flow.NewTaskIDs(syncPoint),
+		})
+		deployWorker = g.Add(flow.Task{
+			Name:         "Deploying worker",
+			Fn:           b.DeployWorker,
+			Dependencies: flow.NewTaskIDs(waitForInfrastructure, deployOperatingSystemConfigs, deployMachineControllerManager),
+		})
+		waitUntilWorkerMachineDeploymentsCreated = g.Add(flow.Task{
+			Name:         "Waiting until machine deployments have been created",
+			Fn:           b.WaitUntilWorkerMachineDeploymentsCreated,
+			Dependencies: flow.NewTaskIDs(deployWorker),
+		})
+		waitUntilMachinesCreated = g.Add(flow.Task{
+			Name: "Waiting until machines have been created",
+			Fn: func(ctx context.Context) error {
+				var err error
+				machines, err = b.WaitUntilMachinesCreated(ctx)
+				return err
+			},
+			Dependencies: flow.NewTaskIDs(waitUntilWorkerMachineDeploymentsCreated),
+		})
+		_ = g.Add(flow.Task{
+			Name:         "Scaling down machine-controller-manager",
+			Fn:           b.ScaleDownMachineControllerManager,
+			Dependencies: flow.NewTaskIDs(waitUntilMachinesCreated),
+		})
+	)
+
+	if err := g.Compile().Run(ctx, flow.Opts{Log: log}); err != nil {
+		return flow.Errors(err)
+	}
+
+	firstMachine := machines[0]
+
+	// The machines of provider-local are pods in the bootstrap cluster, hence gardenadm can access them directly.
+	var machinePod *corev1.Pod
+	if resources.Shoot.Spec.Provider.Type == providerTypeLocal {
+		machinePod = &corev1.Pod{}
+		if err := clientSet.Client().Get(ctx, client.ObjectKey{Namespace: b.Namespace, Name: firstMachine.Spec.ProviderID}, machinePod); err != nil {
+			return fmt.Errorf("failed reading pod of machine %q: %w", firstMachine.Name, err)
+		}
+	}
+
+	address, err := controlPlaneAddress(opts, &firstMachine, machinePod)
+	if err != nil {
+		return err
+	}
+
+	if err := b.DeployDNSRecord(ctx, address); err != nil {
+		return fmt.Errorf("failed deploying DNS record: %w", err)
+	}
+
+	if machinePod == nil {
+		fmt.Fprintf(ioStreams.Out, `
+The machines of your autonomous shoot cluster have been created successfully!
+
+Copy the files from %s and the gardenadm binary from image %s to the first machine %q and run the following command
+there as root:
+
+  gardenadm init --config-dir <path-to-copied-files>
+`, opts.ConfigDir, image, firstMachine.Spec.ProviderID)
+		return nil
+	}
+
+	if err := initializeFirstMachine(ctx, log, ioStreams, clientSet.PodExecutor(), machinePod, objects, image); err != nil {
+		return fmt.Errorf("failed running 'gardenadm init' on machine %q: %w", firstMachine.Name, err)
+	}
+
+	fmt.Fprintf(ioStreams.Out, `
+Your autonomous shoot cluster has been bootstrapped successfully!
+
+The control plane is running on machine %q, the admin kubeconfig is located at %s on the machine.
+`, firstMachine.Name, botanist.PathAdminKubeconfig)
+
 	return nil
 }
+
+// newBootstrapClientSet creates a client set for the bootstrap cluster. If no kubeconfig is given, a KinD cluster is
+// created as bootstrap cluster.
+func newBootstrapClientSet(ctx context.Context, log logr.Logger, opts *Options) (kubernetes.Interface, error) {
+	if opts.Kubeconfig != "" {
+		return cmdutils.NewClientSetFromFile(opts.Kubeconfig, kubernetes.SeedScheme)
+	}
+
+	kubeconfig, err := ensureKindCluster(ctx, log, opts.KindClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating KinD cluster %q: %w", opts.KindClusterName, err)
+	}
+
+	return cmdutils.NewClientSetFromBytes(kubeconfig, kubernetes.SeedScheme)
+}
+
+// gardenadmImage returns the image containing the gardenadm binary which is executed on the first machine.
+func gardenadmImage(opts *Options) (string, error) {
+	if opts.GardenadmImage != "" {
+		return opts.GardenadmImage, nil
+	}
+
+	image, err := imagevector.Containers().FindImage(imagevector.ContainerImageNameGardenadm)
+	if err != nil {
+		return "", fmt.Errorf("failed finding image %q: %w", imagevector.ContainerImageNameGardenadm, err)
+	}
+	image.WithOptionalTag(version.Get().GitVersion)
+
+	return image.String(), nil
+}
+
+// controlPlaneAddress returns the address of the first machine which the DNS record for the API server points to. The
+// machine-controller-manager does not report the addresses of machines, hence the address must be given explicitly
+// unless the machine is a pod of provider-local.
+func controlPlaneAddress(opts *Options, machine *machinev1alpha1.Machine, machinePod *corev1.Pod) (string, error) {
+	if opts.ControlPlaneAddress != "" {
+		return opts.ControlPlaneAddress, nil
+	}
+
+	if machinePod != nil && machinePod.Status.PodIP != "" {
+		return machinePod.Status.PodIP, nil
+	}
+
+	return "", fmt.Errorf("cannot determine the address of machine %q (provider ID %q), provide it with --control-plane-address and re-run the command", machine.Name, machine.Spec.ProviderID)
+}
+
+// initializeFirstMachine copies the manifests into the given machine pod, installs gardenadm from the given image and
+// executes 'gardenadm init' there.
+func initializeFirstMachine(ctx context.Context, log logr.Logger, ioStreams genericiooptions.IOStreams, podExecutor kubernetes.PodExecutor, pod *corev1.Pod, objects []client.Object, image string) error {
+	var manifests bytes.Buffer
+	for _, obj := range objects {
+		data, err := cmdutils.EncodeManifest(obj, kubernetes.GardenScheme)
+		if err != nil {
+			return err
+		}
+		manifests.WriteString("---\n")
+		manifests.Write(data)
+	}
+
+	manifestsPath := filepath.Join(machineConfigDir, "manifests.yaml")
+	log.Info("Copying file to machine", "pod", client.ObjectKeyFromObject(pod), "path", manifestsPath)
+	if err := podExecutor.ExecuteWithStreams(ctx, pod.Namespace, pod.Name, machineContainerName, &manifests, io.Discard, ioStreams.ErrOut,
+		"sh", "-c", fmt.Sprintf("mkdir -p %s && cat > %s && chmod 0600 %s", machineConfigDir, manifestsPath, manifestsPath),
+	); err != nil {
+		return fmt.Errorf("failed copying %s to machine: %w", manifestsPath, err)
+	}
+
+	log.Info("Installing gardenadm on machine", "pod", client.ObjectKeyFromObject(pod), "image", image, "path", machineGardenadmPath)
+	if err := podExecutor.ExecuteWithStreams(ctx, pod.Namespace, pod.Name, machineContainerName, nil, io.Discard, ioStreams.ErrOut,
+		"bash", "-c", installGardenadmScript, "install-gardenadm", image, machineGardenadmPath,
+	); err != nil {
+		return fmt.Errorf("failed installing gardenadm from image %s on machine: %w", image, err)
+	}
+
+	log.Info("Running 'gardenadm init' on machine", "pod", client.ObjectKeyFromObject(pod))
+	return podExecutor.ExecuteWithStreams(ctx, pod.Namespace, pod.Name, machineContainerName, nil, ioStreams.Out, ioStreams.ErrOut,
+		machineGardenadmPath, "init", "--config-dir", machineConfigDir,
+	)
+}
@@ -5,38 +5,136 @@
 package bootstrap_test
 
 import (
-	"bytes"
-	"io"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 
+	"github.com/gardener/gardener/pkg/client/kubernetes"
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/bootstrap"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/utils/test"
 )
 
 var _ = Describe("Bootstrap", func() {
 	var (
 		ioStreams genericiooptions.IOStreams
-		out       *bytes.Buffer
 		cmd       *cobra.Command
 	)
 
 	BeforeEach(func() {
-		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
+		ioStreams, _, _, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
-			Expect(cmd.Flags().Set("kubeconfig", "some-path-to-kubeconfig")).To(Succeed())
+		It("should fail if the config directory does not exist", func() {
+			Expect(cmd.Flags().Set("config-dir", "/non/existing/directory")).To(Succeed())
 
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+			Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("failed reading manifests from config directory")))
+		})
+
+		Context("with config directory", func() {
+			BeforeEach(func() {
+				configDir := GinkgoT().TempDir()
+				Expect(os.WriteFile(filepath.Join(configDir, "manifests.yaml"), []byte(`apiVersion: core.gardener.cloud/v1beta1
+kind: CloudProfile
+metadata:
+  name: local
+---
+apiVersion: core.gardener.cloud/v1beta1
+kind: Shoot
+metadata:
+  name: root
+  namespace: garden
+spec:
+  cloudProfileName: local
+  provider:
+    type: local
+    workers:
+    - name: control-plane
+`), 0600)).To(Succeed())
+				Expect(cmd.Flags().Set("config-dir", configDir)).To(Succeed())
+			})
+
+			Context("without kubeconfig", func() {
+				var kindCalls [][]string
+
+				BeforeEach(func() {
+					kindCalls = nil
+
+					DeferCleanup(test.WithVar(&cmdutils.NewClientSetFromBytes, func(kubeconfig []byte, _ *runtime.Scheme) (kubernetes.Interface, error) {
+						Expect(string(kubeconfig)).To(Equal("kind-kubeconfig"))
+						return nil, fmt.Errorf("fake")
+					}))
+				})
+
+				It("should create a KinD cluster", func() {
+					DeferCleanup(test.WithVar(&RunKind, func(_ context.Context, args ...string) ([]byte, error) {
+						kindCalls = append(kindCalls, args)
+						switch args[0] {
+						case "get":
+							if args[1] == "kubeconfig" {
+								return []byte("kind-kubeconfig"), nil
+							}
+							return []byte("other\n"), nil
+						case "create":
+							return nil, nil
+						}
+						return nil, fmt.Errorf("unexpected call")
+					}))
+					Expect(cmd.Flags().Set("kind-cluster-name", "bootstrap")).To(Succeed())
+
+					Expect(cmd.RunE(cmd, nil)).To(MatchError("failed creating client for bootstrap cluster: fake"))
+					Expect(kindCalls).To(HaveLen(3))
+					Expect(kindCalls[0]).To(Equal([]string{"get", "clusters"}))
+					Expect(kindCalls[1][:4]).To(Equal([]string{"create", "cluster", "--name", "bootstrap"}))
+					Expect(kindCalls[2]).To(Equal([]string{"get", "kubeconfig", "--name", "bootstrap"}))
+				})
+
+				It("should reuse an existing KinD cluster", func() {
+					DeferCleanup(test.WithVar(&RunKind, func(_ context.Context, args ...string) ([]byte, error) {
+						kindCalls = append(kindCalls, args)
+						if args[1] == "kubeconfig" {
+							return []byte("kind-kubeconfig"), nil
+						}
+						return []byte("other\ngardenadm-bootstrap\n"), nil
+					}))
+
+					Expect(cmd.RunE(cmd, nil)).To(MatchError("failed creating client for bootstrap cluster: fake"))
+					Expect(kindCalls).To(Equal([][]string{
+						{"get", "clusters"},
+						{"get", "kubeconfig", "--name", "gardenadm-bootstrap"},
+					}))
+				})
+
+				It("should fail if the KinD cluster cannot be created", func() {
+					DeferCleanup(test.WithVar(&RunKind, func(context.Context, ...string) ([]byte, error) {
+						return nil, fmt.Errorf("kind not found")
+					}))
+
+					Expect(cmd.RunE(cmd, nil)).To(MatchError(`failed creating client for bootstrap cluster: failed creating KinD cluster "gardenadm-bootstrap": kind not found`))
+				})
+			})
+
+			It("should fail if the client for the existing bootstrap cluster cannot be created", func() {
+				Expect(cmd.Flags().Set("kubeconfig", "some-path-to-kubeconfig")).To(Succeed())
+				DeferCleanup(test.WithVar(&RunKind, func(context.Context, ...string) ([]byte, error) {
+					return nil, fmt.Errorf("unexpected call")
+				}))
+				DeferCleanup(test.WithVar(&cmdutils.NewClientSetFromFile, func(path string, _ *runtime.Scheme) (kubernetes.Interface, error) {
+					Expect(path).To(Equal("some-path-to-kubeconfig"))
+					return nil, fmt.Errorf("fake")
+				}))
 
-			output, err := io.ReadAll(out)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented as well\n"))
+				Expect(cmd.RunE(cmd, nil)).To(MatchError("failed creating client for bootstrap cluster: fake"))
+			})
 		})
 	})
 })
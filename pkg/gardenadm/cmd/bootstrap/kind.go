@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// RunKind executes the kind binary with the given arguments and returns its standard output. Exposed for testing.
+var RunKind = func(ctx context.Context, args ...string) ([]byte, error) {
+	var stdErr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "kind", args...)
+	cmd.Stderr = &stdErr
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed running 'kind %s': %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stdErr.String()))
+		}
+		return nil, fmt.Errorf("failed running 'kind %s': %w", strings.Join(args, " "), err)
+	}
+
+	return output, nil
+}
+
+// ensureKindCluster creates the KinD cluster with the given name unless it already exists, and returns its kubeconfig.
+// The kubeconfig of the user is not modified.
+func ensureKindCluster(ctx context.Context, log logr.Logger, name string) ([]byte, error) {
+	clusters, err := RunKind(ctx, "get", "clusters")
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(strings.Fields(string(clusters)), name) {
+		log.Info("Using existing KinD cluster", "name", name)
+	} else {
+		tmpDir, err := os.MkdirTemp("", "gardenadm-bootstrap-")
+		if err != nil {
+			return nil, fmt.Errorf("failed creating temporary directory: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		log.Info("Creating KinD cluster", "name", name)
+		if _, err := RunKind(ctx, "create", "cluster", "--name", name, "--kubeconfig", filepath.Join(tmpDir, "kubeconfig")); err != nil {
+			return nil, err
+		}
+	}
+
+	return RunKind(ctx, "get", "kubeconfig", "--name", name)
+}
@@ -6,35 +6,53 @@ package bootstrap
 
 import (
 	"fmt"
-	"os"
+	"net"
 
 	"github.com/spf13/pflag"
 )
 
 // Options contains options for this command.
 type Options struct {
-	// Kubeconfig is the path to the kubeconfig file pointing to the KinD cluster.
+	// Kubeconfig is the path to the kubeconfig file pointing to an existing bootstrap cluster. If it is not set, a KinD
+	// cluster is created.
 	Kubeconfig string
+	// KindClusterName is the name of the KinD cluster which is created as bootstrap cluster.
+	KindClusterName string
+	// ConfigDir is the path to the directory containing the Gardener configuration resources (Shoot, CloudProfile,
+	// ControllerRegistrations, ControllerDeployments, etc.).
+	ConfigDir string
+	// ControlPlaneAddress is the IP address of the first machine, which the DNS record for the API server of the
+	// autonomous shoot cluster points to. It is determined automatically for provider-local.
+	ControlPlaneAddress string
+	// GardenadmImage is the image containing the gardenadm binary which is executed on the first machine. Defaults to
+	// the gardenadm release image matching the version of this binary.
+	GardenadmImage string
 }
 
 // Complete completes the options.
-func (o *Options) Complete() error {
-	if o.Kubeconfig == "" {
-		o.Kubeconfig = os.Getenv("KUBECONFIG")
-	}
-
-	return nil
-}
+func (o *Options) Complete() error { return nil }
 
 // Validate validates the options.
 func (o *Options) Validate() error {
-	if len(o.Kubeconfig) == 0 {
-		return fmt.Errorf("must provide a path to a KinD cluster kubeconfig")
+	if len(o.Kubeconfig) == 0 && len(o.KindClusterName) == 0 {
+		return fmt.Errorf("must provide a name for the KinD cluster or a path to a bootstrap cluster kubeconfig")
+	}
+
+	if len(o.ConfigDir) == 0 {
+		return fmt.Errorf("must provide a path to the config directory")
+	}
+
+	if len(o.ControlPlaneAddress) > 0 && net.ParseIP(o.ControlPlaneAddress) == nil {
+		return fmt.Errorf("control plane address must be an IP address")
 	}
 
 	return nil
 }
 
 func (o *Options) addFlags(fs *pflag.FlagSet) {
-	fs.StringVarP(&o.Kubeconfig, "kubeconfig", "k", "", "Path to the kubeconfig file pointing to the KinD cluster")
+	fs.StringVarP(&o.Kubeconfig, "kubeconfig", "k", "", "Path to the kubeconfig file pointing to an existing bootstrap cluster (a KinD cluster is created if not set)")
+	fs.StringVarP(&o.KindClusterName, "kind-cluster-name", "", "gardenadm-bootstrap", "Name of the KinD cluster which is created as bootstrap cluster")
+	fs.StringVarP(&o.ConfigDir, "config-dir", "d", ".", "Path to the directory containing the Gardener configuration resources (Shoot, CloudProfile, ControllerRegistrations, ControllerDeployments, etc.)")
+	fs.StringVarP(&o.ControlPlaneAddress, "control-plane-address", "", "", "IP address of the first machine for the DNS record of the API server (determined automatically for provider-local)")
+	fs.StringVarP(&o.GardenadmImage, "gardenadm-image", "", "", "Image containing the gardenadm binary which is executed on the first machine (defaults to the release image matching the version of this binary)")
 }
@@ -29,12 +29,35 @@ var _ = Describe("Options", func() {
 	Describe("#Validate", func() {
 		It("should pass for valid options", func() {
 			options.Kubeconfig = "some-path-to-kubeconfig"
+			options.ConfigDir = "some-path-to-config-dir"
 
 			Expect(options.Validate()).To(Succeed())
 		})
 
-		It("should fail because kubeconfig path is not set", func() {
-			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a path to a KinD cluster kubeconfig")))
+		It("should pass for valid options with KinD cluster name", func() {
+			options.KindClusterName = "gardenadm-bootstrap"
+			options.ConfigDir = "some-path-to-config-dir"
+			options.ControlPlaneAddress = "10.0.0.1"
+
+			Expect(options.Validate()).To(Succeed())
+		})
+
+		It("should fail because neither kubeconfig path nor KinD cluster name is set", func() {
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a name for the KinD cluster or a path to a bootstrap cluster kubeconfig")))
+		})
+
+		It("should fail because config dir path is not set", func() {
+			options.Kubeconfig = "some-path-to-kubeconfig"
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a path to the config directory")))
+		})
+
+		It("should fail because control plane address is not an IP address", func() {
+			options.Kubeconfig = "some-path-to-kubeconfig"
+			options.ConfigDir = "some-path-to-config-dir"
+			options.ControlPlaneAddress = "api.example.com"
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("control plane address must be an IP address")))
 		})
 	})
 })
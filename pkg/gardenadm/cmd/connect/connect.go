@@ -7,9 +7,39 @@ package connect
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/gardener/charts"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+	"github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1/helper"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/controller/gardenletdeployer"
+	"github.com/gardener/gardener/pkg/gardenadm/botanist"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/logger"
+	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/retry"
+)
+
+var (
+	// IntervalWaitForSeed is the interval for waiting until the Seed is registered and ready. Exposed for testing.
+	IntervalWaitForSeed = 10 * time.Second
+
+	// DeployGardenlet deploys gardenlet into the autonomous shoot cluster. Exposed for testing.
+	DeployGardenlet = deployGardenlet
 )
 
 // NewCommand creates a new cobra.Command.
@@ -19,10 +49,14 @@ func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "connect",
 		Short: "Deploy a gardenlet for further cluster management",
-		Long:  "Deploy a gardenlet for further cluster management",
+		Long: "Deploy a gardenlet for further cluster management. " +
+			"The gardenlet is deployed into the autonomous shoot cluster according to the Gardenlet resource in the config directory, using a bootstrap kubeconfig for the garden cluster. " +
+			"Afterwards, the command waits until the gardenlet has registered its Seed and the Seed is ready. " +
+			"The Shoot is not created in the garden cluster yet since gardenlet cannot adopt the control plane of an autonomous shoot cluster. " +
+			"It would create a new control plane for the Shoot instead.",
 
-		Example: `# Deploy a gardenlet
-gardenadm connect`,
+		Example: `# Connect the autonomous shoot cluster to the garden cluster
+gardenadm connect --kubeconfig garden-kubeconfig.yaml --config-dir ./manifests`,
 
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := opts.Complete(); err != nil {
@@ -42,7 +76,125 @@ gardenadm connect`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented")
+func run(ctx context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	objects, err := cmdutils.ReadManifests(opts.ConfigDir, kubernetes.GardenScheme)
+	if err != nil {
+		return fmt.Errorf("failed reading manifests from config directory %q: %w", opts.ConfigDir, err)
+	}
+
+	for _, obj := range objects {
+		kubernetes.GardenScheme.Default(obj)
+	}
+
+	resources, err := botanist.NewResources(objects)
+	if err != nil {
+		return err
+	}
+
+	if resources.Gardenlet == nil {
+		return fmt.Errorf("no Gardenlet found, it is required for deploying gardenlet into the autonomous shoot cluster")
+	}
+
+	log := logger.MustNewZapLogger(logger.InfoLevel, logger.FormatText, logzap.WriteTo(ioStreams.ErrOut))
+
+	gardenClientSet, err := cmdutils.NewClientSetFromFile(opts.Kubeconfig, kubernetes.GardenScheme)
+	if err != nil {
+		return fmt.Errorf("failed creating garden client: %w", err)
+	}
+
+	shootClientSet, err := cmdutils.NewClientSetFromFile(opts.ShootKubeconfig, kubernetes.SeedScheme)
+	if err != nil {
+		return fmt.Errorf("failed creating autonomous shoot client: %w", err)
+	}
+
+	log.Info("Deploying gardenlet into autonomous shoot cluster", "gardenlet", client.ObjectKeyFromObject(resources.Gardenlet))
+	if err := DeployGardenlet(ctx, log, gardenClientSet, shootClientSet, resources.Gardenlet); err != nil {
+		return fmt.Errorf("failed deploying gardenlet: %w", err)
+	}
+
+	log.Info("Waiting until Seed is registered by gardenlet and ready", "seedName", resources.Gardenlet.Name)
+	if err := waitUntilSeedReady(ctx, log, gardenClientSet.Client(), resources.Gardenlet.Name, opts.Timeout); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ioStreams.Out, `
+Your autonomous shoot cluster has been connected to the garden cluster successfully!
+
+The gardenlet running in the cluster has registered Seed %s. The Shoot is not registered in the garden cluster yet
+since gardenlet does not support autonomous shoot clusters, continue to perform operations with gardenadm.
+`, resources.Gardenlet.Name)
+
+	return nil
+}
+
+func deployGardenlet(ctx context.Context, log logr.Logger, gardenClientSet, shootClientSet kubernetes.Interface, gardenlet *seedmanagementv1alpha1.Gardenlet) error {
+	actuator := &gardenletdeployer.Actuator{
+		GardenConfig:    gardenClientSet.RESTConfig(),
+		GardenAPIReader: gardenClientSet.APIReader(),
+		GardenClient:    gardenClientSet.Client(),
+		GetTargetClientFunc: func(_ context.Context) (kubernetes.Interface, error) {
+			return shootClientSet, nil
+		},
+		CheckIfVPAAlreadyExists: func(_ context.Context) (bool, error) {
+			return false, nil
+		},
+		GetInfrastructureSecret: func(ctx context.Context) (*corev1.Secret, error) {
+			seedTemplate, _, err := helper.ExtractSeedTemplateAndGardenletConfig(gardenlet.Name, &gardenlet.Spec.Config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract seed template and gardenlet config: %w", err)
+			}
+
+			if seedTemplate.Spec.Backup == nil {
+				return nil, nil
+			}
+			return kubernetesutils.GetSecretByReference(ctx, gardenClientSet.Client(), &seedTemplate.Spec.Backup.SecretRef)
+		},
+		GetTargetDomain: func() string {
+			return ""
+		},
+		ApplyGardenletChart: func(ctx context.Context, targetChartApplier kubernetes.ChartApplier, values map[string]interface{}) error {
+			return targetChartApplier.ApplyFromEmbeddedFS(ctx, charts.ChartGardenlet, charts.ChartPathGardenlet, v1beta1constants.GardenNamespace, "gardenlet", kubernetes.Values(values))
+		},
+		Clock:                 clock.RealClock{},
+		ValuesHelper:          gardenletdeployer.NewValuesHelper(nil),
+		Recorder:              &record.FakeRecorder{},
+		GardenNamespaceTarget: v1beta1constants.GardenNamespace,
+	}
+
+	_, err := actuator.Reconcile(ctx, log, gardenlet, nil, &gardenlet.Spec.Deployment.GardenletDeployment, &gardenlet.Spec.Config, seedmanagementv1alpha1.BootstrapToken, false)
+	return err
+}
+
+func waitUntilSeedReady(ctx context.Context, log logr.Logger, gardenClient client.Client, seedName string, timeout time.Duration) error {
+	return retry.UntilTimeout(ctx, IntervalWaitForSeed, timeout, func(ctx context.Context) (bool, error) {
+		seed := &gardencorev1beta1.Seed{}
+		if err := gardenClient.Get(ctx, client.ObjectKey{Name: seedName}, seed); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Info("Waiting until Seed is registered by gardenlet")
+				return retry.MinorError(fmt.Errorf("seed %s is not registered yet", seedName))
+			}
+			return retry.SevereError(err)
+		}
+
+		if err := checkSeedReady(seed); err != nil {
+			log.Info("Waiting until Seed is ready", "reason", err.Error())
+			return retry.MinorError(fmt.Errorf("seed %s is not ready yet: %w", seedName, err))
+		}
+
+		return retry.Ok()
+	})
+}
+
+func checkSeedReady(seed *gardencorev1beta1.Seed) error {
+	if seed.Status.ObservedGeneration < seed.Generation {
+		return fmt.Errorf("observed generation outdated (%d/%d)", seed.Status.ObservedGeneration, seed.Generation)
+	}
+
+	for _, conditionType := range []gardencorev1beta1.ConditionType{gardencorev1beta1.SeedGardenletReady, gardencorev1beta1.SeedSystemComponentsHealthy} {
+		if condition := v1beta1helper.GetCondition(seed.Status.Conditions, conditionType); condition == nil || condition.Status != gardencorev1beta1.ConditionTrue {
+			return fmt.Errorf("condition %s is not true", conditionType)
+		}
+	}
+
 	return nil
 }
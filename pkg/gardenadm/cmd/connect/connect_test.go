@@ -5,36 +5,164 @@
 package connect_test
 
 import (
-	"bytes"
-	"io"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/connect"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/utils/test"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
 )
 
 var _ = Describe("Connect", func() {
 	var (
+		ctx       = context.Background()
 		ioStreams genericiooptions.IOStreams
-		out       *bytes.Buffer
 		cmd       *cobra.Command
 	)
 
 	BeforeEach(func() {
-		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
+		ioStreams, _, _, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
+		cmd.SetContext(ctx)
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+		It("should fail if no garden kubeconfig is provided", func() {
+			Expect(cmd.RunE(cmd, nil)).To(MatchError("must provide a path to a garden cluster kubeconfig"))
+		})
+
+		Context("with garden kubeconfig", func() {
+			BeforeEach(func() {
+				Expect(cmd.Flags().Set("kubeconfig", "/non/existing/kubeconfig")).To(Succeed())
+			})
+
+			It("should fail if the config directory does not exist", func() {
+				Expect(cmd.Flags().Set("config-dir", "/non/existing/directory")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("failed reading manifests from config directory")))
+			})
+
+			It("should fail if the config directory does not contain a Gardenlet", func() {
+				configDir := GinkgoT().TempDir()
+				Expect(os.WriteFile(filepath.Join(configDir, "manifests.yaml"), []byte(`apiVersion: core.gardener.cloud/v1beta1
+kind: CloudProfile
+metadata:
+  name: local
+---
+apiVersion: core.gardener.cloud/v1beta1
+kind: Shoot
+metadata:
+  name: root
+  namespace: garden
+spec:
+  cloudProfileName: local
+  provider:
+    type: local
+    workers:
+    - name: control-plane
+`), 0600)).To(Succeed())
+				Expect(cmd.Flags().Set("config-dir", configDir)).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("no Gardenlet found")))
+			})
+
+			Context("with complete config directory", func() {
+				var (
+					fakeGardenClient client.Client
+					seed             *gardencorev1beta1.Seed
+					steps            []string
+				)
+
+				BeforeEach(func() {
+					configDir := GinkgoT().TempDir()
+					Expect(os.WriteFile(filepath.Join(configDir, "manifests.yaml"), []byte(`apiVersion: core.gardener.cloud/v1beta1
+kind: CloudProfile
+metadata:
+  name: local
+---
+apiVersion: core.gardener.cloud/v1beta1
+kind: Shoot
+metadata:
+  name: root
+  namespace: garden
+spec:
+  cloudProfileName: local
+  provider:
+    type: local
+    workers:
+    - name: control-plane
+---
+apiVersion: seedmanagement.gardener.cloud/v1alpha1
+kind: Gardenlet
+metadata:
+  name: root
+  namespace: garden
+`), 0600)).To(Succeed())
+					Expect(cmd.Flags().Set("config-dir", configDir)).To(Succeed())
+					Expect(cmd.Flags().Set("timeout", "100ms")).To(Succeed())
+
+					DeferCleanup(test.WithVar(&IntervalWaitForSeed, time.Millisecond))
+
+					seed = &gardencorev1beta1.Seed{
+						ObjectMeta: metav1.ObjectMeta{Name: "root"},
+						Status: gardencorev1beta1.SeedStatus{
+							Conditions: []gardencorev1beta1.Condition{
+								{Type: gardencorev1beta1.SeedGardenletReady, Status: gardencorev1beta1.ConditionTrue},
+								{Type: gardencorev1beta1.SeedSystemComponentsHealthy, Status: gardencorev1beta1.ConditionTrue},
+							},
+						},
+					}
+					steps = nil
+
+					fakeGardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+
+					DeferCleanup(test.WithVar(&cmdutils.NewClientSetFromFile, func(string, *runtime.Scheme) (kubernetes.Interface, error) {
+						return fakekubernetes.NewClientSetBuilder().WithClient(fakeGardenClient).Build(), nil
+					}))
+				})
+
+				It("should deploy gardenlet and wait until the Seed is ready without creating the Shoot", func() {
+					DeferCleanup(test.WithVar(&DeployGardenlet, func(ctx context.Context, _ logr.Logger, gardenClientSet, _ kubernetes.Interface, gardenlet *seedmanagementv1alpha1.Gardenlet) error {
+						steps = append(steps, "deployGardenlet")
+						Expect(gardenlet.Name).To(Equal("root"))
+						return gardenClientSet.Client().Create(ctx, seed)
+					}))
+
+					Expect(cmd.RunE(cmd, nil)).To(Succeed())
+					Expect(steps).To(Equal([]string{"deployGardenlet"}))
+					Expect(fakeGardenClient.Get(ctx, client.ObjectKey{Name: "root", Namespace: "garden"}, &gardencorev1beta1.Shoot{})).To(BeNotFoundError())
+				})
+
+				It("should fail if the Seed does not become ready", func() {
+					seed.Status.Conditions[1].Status = gardencorev1beta1.ConditionFalse
+
+					DeferCleanup(test.WithVar(&DeployGardenlet, func(ctx context.Context, _ logr.Logger, gardenClientSet, _ kubernetes.Interface, _ *seedmanagementv1alpha1.Gardenlet) error {
+						steps = append(steps, "deployGardenlet")
+						return gardenClientSet.Client().Create(ctx, seed)
+					}))
 
-			output, err := io.ReadAll(out)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented\n"))
+					Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("seed root is not ready yet")))
+					Expect(steps).To(Equal([]string{"deployGardenlet"}))
+					Expect(fakeGardenClient.Get(ctx, client.ObjectKey{Name: "root", Namespace: "garden"}, &gardencorev1beta1.Shoot{})).To(BeNotFoundError())
+				})
+			})
 		})
 	})
 })
@@ -5,16 +5,54 @@
 package connect
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/pflag"
+
+	"github.com/gardener/gardener/pkg/gardenadm/botanist"
 )
 
 // Options contains options for this command.
-type Options struct{}
+type Options struct {
+	// Kubeconfig is the path to the kubeconfig file pointing to the garden cluster.
+	Kubeconfig string
+	// ShootKubeconfig is the path to the kubeconfig file pointing to the autonomous shoot cluster.
+	ShootKubeconfig string
+	// ConfigDir is the path to the directory containing the Gardener configuration resources (Shoot, CloudProfile,
+	// Gardenlet, etc.).
+	ConfigDir string
+	// Timeout is the maximum duration to wait for the Seed to become ready.
+	Timeout time.Duration
+}
 
 // Complete completes the options.
 func (o *Options) Complete() error { return nil }
 
 // Validate validates the options.
-func (o *Options) Validate() error { return nil }
+func (o *Options) Validate() error {
+	if len(o.Kubeconfig) == 0 {
+		return fmt.Errorf("must provide a path to a garden cluster kubeconfig")
+	}
+
+	if len(o.ShootKubeconfig) == 0 {
+		return fmt.Errorf("must provide a path to an autonomous shoot cluster kubeconfig")
+	}
+
+	if len(o.ConfigDir) == 0 {
+		return fmt.Errorf("must provide a path to the config directory")
+	}
+
+	if o.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+
+	return nil
+}
 
-func (o *Options) addFlags(_ *pflag.FlagSet) {}
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Kubeconfig, "kubeconfig", "k", "", "Path to the kubeconfig file pointing to the garden cluster")
+	fs.StringVarP(&o.ShootKubeconfig, "shoot-kubeconfig", "", botanist.PathAdminKubeconfig, "Path to the kubeconfig file pointing to the autonomous shoot cluster")
+	fs.StringVarP(&o.ConfigDir, "config-dir", "d", ".", "Path to the directory containing the Gardener configuration resources (Shoot, CloudProfile, Gardenlet, etc.)")
+	fs.DurationVarP(&o.Timeout, "timeout", "", 30*time.Minute, "Maximum duration to wait for the Seed to become ready")
+}
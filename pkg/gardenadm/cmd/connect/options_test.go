@@ -5,6 +5,8 @@
 package connect_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -17,7 +19,12 @@ var _ = Describe("Options", func() {
 	)
 
 	BeforeEach(func() {
-		options = &Options{}
+		options = &Options{
+			Kubeconfig:      "garden-kubeconfig",
+			ShootKubeconfig: "shoot-kubeconfig",
+			ConfigDir:       ".",
+			Timeout:         time.Minute,
+		}
 	})
 
 	Describe("#Complete", func() {
@@ -30,5 +37,29 @@ var _ = Describe("Options", func() {
 		It("should return nil", func() {
 			Expect(options.Validate()).To(Succeed())
 		})
+
+		It("should fail if the garden kubeconfig is empty", func() {
+			options.Kubeconfig = ""
+
+			Expect(options.Validate()).To(MatchError("must provide a path to a garden cluster kubeconfig"))
+		})
+
+		It("should fail if the shoot kubeconfig is empty", func() {
+			options.ShootKubeconfig = ""
+
+			Expect(options.Validate()).To(MatchError("must provide a path to an autonomous shoot cluster kubeconfig"))
+		})
+
+		It("should fail if the config directory is empty", func() {
+			options.ConfigDir = ""
+
+			Expect(options.Validate()).To(MatchError("must provide a path to the config directory"))
+		})
+
+		It("should fail if the timeout is not positive", func() {
+			options.Timeout = 0
+
+			Expect(options.Validate()).To(MatchError("timeout must be positive"))
+		})
 	})
 })
@@ -7,9 +7,19 @@ package discover
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
 )
 
 // NewCommand creates a new cobra.Command.
@@ -19,10 +29,15 @@ func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "discover",
 		Short: "Conveniently download Gardener configuration resources from an existing garden cluster",
-		Long:  "Conveniently download Gardener configuration resources from an existing garden cluster (CloudProfile, ControllerRegistrations, ControllerDeployments, etc.)",
+		Long: "Conveniently download Gardener configuration resources from an existing garden cluster (CloudProfile, ControllerRegistrations, ControllerDeployments, etc.). " +
+			"The manifests of the given Shoot and of all resources it requires are written to the config directory which can be consumed by 'gardenadm init'. " +
+			"Server-managed fields (status, managedFields, resourceVersion, uid, etc.) are removed from the manifests.",
+
+		Example: `# Download the configuration for the Shoot garden-local/root to the current directory
+gardenadm discover --kubeconfig ~/.kube/config --shoot-namespace garden-local --shoot-name root
 
-		Example: `# Download the configuration
-gardenadm discover --kubeconfig ~/.kube/config`,
+# Download the configuration to a specific directory
+gardenadm discover --kubeconfig ~/.kube/config --shoot-namespace garden-local --shoot-name root --config-dir ./manifests`,
 
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := opts.Complete(); err != nil {
@@ -42,7 +57,130 @@ gardenadm discover --kubeconfig ~/.kube/config`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented")
+func run(ctx context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	clientSet, err := cmdutils.NewClientSetFromFile(opts.Kubeconfig, kubernetes.GardenScheme)
+	if err != nil {
+		return fmt.Errorf("failed creating garden client: %w", err)
+	}
+
+	objects, err := discoverObjects(ctx, clientSet.Client(), client.ObjectKey{Namespace: opts.ShootNamespace, Name: opts.ShootName})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(opts.ConfigDir, 0700); err != nil {
+		return fmt.Errorf("failed creating config directory %q: %w", opts.ConfigDir, err)
+	}
+
+	for _, obj := range objects {
+		path, err := cmdutils.WriteManifest(opts.ConfigDir, obj, kubernetes.GardenScheme)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(ioStreams.Out, "Wrote %s\n", path)
+	}
+
 	return nil
 }
+
+// discoverObjects reads the Shoot with the given key and all objects it requires from the garden cluster, i.e., the
+// (Namespaced)CloudProfile, and the ControllerRegistrations and ControllerDeployments of the required extensions.
+func discoverObjects(ctx context.Context, c client.Reader, shootKey client.ObjectKey) ([]client.Object, error) {
+	shoot := &gardencorev1beta1.Shoot{}
+	if err := c.Get(ctx, shootKey, shoot); err != nil {
+		return nil, fmt.Errorf("failed reading Shoot %s: %w", shootKey, err)
+	}
+
+	cloudProfileObjects, err := discoverCloudProfile(ctx, c, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	extensionObjects, err := discoverExtensions(ctx, c, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]client.Object{shoot}, cloudProfileObjects...), extensionObjects...), nil
+}
+
+func discoverCloudProfile(ctx context.Context, c client.Reader, shoot *gardencorev1beta1.Shoot) ([]client.Object, error) {
+	cloudProfileReference := gardenerutils.BuildCloudProfileReference(shoot)
+	if cloudProfileReference == nil {
+		return nil, fmt.Errorf("could not determine CloudProfile from Shoot %s", client.ObjectKeyFromObject(shoot))
+	}
+
+	var (
+		objects          []client.Object
+		cloudProfileName = cloudProfileReference.Name
+	)
+
+	if cloudProfileReference.Kind == v1beta1constants.CloudProfileReferenceKindNamespacedCloudProfile {
+		namespacedCloudProfile := &gardencorev1beta1.NamespacedCloudProfile{}
+		if err := c.Get(ctx, client.ObjectKey{Name: cloudProfileReference.Name, Namespace: shoot.Namespace}, namespacedCloudProfile); err != nil {
+			return nil, fmt.Errorf("failed reading NamespacedCloudProfile %s: %w", cloudProfileReference.Name, err)
+		}
+
+		objects = append(objects, namespacedCloudProfile)
+		cloudProfileName = namespacedCloudProfile.Spec.Parent.Name
+	}
+
+	cloudProfile := &gardencorev1beta1.CloudProfile{}
+	if err := c.Get(ctx, client.ObjectKey{Name: cloudProfileName}, cloudProfile); err != nil {
+		return nil, fmt.Errorf("failed reading CloudProfile %s: %w", cloudProfileName, err)
+	}
+
+	return append([]client.Object{cloudProfile}, objects...), nil
+}
+
+func discoverExtensions(ctx context.Context, c client.Reader, shoot *gardencorev1beta1.Shoot) ([]client.Object, error) {
+	controllerRegistrationList := &gardencorev1beta1.ControllerRegistrationList{}
+	if err := c.List(ctx, controllerRegistrationList); err != nil {
+		return nil, fmt.Errorf("failed listing ControllerRegistrations: %w", err)
+	}
+
+	// Autonomous shoot clusters do not have a seed, hence the control plane is running in the shoot itself. Consequently,
+	// the shoot provider type is also the seed provider type.
+	seed := &gardencorev1beta1.Seed{Spec: gardencorev1beta1.SeedSpec{Provider: gardencorev1beta1.SeedProvider{Type: shoot.Spec.Provider.Type}}}
+	requiredExtensions := gardenerutils.ComputeRequiredExtensionsForShoot(shoot, seed, controllerRegistrationList, nil, nil)
+
+	var (
+		objects                   []client.Object
+		controllerDeploymentNames = sets.New[string]()
+	)
+
+	for _, controllerRegistration := range controllerRegistrationList.Items {
+		if !isRequired(controllerRegistration, requiredExtensions) {
+			continue
+		}
+
+		objects = append(objects, controllerRegistration.DeepCopy())
+
+		if controllerRegistration.Spec.Deployment != nil {
+			for _, deploymentRef := range controllerRegistration.Spec.Deployment.DeploymentRefs {
+				controllerDeploymentNames.Insert(deploymentRef.Name)
+			}
+		}
+	}
+
+	for _, name := range sets.List(controllerDeploymentNames) {
+		controllerDeployment := &gardencorev1.ControllerDeployment{}
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, controllerDeployment); err != nil {
+			return nil, fmt.Errorf("failed reading ControllerDeployment %s: %w", name, err)
+		}
+
+		objects = append(objects, controllerDeployment)
+	}
+
+	return objects, nil
+}
+
+func isRequired(controllerRegistration gardencorev1beta1.ControllerRegistration, requiredExtensions sets.Set[string]) bool {
+	for _, resource := range controllerRegistration.Spec.Resources {
+		if requiredExtensions.Has(gardenerutils.ExtensionsID(resource.Kind, resource.Type)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -6,37 +6,136 @@ package discover_test
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/discover"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/utils/test"
 )
 
 var _ = Describe("Discover", func() {
 	var (
-		ioStreams genericiooptions.IOStreams
-		out       *bytes.Buffer
-		cmd       *cobra.Command
+		ctx        = context.Background()
+		ioStreams  genericiooptions.IOStreams
+		out        *bytes.Buffer
+		cmd        *cobra.Command
+		fakeClient client.Client
+		configDir  string
+
+		shoot *gardencorev1beta1.Shoot
 	)
 
 	BeforeEach(func() {
 		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
+		cmd.SetContext(ctx)
+
+		configDir = filepath.Join(GinkgoT().TempDir(), "manifests")
+		Expect(cmd.Flags().Set("kubeconfig", "some-path-to-kubeconfig")).To(Succeed())
+		Expect(cmd.Flags().Set("shoot-namespace", "garden-local")).To(Succeed())
+		Expect(cmd.Flags().Set("shoot-name", "root")).To(Succeed())
+		Expect(cmd.Flags().Set("config-dir", configDir)).To(Succeed())
+
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+		DeferCleanup(test.WithVar(&cmdutils.NewClientSetFromFile, func(string, *runtime.Scheme) (kubernetes.Interface, error) {
+			return fakekubernetes.NewClientSetBuilder().WithClient(fakeClient).Build(), nil
+		}))
+
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "root", Namespace: "garden-local"},
+			Spec: gardencorev1beta1.ShootSpec{
+				CloudProfile: &gardencorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "local"},
+				Provider: gardencorev1beta1.Provider{
+					Type: "local",
+					Workers: []gardencorev1beta1.Worker{{
+						Name:    "control-plane",
+						Machine: gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "local"}},
+					}},
+				},
+			},
+			Status: gardencorev1beta1.ShootStatus{TechnicalID: "shoot--local--root"},
+		}
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
-			Expect(cmd.Flags().Set("kubeconfig", "some-path-to-kubeconfig")).To(Succeed())
+		It("should fail if the Shoot does not exist", func() {
+			Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("failed reading Shoot garden-local/root")))
+		})
+
+		It("should write the manifests of the Shoot and all required resources", func() {
+			Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.CloudProfile{ObjectMeta: metav1.ObjectMeta{Name: "local"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.ControllerRegistration{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider-local"},
+				Spec: gardencorev1beta1.ControllerRegistrationSpec{
+					Resources: []gardencorev1beta1.ControllerResource{
+						{Kind: "Infrastructure", Type: "local"},
+						{Kind: "OperatingSystemConfig", Type: "local"},
+					},
+					Deployment: &gardencorev1beta1.ControllerRegistrationDeployment{DeploymentRefs: []gardencorev1beta1.DeploymentRef{{Name: "provider-local"}}},
+				},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.ControllerRegistration{
+				ObjectMeta: metav1.ObjectMeta{Name: "extension-foo"},
+				Spec: gardencorev1beta1.ControllerRegistrationSpec{
+					Resources:  []gardencorev1beta1.ControllerResource{{Kind: "Extension", Type: "foo"}},
+					Deployment: &gardencorev1beta1.ControllerRegistrationDeployment{DeploymentRefs: []gardencorev1beta1.DeploymentRef{{Name: "extension-foo"}}},
+				},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.ControllerRegistration{
+				ObjectMeta: metav1.ObjectMeta{Name: "extension-bar"},
+				Spec: gardencorev1beta1.ControllerRegistrationSpec{
+					Resources: []gardencorev1beta1.ControllerResource{{Kind: "Extension", Type: "bar", GloballyEnabled: ptr.To(true)}},
+				},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &gardencorev1.ControllerDeployment{ObjectMeta: metav1.ObjectMeta{Name: "provider-local"}})).To(Succeed())
 
 			Expect(cmd.RunE(cmd, nil)).To(Succeed())
 
 			output, err := io.ReadAll(out)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented\n"))
+			Expect(string(output)).To(Equal("Wrote " + filepath.Join(configDir, "shoot-root.yaml") + "\n" +
+				"Wrote " + filepath.Join(configDir, "cloudprofile-local.yaml") + "\n" +
+				"Wrote " + filepath.Join(configDir, "controllerregistration-extension-bar.yaml") + "\n" +
+				"Wrote " + filepath.Join(configDir, "controllerregistration-provider-local.yaml") + "\n" +
+				"Wrote " + filepath.Join(configDir, "controllerdeployment-provider-local.yaml") + "\n"))
+
+			shootManifest, err := os.ReadFile(filepath.Join(configDir, "shoot-root.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(shootManifest)).To(ContainSubstring("apiVersion: core.gardener.cloud/v1beta1\nkind: Shoot\n"))
+			Expect(string(shootManifest)).NotTo(Or(ContainSubstring("status:"), ContainSubstring("resourceVersion:")))
+		})
+
+		It("should also write the NamespacedCloudProfile and its parent", func() {
+			shoot.Spec.CloudProfile = &gardencorev1beta1.CloudProfileReference{Kind: "NamespacedCloudProfile", Name: "custom"}
+			Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.CloudProfile{ObjectMeta: metav1.ObjectMeta{Name: "local"}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.NamespacedCloudProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "garden-local"},
+				Spec:       gardencorev1beta1.NamespacedCloudProfileSpec{Parent: gardencorev1beta1.CloudProfileReference{Kind: "CloudProfile", Name: "local"}},
+			})).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(filepath.Join(configDir, "cloudprofile-local.yaml")).To(BeAnExistingFile())
+			Expect(filepath.Join(configDir, "namespacedcloudprofile-custom.yaml")).To(BeAnExistingFile())
 		})
 	})
 })
@@ -14,6 +14,12 @@ import (
 type Options struct {
 	// Kubeconfig is the path to the kubeconfig file pointing to the garden cluster.
 	Kubeconfig string
+	// ShootNamespace is the namespace of the Shoot in the garden cluster.
+	ShootNamespace string
+	// ShootName is the name of the Shoot in the garden cluster.
+	ShootName string
+	// ConfigDir is the path to the directory the discovered manifests are written to.
+	ConfigDir string
 }
 
 // Complete completes the options.
@@ -25,9 +31,20 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("must provide a path to a garden cluster kubeconfig")
 	}
 
+	if len(o.ShootNamespace) == 0 || len(o.ShootName) == 0 {
+		return fmt.Errorf("must provide the namespace and name of the Shoot")
+	}
+
+	if len(o.ConfigDir) == 0 {
+		return fmt.Errorf("must provide a path to the config directory")
+	}
+
 	return nil
 }
 
 func (o *Options) addFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&o.Kubeconfig, "kubeconfig", "k", "", "Path to the kubeconfig file pointing to the garden cluster")
+	fs.StringVarP(&o.ShootNamespace, "shoot-namespace", "n", "", "Namespace of the Shoot in the garden cluster (i.e., the project namespace)")
+	fs.StringVarP(&o.ShootName, "shoot-name", "s", "", "Name of the Shoot in the garden cluster")
+	fs.StringVarP(&o.ConfigDir, "config-dir", "d", ".", "Path to the directory the discovered manifests are written to")
 }
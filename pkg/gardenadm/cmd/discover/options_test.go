@@ -17,7 +17,12 @@ var _ = Describe("Options", func() {
 	)
 
 	BeforeEach(func() {
-		options = &Options{}
+		options = &Options{
+			Kubeconfig:     "some-path-to-kubeconfig",
+			ShootNamespace: "garden-local",
+			ShootName:      "root",
+			ConfigDir:      "some-dir",
+		}
 	})
 
 	Describe("#Complete", func() {
@@ -28,13 +33,25 @@ var _ = Describe("Options", func() {
 
 	Describe("#Validate", func() {
 		It("should pass for valid options", func() {
-			options.Kubeconfig = "some-path-to-kubeconfig"
-
 			Expect(options.Validate()).To(Succeed())
 		})
 
 		It("should fail because kubeconfig path is not set", func() {
+			options.Kubeconfig = ""
+
 			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a path to a garden cluster kubeconfig")))
 		})
+
+		It("should fail because shoot name is not set", func() {
+			options.ShootName = ""
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide the namespace and name of the Shoot")))
+		})
+
+		It("should fail because config directory is not set", func() {
+			options.ConfigDir = ""
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a path to the config directory")))
+		})
 	})
 })
@@ -8,8 +8,20 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/utils/clock"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/gardenadm/botanist"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/logger"
+	"github.com/gardener/gardener/pkg/nodeagent"
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+	"github.com/gardener/gardener/pkg/utils/flow"
 )
 
 // NewCommand creates a new cobra.Command.
@@ -19,10 +31,15 @@ func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Bootstrap the first control plane node",
-		Long:  "Bootstrap the first control plane node",
+		Long: "Bootstrap the first control plane node. " +
+			"The Gardener configuration resources (Shoot, CloudProfile, ControllerRegistrations, ControllerDeployments, etc.) are read from the config directory. " +
+			"The control plane components (etcd, kube-apiserver, kube-controller-manager, kube-scheduler) are started as static pods on this machine.",
+
+		Example: `# Bootstrap the first control plane node with the configuration from the current directory
+gardenadm init
 
-		Example: `# Bootstrap the first control plane node
-gardenadm init`,
+# Bootstrap the first control plane node with the configuration from a specific directory
+gardenadm init --config-dir ./manifests`,
 
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := opts.Complete(); err != nil {
@@ -42,7 +59,119 @@ gardenadm init`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented")
+func run(ctx context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	objects, err := cmdutils.ReadManifests(opts.ConfigDir, kubernetes.GardenScheme)
+	if err != nil {
+		return fmt.Errorf("failed reading manifests from config directory %q: %w", opts.ConfigDir, err)
+	}
+
+	for _, obj := range objects {
+		kubernetes.GardenScheme.Default(obj)
+	}
+
+	resources, err := botanist.NewResources(objects)
+	if err != nil {
+		return err
+	}
+
+	hostName, err := nodeagent.GetHostName()
+	if err != nil {
+		return fmt.Errorf("failed fetching host name: %w", err)
+	}
+
+	log := logger.MustNewZapLogger(logger.InfoLevel, logger.FormatText, logzap.WriteTo(ioStreams.ErrOut))
+
+	b, err := botanist.NewAutonomousBotanist(ctx, log, afero.Afero{Fs: afero.NewOsFs()}, dbus.New(log), clock.RealClock{}, resources, hostName)
+	if err != nil {
+		return fmt.Errorf("failed creating botanist: %w", err)
+	}
+
+	var (
+		osc *extensionsv1alpha1.OperatingSystemConfig
+		g   = flow.NewGraph("init")
+
+		initializeSecretsManagement = g.Add(flow.Task{
+			Name: "Initializing secrets management",
+			Fn:   b.InitializeSecretsManagement,
+		})
+		deployEtcd = g.Add(flow.Task{
+			Name:         "Computing static pod for etcd",
+			Fn:           b.DeployEtcd,
+			Dependencies: flow.NewTaskIDs(initializeSecretsManagement),
+		})
+		deployKubeAPIServer = g.Add(flow.Task{
+			Name:         "Computing static pod for kube-apiserver",
+			Fn:           b.DeployKubeAPIServer,
+			Dependencies: flow.NewTaskIDs(deployEtcd),
+		})
+		deployKubeControllerManager = g.Add(flow.Task{
+			Name:         "Computing static pod for kube-controller-manager",
+			Fn:           b.DeployKubeControllerManager,
+			Dependencies: flow.NewTaskIDs(deployKubeAPIServer),
+		})
+		deployKubeScheduler = g.Add(flow.Task{
+			Name:         "Computing static pod for kube-scheduler",
+			Fn:           b.DeployKubeScheduler,
+			Dependencies: flow.NewTaskIDs(initializeSecretsManagement),
+		})
+		deployKubeconfigs = g.Add(flow.Task{
+			Name:         "Computing kubeconfigs for administrator and kubelet",
+			Fn:           b.DeployKubeconfigs,
+			Dependencies: flow.NewTaskIDs(initializeSecretsManagement),
+		})
+		persistSecrets = g.Add(flow.Task{
+			Name:         "Persisting secrets on the machine",
+			Fn:           b.PersistSecrets,
+			Dependencies: flow.NewTaskIDs(deployEtcd, deployKubeAPIServer, deployKubeControllerManager, deployKubeScheduler, deployKubeconfigs),
+		})
+		computeOperatingSystemConfig = g.Add(flow.Task{
+			Name: "Computing operating system config",
+			Fn: func(ctx context.Context) error {
+				var err error
+				osc, err = b.ComputeOperatingSystemConfig(ctx)
+				return err
+			},
+			Dependencies: flow.NewTaskIDs(deployEtcd, deployKubeAPIServer, deployKubeControllerManager, deployKubeScheduler, deployKubeconfigs),
+		})
+		applyOperatingSystemConfig = g.Add(flow.Task{
+			Name: "Applying operating system config",
+			Fn: func(ctx context.Context) error {
+				return b.ApplyOperatingSystemConfig(ctx, osc)
+			},
+			Dependencies: flow.NewTaskIDs(computeOperatingSystemConfig, persistSecrets),
+		})
+		deployGardenerNodeAgentResources = g.Add(flow.Task{
+			Name:         "Deploying operating system configs of worker pools and RBAC resources for gardener-node-agent",
+			Fn:           b.DeployGardenerNodeAgentResources,
+			Dependencies: flow.NewTaskIDs(deployKubeconfigs),
+		})
+		waitUntilKubeAPIServerReady = g.Add(flow.Task{
+			Name:         "Waiting until kube-apiserver is ready",
+			Fn:           b.WaitUntilKubeAPIServerReady,
+			Dependencies: flow.NewTaskIDs(applyOperatingSystemConfig),
+		})
+		_ = g.Add(flow.Task{
+			Name:         "Applying resources required by the control plane components",
+			Fn:           b.ApplyManagedResources,
+			Dependencies: flow.NewTaskIDs(waitUntilKubeAPIServerReady, deployGardenerNodeAgentResources),
+		})
+	)
+
+	if err := g.Compile().Run(ctx, flow.Opts{Log: log}); err != nil {
+		return flow.Errors(err)
+	}
+
+	fmt.Fprintf(ioStreams.Out, `
+Your autonomous shoot cluster control plane has initialized successfully!
+
+To start using your cluster, run the following as root:
+
+  export KUBECONFIG=%s
+
+To join worker nodes, create a bootstrap token and print the join command on this node:
+
+  gardenadm token create --print-join-command --kubeconfig %s --control-plane-address <address-of-this-node>
+`, botanist.PathAdminKubeconfig, botanist.PathAdminKubeconfig)
+
 	return nil
 }
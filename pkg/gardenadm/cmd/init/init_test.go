@@ -5,9 +5,6 @@
 package init_test
 
 import (
-	"bytes"
-	"io"
-
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
@@ -19,22 +16,31 @@ import (
 var _ = Describe("Init", func() {
 	var (
 		ioStreams genericiooptions.IOStreams
-		out       *bytes.Buffer
 		cmd       *cobra.Command
 	)
 
 	BeforeEach(func() {
-		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
+		ioStreams, _, _, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+		It("should fail if the config directory is empty", func() {
+			Expect(cmd.Flags().Set("config-dir", "")).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(MatchError("must provide a path to the config directory"))
+		})
+
+		It("should fail if the config directory does not exist", func() {
+			Expect(cmd.Flags().Set("config-dir", "/non/existing/directory")).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("failed reading manifests from config directory")))
+		})
+
+		It("should fail if the config directory does not contain a Shoot", func() {
+			Expect(cmd.Flags().Set("config-dir", GinkgoT().TempDir())).To(Succeed())
 
-			output, err := io.ReadAll(out)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented\n"))
+			Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("no Shoot found")))
 		})
 	})
 })
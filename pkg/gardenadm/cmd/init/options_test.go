@@ -28,7 +28,13 @@ var _ = Describe("Options", func() {
 
 	Describe("#Validate", func() {
 		It("should return nil", func() {
+			options.ConfigDir = "."
+
 			Expect(options.Validate()).To(Succeed())
 		})
+
+		It("should fail if the config directory is empty", func() {
+			Expect(options.Validate()).To(MatchError("must provide a path to the config directory"))
+		})
 	})
 })
@@ -7,25 +7,78 @@ package join
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/go-logr/logr"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubernetesclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	nodeagentcomponent "github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/nodeagent"
+	oscutils "github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/utils"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/logger"
+	"github.com/gardener/gardener/pkg/nodeagent"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/bootstrap"
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+	"github.com/gardener/gardener/pkg/nodeagent/registry"
+)
+
+var (
+	// FS is the file system used for writing the files of gardener-node-agent. Exposed for testing.
+	FS = afero.Afero{Fs: afero.NewOsFs()}
+	// NewDBus creates a new dbus.DBus for managing the systemd unit of gardener-node-agent. Exposed for testing.
+	NewDBus = dbus.New
+	// NewExtractor creates a new registry.Extractor for extracting the gardener-node-agent binary. Exposed for testing.
+	NewExtractor = registry.NewExtractor
+	// GetHostName returns the host name of the machine. Exposed for testing.
+	GetHostName = nodeagent.GetHostName
+	// RequestAndStoreKubeconfig requests a client certificate for gardener-node-agent and stores the resulting kubeconfig.
+	// Exposed for testing.
+	RequestAndStoreKubeconfig = nodeagent.RequestAndStoreKubeconfig
+
+	decoder runtime.Decoder
 )
 
+func init() {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(extensionsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(nodeagentconfigv1alpha1.AddToScheme(scheme))
+	decoder = serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
+
 // NewCommand creates a new cobra.Command.
 func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	opts := &Options{}
 
 	cmd := &cobra.Command{
-		Use:   "join",
+		Use:   "join [control-plane-address]",
 		Short: "Bootstrap further control plane nodes or worker nodes and join them to the cluster",
-		Long:  "Bootstrap further control plane nodes or worker nodes and join them to the cluster",
+		Long: "Bootstrap further control plane nodes or worker nodes and join them to the cluster. " +
+			"The CA certificate of the cluster is discovered from the API server of the control plane and verified with the given hash. " +
+			"Afterwards, the bootstrap token is used for requesting a client certificate for gardener-node-agent (TLS bootstrapping) " +
+			"and for fetching the operating system config of the worker pool. Finally, gardener-node-agent is installed and started, " +
+			"and it takes over the configuration of the machine. " +
+			"If joining fails, the command can safely be re-run.",
 
 		Example: `# Bootstrap a worker node and join it to the cluster
-gardenadm join`,
+gardenadm join --bootstrap-token foo123.bar4567890baz123 --ca-certificate-hash sha256:6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b --gardener-node-agent-secret-name gardener-node-agent-worker-abc12 https://api.example.com`,
 
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			if err := opts.Complete(); err != nil {
+		Args: cobra.MaximumNArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Complete(args); err != nil {
 				return err
 			}
 
@@ -42,7 +95,194 @@ gardenadm join`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented either")
+func run(ctx context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	log := logger.MustNewZapLogger(logger.InfoLevel, logger.FormatText, logzap.WriteTo(ioStreams.ErrOut))
+
+	hostName, err := GetHostName()
+	if err != nil {
+		return fmt.Errorf("failed fetching host name: %w", err)
+	}
+
+	log.Info("Discovering CA certificate of the cluster", "controlPlaneAddress", opts.ControlPlaneAddress)
+	caBundle, err := cmdutils.DiscoverCACertificate(ctx, opts.ControlPlaneAddress, opts.CACertificateHash)
+	if err != nil {
+		return fmt.Errorf("failed discovering CA certificate: %w", err)
+	}
+
+	bootstrapRESTConfig := &rest.Config{
+		Host:            opts.ControlPlaneAddress,
+		TLSClientConfig: rest.TLSClientConfig{CAData: caBundle},
+		BearerToken:     opts.BootstrapToken,
+	}
+
+	log.Info("Fetching operating system config", "secretName", opts.GardenerNodeAgentSecretName)
+	osc, err := fetchOperatingSystemConfig(ctx, bootstrapRESTConfig, opts.GardenerNodeAgentSecretName)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCredentials(log, opts.BootstrapToken, hostName); err != nil {
+		return err
+	}
+
+	if err := requestKubeconfig(ctx, log, bootstrapRESTConfig, hostName); err != nil {
+		return err
+	}
+
+	nodeAgentConfig, err := writeNodeAgentConfig(log, osc, opts.ControlPlaneAddress, caBundle)
+	if err != nil {
+		return err
+	}
+
+	if err := extractNodeAgentBinary(ctx, log, osc); err != nil {
+		return err
+	}
+
+	if err := bootstrap.Bootstrap(ctx, log, FS, NewDBus(log), nodeAgentConfig.Bootstrap); err != nil {
+		return fmt.Errorf("failed bootstrapping gardener-node-agent: %w", err)
+	}
+
+	fmt.Fprintf(ioStreams.Out, `
+This node has joined the cluster!
+
+gardener-node-agent has been started and configures this machine according to the operating system config %q.
+Run 'kubectl get nodes' on the control plane node to see this node join the cluster.
+`, opts.GardenerNodeAgentSecretName)
+
+	return nil
+}
+
+func fetchOperatingSystemConfig(ctx context.Context, restConfig *rest.Config, secretName string) (*extensionsv1alpha1.OperatingSystemConfig, error) {
+	clientSet, err := kubernetesclientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating client set with bootstrap token: %w", err)
+	}
+
+	secret, err := clientSet.CoreV1().Secrets(metav1.NamespaceSystem).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching operating system config secret %q: %w", secretName, err)
+	}
+
+	return decodeOperatingSystemConfig(secret)
+}
+
+func decodeOperatingSystemConfig(secret *corev1.Secret) (*extensionsv1alpha1.OperatingSystemConfig, error) {
+	oscRaw, ok := secret.Data[nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig]
+	if !ok {
+		return nil, fmt.Errorf("no %s key found in operating system config secret %q", nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig, secret.Name)
+	}
+
+	osc := &extensionsv1alpha1.OperatingSystemConfig{}
+	if err := runtime.DecodeInto(decoder, oscRaw, osc); err != nil {
+		return nil, fmt.Errorf("failed decoding operating system config from secret %q: %w", secret.Name, err)
+	}
+
+	return osc, nil
+}
+
+func writeCredentials(log logr.Logger, bootstrapToken, machineName string) error {
+	if err := FS.MkdirAll(nodeagentconfigv1alpha1.CredentialsDir, os.ModeDir); err != nil {
+		return fmt.Errorf("failed creating directory %q: %w", nodeagentconfigv1alpha1.CredentialsDir, err)
+	}
+
+	log.Info("Writing bootstrap token", "path", nodeagentconfigv1alpha1.BootstrapTokenFilePath)
+	if err := FS.WriteFile(nodeagentconfigv1alpha1.BootstrapTokenFilePath, []byte(bootstrapToken), 0600); err != nil {
+		return fmt.Errorf("failed writing bootstrap token file: %w", err)
+	}
+
+	log.Info("Writing machine name", "path", nodeagentconfigv1alpha1.MachineNameFilePath)
+	if err := FS.WriteFile(nodeagentconfigv1alpha1.MachineNameFilePath, []byte(machineName), 0600); err != nil {
+		return fmt.Errorf("failed writing machine name file: %w", err)
+	}
+
+	return nil
+}
+
+func requestKubeconfig(ctx context.Context, log logr.Logger, bootstrapRESTConfig *rest.Config, machineName string) error {
+	exists, err := FS.Exists(nodeagentconfigv1alpha1.KubeconfigFilePath)
+	if err != nil {
+		return fmt.Errorf("failed checking whether kubeconfig file %q exists: %w", nodeagentconfigv1alpha1.KubeconfigFilePath, err)
+	}
+	if exists {
+		log.Info("Kubeconfig for gardener-node-agent already exists, skipping TLS bootstrapping", "path", nodeagentconfigv1alpha1.KubeconfigFilePath)
+		return nil
+	}
+
+	log.Info("Requesting client certificate for gardener-node-agent (TLS bootstrapping)")
+	if err := RequestAndStoreKubeconfig(ctx, log, FS, bootstrapRESTConfig, machineName); err != nil {
+		return fmt.Errorf("failed requesting kubeconfig for gardener-node-agent: %w", err)
+	}
+
+	return nil
+}
+
+// writeNodeAgentConfig writes the configuration of gardener-node-agent contained in the operating system config. The
+// API server configuration is replaced with the address and CA certificate used for joining since the operating system
+// config might have been computed for a different endpoint.
+func writeNodeAgentConfig(log logr.Logger, osc *extensionsv1alpha1.OperatingSystemConfig, controlPlaneAddress string, caBundle []byte) (*nodeagentconfigv1alpha1.NodeAgentConfiguration, error) {
+	file := findFile(osc, nodeagentconfigv1alpha1.ConfigFilePath)
+	if file == nil || file.Content.Inline == nil {
+		return nil, fmt.Errorf("operating system config does not contain the gardener-node-agent configuration file %q", nodeagentconfigv1alpha1.ConfigFilePath)
+	}
+
+	configRaw, err := oscutils.NewFileContentInlineCodec().Decode(file.Content.Inline)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding gardener-node-agent configuration file: %w", err)
+	}
+
+	config := &nodeagentconfigv1alpha1.NodeAgentConfiguration{}
+	if err := runtime.DecodeInto(decoder, configRaw, config); err != nil {
+		return nil, fmt.Errorf("failed decoding gardener-node-agent configuration: %w", err)
+	}
+
+	config.APIServer.Server = controlPlaneAddress
+	config.APIServer.CABundle = caBundle
+
+	files, err := nodeagentcomponent.Files(config)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := oscutils.NewFileContentInlineCodec().Decode(files[0].Content.Inline)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding gardener-node-agent configuration file: %w", err)
+	}
+
+	if err := FS.MkdirAll(filepath.Dir(nodeagentconfigv1alpha1.ConfigFilePath), os.ModeDir); err != nil {
+		return nil, fmt.Errorf("failed creating directory %q: %w", filepath.Dir(nodeagentconfigv1alpha1.ConfigFilePath), err)
+	}
+
+	log.Info("Writing gardener-node-agent configuration", "path", nodeagentconfigv1alpha1.ConfigFilePath)
+	if err := FS.WriteFile(nodeagentconfigv1alpha1.ConfigFilePath, content, 0600); err != nil {
+		return nil, fmt.Errorf("failed writing gardener-node-agent configuration file: %w", err)
+	}
+
+	return config, nil
+}
+
+func extractNodeAgentBinary(ctx context.Context, log logr.Logger, osc *extensionsv1alpha1.OperatingSystemConfig) error {
+	file := findFile(osc, nodeagentcomponent.PathBinary)
+	if file == nil || file.Content.ImageRef == nil {
+		return fmt.Errorf("operating system config does not contain the gardener-node-agent binary %q", nodeagentcomponent.PathBinary)
+	}
+
+	if err := FS.MkdirAll(nodeagentconfigv1alpha1.TempDir, os.ModeDir); err != nil {
+		return fmt.Errorf("failed creating directory %q: %w", nodeagentconfigv1alpha1.TempDir, err)
+	}
+
+	log.Info("Extracting gardener-node-agent binary", "image", file.Content.ImageRef.Image, "path", nodeagentcomponent.PathBinary)
+	if err := NewExtractor().CopyFromImage(ctx, file.Content.ImageRef.Image, file.Content.ImageRef.FilePathInImage, nodeagentcomponent.PathBinary, 0755, nil); err != nil {
+		return fmt.Errorf("failed extracting gardener-node-agent binary: %w", err)
+	}
+
+	return nil
+}
+
+func findFile(osc *extensionsv1alpha1.OperatingSystemConfig, path string) *extensionsv1alpha1.File {
+	for _, file := range osc.Spec.Files {
+		if file.Path == path {
+			return &file
+		}
+	}
 	return nil
 }
@@ -6,14 +6,32 @@ package join_test
 
 import (
 	"bytes"
-	"io"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/rest"
 
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	nodeagentcomponent "github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/nodeagent"
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/join"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	"github.com/gardener/gardener/pkg/nodeagent/registry"
+	fakeregistry "github.com/gardener/gardener/pkg/nodeagent/registry/fake"
+	"github.com/gardener/gardener/pkg/utils/test"
 )
 
 var _ = Describe("Join", func() {
@@ -26,15 +44,120 @@ var _ = Describe("Join", func() {
 	BeforeEach(func() {
 		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
+		cmd.SetContext(context.Background())
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+		It("should fail if the control plane address is missing", func() {
+			Expect(cmd.RunE(cmd, nil)).To(MatchError("must provide the address of the control plane"))
+		})
+
+		When("the control plane is reachable", func() {
+			const secretName = "gardener-node-agent-worker"
+
+			var (
+				fakeFS                 afero.Afero
+				fakeDBus               *fakedbus.DBus
+				server                 *httptest.Server
+				requestedKubeconfigs   int
+				secretRequestsReceived int
+			)
+
+			BeforeEach(func() {
+				fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+				fakeDBus = fakedbus.New()
+				requestedKubeconfigs, secretRequestsReceived = 0, 0
+
+				Expect(fakeFS.WriteFile("/source/gardener-node-agent", []byte("binary"), 0755)).To(Succeed())
+
+				nodeAgentFiles, err := nodeagentcomponent.Files(&nodeagentconfigv1alpha1.NodeAgentConfiguration{
+					APIServer: nodeagentconfigv1alpha1.APIServer{Server: "https://wrong.example.com"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				osc := &extensionsv1alpha1.OperatingSystemConfig{
+					TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+					Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+						Files: append(nodeAgentFiles, extensionsv1alpha1.File{
+							Path:    nodeagentcomponent.PathBinary,
+							Content: extensionsv1alpha1.FileContent{ImageRef: &extensionsv1alpha1.FileContentImageRef{Image: "gardener-node-agent:v1", FilePathInImage: "/gardener-node-agent"}},
+						}),
+					},
+				}
+				oscRaw, err := json.Marshal(osc)
+				Expect(err).NotTo(HaveOccurred())
+
+				secretRaw, err := json.Marshal(&corev1.Secret{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+					ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: metav1.NamespaceSystem},
+					Data:       map[string][]byte{nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig: oscRaw},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+
+					Expect(r.Header.Get("Authorization")).To(Equal("Bearer foo123.bar4567890baz123"))
+					Expect(r.URL.Path).To(Equal("/api/v1/namespaces/kube-system/secrets/" + secretName))
+					secretRequestsReceived++
+
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write(secretRaw)
+				}))
+				DeferCleanup(server.Close)
+
+				caHash, err := cmdutils.CACertificateHash(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+				Expect(err).NotTo(HaveOccurred())
+
+				DeferCleanup(test.WithVars(
+					&FS, fakeFS,
+					&NewDBus, func(logr.Logger) dbus.DBus { return fakeDBus },
+					&NewExtractor, func() registry.Extractor { return fakeregistry.NewExtractor(fakeFS, "/source") },
+					&GetHostName, func() (string, error) { return "machine-0", nil },
+					&RequestAndStoreKubeconfig, func(_ context.Context, _ logr.Logger, fs afero.Afero, config *rest.Config, machineName string) error {
+						requestedKubeconfigs++
+						Expect(config.BearerToken).To(Equal("foo123.bar4567890baz123"))
+						Expect(machineName).To(Equal("machine-0"))
+						return fs.WriteFile(nodeagentconfigv1alpha1.KubeconfigFilePath, []byte("kubeconfig"), 0600)
+					},
+				))
+
+				Expect(cmd.Flags().Set("bootstrap-token", "foo123.bar4567890baz123")).To(Succeed())
+				Expect(cmd.Flags().Set("ca-certificate-hash", caHash)).To(Succeed())
+				Expect(cmd.Flags().Set("gardener-node-agent-secret-name", secretName)).To(Succeed())
+			})
+
+			It("should join the node and be idempotent", func() {
+				Expect(cmd.RunE(cmd, []string{server.URL})).To(Succeed())
+				Expect(out.String()).To(ContainSubstring("This node has joined the cluster!"))
+
+				Expect(secretRequestsReceived).To(Equal(1))
+				Expect(requestedKubeconfigs).To(Equal(1))
+
+				Expect(fakeFS.ReadFile(nodeagentconfigv1alpha1.BootstrapTokenFilePath)).To(Equal([]byte("foo123.bar4567890baz123")))
+				Expect(fakeFS.ReadFile(nodeagentconfigv1alpha1.MachineNameFilePath)).To(Equal([]byte("machine-0")))
+				Expect(fakeFS.ReadFile(nodeagentcomponent.PathBinary)).To(Equal([]byte("binary")))
+				Expect(fakeFS.Exists("/etc/systemd/system/gardener-node-agent.service")).To(BeTrue())
+
+				config, err := fakeFS.ReadFile(nodeagentconfigv1alpha1.ConfigFilePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(config)).To(ContainSubstring("server: " + server.URL))
+				Expect(string(config)).NotTo(ContainSubstring("wrong.example.com"))
+
+				Expect(fakeDBus.Actions).To(ContainElement(fakedbus.SystemdAction{Action: fakedbus.ActionStart, UnitNames: []string{nodeagentconfigv1alpha1.UnitName}}))
+
+				By("Re-running join")
+				Expect(cmd.RunE(cmd, []string{server.URL})).To(Succeed())
+				Expect(secretRequestsReceived).To(Equal(2))
+				Expect(requestedKubeconfigs).To(Equal(1))
+			})
+
+			It("should fail if the CA certificate hash does not match", func() {
+				Expect(cmd.Flags().Set("ca-certificate-hash", "sha256:abc")).To(Succeed())
 
-			output, err := io.ReadAll(out)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented either\n"))
+				Expect(cmd.RunE(cmd, []string{server.URL})).To(MatchError(ContainSubstring("failed discovering CA certificate")))
+				Expect(secretRequestsReceived).To(BeZero())
+			})
 		})
 	})
 })
@@ -5,16 +5,65 @@
 package join
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/pflag"
+	bootstraptokenutil "k8s.io/cluster-bootstrap/token/util"
+
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 // Options contains options for this command.
-type Options struct{}
+type Options struct {
+	// ControlPlaneAddress is the address of the API server of the control plane.
+	ControlPlaneAddress string
+	// BootstrapToken is the bootstrap token used for authenticating against the API server.
+	BootstrapToken string
+	// CACertificateHash is the hash of the CA certificate of the cluster. It is used to verify the CA certificate
+	// discovered from the API server.
+	CACertificateHash string
+	// GardenerNodeAgentSecretName is the name of the secret in the kube-system namespace containing the operating system
+	// config of the worker pool the node shall join.
+	GardenerNodeAgentSecretName string
+}
 
 // Complete completes the options.
-func (o *Options) Complete() error { return nil }
+func (o *Options) Complete(args []string) error {
+	if len(args) > 0 {
+		o.ControlPlaneAddress = strings.TrimSpace(args[0])
+	}
+
+	if o.ControlPlaneAddress != "" && !strings.Contains(o.ControlPlaneAddress, "://") {
+		o.ControlPlaneAddress = "https://" + o.ControlPlaneAddress
+	}
+
+	return nil
+}
 
 // Validate validates the options.
-func (o *Options) Validate() error { return nil }
+func (o *Options) Validate() error {
+	if o.ControlPlaneAddress == "" {
+		return fmt.Errorf("must provide the address of the control plane")
+	}
+
+	if !bootstraptokenutil.IsValidBootstrapToken(o.BootstrapToken) {
+		return fmt.Errorf("must provide a valid bootstrap token of the form \"[a-z0-9]{6}.[a-z0-9]{16}\"")
+	}
+
+	if !strings.HasPrefix(o.CACertificateHash, cmdutils.CACertificateHashPrefix) {
+		return fmt.Errorf("must provide a CA certificate hash of the form \"%s<hex-encoded-hash>\"", cmdutils.CACertificateHashPrefix)
+	}
+
+	if o.GardenerNodeAgentSecretName == "" {
+		return fmt.Errorf("must provide the name of the gardener-node-agent secret containing the operating system config")
+	}
+
+	return nil
+}
 
-func (o *Options) addFlags(_ *pflag.FlagSet) {}
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.BootstrapToken, "bootstrap-token", "", "", "Bootstrap token for authenticating against the API server of the control plane (can be created with 'gardenadm token create')")
+	fs.StringVarP(&o.CACertificateHash, "ca-certificate-hash", "", "", "Hash of the CA certificate of the cluster of the form \"sha256:<hex-encoded-hash>\" for verifying the identity of the API server of the control plane")
+	fs.StringVarP(&o.GardenerNodeAgentSecretName, "gardener-node-agent-secret-name", "", "", "Name of the secret in the kube-system namespace containing the operating system config of the worker pool the node shall join")
+}
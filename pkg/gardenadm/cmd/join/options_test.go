@@ -17,18 +17,52 @@ var _ = Describe("Options", func() {
 	)
 
 	BeforeEach(func() {
-		options = &Options{}
+		options = &Options{
+			BootstrapToken:              "foo123.bar4567890baz123",
+			CACertificateHash:           "sha256:abc",
+			GardenerNodeAgentSecretName: "gardener-node-agent-worker",
+		}
 	})
 
 	Describe("#Complete", func() {
-		It("should return nil", func() {
-			Expect(options.Complete()).To(Succeed())
+		It("should take the control plane address from the arguments", func() {
+			Expect(options.Complete([]string{"https://api.example.com"})).To(Succeed())
+			Expect(options.ControlPlaneAddress).To(Equal("https://api.example.com"))
+		})
+
+		It("should default the scheme of the control plane address", func() {
+			Expect(options.Complete([]string{"api.example.com:6443"})).To(Succeed())
+			Expect(options.ControlPlaneAddress).To(Equal("https://api.example.com:6443"))
 		})
 	})
 
 	Describe("#Validate", func() {
+		BeforeEach(func() {
+			options.ControlPlaneAddress = "https://api.example.com"
+		})
+
 		It("should return nil", func() {
 			Expect(options.Validate()).To(Succeed())
 		})
+
+		It("should fail if the control plane address is empty", func() {
+			options.ControlPlaneAddress = ""
+			Expect(options.Validate()).To(MatchError("must provide the address of the control plane"))
+		})
+
+		It("should fail if the bootstrap token is invalid", func() {
+			options.BootstrapToken = "foo"
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a valid bootstrap token")))
+		})
+
+		It("should fail if the CA certificate hash is invalid", func() {
+			options.CACertificateHash = "abc"
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a CA certificate hash")))
+		})
+
+		It("should fail if the gardener-node-agent secret name is empty", func() {
+			options.GardenerNodeAgentSecretName = ""
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide the name of the gardener-node-agent secret")))
+		})
 	})
 })
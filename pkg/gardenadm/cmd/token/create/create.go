@@ -9,7 +9,15 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/bootstraptoken"
 )
 
 // NewCommand creates a new cobra.Command.
@@ -19,15 +27,22 @@ func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create [token]",
 		Short: "Create a bootstrap token on the server",
-		Long: "The [token] is the actual token to write." +
-			"This should be a securely generated random token of the form \"[a-z0-9]{6}.[a-z0-9]{16}\"." +
-			"If no [token] is given, gardenadm will generate a random token instead.",
+		Long: "The [token] is the actual token to write. " +
+			"This should be a securely generated random token of the form \"[a-z0-9]{6}.[a-z0-9]{16}\". " +
+			"If no [token] is given, gardenadm will generate a random token instead. " +
+			"The created token is printed to stdout.",
 
 		Example: `# Create a bootstrap token with id "foo123" on the server
 gardenadm token create foo123.bar4567890baz123
 
 # Create a bootstrap token generated randomly
-gardenadm token create`,
+gardenadm token create
+
+# Create a bootstrap token with a custom description and validity
+gardenadm token create --description "Used for joining worker nodes" --validity 2h
+
+# Create a bootstrap token and print the full join command for the worker pool "worker"
+gardenadm token create --print-join-command --worker-pool-name worker`,
 
 		Args: cobra.MaximumNArgs(1),
 
@@ -49,7 +64,65 @@ gardenadm token create`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented")
+func run(ctx context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	clientSet, err := opts.CreateClientSet()
+	if err != nil {
+		return err
+	}
+
+	if _, err := bootstraptoken.CreateBootstrapToken(ctx, clientSet.Client(), opts.Token, opts.Description, opts.Validity); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("bootstrap token with the same ID already exists: %w", err)
+		}
+		return fmt.Errorf("failed creating bootstrap token: %w", err)
+	}
+
+	if !opts.PrintJoinCommand {
+		fmt.Fprintln(ioStreams.Out, opts.Token)
+		return nil
+	}
+
+	apiServerAddress, caCertificateHash, err := cmdutils.ClusterInfoFromKubeconfig(opts.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if opts.ControlPlaneAddress != "" {
+		apiServerAddress = opts.ControlPlaneAddress
+	}
+
+	gardenerNodeAgentSecretName, err := gardenerNodeAgentSecretName(ctx, clientSet.Client(), opts.WorkerPoolName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(ioStreams.Out, cmdutils.JoinCommand(apiServerAddress, opts.Token, caCertificateHash, gardenerNodeAgentSecretName))
 	return nil
 }
+
+// gardenerNodeAgentSecretName returns the name of the secret containing the operating system config for
+// gardener-node-agent of the given worker pool. If no worker pool name is given, the cluster must contain exactly one
+// such secret.
+func gardenerNodeAgentSecretName(ctx context.Context, c client.Client, workerPoolName string) (string, error) {
+	labels := client.MatchingLabels{v1beta1constants.GardenRole: v1beta1constants.GardenRoleOperatingSystemConfig}
+	if workerPoolName != "" {
+		labels[v1beta1constants.LabelWorkerPool] = workerPoolName
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList, client.InNamespace(metav1.NamespaceSystem), labels); err != nil {
+		return "", fmt.Errorf("failed listing gardener-node-agent secrets: %w", err)
+	}
+
+	switch len(secretList.Items) {
+	case 0:
+		if workerPoolName != "" {
+			return "", fmt.Errorf("no gardener-node-agent secret found for worker pool %q", workerPoolName)
+		}
+		return "", fmt.Errorf("no gardener-node-agent secret found")
+	case 1:
+		return secretList.Items[0].Name, nil
+	default:
+		return "", fmt.Errorf("found %d gardener-node-agent secrets, specify the worker pool via --worker-pool-name", len(secretList.Items))
+	}
+}
@@ -6,35 +6,143 @@ package create_test
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/token/create"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/gardener/gardener/pkg/utils/test"
 )
 
 var _ = Describe("Create", func() {
 	var (
-		ioStreams genericiooptions.IOStreams
-		out       *bytes.Buffer
-		cmd       *cobra.Command
+		ctx        = context.Background()
+		ioStreams  genericiooptions.IOStreams
+		out        *bytes.Buffer
+		cmd        *cobra.Command
+		fakeClient client.Client
 	)
 
 	BeforeEach(func() {
 		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
+		cmd.SetContext(ctx)
+		Expect(cmd.Flags().Set("kubeconfig", "some-path")).To(Succeed())
+
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.ShootScheme).Build()
+		DeferCleanup(test.WithVar(&cmdutils.NewClientSetFromFile, func(string, *runtime.Scheme) (kubernetes.Interface, error) {
+			return fakekubernetes.NewClientSetBuilder().WithClient(fakeClient).Build(), nil
+		}))
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
-			Expect(cmd.RunE(cmd, []string{"some-token"})).To(Succeed())
+		It("should create the bootstrap token secret and print the token", func() {
+			Expect(cmd.RunE(cmd, []string{"foo123.bar4567890baz123"})).To(Succeed())
 
 			output, err := io.ReadAll(out)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented\n"))
+			Expect(string(output)).To(Equal("foo123.bar4567890baz123\n"))
+
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "bootstrap-token-foo123", Namespace: metav1.NamespaceSystem}, secret)).To(Succeed())
+			Expect(secret.Type).To(Equal(corev1.SecretType("bootstrap.kubernetes.io/token")))
+			Expect(secret.Data).To(HaveKeyWithValue("token-id", []byte("foo123")))
+			Expect(secret.Data).To(HaveKeyWithValue("token-secret", []byte("bar4567890baz123")))
+			Expect(secret.Data).To(HaveKeyWithValue("usage-bootstrap-authentication", []byte("true")))
+			Expect(secret.Data).To(HaveKeyWithValue("usage-bootstrap-signing", []byte("true")))
+			Expect(secret.Data).To(HaveKey("expiration"))
+		})
+
+		It("should fail if a bootstrap token with the same ID already exists", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-foo123", Namespace: metav1.NamespaceSystem}})).To(Succeed())
+
+			Expect(cmd.RunE(cmd, []string{"foo123.bar4567890baz123"})).To(MatchError(ContainSubstring("bootstrap token with the same ID already exists")))
+		})
+
+		When("the join command should be printed", func() {
+			var caCertificateHash string
+
+			BeforeEach(func() {
+				ca, err := (&secretsutils.CertificateSecretConfig{Name: "ca", CommonName: "ca", CertType: secretsutils.CACert}).GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				caCertificateHash, err = cmdutils.CACertificateHash(ca.CertificatePEM)
+				Expect(err).NotTo(HaveOccurred())
+
+				kubeconfigPath := filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+				Expect(clientcmd.WriteToFile(clientcmdapi.Config{
+					Clusters:       map[string]*clientcmdapi.Cluster{"cluster": {Server: "https://localhost", CertificateAuthorityData: ca.CertificatePEM}},
+					AuthInfos:      map[string]*clientcmdapi.AuthInfo{"user": {Token: "foo"}},
+					Contexts:       map[string]*clientcmdapi.Context{"context": {Cluster: "cluster", AuthInfo: "user"}},
+					CurrentContext: "context",
+				}, kubeconfigPath)).To(Succeed())
+
+				Expect(cmd.Flags().Set("kubeconfig", kubeconfigPath)).To(Succeed())
+				Expect(cmd.Flags().Set("print-join-command", "true")).To(Succeed())
+			})
+
+			createOperatingSystemConfigSecret := func(name, workerPoolName string) {
+				Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: metav1.NamespaceSystem,
+					Labels: map[string]string{
+						"gardener.cloud/role":        "operating-system-config",
+						"worker.gardener.cloud/pool": workerPoolName,
+					},
+				}})).To(Succeed())
+			}
+
+			It("should print the join command with the secret of the only worker pool", func() {
+				createOperatingSystemConfigSecret("gardener-node-agent-worker-1234", "worker")
+
+				Expect(cmd.RunE(cmd, []string{"foo123.bar4567890baz123"})).To(Succeed())
+
+				output, err := io.ReadAll(out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("gardenadm join --bootstrap-token foo123.bar4567890baz123 --ca-certificate-hash " + caCertificateHash + " --gardener-node-agent-secret-name gardener-node-agent-worker-1234 https://localhost\n"))
+			})
+
+			It("should print the join command with the secret of the given worker pool and the given address", func() {
+				createOperatingSystemConfigSecret("gardener-node-agent-worker-1234", "worker")
+				createOperatingSystemConfigSecret("gardener-node-agent-other-5678", "other")
+				Expect(cmd.Flags().Set("worker-pool-name", "other")).To(Succeed())
+				Expect(cmd.Flags().Set("control-plane-address", "https://10.1.2.3")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, []string{"foo123.bar4567890baz123"})).To(Succeed())
+
+				output, err := io.ReadAll(out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("gardenadm join --bootstrap-token foo123.bar4567890baz123 --ca-certificate-hash " + caCertificateHash + " --gardener-node-agent-secret-name gardener-node-agent-other-5678 https://10.1.2.3\n"))
+			})
+
+			It("should fail if there is no secret for the given worker pool", func() {
+				createOperatingSystemConfigSecret("gardener-node-agent-worker-1234", "worker")
+				Expect(cmd.Flags().Set("worker-pool-name", "other")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, []string{"foo123.bar4567890baz123"})).To(MatchError(ContainSubstring(`no gardener-node-agent secret found for worker pool "other"`)))
+			})
+
+			It("should fail if there are multiple secrets and no worker pool is given", func() {
+				createOperatingSystemConfigSecret("gardener-node-agent-worker-1234", "worker")
+				createOperatingSystemConfigSecret("gardener-node-agent-other-5678", "other")
+
+				Expect(cmd.RunE(cmd, []string{"foo123.bar4567890baz123"})).To(MatchError(ContainSubstring("specify the worker pool via --worker-pool-name")))
+			})
 		})
 	})
 })
@@ -7,14 +7,32 @@ package create
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
+	bootstraptokenutil "k8s.io/cluster-bootstrap/token/util"
+
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 // Options contains options for this command.
 type Options struct {
+	cmdutils.KubeconfigOptions
+
 	// Token is the token to create.
 	Token string
+	// Description is the description for the bootstrap token.
+	Description string
+	// Validity duration of the bootstrap token.
+	Validity time.Duration
+	// PrintJoinCommand specifies whether to print the full `gardenadm join` command instead of only the token.
+	PrintJoinCommand bool
+	// WorkerPoolName is the name of the worker pool whose gardener-node-agent secret is used in the printed join
+	// command.
+	WorkerPoolName string
+	// ControlPlaneAddress is the address of the API server used in the printed join command. Defaults to the server of
+	// the kubeconfig.
+	ControlPlaneAddress string
 }
 
 // Complete completes the options.
@@ -24,11 +42,14 @@ func (o *Options) Complete(args []string) error {
 	}
 
 	if o.Token == "" {
-		// TODO: Generate a random token instead.
-		o.Token = "foo123.bar4567890baz123"
+		token, err := bootstraptokenutil.GenerateBootstrapToken()
+		if err != nil {
+			return fmt.Errorf("failed generating random bootstrap token: %w", err)
+		}
+		o.Token = token
 	}
 
-	return nil
+	return o.KubeconfigOptions.Complete()
 }
 
 // Validate validates the options.
@@ -37,9 +58,22 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("must provide a token to create")
 	}
 
-	// TODO: Validate that the token has the correct/expected format
+	if !bootstraptokenutil.IsValidBootstrapToken(o.Token) {
+		return fmt.Errorf("token %q is invalid, it must be of the form \"[a-z0-9]{6}.[a-z0-9]{16}\"", o.Token)
+	}
+
+	if o.Validity < 10*time.Minute || o.Validity > 24*time.Hour {
+		return fmt.Errorf("validity must be at least 10m and at most 24h")
+	}
 
-	return nil
+	return o.KubeconfigOptions.Validate()
 }
 
-func (o *Options) addFlags(_ *pflag.FlagSet) {}
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	o.KubeconfigOptions.AddFlags(fs)
+	fs.StringVarP(&o.Description, "description", "d", "Used for joining nodes via gardenadm join", "Description for the bootstrap token")
+	fs.DurationVarP(&o.Validity, "validity", "", time.Hour, "Validity duration of the bootstrap token. Minimum is 10m, maximum is 24h.")
+	fs.BoolVarP(&o.PrintJoinCommand, "print-join-command", "", false, "Instead of only printing the token, print the full 'gardenadm join' command")
+	fs.StringVarP(&o.WorkerPoolName, "worker-pool-name", "w", "", "Name of the worker pool the node should join, used to look up the gardener-node-agent secret for the printed join command. Can be omitted if the cluster has only one worker pool with such a secret.")
+	fs.StringVarP(&o.ControlPlaneAddress, "control-plane-address", "", "", "Address of the API server used in the printed join command. Defaults to the server of the kubeconfig.")
+}
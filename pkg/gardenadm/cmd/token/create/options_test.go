@@ -5,21 +5,27 @@
 package create_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/token/create"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 var _ = Describe("Options", func() {
 	var (
 		options *Options
 
-		token = "some.token"
+		token = "foo123.bar4567890baz123"
 	)
 
 	BeforeEach(func() {
-		options = &Options{}
+		options = &Options{
+			KubeconfigOptions: cmdutils.KubeconfigOptions{Kubeconfig: "some-path"},
+			Validity:          time.Hour,
+		}
 	})
 
 	Describe("#Complete", func() {
@@ -30,13 +36,13 @@ var _ = Describe("Options", func() {
 
 		It("should generate a random token", func() {
 			Expect(options.Complete(nil)).To(Succeed())
-			Expect(options.Token).To(Equal("foo123.bar4567890baz123"))
+			Expect(options.Token).To(MatchRegexp(`^[a-z0-9]{6}\.[a-z0-9]{16}$`))
 		})
 	})
 
 	Describe("#Validate", func() {
 		It("should pass for valid options", func() {
-			options.Token = "foo"
+			options.Token = token
 
 			Expect(options.Validate()).To(Succeed())
 		})
@@ -44,5 +50,25 @@ var _ = Describe("Options", func() {
 		It("should fail because token is not set", func() {
 			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a token to create")))
 		})
+
+		It("should fail because token has an invalid format", func() {
+			options.Token = "foo"
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("token \"foo\" is invalid")))
+		})
+
+		It("should fail because validity is too short", func() {
+			options.Token = token
+			options.Validity = time.Minute
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("validity must be at least 10m and at most 24h")))
+		})
+
+		It("should fail because kubeconfig is not set", func() {
+			options.Token = token
+			options.Kubeconfig = ""
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a path to a kubeconfig file")))
+		})
 	})
 })
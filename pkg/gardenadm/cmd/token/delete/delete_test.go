@@ -6,35 +6,65 @@ package delete_test
 
 import (
 	"bytes"
+	"context"
 	"io"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/token/delete"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/utils/test"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
 )
 
 var _ = Describe("Delete", func() {
 	var (
-		ioStreams genericiooptions.IOStreams
-		out       *bytes.Buffer
-		cmd       *cobra.Command
+		ctx        = context.Background()
+		ioStreams  genericiooptions.IOStreams
+		out        *bytes.Buffer
+		cmd        *cobra.Command
+		fakeClient client.Client
+		secret     *corev1.Secret
 	)
 
 	BeforeEach(func() {
 		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
+		cmd.SetContext(ctx)
+		Expect(cmd.Flags().Set("kubeconfig", "some-path")).To(Succeed())
+
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.ShootScheme).Build()
+		DeferCleanup(test.WithVar(&cmdutils.NewClientSetFromFile, func(string, *runtime.Scheme) (kubernetes.Interface, error) {
+			return fakekubernetes.NewClientSetBuilder().WithClient(fakeClient).Build(), nil
+		}))
+
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-foo123", Namespace: metav1.NamespaceSystem}}
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
-			Expect(cmd.RunE(cmd, []string{"some-token-id"})).To(Succeed())
+		It("should delete the bootstrap token secret", func() {
+			Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+
+			Expect(cmd.RunE(cmd, []string{"foo123"})).To(Succeed())
 
 			output, err := io.ReadAll(out)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented\n"))
+			Expect(string(output)).To(Equal("bootstrap token \"foo123\" deleted\n"))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(BeNotFoundError())
+		})
+
+		It("should fail if the bootstrap token does not exist", func() {
+			Expect(cmd.RunE(cmd, []string{"foo123"})).To(MatchError(ContainSubstring("bootstrap token \"foo123\" does not exist")))
 		})
 	})
 })
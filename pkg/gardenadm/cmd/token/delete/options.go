@@ -9,10 +9,15 @@ import (
 	"strings"
 
 	"github.com/spf13/pflag"
+	bootstraptokenutil "k8s.io/cluster-bootstrap/token/util"
+
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 // Options contains options for this command.
 type Options struct {
+	cmdutils.KubeconfigOptions
+
 	// TokenID is the ID of the token to delete.
 	TokenID string
 }
@@ -23,7 +28,12 @@ func (o *Options) Complete(args []string) error {
 		o.TokenID = strings.TrimSpace(args[0])
 	}
 
-	return nil
+	// Allow passing the full token instead of only its ID.
+	if tokenID, _, found := strings.Cut(o.TokenID, "."); found {
+		o.TokenID = tokenID
+	}
+
+	return o.KubeconfigOptions.Complete()
 }
 
 // Validate validates the options.
@@ -32,7 +42,13 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("must provide a token ID to delete")
 	}
 
-	return nil
+	if !bootstraptokenutil.IsValidBootstrapTokenID(o.TokenID) {
+		return fmt.Errorf("token ID %q is invalid, it must be of the form \"[a-z0-9]{6}\"", o.TokenID)
+	}
+
+	return o.KubeconfigOptions.Validate()
 }
 
-func (o *Options) addFlags(_ *pflag.FlagSet) {}
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	o.KubeconfigOptions.AddFlags(fs)
+}
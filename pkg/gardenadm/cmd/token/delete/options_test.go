@@ -9,17 +9,18 @@ import (
 	. "github.com/onsi/gomega"
 
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/token/delete"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 var _ = Describe("Options", func() {
 	var (
 		options *Options
 
-		tokenID = "token-id"
+		tokenID = "foo123"
 	)
 
 	BeforeEach(func() {
-		options = &Options{}
+		options = &Options{KubeconfigOptions: cmdutils.KubeconfigOptions{Kubeconfig: "some-path"}}
 	})
 
 	Describe("#Complete", func() {
@@ -27,11 +28,16 @@ var _ = Describe("Options", func() {
 			Expect(options.Complete([]string{tokenID})).To(Succeed())
 			Expect(options.TokenID).To(Equal(tokenID))
 		})
+
+		It("should extract the token ID from a full token", func() {
+			Expect(options.Complete([]string{"foo123.bar4567890baz123"})).To(Succeed())
+			Expect(options.TokenID).To(Equal(tokenID))
+		})
 	})
 
 	Describe("#Validate", func() {
 		It("should pass for valid options", func() {
-			options.TokenID = "foo123"
+			options.TokenID = tokenID
 
 			Expect(options.Validate()).To(Succeed())
 		})
@@ -39,5 +45,11 @@ var _ = Describe("Options", func() {
 		It("should fail because token ID is not set", func() {
 			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a token ID to delete")))
 		})
+
+		It("should fail because token ID has an invalid format", func() {
+			options.TokenID = "token-id"
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("token ID \"token-id\" is invalid")))
+		})
 	})
 })
@@ -10,6 +10,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	bootstraptokenutil "k8s.io/cluster-bootstrap/token/util"
+
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 // NewCommand creates a new cobra.Command.
@@ -19,10 +22,17 @@ func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generate a random bootstrap token",
-		Long:  "Generate a random bootstrap token",
+		Long: "Generate a cryptographically secure random bootstrap token of the form \"[a-z0-9]{6}.[a-z0-9]{16}\". " +
+			"The token is generated locally and not created on the server, use 'gardenadm token create' for this. " +
+			"This allows to generate tokens before the control plane is reachable.",
 
 		Example: `# Generate a random bootstrap token
-gardenadm token generate`,
+gardenadm token generate
+
+# Generate a random bootstrap token and print the full join command
+gardenadm token generate --print-join-command --kubeconfig /path/to/kubeconfig`,
+
+		Args: cobra.NoArgs,
 
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := opts.Complete(); err != nil {
@@ -42,7 +52,22 @@ gardenadm token generate`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented")
+func run(_ context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	token, err := bootstraptokenutil.GenerateBootstrapToken()
+	if err != nil {
+		return fmt.Errorf("failed generating random bootstrap token: %w", err)
+	}
+
+	if !opts.PrintJoinCommand {
+		fmt.Fprintln(ioStreams.Out, token)
+		return nil
+	}
+
+	apiServerAddress, caCertificateHash, err := cmdutils.ClusterInfoFromKubeconfig(opts.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(ioStreams.Out, cmdutils.JoinCommand(apiServerAddress, token, caCertificateHash, cmdutils.GardenerNodeAgentSecretNamePlaceholder))
 	return nil
 }
@@ -7,13 +7,19 @@ package generate_test
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/token/generate"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
 )
 
 var _ = Describe("Generate", func() {
@@ -29,12 +35,64 @@ var _ = Describe("Generate", func() {
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
+		It("should print a random bootstrap token", func() {
 			Expect(cmd.RunE(cmd, nil)).To(Succeed())
 
 			output, err := io.ReadAll(out)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented\n"))
+			Expect(string(output)).To(MatchRegexp(`^[a-z0-9]{6}\.[a-z0-9]{16}\n$`))
+		})
+
+		When("the join command should be printed", func() {
+			var (
+				kubeconfigPath string
+				caBundle       []byte
+			)
+
+			BeforeEach(func() {
+				ca, err := (&secretsutils.CertificateSecretConfig{Name: "ca", CommonName: "ca", CertType: secretsutils.CACert}).GenerateCertificate()
+				Expect(err).NotTo(HaveOccurred())
+				caBundle = ca.CertificatePEM
+
+				kubeconfigPath = filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+				Expect(cmd.Flags().Set("print-join-command", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("kubeconfig", kubeconfigPath)).To(Succeed())
+			})
+
+			It("should print the join command", func() {
+				Expect(clientcmd.WriteToFile(clientcmdapi.Config{
+					Clusters:       map[string]*clientcmdapi.Cluster{"cluster": {Server: "https://api.example.com:6443", CertificateAuthorityData: caBundle}},
+					AuthInfos:      map[string]*clientcmdapi.AuthInfo{"user": {Token: "foo"}},
+					Contexts:       map[string]*clientcmdapi.Context{"context": {Cluster: "cluster", AuthInfo: "user"}},
+					CurrentContext: "context",
+				}, kubeconfigPath)).To(Succeed())
+
+				caCertificateHash, err := cmdutils.CACertificateHash(caBundle)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				output, err := io.ReadAll(out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(MatchRegexp(`^gardenadm join --bootstrap-token [a-z0-9]{6}\.[a-z0-9]{16} --ca-certificate-hash ` + caCertificateHash + ` --gardener-node-agent-secret-name <gardener-node-agent-secret-name> https://api.example.com:6443\n$`))
+			})
+
+			It("should fail if the kubeconfig does not contain a CA certificate", func() {
+				Expect(clientcmd.WriteToFile(clientcmdapi.Config{
+					Clusters:       map[string]*clientcmdapi.Cluster{"cluster": {Server: "https://api.example.com:6443"}},
+					AuthInfos:      map[string]*clientcmdapi.AuthInfo{"user": {Token: "foo"}},
+					Contexts:       map[string]*clientcmdapi.Context{"context": {Cluster: "cluster", AuthInfo: "user"}},
+					CurrentContext: "context",
+				}, kubeconfigPath)).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("does not contain a CA certificate")))
+			})
+
+			It("should fail if the kubeconfig does not exist", func() {
+				Expect(os.Remove(kubeconfigPath)).To(Or(Succeed(), MatchError(os.ErrNotExist)))
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("failed reading kubeconfig")))
+			})
 		})
 	})
 })
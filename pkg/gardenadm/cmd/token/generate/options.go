@@ -6,15 +6,33 @@ package generate
 
 import (
 	"github.com/spf13/pflag"
+
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 // Options contains options for this command.
-type Options struct{}
+type Options struct {
+	cmdutils.KubeconfigOptions
+
+	// PrintJoinCommand specifies whether to print the full `gardenadm join` command instead of only the token.
+	PrintJoinCommand bool
+}
 
 // Complete completes the options.
-func (o *Options) Complete() error { return nil }
+func (o *Options) Complete() error {
+	return o.KubeconfigOptions.Complete()
+}
 
 // Validate validates the options.
-func (o *Options) Validate() error { return nil }
+func (o *Options) Validate() error {
+	if o.PrintJoinCommand {
+		return o.KubeconfigOptions.Validate()
+	}
+
+	return nil
+}
 
-func (o *Options) addFlags(_ *pflag.FlagSet) {}
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	o.KubeconfigOptions.AddFlags(fs)
+	fs.BoolVarP(&o.PrintJoinCommand, "print-join-command", "", false, "Instead of only printing the token, print the full 'gardenadm join' command using the API server address and CA certificate from the kubeconfig")
+}
@@ -27,7 +27,20 @@ var _ = Describe("Options", func() {
 	})
 
 	Describe("#Validate", func() {
-		It("should return nil", func() {
+		It("should pass without kubeconfig if join command should not be printed", func() {
+			Expect(options.Validate()).To(Succeed())
+		})
+
+		It("should fail without kubeconfig if join command should be printed", func() {
+			options.PrintJoinCommand = true
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a path to a kubeconfig file")))
+		})
+
+		It("should pass with kubeconfig if join command should be printed", func() {
+			options.PrintJoinCommand = true
+			options.Kubeconfig = "some-path"
+
 			Expect(options.Validate()).To(Succeed())
 		})
 	})
@@ -7,11 +7,27 @@ package list
 import (
 	"context"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	bootstraptokenapi "k8s.io/cluster-bootstrap/token/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/bootstraptoken"
 )
 
+// TimeNow returns the current time. Exposed for testing.
+var TimeNow = time.Now
+
 // NewCommand creates a new cobra.Command.
 func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 	opts := &Options{}
@@ -22,7 +38,10 @@ func NewCommand(ioStreams genericiooptions.IOStreams) *cobra.Command {
 		Long:  "List all bootstrap tokens on the server",
 
 		Example: `# List all bootstrap tokens on the server
-gardenadm token list`,
+gardenadm token list
+
+# List all bootstrap tokens on the server in JSON format
+gardenadm token list -o json`,
 
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := opts.Complete(); err != nil {
@@ -42,7 +61,106 @@ gardenadm token list`,
 	return cmd
 }
 
-func run(_ context.Context, ioStreams genericiooptions.IOStreams, _ *Options) error {
-	fmt.Fprintln(ioStreams.Out, "not implemented")
-	return nil
+// Token contains the information about a bootstrap token which is printed by this command.
+type Token struct {
+	// TokenID is the ID of the bootstrap token.
+	TokenID string `json:"tokenID"`
+	// Token is the full bootstrap token of the form "[a-z0-9]{6}.[a-z0-9]{16}".
+	Token string `json:"token"`
+	// Description is the description of the bootstrap token.
+	Description string `json:"description,omitempty"`
+	// Expiration is the time after which the bootstrap token is no longer valid.
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+	// Usages are the usages of the bootstrap token.
+	Usages []string `json:"usages,omitempty"`
+	// ExtraGroups are the additional groups the bootstrap token authenticates as.
+	ExtraGroups []string `json:"extraGroups,omitempty"`
+}
+
+func run(ctx context.Context, ioStreams genericiooptions.IOStreams, opts *Options) error {
+	clientSet, err := opts.CreateClientSet()
+	if err != nil {
+		return err
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := clientSet.Client().List(ctx, secretList, client.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return fmt.Errorf("failed listing secrets: %w", err)
+	}
+
+	tokens := make([]Token, 0, len(secretList.Items))
+	for _, secret := range secretList.Items {
+		if secret.Type != bootstraptokenapi.SecretTypeBootstrapToken {
+			continue
+		}
+		tokens = append(tokens, tokenFromSecret(&secret))
+	}
+
+	slices.SortFunc(tokens, func(a, b Token) int { return strings.Compare(a.TokenID, b.TokenID) })
+
+	if opts.Output == cmdutils.OutputFormatTable {
+		return printTable(ioStreams.Out, tokens)
+	}
+
+	return opts.PrintStructured(ioStreams.Out, tokens)
+}
+
+func tokenFromSecret(secret *corev1.Secret) Token {
+	token := Token{
+		TokenID:     string(secret.Data[bootstraptokenapi.BootstrapTokenIDKey]),
+		Token:       bootstraptoken.FromSecretData(secret.Data),
+		Description: string(secret.Data[bootstraptokenapi.BootstrapTokenDescriptionKey]),
+	}
+
+	if expiration, err := time.Parse(time.RFC3339, string(secret.Data[bootstraptokenapi.BootstrapTokenExpirationKey])); err == nil {
+		token.Expiration = &metav1.Time{Time: expiration}
+	}
+
+	for _, usage := range bootstraptokenapi.KnownTokenUsages {
+		if string(secret.Data[bootstraptokenapi.BootstrapTokenUsagePrefix+usage]) == "true" {
+			token.Usages = append(token.Usages, usage)
+		}
+	}
+
+	if extraGroups := string(secret.Data[bootstraptokenapi.BootstrapTokenExtraGroupsKey]); extraGroups != "" {
+		token.ExtraGroups = strings.Split(extraGroups, ",")
+	}
+
+	return token
+}
+
+func printTable(w io.Writer, tokens []Token) error {
+	if len(tokens) == 0 {
+		_, err := fmt.Fprintln(w, "No bootstrap tokens found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, "TOKEN\tTTL\tEXPIRES\tUSAGES\tDESCRIPTION")
+
+	for _, token := range tokens {
+		ttl, expires := "<forever>", "<never>"
+		if token.Expiration != nil {
+			expires = token.Expiration.UTC().Format(time.RFC3339)
+
+			ttl = "<expired>"
+			if remaining := token.Expiration.Sub(TimeNow()); remaining > 0 {
+				ttl = duration.HumanDuration(remaining)
+			}
+		}
+
+		usages := "<none>"
+		if len(token.Usages) > 0 {
+			usages = strings.Join(token.Usages, ",")
+		}
+
+		description := "<none>"
+		if token.Description != "" {
+			description = token.Description
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", token.Token, ttl, expires, usages, description)
+	}
+
+	return tw.Flush()
 }
@@ -6,35 +6,132 @@ package list_test
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakekubernetes "github.com/gardener/gardener/pkg/client/kubernetes/fake"
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/token/list"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	"github.com/gardener/gardener/pkg/utils/test"
 )
 
 var _ = Describe("List", func() {
 	var (
-		ioStreams genericiooptions.IOStreams
-		out       *bytes.Buffer
-		cmd       *cobra.Command
+		ctx        = context.Background()
+		ioStreams  genericiooptions.IOStreams
+		out        *bytes.Buffer
+		cmd        *cobra.Command
+		fakeClient client.Client
+
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 	)
 
 	BeforeEach(func() {
 		ioStreams, _, out, _ = genericiooptions.NewTestIOStreams()
 		cmd = NewCommand(ioStreams)
+		cmd.SetContext(ctx)
+		Expect(cmd.Flags().Set("kubeconfig", "some-path")).To(Succeed())
+
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.ShootScheme).Build()
+		DeferCleanup(test.WithVars(
+			&cmdutils.NewClientSetFromFile, func(string, *runtime.Scheme) (kubernetes.Interface, error) {
+				return fakekubernetes.NewClientSetBuilder().WithClient(fakeClient).Build(), nil
+			},
+			&TimeNow, func() time.Time { return now },
+		))
 	})
 
 	Describe("#RunE", func() {
-		It("should return the expected output", func() {
+		It("should print a message if there are no bootstrap tokens", func() {
 			Expect(cmd.RunE(cmd, nil)).To(Succeed())
 
 			output, err := io.ReadAll(out)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(string(output)).To(Equal("not implemented\n"))
+			Expect(string(output)).To(Equal("No bootstrap tokens found\n"))
+		})
+
+		When("bootstrap tokens exist", func() {
+			BeforeEach(func() {
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-foo123", Namespace: metav1.NamespaceSystem},
+					Type:       "bootstrap.kubernetes.io/token",
+					Data: map[string][]byte{
+						"token-id":                       []byte("foo123"),
+						"token-secret":                   []byte("bar4567890baz123"),
+						"description":                    []byte("some description"),
+						"expiration":                     []byte(now.Add(90 * time.Minute).Format(time.RFC3339)),
+						"usage-bootstrap-authentication": []byte("true"),
+						"usage-bootstrap-signing":        []byte("true"),
+					},
+				})).To(Succeed())
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-abc123", Namespace: metav1.NamespaceSystem},
+					Type:       "bootstrap.kubernetes.io/token",
+					Data: map[string][]byte{
+						"token-id":                       []byte("abc123"),
+						"token-secret":                   []byte("def4567890ghi123"),
+						"usage-bootstrap-authentication": []byte("true"),
+					},
+				})).To(Succeed())
+				Expect(fakeClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: metav1.NamespaceSystem},
+				})).To(Succeed())
+			})
+
+			It("should print the bootstrap tokens as table", func() {
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				output, err := io.ReadAll(out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal(`TOKEN                     TTL         EXPIRES                USAGES                   DESCRIPTION
+abc123.def4567890ghi123   <forever>   <never>                authentication           <none>
+foo123.bar4567890baz123   90m         2024-01-01T13:30:00Z   signing,authentication   some description
+`))
+			})
+
+			It("should print the bootstrap tokens as JSON", func() {
+				Expect(cmd.Flags().Set("output", "json")).To(Succeed())
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				output, err := io.ReadAll(out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(MatchJSON(`[
+  {"tokenID": "abc123", "token": "abc123.def4567890ghi123", "usages": ["authentication"]},
+  {"tokenID": "foo123", "token": "foo123.bar4567890baz123", "description": "some description", "expiration": "2024-01-01T13:30:00Z", "usages": ["signing", "authentication"]}
+]`))
+			})
+
+			It("should print the bootstrap tokens as YAML", func() {
+				Expect(cmd.Flags().Set("output", "yaml")).To(Succeed())
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				output, err := io.ReadAll(out)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(MatchYAML(`- tokenID: abc123
+  token: abc123.def4567890ghi123
+  usages:
+  - authentication
+- tokenID: foo123
+  token: foo123.bar4567890baz123
+  description: some description
+  expiration: "2024-01-01T13:30:00Z"
+  usages:
+  - signing
+  - authentication
+`))
+			})
 		})
 	})
 })
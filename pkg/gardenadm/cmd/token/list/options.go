@@ -5,16 +5,30 @@
 package list
 
 import (
+	"errors"
+
 	"github.com/spf13/pflag"
+
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 // Options contains options for this command.
-type Options struct{}
+type Options struct {
+	cmdutils.KubeconfigOptions
+	cmdutils.OutputOptions
+}
 
 // Complete completes the options.
-func (o *Options) Complete() error { return nil }
+func (o *Options) Complete() error {
+	return errors.Join(o.KubeconfigOptions.Complete(), o.OutputOptions.Complete())
+}
 
 // Validate validates the options.
-func (o *Options) Validate() error { return nil }
+func (o *Options) Validate() error {
+	return errors.Join(o.KubeconfigOptions.Validate(), o.OutputOptions.Validate())
+}
 
-func (o *Options) addFlags(_ *pflag.FlagSet) {}
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	o.KubeconfigOptions.AddFlags(fs)
+	o.OutputOptions.AddFlags(fs)
+}
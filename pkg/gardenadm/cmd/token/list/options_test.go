@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	. "github.com/gardener/gardener/pkg/gardenadm/cmd/token/list"
+	cmdutils "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
 )
 
 var _ = Describe("Options", func() {
@@ -17,18 +18,27 @@ var _ = Describe("Options", func() {
 	)
 
 	BeforeEach(func() {
-		options = &Options{}
+		options = &Options{KubeconfigOptions: cmdutils.KubeconfigOptions{Kubeconfig: "some-path"}}
 	})
 
 	Describe("#Complete", func() {
-		It("should return nil", func() {
+		It("should default the output format", func() {
 			Expect(options.Complete()).To(Succeed())
+			Expect(options.Output).To(Equal("table"))
 		})
 	})
 
 	Describe("#Validate", func() {
-		It("should return nil", func() {
+		It("should pass for valid options", func() {
+			options.Output = "json"
+
 			Expect(options.Validate()).To(Succeed())
 		})
+
+		It("should fail for an unsupported output format", func() {
+			options.Output = "wide"
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("output format must be one of [table json yaml]")))
+		})
 	})
 })
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// CACertificateHashPrefix is the prefix of CA certificate hashes, indicating the used hash algorithm.
+const CACertificateHashPrefix = "sha256:"
+
+// CACertificateHash computes the hash of the first certificate in the given PEM-encoded CA bundle. The hash is
+// computed over the DER-encoded SubjectPublicKeyInfo of the certificate (as done by kubeadm) and is returned in the
+// form "sha256:<hex-encoded-hash>".
+func CACertificateHash(caBundle []byte) (string, error) {
+	block, _ := pem.Decode(caBundle)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("no PEM-encoded certificate found in CA bundle")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing CA certificate: %w", err)
+	}
+
+	return certificateHash(certificate), nil
+}
+
+func certificateHash(certificate *x509.Certificate) string {
+	hash := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+	return CACertificateHashPrefix + hex.EncodeToString(hash[:])
+}
+
+// DiscoverCACertificate connects to the API server with the given address and returns the PEM-encoded CA certificate
+// from the certificate chain presented by the server whose hash matches the given CA certificate hash. The serving
+// certificate of the server must be signed by this CA certificate. No credentials are sent to the server, hence it is
+// safe to connect without verifying the serving certificate upfront.
+func DiscoverCACertificate(ctx context.Context, apiServerAddress, caCertificateHash string) ([]byte, error) {
+	address, err := hostPort(apiServerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &tls.Dialer{Config: &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The serving certificate is verified below once the CA certificate matching the given hash has been found.
+		InsecureSkipVerify: true, // #nosec G402 -- Verified manually, see below.
+	}}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed connecting to API server %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return nil, fmt.Errorf("unexpected connection type %T", conn)
+	}
+
+	certificates := tlsConn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("API server %s did not present any certificates", address)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	for _, certificate := range certificates {
+		if !certificate.IsCA || certificateHash(certificate) != caCertificateHash {
+			continue
+		}
+
+		roots := x509.NewCertPool()
+		roots.AddCert(certificate)
+		if _, err := certificates[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates}); err != nil {
+			return nil, fmt.Errorf("failed verifying serving certificate of API server %s: %w", address, err)
+		}
+
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}), nil
+	}
+
+	return nil, fmt.Errorf("no CA certificate matching hash %q found in certificate chain presented by API server %s", caCertificateHash, address)
+}
+
+func hostPort(apiServerAddress string) (string, error) {
+	if !strings.Contains(apiServerAddress, "://") {
+		apiServerAddress = "https://" + apiServerAddress
+	}
+
+	u, err := url.Parse(apiServerAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing API server address %q: %w", apiServerAddress, err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("API server address %q does not contain a host", apiServerAddress)
+	}
+
+	if u.Port() == "" {
+		return net.JoinHostPort(u.Hostname(), "443"), nil
+	}
+	return u.Host, nil
+}
+
+// ClusterInfoFromKubeconfig reads the API server address and the CA certificate hash from the current context of the
+// given kubeconfig file without connecting to the cluster.
+func ClusterInfoFromKubeconfig(kubeconfigPath string) (string, string, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed reading kubeconfig %q: %w", kubeconfigPath, err)
+	}
+
+	caBundle := restConfig.CAData
+	if len(caBundle) == 0 && restConfig.CAFile != "" {
+		caBundle, err = os.ReadFile(restConfig.CAFile)
+		if err != nil {
+			return "", "", fmt.Errorf("failed reading CA file %q: %w", restConfig.CAFile, err)
+		}
+	}
+
+	if len(caBundle) == 0 {
+		return "", "", fmt.Errorf("kubeconfig %q does not contain a CA certificate for the API server", kubeconfigPath)
+	}
+
+	caCertificateHash, err := CACertificateHash(caBundle)
+	if err != nil {
+		return "", "", err
+	}
+
+	return restConfig.Host, caCertificateHash, nil
+}
+
+// GardenerNodeAgentSecretNamePlaceholder is used in join commands instead of the name of the gardener-node-agent secret
+// if the worker pool of the node to join is not known.
+const GardenerNodeAgentSecretNamePlaceholder = "<gardener-node-agent-secret-name>"
+
+// JoinCommand returns the `gardenadm join` command line for joining a node to the cluster with the given API server
+// address, using the given bootstrap token, CA certificate hash and gardener-node-agent secret name.
+func JoinCommand(apiServerAddress, bootstrapToken, caCertificateHash, gardenerNodeAgentSecretName string) string {
+	return strings.Join([]string{
+		"gardenadm", "join",
+		"--bootstrap-token", bootstrapToken,
+		"--ca-certificate-hash", caCertificateHash,
+		"--gardener-node-agent-secret-name", gardenerNodeAgentSecretName,
+		apiServerAddress,
+	}, " ")
+}
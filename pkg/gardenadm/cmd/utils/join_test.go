@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+)
+
+var _ = Describe("Join", func() {
+	Describe("#CACertificateHash", func() {
+		It("should compute the hash of the CA certificate", func() {
+			ca, err := (&secretsutils.CertificateSecretConfig{Name: "ca", CommonName: "ca", CertType: secretsutils.CACert}).GenerateCertificate()
+			Expect(err).NotTo(HaveOccurred())
+
+			hash, err := CACertificateHash(ca.CertificatePEM)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(MatchRegexp(`^sha256:[a-f0-9]{64}$`))
+
+			otherCA, err := (&secretsutils.CertificateSecretConfig{Name: "ca", CommonName: "ca", CertType: secretsutils.CACert}).GenerateCertificate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(CACertificateHash(otherCA.CertificatePEM)).NotTo(Equal(hash))
+		})
+
+		It("should fail if the bundle does not contain a certificate", func() {
+			_, err := CACertificateHash([]byte("foo"))
+			Expect(err).To(MatchError(ContainSubstring("no PEM-encoded certificate found")))
+		})
+	})
+
+	Describe("#DiscoverCACertificate", func() {
+		var (
+			ctx    = context.Background()
+			server *httptest.Server
+			caPEM  []byte
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(http.NotFoundHandler())
+			DeferCleanup(server.Close)
+
+			// The certificate of the test server is self-signed, i.e., it is its own CA certificate.
+			caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		})
+
+		It("should return the CA certificate matching the hash", func() {
+			hash, err := CACertificateHash(caPEM)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(DiscoverCACertificate(ctx, server.URL, hash)).To(Equal(caPEM))
+		})
+
+		It("should fail if no CA certificate matches the hash", func() {
+			_, err := DiscoverCACertificate(ctx, server.URL, "sha256:abc")
+			Expect(err).To(MatchError(ContainSubstring(`no CA certificate matching hash "sha256:abc" found`)))
+		})
+
+		It("should fail if the API server is not reachable", func() {
+			address := server.URL
+			server.Close()
+
+			_, err := DiscoverCACertificate(ctx, address, "sha256:abc")
+			Expect(err).To(MatchError(ContainSubstring("failed connecting to API server")))
+		})
+	})
+
+	Describe("#JoinCommand", func() {
+		It("should return the expected command", func() {
+			Expect(JoinCommand("https://api.example.com", "foo123.bar4567890baz123", "sha256:abc", "gardener-node-agent-worker")).To(Equal("gardenadm join --bootstrap-token foo123.bar4567890baz123 --ca-certificate-hash sha256:abc --gardener-node-agent-secret-name gardener-node-agent-worker https://api.example.com"))
+		})
+	})
+})
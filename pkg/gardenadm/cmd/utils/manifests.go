@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	kubernetesyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// serverManagedFields are the fields which are managed by the API server and are removed when writing manifests.
+var serverManagedFields = [][]string{
+	{"status"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+}
+
+// EncodeManifest encodes the given object to YAML after removing all server-managed fields (status, managedFields,
+// resourceVersion, uid, creationTimestamp, generation). The apiVersion and kind fields are set based on the given
+// scheme.
+func EncodeManifest(obj client.Object, scheme *runtime.Scheme) ([]byte, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed determining GroupVersionKind of object %s: %w", client.ObjectKeyFromObject(obj), err)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting object %s to unstructured: %w", client.ObjectKeyFromObject(obj), err)
+	}
+
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	for _, fields := range serverManagedFields {
+		unstructured.RemoveNestedField(u.Object, fields...)
+	}
+
+	return yaml.Marshal(u.Object)
+}
+
+// WriteManifest encodes the given object via EncodeManifest and writes it to a file in the given directory. The file
+// name is derived from the kind and the name of the object.
+func WriteManifest(dir string, obj client.Object, scheme *runtime.Scheme) (string, error) {
+	data, err := EncodeManifest(obj, scheme)
+	if err != nil {
+		return "", err
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.yaml", strings.ToLower(gvk.Kind), obj.GetName()))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed writing manifest file %q: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// ReadManifests reads all YAML files (*.yaml, *.yml) in the given directory and decodes the contained objects with the
+// given scheme. Files may contain multiple documents separated by '---'. The objects are returned in the lexical order
+// of the file names and in the order of the documents within a file.
+func ReadManifests(dir string, scheme *runtime.Scheme) ([]client.Object, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading directory %q: %w", dir, err)
+	}
+
+	var (
+		decoder = serializer.NewCodecFactory(scheme).UniversalDeserializer()
+		objects []client.Object
+	)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !slices.Contains([]string{".yaml", ".yml"}, filepath.Ext(entry.Name())) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading file %q: %w", path, err)
+		}
+
+		reader := kubernetesyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+		for {
+			document, err := reader.Read()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("failed reading YAML document from file %q: %w", path, err)
+			}
+
+			if len(bytes.TrimSpace(document)) == 0 {
+				continue
+			}
+
+			obj, _, err := decoder.Decode(document, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed decoding object from file %q: %w", path, err)
+			}
+
+			clientObj, ok := obj.(client.Object)
+			if !ok {
+				return nil, fmt.Errorf("object %T decoded from file %q is not a client.Object", obj, path)
+			}
+			objects = append(objects, clientObj)
+		}
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+)
+
+var _ = Describe("Manifests", func() {
+	var configMap *corev1.ConfigMap
+
+	BeforeEach(func() {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "foo",
+				Namespace:       "bar",
+				UID:             types.UID("1234"),
+				ResourceVersion: "42",
+				Generation:      1,
+				ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "test"}},
+				Labels:          map[string]string{"foo": "bar"},
+			},
+			Data: map[string]string{"key": "value"},
+		}
+	})
+
+	Describe("#EncodeManifest", func() {
+		It("should encode the object without server-managed fields", func() {
+			Expect(EncodeManifest(configMap, kubernetes.ShootScheme)).To(MatchYAML(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: bar
+  labels:
+    foo: bar
+data:
+  key: value
+`))
+		})
+	})
+
+	Describe("#WriteManifest", func() {
+		It("should write the manifest to a file named after the kind and name", func() {
+			dir := GinkgoT().TempDir()
+
+			path, err := WriteManifest(dir, configMap, kubernetes.ShootScheme)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal(filepath.Join(dir, "configmap-foo.yaml")))
+
+			data, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("kind: ConfigMap"))
+		})
+	})
+
+	Describe("#ReadManifests", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+
+		It("should read all objects from the YAML files in the directory", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: bar
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: baz
+  namespace: bar
+`), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "b.yml"), []byte(`---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bar
+`), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0600)).To(Succeed())
+
+			objects, err := ReadManifests(dir, kubernetes.ShootScheme)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(3))
+			Expect(objects[0]).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+			Expect(objects[0].GetName()).To(Equal("foo"))
+			Expect(objects[1]).To(BeAssignableToTypeOf(&corev1.Secret{}))
+			Expect(objects[2]).To(BeAssignableToTypeOf(&corev1.Namespace{}))
+		})
+
+		It("should fail for unknown kinds", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(`apiVersion: foo.bar/v1
+kind: Baz
+metadata:
+  name: foo
+`), 0600)).To(Succeed())
+
+			_, err := ReadManifests(dir, kubernetes.ShootScheme)
+			Expect(err).To(MatchError(ContainSubstring("failed decoding object")))
+		})
+
+		It("should fail if the directory does not exist", func() {
+			_, err := ReadManifests(filepath.Join(dir, "missing"), kubernetes.ShootScheme)
+			Expect(err).To(MatchError(ContainSubstring("failed reading directory")))
+		})
+	})
+})
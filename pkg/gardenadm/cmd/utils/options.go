@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+)
+
+// NewClientSetFromFile creates a new client set with the given scheme for the cluster the given kubeconfig file points
+// to. Exposed for testing.
+var NewClientSetFromFile = func(kubeconfigPath string, scheme *runtime.Scheme) (kubernetes.Interface, error) {
+	return kubernetes.NewClientFromFile("", kubeconfigPath,
+		kubernetes.WithClientOptions(client.Options{Scheme: scheme}),
+		kubernetes.WithDisabledCachedClient(),
+	)
+}
+
+// NewClientSetFromBytes creates a new client set with the given scheme for the cluster the given kubeconfig points to.
+// Exposed for testing.
+var NewClientSetFromBytes = func(kubeconfig []byte, scheme *runtime.Scheme) (kubernetes.Interface, error) {
+	return kubernetes.NewClientFromBytes(kubeconfig,
+		kubernetes.WithClientOptions(client.Options{Scheme: scheme}),
+		kubernetes.WithDisabledCachedClient(),
+	)
+}
+
+// KubeconfigOptions contains options for commands which need to interact with a cluster.
+type KubeconfigOptions struct {
+	// Kubeconfig is the path to the kubeconfig file pointing to the cluster. Defaults to the value of the KUBECONFIG
+	// environment variable.
+	Kubeconfig string
+}
+
+// Complete completes the options.
+func (o *KubeconfigOptions) Complete() error {
+	if o.Kubeconfig == "" {
+		o.Kubeconfig = os.Getenv("KUBECONFIG")
+	}
+
+	return nil
+}
+
+// Validate validates the options.
+func (o *KubeconfigOptions) Validate() error {
+	if o.Kubeconfig == "" {
+		return fmt.Errorf("must provide a path to a kubeconfig file via --kubeconfig or the KUBECONFIG environment variable")
+	}
+
+	return nil
+}
+
+// AddFlags adds the flags of the options to the given flag set.
+func (o *KubeconfigOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file pointing to the cluster (defaults to the KUBECONFIG environment variable)")
+}
+
+// CreateClientSet creates a new client set for the (autonomous shoot) cluster referenced by the kubeconfig.
+func (o *KubeconfigOptions) CreateClientSet() (kubernetes.Interface, error) {
+	clientSet, err := NewClientSetFromFile(o.Kubeconfig, kubernetes.ShootScheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating client from kubeconfig %q: %w", o.Kubeconfig, err)
+	}
+
+	return clientSet, nil
+}
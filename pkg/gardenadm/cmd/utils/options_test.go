@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+)
+
+var _ = Describe("KubeconfigOptions", func() {
+	var options *KubeconfigOptions
+
+	BeforeEach(func() {
+		options = &KubeconfigOptions{}
+	})
+
+	Describe("#Complete", func() {
+		It("should default the kubeconfig path from the KUBECONFIG environment variable", func() {
+			GinkgoT().Setenv("KUBECONFIG", "/some/path")
+
+			Expect(options.Complete()).To(Succeed())
+			Expect(options.Kubeconfig).To(Equal("/some/path"))
+		})
+
+		It("should not overwrite an explicitly set kubeconfig path", func() {
+			GinkgoT().Setenv("KUBECONFIG", "/some/path")
+			options.Kubeconfig = "/other/path"
+
+			Expect(options.Complete()).To(Succeed())
+			Expect(options.Kubeconfig).To(Equal("/other/path"))
+		})
+	})
+
+	Describe("#Validate", func() {
+		It("should pass for valid options", func() {
+			options.Kubeconfig = "/some/path"
+
+			Expect(options.Validate()).To(Succeed())
+		})
+
+		It("should fail because kubeconfig is not set", func() {
+			Expect(options.Validate()).To(MatchError(ContainSubstring("must provide a path to a kubeconfig file")))
+		})
+	})
+})
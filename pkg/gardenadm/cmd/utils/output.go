@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OutputFormatTable is the constant for the table output format.
+	OutputFormatTable = "table"
+	// OutputFormatJSON is the constant for the JSON output format.
+	OutputFormatJSON = "json"
+	// OutputFormatYAML is the constant for the YAML output format.
+	OutputFormatYAML = "yaml"
+)
+
+var supportedOutputFormats = []string{OutputFormatTable, OutputFormatJSON, OutputFormatYAML}
+
+// OutputOptions contains options for commands which print structured output.
+type OutputOptions struct {
+	// Output is the output format. Must be one of [table,json,yaml].
+	Output string
+}
+
+// Complete completes the options.
+func (o *OutputOptions) Complete() error {
+	if o.Output == "" {
+		o.Output = OutputFormatTable
+	}
+
+	return nil
+}
+
+// Validate validates the options.
+func (o *OutputOptions) Validate() error {
+	if !slices.Contains(supportedOutputFormats, o.Output) {
+		return fmt.Errorf("output format must be one of %v", supportedOutputFormats)
+	}
+
+	return nil
+}
+
+// AddFlags adds the flags of the options to the given flag set.
+func (o *OutputOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Output, "output", "o", OutputFormatTable, fmt.Sprintf("Output format, one of %v", supportedOutputFormats))
+}
+
+// PrintStructured prints the given object in the configured structured output format (JSON or YAML) to the writer.
+func (o *OutputOptions) PrintStructured(w io.Writer, obj any) error {
+	switch o.Output {
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed marshalling output to JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+
+	case OutputFormatYAML:
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed marshalling output to YAML: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+
+	default:
+		return fmt.Errorf("output format %q is not a structured format", o.Output)
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener/pkg/gardenadm/cmd/utils"
+)
+
+var _ = Describe("OutputOptions", func() {
+	var (
+		options *OutputOptions
+		out     *bytes.Buffer
+
+		obj = map[string]any{"foo": "bar"}
+	)
+
+	BeforeEach(func() {
+		options = &OutputOptions{}
+		out = &bytes.Buffer{}
+	})
+
+	Describe("#Complete", func() {
+		It("should default the output format", func() {
+			Expect(options.Complete()).To(Succeed())
+			Expect(options.Output).To(Equal("table"))
+		})
+	})
+
+	Describe("#Validate", func() {
+		It("should pass for supported output formats", func() {
+			for _, output := range []string{"table", "json", "yaml"} {
+				options.Output = output
+				Expect(options.Validate()).To(Succeed())
+			}
+		})
+
+		It("should fail for unsupported output formats", func() {
+			options.Output = "xml"
+
+			Expect(options.Validate()).To(MatchError(ContainSubstring("output format must be one of")))
+		})
+	})
+
+	Describe("#PrintStructured", func() {
+		It("should print JSON", func() {
+			options.Output = "json"
+
+			Expect(options.PrintStructured(out, obj)).To(Succeed())
+			Expect(out.String()).To(Equal("{\n  \"foo\": \"bar\"\n}\n"))
+		})
+
+		It("should print YAML", func() {
+			options.Output = "yaml"
+
+			Expect(options.PrintStructured(out, obj)).To(Succeed())
+			Expect(out.String()).To(Equal("foo: bar\n"))
+		})
+
+		It("should fail for the table format", func() {
+			options.Output = "table"
+
+			Expect(options.PrintStructured(out, obj)).To(MatchError(ContainSubstring("not a structured format")))
+		})
+	})
+})
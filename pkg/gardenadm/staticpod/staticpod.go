@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package staticpod
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils"
+)
+
+const (
+	// ManifestsDirectory is the directory watched by the kubelet for static pod manifests.
+	ManifestsDirectory = "/etc/kubernetes/manifests"
+	// VolumesDirectory is the directory containing the files of the secret and config map volumes of static pods.
+	VolumesDirectory = "/var/lib/gardenadm/static-pods"
+	// Namespace is the namespace static pods are created in.
+	Namespace = metav1.NamespaceSystem
+	// PriorityClassName is the name of the priority class used for static pods.
+	PriorityClassName = "system-node-critical"
+)
+
+// Translate translates the pod template of the given object (Deployment, StatefulSet, or Pod) into a static pod
+// manifest. The secret, config map and projected volumes of the pod are read from the given reader and translated into
+// files which are mounted into the static pod via hostPath volumes. The returned files contain both the manifest and
+// the volume files and are meant to be added to an OperatingSystemConfig.
+func Translate(ctx context.Context, c client.Reader, obj client.Object) ([]extensionsv1alpha1.File, error) {
+	var (
+		objectMeta metav1.ObjectMeta
+		podSpec    corev1.PodSpec
+	)
+
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		objectMeta, podSpec = o.Spec.Template.ObjectMeta, o.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		objectMeta, podSpec = o.Spec.Template.ObjectMeta, o.Spec.Template.Spec
+	case *corev1.Pod:
+		objectMeta, podSpec = o.ObjectMeta, o.Spec
+	default:
+		return nil, fmt.Errorf("unsupported object type %T for static pod translation", obj)
+	}
+
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        obj.GetName(),
+			Namespace:   Namespace,
+			Labels:      objectMeta.Labels,
+			Annotations: objectMeta.Annotations,
+		},
+		Spec: *podSpec.DeepCopy(),
+	}
+
+	files, err := translateVolumes(ctx, c, obj.GetNamespace(), pod)
+	if err != nil {
+		return nil, err
+	}
+
+	translatePodSpec(&pod.Spec)
+
+	manifest, err := yaml.Marshal(pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshalling static pod manifest for %s: %w", pod.Name, err)
+	}
+
+	return append(files, extensionsv1alpha1.File{
+		Path:        filepath.Join(ManifestsDirectory, pod.Name+".yaml"),
+		Permissions: ptr.To[uint32](0600),
+		Content:     inlineContent(manifest),
+	}), nil
+}
+
+// translatePodSpec removes or replaces all fields which are not supported or not meaningful for static pods.
+func translatePodSpec(podSpec *corev1.PodSpec) {
+	podSpec.HostNetwork = true
+	podSpec.DNSPolicy = corev1.DNSDefault
+	podSpec.PriorityClassName = PriorityClassName
+	podSpec.Priority = nil
+	podSpec.ServiceAccountName = ""
+	podSpec.DeprecatedServiceAccount = ""
+	podSpec.AutomountServiceAccountToken = ptr.To(false)
+	podSpec.Affinity = nil
+	podSpec.TopologySpreadConstraints = nil
+	podSpec.NodeSelector = nil
+	podSpec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+
+	// The files of the volumes are written to the host with root ownership, hence the containers must run as root to be
+	// able to read them (similar to the control plane static pods of kubeadm).
+	if podSpec.SecurityContext != nil {
+		podSpec.SecurityContext.RunAsNonRoot = nil
+		podSpec.SecurityContext.RunAsUser = nil
+		podSpec.SecurityContext.RunAsGroup = nil
+		podSpec.SecurityContext.FSGroup = nil
+	}
+}
+
+// translateVolumes replaces all secret, config map and projected volumes of the given pod with hostPath volumes and
+// returns the files which must be written to the host.
+func translateVolumes(ctx context.Context, c client.Reader, namespace string, pod *corev1.Pod) ([]extensionsv1alpha1.File, error) {
+	var files []extensionsv1alpha1.File
+
+	for i, volume := range pod.Spec.Volumes {
+		var (
+			volumeFiles []extensionsv1alpha1.File
+			err         error
+			directory   = filepath.Join(VolumesDirectory, pod.Name, volume.Name)
+		)
+
+		switch {
+		case volume.Secret != nil:
+			volumeFiles, err = filesForSecret(ctx, c, client.ObjectKey{Namespace: namespace, Name: volume.Secret.SecretName}, volume.Secret.Items, ptr.Deref(volume.Secret.Optional, false), directory, volume.Secret.DefaultMode)
+		case volume.ConfigMap != nil:
+			volumeFiles, err = filesForConfigMap(ctx, c, client.ObjectKey{Namespace: namespace, Name: volume.ConfigMap.Name}, volume.ConfigMap.Items, ptr.Deref(volume.ConfigMap.Optional, false), directory, volume.ConfigMap.DefaultMode)
+		case volume.Projected != nil:
+			volumeFiles, err = filesForProjectedVolume(ctx, c, namespace, volume.Projected, directory)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed translating volume %q of pod %s: %w", volume.Name, pod.Name, err)
+		}
+
+		files = append(files, volumeFiles...)
+		pod.Spec.Volumes[i].VolumeSource = corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: directory,
+				Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+			},
+		}
+	}
+
+	return files, nil
+}
+
+func filesForProjectedVolume(ctx context.Context, c client.Reader, namespace string, projected *corev1.ProjectedVolumeSource, directory string) ([]extensionsv1alpha1.File, error) {
+	var files []extensionsv1alpha1.File
+
+	for _, source := range projected.Sources {
+		var (
+			sourceFiles []extensionsv1alpha1.File
+			err         error
+		)
+
+		switch {
+		case source.Secret != nil:
+			sourceFiles, err = filesForSecret(ctx, c, client.ObjectKey{Namespace: namespace, Name: source.Secret.Name}, source.Secret.Items, ptr.Deref(source.Secret.Optional, false), directory, projected.DefaultMode)
+		case source.ConfigMap != nil:
+			sourceFiles, err = filesForConfigMap(ctx, c, client.ObjectKey{Namespace: namespace, Name: source.ConfigMap.Name}, source.ConfigMap.Items, ptr.Deref(source.ConfigMap.Optional, false), directory, projected.DefaultMode)
+		default:
+			return nil, fmt.Errorf("unsupported projected volume source, only secrets and config maps are supported")
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, sourceFiles...)
+	}
+
+	return files, nil
+}
+
+func filesForSecret(ctx context.Context, c client.Reader, key client.ObjectKey, items []corev1.KeyToPath, optional bool, directory string, defaultMode *int32) ([]extensionsv1alpha1.File, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, key, secret); err != nil {
+		if optional && client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed reading secret %s: %w", key, err)
+	}
+
+	return filesForData(secret.Data, items, directory, defaultMode), nil
+}
+
+func filesForConfigMap(ctx context.Context, c client.Reader, key client.ObjectKey, items []corev1.KeyToPath, optional bool, directory string, defaultMode *int32) ([]extensionsv1alpha1.File, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, key, configMap); err != nil {
+		if optional && client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed reading config map %s: %w", key, err)
+	}
+
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for k, v := range configMap.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range configMap.BinaryData {
+		data[k] = v
+	}
+
+	return filesForData(data, items, directory, defaultMode), nil
+}
+
+func filesForData(data map[string][]byte, items []corev1.KeyToPath, directory string, defaultMode *int32) []extensionsv1alpha1.File {
+	if len(items) == 0 {
+		for key := range data {
+			items = append(items, corev1.KeyToPath{Key: key, Path: key})
+		}
+		slices.SortFunc(items, func(a, b corev1.KeyToPath) int { return strings.Compare(a.Key, b.Key) })
+	}
+
+	var files []extensionsv1alpha1.File
+	for _, item := range items {
+		value, ok := data[item.Key]
+		if !ok {
+			continue
+		}
+
+		mode := ptr.Deref(defaultMode, 0600)
+		if item.Mode != nil {
+			mode = *item.Mode
+		}
+
+		files = append(files, extensionsv1alpha1.File{
+			Path:        filepath.Join(directory, item.Path),
+			Permissions: ptr.To(uint32(mode)),
+			Content:     inlineContent(value),
+		})
+	}
+
+	return files
+}
+
+func inlineContent(data []byte) extensionsv1alpha1.FileContent {
+	return extensionsv1alpha1.FileContent{
+		Inline: &extensionsv1alpha1.FileContentInline{
+			Encoding: "b64",
+			Data:     utils.EncodeBase64(data),
+		},
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package staticpod_test
+
+import (
+	"context"
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/gardener/gardener/pkg/gardenadm/staticpod"
+)
+
+var _ = Describe("StaticPod", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		namespace  = "shoot--foo--bar"
+		deployment *appsv1.Deployment
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).Build()
+
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "server", Namespace: namespace},
+			Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: namespace},
+			Data:       map[string]string{"config.yaml": "foo: bar"},
+		})).To(Succeed())
+
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-scheduler", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "kubernetes"}},
+					Spec: corev1.PodSpec{
+						PriorityClassName:  "gardener-system-300",
+						ServiceAccountName: "kube-scheduler",
+						Affinity:           &corev1.Affinity{},
+						SecurityContext: &corev1.PodSecurityContext{
+							RunAsNonRoot: ptr.To(true),
+							RunAsUser:    ptr.To[int64](65532),
+						},
+						Containers: []corev1.Container{{Name: "kube-scheduler", Image: "kube-scheduler:v1.31.1"}},
+						Volumes: []corev1.Volume{
+							{Name: "server", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "server", DefaultMode: ptr.To[int32](0640)}}},
+							{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}}}},
+							{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+								{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "server"}, Items: []corev1.KeyToPath{{Key: "tls.crt", Path: "server.crt"}}}},
+								{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Optional: ptr.To(true)}},
+							}}}},
+							{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("#Translate", func() {
+		It("should translate the deployment into a static pod manifest and volume files", func() {
+			files, err := Translate(ctx, fakeClient, deployment)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(files).To(HaveLen(5))
+			Expect(files[0]).To(Equal(file("/var/lib/gardenadm/static-pods/kube-scheduler/server/tls.crt", 0640, "cert")))
+			Expect(files[1]).To(Equal(file("/var/lib/gardenadm/static-pods/kube-scheduler/server/tls.key", 0640, "key")))
+			Expect(files[2]).To(Equal(file("/var/lib/gardenadm/static-pods/kube-scheduler/config/config.yaml", 0600, "foo: bar")))
+			Expect(files[3]).To(Equal(file("/var/lib/gardenadm/static-pods/kube-scheduler/projected/server.crt", 0600, "cert")))
+			Expect(files[4].Path).To(Equal("/etc/kubernetes/manifests/kube-scheduler.yaml"))
+
+			manifest, err := base64.StdEncoding.DecodeString(files[4].Content.Inline.Data)
+			Expect(err).NotTo(HaveOccurred())
+
+			pod := &corev1.Pod{}
+			Expect(yaml.Unmarshal(manifest, pod)).To(Succeed())
+
+			Expect(pod.Name).To(Equal("kube-scheduler"))
+			Expect(pod.Namespace).To(Equal("kube-system"))
+			Expect(pod.Labels).To(Equal(map[string]string{"app": "kubernetes"}))
+			Expect(pod.Spec.HostNetwork).To(BeTrue())
+			Expect(pod.Spec.PriorityClassName).To(Equal("system-node-critical"))
+			Expect(pod.Spec.ServiceAccountName).To(BeEmpty())
+			Expect(pod.Spec.Affinity).To(BeNil())
+			Expect(pod.Spec.SecurityContext.RunAsNonRoot).To(BeNil())
+			Expect(pod.Spec.SecurityContext.RunAsUser).To(BeNil())
+			Expect(pod.Spec.Volumes).To(ConsistOf(
+				hostPathVolume("server", "/var/lib/gardenadm/static-pods/kube-scheduler/server"),
+				hostPathVolume("config", "/var/lib/gardenadm/static-pods/kube-scheduler/config"),
+				hostPathVolume("projected", "/var/lib/gardenadm/static-pods/kube-scheduler/projected"),
+				corev1.Volume{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			))
+		})
+
+		It("should fail if a referenced secret does not exist", func() {
+			deployment.Spec.Template.Spec.Volumes[0].Secret.SecretName = "missing"
+
+			_, err := Translate(ctx, fakeClient, deployment)
+			Expect(err).To(MatchError(ContainSubstring(`failed translating volume "server"`)))
+		})
+
+		It("should fail for unsupported object types", func() {
+			_, err := Translate(ctx, fakeClient, &corev1.Secret{})
+			Expect(err).To(MatchError(ContainSubstring("unsupported object type")))
+		})
+	})
+})
+
+func file(path string, permissions uint32, content string) extensionsv1alpha1.File {
+	return extensionsv1alpha1.File{
+		Path:        path,
+		Permissions: &permissions,
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Encoding: "b64",
+				Data:     base64.StdEncoding.EncodeToString([]byte(content)),
+			},
+		},
+	}
+}
+
+func hostPathVolume(name, path string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: path, Type: ptr.To(corev1.HostPathDirectoryOrCreate)},
+		},
+	}
+}
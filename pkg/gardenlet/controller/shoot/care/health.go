@@ -15,6 +15,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -593,6 +594,33 @@ func ComputeRequiredMonitoringSeedDeployments(shoot *gardencorev1beta1.Shoot) se
 	return requiredDeployments
 }
 
+// updateWorkerOperatingSystemConfigStatuses updates the status of applying the latest operating system configs in the
+// status of the Worker. It does nothing if the Worker does not exist yet.
+func (h *Health) updateWorkerOperatingSystemConfigStatuses(ctx context.Context, statuses []extensionsv1alpha1.WorkerPoolOperatingSystemConfigStatus) error {
+	worker := &extensionsv1alpha1.Worker{}
+	if err := h.seedClient.Client().Get(ctx, client.ObjectKey{Name: h.shoot.GetInfo().Name, Namespace: h.shoot.SeedNamespace}, worker); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed reading Worker: %w", err)
+	}
+
+	if apiequality.Semantic.DeepEqual(worker.Status.OperatingSystemConfigs, statuses) {
+		return nil
+	}
+
+	// The Worker status is owned by the extension, except for the operating system config statuses which are owned by
+	// gardenlet. Hence, only the operating system config statuses are patched. The patch uses an optimistic lock, so
+	// that a concurrent status update of the extension is never overwritten with stale data. In case of a conflict, the
+	// statuses are reported with the next health check.
+	patch := client.MergeFromWithOptions(worker.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	worker.Status.OperatingSystemConfigs = statuses
+	if err := h.seedClient.Client().Status().Patch(ctx, worker, patch); err != nil {
+		return fmt.Errorf("failed updating operating system config statuses of Worker: %w", err)
+	}
+	return nil
+}
+
 // annotationKeyNotManagedByMCM is a constant for an annotation on the node resource that indicates that the node is not
 // handled by machine-controller-manager.
 const annotationKeyNotManagedByMCM = "node.machine.sapcloud.io/not-managed-by-mcm"
@@ -617,6 +645,11 @@ func (h *Health) CheckClusterNodes(
 		return nil, err
 	}
 
+	// The statuses are only informational, hence failing to report them must not fail the health check of the nodes.
+	if err := h.updateWorkerOperatingSystemConfigStatuses(ctx, botanist.ComputeOperatingSystemConfigStatuses(h.shoot.GetInfo().Spec.Provider.Workers, workerPoolToNodes, workerPoolToCloudConfigSecretMeta)); err != nil {
+		h.log.Error(err, "Failed reporting the operating system config statuses in the Worker status")
+	}
+
 	for _, pool := range h.shoot.GetInfo().Spec.Provider.Workers {
 		nodes := workerPoolToNodes[pool.Name]
 
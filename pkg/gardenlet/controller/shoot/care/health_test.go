@@ -26,8 +26,10 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
 	kubernetesfake "github.com/gardener/gardener/pkg/client/kubernetes/fake"
 	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig"
@@ -244,7 +246,7 @@ var _ = Describe("health check", func() {
 			nodeName                   = "node1"
 			oscSecretMeta              = map[string]metav1.ObjectMeta{
 				workerPoolName1: {
-					Name:        operatingsystemconfig.KeyV1(workerPoolName1, kubernetesVersion, nil),
+					Name:        operatingsystemconfig.KeyV1(&gardencorev1beta1.Worker{Name: workerPoolName1}, kubernetesVersion),
 					Labels:      map[string]string{"worker.gardener.cloud/pool": workerPoolName1},
 					Annotations: map[string]string{"checksum/data-script": cloudConfigSecretChecksum1},
 				},
@@ -393,7 +395,7 @@ var _ = Describe("health check", func() {
 				},
 				map[string]metav1.ObjectMeta{
 					workerPoolName1: {
-						Name:        operatingsystemconfig.KeyV1(workerPoolName1, kubernetesVersion, nil),
+						Name:        operatingsystemconfig.KeyV1(&gardencorev1beta1.Worker{Name: workerPoolName1}, kubernetesVersion),
 						Annotations: map[string]string{"checksum/data-script": cloudConfigSecretChecksum1},
 						Labels:      map[string]string{"worker.gardener.cloud/pool": workerPoolName1},
 					},
@@ -413,13 +415,164 @@ var _ = Describe("health check", func() {
 				},
 				map[string]metav1.ObjectMeta{
 					workerPoolName1: {
-						Name:        operatingsystemconfig.KeyV1(workerPoolName1, kubernetesVersion, nil),
+						Name:        operatingsystemconfig.KeyV1(&gardencorev1beta1.Worker{Name: workerPoolName1}, kubernetesVersion),
 						Annotations: map[string]string{"checksum/data-script": cloudConfigSecretChecksum1},
 						Labels:      map[string]string{"worker.gardener.cloud/pool": workerPoolName1},
 					},
 				},
 				PointTo(beConditionWithStatusAndMsg(gardencorev1beta1.ConditionFalse, "OperatingSystemConfigOutdated", fmt.Sprintf("the last successfully applied operating system config on node %q is outdated", nodeName)))),
 		)
+
+		It("should report the operating system config statuses in the Worker status", func() {
+			node := newNode(labels.Set{"worker.gardener.cloud/pool": workerPoolName1, "worker.gardener.cloud/kubernetes-version": kubernetesVersion.Original()}, map[string]string{
+				nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig: "outdated",
+				nodeagentconfigv1alpha1.AnnotationKeyOperatingSystemConfigApplyStatus:     `{"checksum":"foo","time":"2024-01-01T00:00:00Z","duration":"2s","failedStep":"Files","error":"failed writing file"}`,
+			}, kubernetesVersion.Original())
+
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&corev1.NodeList{})).DoAndReturn(func(_ context.Context, list *corev1.NodeList, _ ...client.ListOption) error {
+				*list = corev1.NodeList{Items: []corev1.Node{node}}
+				return nil
+			})
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&corev1.SecretList{}), gomock.Any()).DoAndReturn(func(_ context.Context, list *corev1.SecretList, _ ...client.ListOption) error {
+				*list = corev1.SecretList{Items: []corev1.Secret{{ObjectMeta: oscSecretMeta[workerPoolName1]}}}
+				return nil
+			})
+
+			worker := &extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: seedNamespace}}
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(worker).WithStatusSubresource(worker).Build()
+
+			shootObj := &shootpkg.Shoot{
+				SeedNamespace:     seedNamespace,
+				KubernetesVersion: kubernetesVersion,
+			}
+			shootObj.SetInfo(&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot"},
+				Spec: gardencorev1beta1.ShootSpec{
+					Provider: gardencorev1beta1.Provider{
+						Workers: []gardencorev1beta1.Worker{{Name: workerPoolName1, Maximum: 10, Minimum: 1}},
+					},
+				},
+			})
+			seedObj := &seedpkg.Seed{}
+			seedObj.SetInfo(&gardencorev1beta1.Seed{})
+
+			health := NewHealth(logr.Discard(), shootObj, seedObj, kubernetesfake.NewClientSetBuilder().WithClient(fakeClient).Build(), nil, nil, fakeClock, nil, nil)
+
+			exitCondition, err := health.CheckClusterNodes(ctx, kubernetesfake.NewClientSetBuilder().WithClient(c).Build(), condition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exitCondition).To(PointTo(beConditionWithStatusAndMsg(gardencorev1beta1.ConditionFalse, "OperatingSystemConfigOutdated", `0 of 1 nodes in worker pool "cpu-worker-1" are on the latest operating system config, 1 failed applying it`)))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			Expect(worker.Status.OperatingSystemConfigs).To(ConsistOf(extensionsv1alpha1.WorkerPoolOperatingSystemConfigStatus{
+				Name:          workerPoolName1,
+				Checksum:      cloudConfigSecretChecksum1,
+				Nodes:         1,
+				UpToDateNodes: 0,
+				FailedNodes:   1,
+				Failures: []extensionsv1alpha1.NodeOperatingSystemConfigApplyFailure{{
+					NodeName:        nodeName,
+					FailedStep:      "Files",
+					Message:         "failed writing file",
+					LastAttemptTime: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Local()),
+				}},
+			}))
+		})
+
+		It("should report the operating system config statuses in the Worker status with an optimistic lock", func() {
+			node := newNode(labels.Set{"worker.gardener.cloud/pool": workerPoolName1, "worker.gardener.cloud/kubernetes-version": kubernetesVersion.Original()}, map[string]string{
+				nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig: "outdated",
+			}, kubernetesVersion.Original())
+
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&corev1.NodeList{})).DoAndReturn(func(_ context.Context, list *corev1.NodeList, _ ...client.ListOption) error {
+				*list = corev1.NodeList{Items: []corev1.Node{node}}
+				return nil
+			})
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&corev1.SecretList{}), gomock.Any()).DoAndReturn(func(_ context.Context, list *corev1.SecretList, _ ...client.ListOption) error {
+				*list = corev1.SecretList{Items: []corev1.Secret{{ObjectMeta: oscSecretMeta[workerPoolName1]}}}
+				return nil
+			})
+
+			var patchData []byte
+			worker := &extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: seedNamespace}}
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(worker).WithStatusSubresource(worker).WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					var err error
+					patchData, err = patch.Data(obj)
+					Expect(err).NotTo(HaveOccurred())
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+
+			shootObj := &shootpkg.Shoot{
+				SeedNamespace:     seedNamespace,
+				KubernetesVersion: kubernetesVersion,
+			}
+			shootObj.SetInfo(&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot"},
+				Spec: gardencorev1beta1.ShootSpec{
+					Provider: gardencorev1beta1.Provider{
+						Workers: []gardencorev1beta1.Worker{{Name: workerPoolName1, Maximum: 10, Minimum: 1}},
+					},
+				},
+			})
+			seedObj := &seedpkg.Seed{}
+			seedObj.SetInfo(&gardencorev1beta1.Seed{})
+
+			health := NewHealth(logr.Discard(), shootObj, seedObj, kubernetesfake.NewClientSetBuilder().WithClient(fakeClient).Build(), nil, nil, fakeClock, nil, nil)
+
+			_, err := health.CheckClusterNodes(ctx, kubernetesfake.NewClientSetBuilder().WithClient(c).Build(), condition)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(patchData)).To(ContainSubstring(`"resourceVersion"`))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			Expect(worker.Status.OperatingSystemConfigs).To(HaveLen(1))
+		})
+
+		It("should not fail if the operating system config statuses cannot be reported in the Worker status", func() {
+			node := newNode(labels.Set{"worker.gardener.cloud/pool": workerPoolName1, "worker.gardener.cloud/kubernetes-version": kubernetesVersion.Original()}, map[string]string{
+				nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig: "outdated",
+			}, kubernetesVersion.Original())
+
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&corev1.NodeList{})).DoAndReturn(func(_ context.Context, list *corev1.NodeList, _ ...client.ListOption) error {
+				*list = corev1.NodeList{Items: []corev1.Node{node}}
+				return nil
+			})
+			c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&corev1.SecretList{}), gomock.Any()).DoAndReturn(func(_ context.Context, list *corev1.SecretList, _ ...client.ListOption) error {
+				*list = corev1.SecretList{Items: []corev1.Secret{{ObjectMeta: oscSecretMeta[workerPoolName1]}}}
+				return nil
+			})
+
+			worker := &extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: seedNamespace}}
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(worker).WithStatusSubresource(worker).WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+					return fmt.Errorf("fake")
+				},
+			}).Build()
+
+			shootObj := &shootpkg.Shoot{
+				SeedNamespace:     seedNamespace,
+				KubernetesVersion: kubernetesVersion,
+			}
+			shootObj.SetInfo(&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot"},
+				Spec: gardencorev1beta1.ShootSpec{
+					Provider: gardencorev1beta1.Provider{
+						Workers: []gardencorev1beta1.Worker{{Name: workerPoolName1, Maximum: 10, Minimum: 1}},
+					},
+				},
+			})
+			seedObj := &seedpkg.Seed{}
+			seedObj.SetInfo(&gardencorev1beta1.Seed{})
+
+			health := NewHealth(logr.Discard(), shootObj, seedObj, kubernetesfake.NewClientSetBuilder().WithClient(fakeClient).Build(), nil, nil, fakeClock, nil, nil)
+
+			exitCondition, err := health.CheckClusterNodes(ctx, kubernetesfake.NewClientSetBuilder().WithClient(c).Build(), condition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exitCondition).To(PointTo(beConditionWithStatusAndMsg(gardencorev1beta1.ConditionFalse, "OperatingSystemConfigOutdated", `0 of 1 nodes in worker pool "cpu-worker-1" are on the latest operating system config`)))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(worker), worker)).To(Succeed())
+			Expect(worker.Status.OperatingSystemConfigs).To(BeEmpty())
+		})
 	})
 
 	Describe("#CheckIfDependencyWatchdogProberScaledDownControllers", func() {
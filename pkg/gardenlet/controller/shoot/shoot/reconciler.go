@@ -5,6 +5,7 @@
 package shoot
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -513,6 +514,32 @@ func (r *Reconciler) newProgressReporter(reporterFn flow.ProgressReporterFn) flo
 	return flow.NewImmediateProgressReporter(reporterFn)
 }
 
+// newFlowTrace logs the graph of the given flow as Mermaid flowchart and returns a trace for its execution if debug
+// logging is enabled. Otherwise, it returns nil.
+func newFlowTrace(log logr.Logger, f *flow.Flow) *flow.Trace {
+	if !log.V(1).Enabled() {
+		return nil
+	}
+
+	log.V(1).Info("Running flow", "flow", f.Name(), "mermaid", f.Mermaid())
+	return flow.NewTrace()
+}
+
+// logFlowTrace logs the given trace in the Chrome trace event format, see newFlowTrace.
+func logFlowTrace(log logr.Logger, trace *flow.Trace) {
+	if trace == nil {
+		return
+	}
+
+	var events bytes.Buffer
+	if err := trace.WriteChromeTraceEvents(&events); err != nil {
+		log.Error(err, "Failed writing flow trace", "flow", trace.FlowName())
+		return
+	}
+
+	log.V(1).Info("Finished flow", "flow", trace.FlowName(), "chromeTraceEvents", events.String())
+}
+
 func (r *Reconciler) updateShootStatusOperationStart(
 	ctx context.Context,
 	shoot *gardencorev1beta1.Shoot,
@@ -727,9 +727,13 @@ func (r *Reconciler) runDeleteShootFlow(ctx context.Context, o *operation.Operat
 		f = g.Compile()
 	)
 
+	trace := newFlowTrace(o.Logger, f)
+	defer logFlowTrace(o.Logger, trace)
+
 	if err := f.Run(ctx, flow.Opts{
 		Log:              o.Logger,
 		ProgressReporter: r.newProgressReporter(o.ReportShootProgress),
+		Trace:            trace,
 		ErrorCleaner:     o.CleanShootTaskError,
 		ErrorContext:     errorContext,
 	}); err != nil {
@@ -7,11 +7,13 @@ package shoot
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	"github.com/gardener/gardener/pkg/client/kubernetes/clientmap/keys"
 	"github.com/gardener/gardener/pkg/gardenlet/operation"
@@ -22,6 +24,10 @@ import (
 	retryutils "github.com/gardener/gardener/pkg/utils/retry"
 )
 
+// forceDeletionCheckpointPrefix is the prefix of the name of the ConfigMap in the garden namespace of the seed which
+// persists the progress of the force deletion of a shoot.
+const forceDeletionCheckpointPrefix = "shoot-force-deletion-checkpoint-"
+
 // runForceDeleteShootFlow force deletes a Shoot cluster.
 // It receives an Operation object <o> which stores the Shoot object and an ErrorContext which contains error from the previous operation.
 func (r *Reconciler) runForceDeleteShootFlow(ctx context.Context, log logr.Logger, o *operation.Operation) *v1beta1helper.WrappedLastErrors {
@@ -193,11 +199,22 @@ func (r *Reconciler) runForceDeleteShootFlow(ctx context.Context, log logr.Logge
 		f = g.Compile()
 	)
 
+	trace := newFlowTrace(o.Logger, f)
+	defer logFlowTrace(o.Logger, trace)
+
 	if err := f.Run(ctx, flow.Opts{
 		Log:              o.Logger,
 		ProgressReporter: r.newProgressReporter(o.ReportShootProgress),
+		Trace:            trace,
 		ErrorCleaner:     o.CleanShootTaskError,
 		ErrorContext:     errorContext,
+		// All tasks of the force deletion only act on the state in the clusters, hence the flow can be resumed after a
+		// restart of gardenlet without running the succeeded tasks again. The checkpoint is not kept in the shoot
+		// namespace since its ConfigMaps are deleted by the flow. The UID of the shoot is part of both the ConfigMap
+		// name and the spec hash, so that a checkpoint of a deleted shoot is never used for a new shoot with the same
+		// name.
+		Checkpoint: flow.NewConfigMapCheckpointStore(botanist.SeedClientSet.Client(), botanist.SeedClientSet.APIReader(), v1beta1constants.GardenNamespace, forceDeletionCheckpointPrefix+o.Shoot.SeedNamespace+"-"+string(o.Shoot.GetInfo().UID)),
+		SpecHash:   string(o.Shoot.GetInfo().UID) + "-" + strconv.FormatInt(o.Shoot.GetInfo().Generation, 10),
 	}); err != nil {
 		return v1beta1helper.NewWrappedLastErrors(v1beta1helper.FormatLastErrDescription(err), flow.Errors(err))
 	}
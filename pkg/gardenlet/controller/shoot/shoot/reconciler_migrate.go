@@ -429,9 +429,13 @@ func (r *Reconciler) runMigrateShootFlow(ctx context.Context, o *operation.Opera
 		f = g.Compile()
 	)
 
+	trace := newFlowTrace(o.Logger, f)
+	defer logFlowTrace(o.Logger, trace)
+
 	if err := f.Run(ctx, flow.Opts{
 		Log:              o.Logger,
 		ProgressReporter: r.newProgressReporter(o.ReportShootProgress),
+		Trace:            trace,
 		ErrorContext:     errorContext,
 		ErrorCleaner:     o.CleanShootTaskError,
 	}); err != nil {
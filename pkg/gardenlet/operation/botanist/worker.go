@@ -6,7 +6,10 @@ package botanist
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -17,6 +20,7 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/component/extensions/worker"
 	"github.com/gardener/gardener/pkg/controllerutils"
 	shootpkg "github.com/gardener/gardener/pkg/gardenlet/operation/shoot"
@@ -106,7 +110,9 @@ func WorkerPoolToOperatingSystemConfigSecretMetaMap(ctx context.Context, shootCl
 }
 
 // OperatingSystemConfigUpdatedForAllWorkerPools checks if all the nodes for all the provided worker pools have successfully
-// applied the desired version of their cloud-config user data.
+// applied the desired version of their cloud-config user data. For each worker pool with outdated nodes, the returned
+// error summarizes how many nodes are on the latest operating system config and how many failed applying it, followed
+// by the reasons reported by gardener-node-agent.
 func OperatingSystemConfigUpdatedForAllWorkerPools(
 	workers []gardencorev1beta1.Worker,
 	workerPoolToNodes map[string][]corev1.Node,
@@ -121,29 +127,127 @@ func OperatingSystemConfigUpdatedForAllWorkerPools(
 			continue
 		}
 
-		var (
-			gardenerNodeAgentSecretName = secretMeta.Name
-			secretChecksum              = secretMeta.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig]
-		)
-
-		for _, node := range workerPoolToNodes[worker.Name] {
-			if nodeToBeDeleted(node, gardenerNodeAgentSecretName) {
-				continue
-			}
+		status, outdatedNodeErrs := operatingSystemConfigStatusOfWorkerPool(worker.Name, workerPoolToNodes[worker.Name], secretMeta)
+		if status.UpToDateNodes == status.Nodes {
+			continue
+		}
 
-			if nodeChecksum, ok := node.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig]; nodeChecksum != secretChecksum {
-				if !ok {
-					result = multierror.Append(result, fmt.Errorf("the last successfully applied operating system config on node %q hasn't been reported yet", node.Name))
-				} else {
-					result = multierror.Append(result, fmt.Errorf("the last successfully applied operating system config on node %q is outdated (current: %s, desired: %s)", node.Name, nodeChecksum, secretChecksum))
-				}
-			}
+		result = multierror.Append(result, fmt.Errorf("%d of %d nodes in worker pool %q are on the latest operating system config, %d failed applying it", status.UpToDateNodes, status.Nodes, worker.Name, status.FailedNodes))
+		for _, failure := range status.Failures {
+			result = multierror.Append(result, applyFailure(failure))
 		}
+		result = multierror.Append(result, outdatedNodeErrs...)
 	}
 
 	return result
 }
 
+// maxReportedOperatingSystemConfigApplyFailures is the maximum number of failed nodes per worker pool which are reported
+// in the status of the Worker.
+const maxReportedOperatingSystemConfigApplyFailures = 10
+
+// ComputeOperatingSystemConfigStatuses computes the status of applying the latest operating system configs on the nodes
+// of the provided worker pools based on the apply status published by gardener-node-agent. Worker pools without
+// operating system config secret metadata are skipped.
+func ComputeOperatingSystemConfigStatuses(
+	workers []gardencorev1beta1.Worker,
+	workerPoolToNodes map[string][]corev1.Node,
+	workerPoolToOperatingSystemConfigSecretMeta map[string]metav1.ObjectMeta,
+) []extensionsv1alpha1.WorkerPoolOperatingSystemConfigStatus {
+	var statuses []extensionsv1alpha1.WorkerPoolOperatingSystemConfigStatus
+
+	for _, worker := range workers {
+		secretMeta, ok := workerPoolToOperatingSystemConfigSecretMeta[worker.Name]
+		if !ok {
+			continue
+		}
+
+		status, _ := operatingSystemConfigStatusOfWorkerPool(worker.Name, workerPoolToNodes[worker.Name], secretMeta)
+		if len(status.Failures) > maxReportedOperatingSystemConfigApplyFailures {
+			status.Failures = status.Failures[:maxReportedOperatingSystemConfigApplyFailures]
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// operatingSystemConfigStatusOfWorkerPool computes the status of applying the latest operating system config on the
+// given nodes of a worker pool. Additionally, it returns errors for the outdated nodes which did not report a failed
+// attempt to apply the latest operating system config.
+func operatingSystemConfigStatusOfWorkerPool(
+	name string,
+	nodes []corev1.Node,
+	secretMeta metav1.ObjectMeta,
+) (
+	extensionsv1alpha1.WorkerPoolOperatingSystemConfigStatus,
+	[]error,
+) {
+	var (
+		secretChecksum = secretMeta.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig]
+		status         = extensionsv1alpha1.WorkerPoolOperatingSystemConfigStatus{Name: name, Checksum: secretChecksum}
+		errs           []error
+	)
+
+	for _, node := range nodes {
+		if nodeToBeDeleted(node, secretMeta.Name) {
+			continue
+		}
+		status.Nodes++
+
+		nodeChecksum, ok := node.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig]
+		if nodeChecksum == secretChecksum {
+			status.UpToDateNodes++
+			continue
+		}
+
+		if applyStatus := operatingSystemConfigApplyStatus(node); applyStatus != nil && applyStatus.Checksum == secretChecksum && applyStatus.Error != "" {
+			status.FailedNodes++
+			status.Failures = append(status.Failures, extensionsv1alpha1.NodeOperatingSystemConfigApplyFailure{
+				NodeName:        node.Name,
+				FailedStep:      string(applyStatus.FailedStep),
+				Message:         applyStatus.Error,
+				LastAttemptTime: applyStatus.Time,
+			})
+			continue
+		}
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("the last successfully applied operating system config on node %q hasn't been reported yet", node.Name))
+		} else {
+			errs = append(errs, fmt.Errorf("the last successfully applied operating system config on node %q is outdated (current: %s, desired: %s)", node.Name, nodeChecksum, secretChecksum))
+		}
+	}
+
+	slices.SortFunc(status.Failures, func(a, b extensionsv1alpha1.NodeOperatingSystemConfigApplyFailure) int {
+		return strings.Compare(a.NodeName, b.NodeName)
+	})
+
+	return status, errs
+}
+
+// operatingSystemConfigApplyStatus returns the status of the last attempt to apply an operating system config which is
+// published by gardener-node-agent on the given node. It returns nil if the status is not available.
+func operatingSystemConfigApplyStatus(node corev1.Node) *nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus {
+	raw, ok := node.Annotations[nodeagentconfigv1alpha1.AnnotationKeyOperatingSystemConfigApplyStatus]
+	if !ok {
+		return nil
+	}
+
+	status := &nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus{}
+	if err := json.Unmarshal([]byte(raw), status); err != nil {
+		return nil
+	}
+	return status
+}
+
+func applyFailure(failure extensionsv1alpha1.NodeOperatingSystemConfigApplyFailure) error {
+	if failure.FailedStep == "" {
+		return fmt.Errorf("applying the desired operating system config on node %q failed at %s: %s", failure.NodeName, failure.LastAttemptTime.UTC().Format(time.RFC3339), failure.Message)
+	}
+	return fmt.Errorf("applying the desired operating system config on node %q failed in step %s at %s: %s", failure.NodeName, failure.FailedStep, failure.LastAttemptTime.UTC().Format(time.RFC3339), failure.Message)
+}
+
 func nodeToBeDeleted(node corev1.Node, gardenerNodeAgentSecretName string) bool {
 	if nodeTaintedForNoSchedule(node) {
 		return true
@@ -7,6 +7,7 @@ package botanist_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -20,6 +21,7 @@ import (
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
 	kubernetesmock "github.com/gardener/gardener/pkg/client/kubernetes/mock"
@@ -302,6 +304,41 @@ var _ = Describe("Worker", func() {
 			}},
 			MatchError(ContainSubstring("is outdated")),
 		),
+		Entry("applying checksum failed",
+			[]gardencorev1beta1.Worker{{Name: "pool1"}},
+			map[string][]corev1.Node{"pool1": {
+				{ObjectMeta: metav1.ObjectMeta{
+					Name: "node1",
+					Annotations: map[string]string{
+						"checksum/cloud-config-data":                                     "outdated",
+						"node-agent.gardener.cloud/operating-system-config-apply-status": `{"checksum":"foo","time":"2024-01-01T00:00:00Z","duration":"2s","failedStep":"UnitCommands","error":"failed restarting unit"}`,
+					},
+					Labels: map[string]string{"worker.gardener.cloud/gardener-node-agent-secret-name": "gardener-node-agent--c63c0"},
+				}},
+				{ObjectMeta: metav1.ObjectMeta{
+					Name:        "node2",
+					Annotations: map[string]string{"checksum/cloud-config-data": "foo"},
+					Labels:      map[string]string{"worker.gardener.cloud/gardener-node-agent-secret-name": "gardener-node-agent--c63c0"},
+				}},
+				{ObjectMeta: metav1.ObjectMeta{
+					Name: "node3",
+					Annotations: map[string]string{
+						"checksum/cloud-config-data":                                     "outdated",
+						"node-agent.gardener.cloud/operating-system-config-apply-status": `{"checksum":"outdated","time":"2024-01-01T00:00:00Z","duration":"2s","failedStep":"Files","error":"failed writing file"}`,
+					},
+					Labels: map[string]string{"worker.gardener.cloud/gardener-node-agent-secret-name": "gardener-node-agent--c63c0"},
+				}},
+			}},
+			map[string]metav1.ObjectMeta{"pool1": {
+				Name:        "gardener-node-agent--c63c0",
+				Annotations: map[string]string{"checksum/data-script": "foo"},
+			}},
+			And(
+				MatchError(ContainSubstring(`1 of 3 nodes in worker pool "pool1" are on the latest operating system config, 1 failed applying it`)),
+				MatchError(ContainSubstring(`applying the desired operating system config on node "node1" failed in step UnitCommands at 2024-01-01T00:00:00Z: failed restarting unit`)),
+				MatchError(ContainSubstring(`the last successfully applied operating system config on node "node3" is outdated`)),
+			),
+		),
 		Entry("skip node marked by MCM for termination",
 			[]gardencorev1beta1.Worker{{Name: "pool1"}},
 			map[string][]corev1.Node{"pool1": {{
@@ -367,6 +404,81 @@ var _ = Describe("Worker", func() {
 		),
 	)
 
+	Describe("#ComputeOperatingSystemConfigStatuses", func() {
+		var (
+			secretLabels = map[string]string{"worker.gardener.cloud/gardener-node-agent-secret-name": "gardener-node-agent--c63c0"}
+			secretMeta   = map[string]metav1.ObjectMeta{"pool1": {
+				Name:        "gardener-node-agent--c63c0",
+				Annotations: map[string]string{"checksum/data-script": "foo"},
+			}}
+			attemptTime = metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Local())
+		)
+
+		It("should aggregate the apply status of the nodes per worker pool", func() {
+			Expect(ComputeOperatingSystemConfigStatuses(
+				[]gardencorev1beta1.Worker{{Name: "pool1"}, {Name: "pool2"}},
+				map[string][]corev1.Node{"pool1": {
+					{ObjectMeta: metav1.ObjectMeta{
+						Name: "node2",
+						Annotations: map[string]string{
+							"checksum/cloud-config-data":                                     "outdated",
+							"node-agent.gardener.cloud/operating-system-config-apply-status": `{"checksum":"foo","time":"2024-01-01T00:00:00Z","duration":"2s","failedStep":"UnitCommands","error":"failed restarting unit"}`,
+						},
+						Labels: secretLabels,
+					}},
+					{ObjectMeta: metav1.ObjectMeta{
+						Name:        "node1",
+						Annotations: map[string]string{"checksum/cloud-config-data": "foo"},
+						Labels:      secretLabels,
+					}},
+					{ObjectMeta: metav1.ObjectMeta{
+						Name: "node3",
+						Annotations: map[string]string{
+							"checksum/cloud-config-data":                                     "outdated",
+							"node-agent.gardener.cloud/operating-system-config-apply-status": `{"checksum":"outdated","time":"2024-01-01T00:00:00Z","duration":"2s","failedStep":"Files","error":"failed writing file"}`,
+						},
+						Labels: secretLabels,
+					}},
+					{ObjectMeta: metav1.ObjectMeta{
+						Name:   "node4",
+						Labels: map[string]string{"worker.gardener.cloud/gardener-node-agent-secret-name": "gardener-node-agent--old"},
+					}},
+				}},
+				secretMeta,
+			)).To(ConsistOf(extensionsv1alpha1.WorkerPoolOperatingSystemConfigStatus{
+				Name:          "pool1",
+				Checksum:      "foo",
+				Nodes:         3,
+				UpToDateNodes: 1,
+				FailedNodes:   1,
+				Failures: []extensionsv1alpha1.NodeOperatingSystemConfigApplyFailure{{
+					NodeName:        "node2",
+					FailedStep:      "UnitCommands",
+					Message:         "failed restarting unit",
+					LastAttemptTime: attemptTime,
+				}},
+			}))
+		})
+
+		It("should limit the number of reported failures", func() {
+			var nodes []corev1.Node
+			for i := range 12 {
+				nodes = append(nodes, corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:        fmt.Sprintf("node%02d", i),
+					Annotations: map[string]string{"node-agent.gardener.cloud/operating-system-config-apply-status": `{"checksum":"foo","time":"2024-01-01T00:00:00Z","duration":"2s","error":"failed"}`},
+					Labels:      secretLabels,
+				}})
+			}
+
+			statuses := ComputeOperatingSystemConfigStatuses([]gardencorev1beta1.Worker{{Name: "pool1"}}, map[string][]corev1.Node{"pool1": nodes}, secretMeta)
+			Expect(statuses).To(HaveLen(1))
+			Expect(statuses[0].Nodes).To(BeEquivalentTo(12))
+			Expect(statuses[0].FailedNodes).To(BeEquivalentTo(12))
+			Expect(statuses[0].Failures).To(HaveLen(10))
+			Expect(statuses[0].Failures[0].NodeName).To(Equal("node00"))
+		})
+	})
+
 	Describe("#WaitUntilOperatingSystemConfigUpdatedForAllWorkerPools", func() {
 		var (
 			seedInterface  *kubernetesmock.MockInterface
@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/gardener/pkg/logger"
 )
@@ -35,6 +36,46 @@ func SetDefaults_OperatingSystemConfigControllerConfig(obj *OperatingSystemConfi
 	}
 }
 
+// SetDefaults_DriftDetectionConfig sets defaults for the DriftDetectionConfig object.
+func SetDefaults_DriftDetectionConfig(obj *DriftDetectionConfig) {
+	if obj.Period == nil {
+		obj.Period = &metav1.Duration{Duration: 10 * time.Minute}
+	}
+}
+
+// SetDefaults_RebootConfig sets defaults for the RebootConfig object.
+func SetDefaults_RebootConfig(obj *RebootConfig) {
+	if obj.MaxConcurrentReboots == nil {
+		obj.MaxConcurrentReboots = ptr.To[int32](1)
+	}
+	if obj.DrainTimeout == nil {
+		obj.DrainTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+	}
+	if obj.LeaseDuration == nil {
+		obj.LeaseDuration = &metav1.Duration{Duration: time.Hour}
+	}
+}
+
+// SetDefaults_DirectImagePullConfig sets defaults for the DirectImagePullConfig object.
+func SetDefaults_DirectImagePullConfig(obj *DirectImagePullConfig) {
+	if obj.RequireDigest == nil {
+		obj.RequireDigest = ptr.To(true)
+	}
+	if obj.CacheMaxAge == nil {
+		obj.CacheMaxAge = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+	}
+}
+
+// SetDefaults_InPlaceUpdateConfig sets defaults for the InPlaceUpdateConfig object.
+func SetDefaults_InPlaceUpdateConfig(obj *InPlaceUpdateConfig) {
+	if obj.DrainTimeout == nil {
+		obj.DrainTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+	}
+	if obj.KubeletHealthTimeout == nil {
+		obj.KubeletHealthTimeout = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+}
+
 // SetDefaults_TokenControllerConfig sets defaults for the TokenControllerConfig object.
 func SetDefaults_TokenControllerConfig(obj *TokenControllerConfig) {
 	if obj.SyncPeriod == nil {
@@ -42,6 +83,50 @@ func SetDefaults_TokenControllerConfig(obj *TokenControllerConfig) {
 	}
 }
 
+// SetDefaults_TokenSecretSyncConfig sets defaults for the TokenSecretSyncConfig object.
+func SetDefaults_TokenSecretSyncConfig(obj *TokenSecretSyncConfig) {
+	if obj.Permissions == nil {
+		obj.Permissions = ptr.To[uint32](0600)
+	}
+}
+
+// SetDefaults_HealthCheckerConfig sets defaults for the HealthCheckerConfig object.
+func SetDefaults_HealthCheckerConfig(obj *HealthCheckerConfig) {
+	if obj.Timeout == nil {
+		obj.Timeout = &metav1.Duration{Duration: 10 * time.Second}
+	}
+	if obj.FailureDuration == nil {
+		obj.FailureDuration = &metav1.Duration{Duration: time.Minute}
+	}
+}
+
+// SetDefaults_DiskPressureHealthCheck sets defaults for the DiskPressureHealthCheck object.
+func SetDefaults_DiskPressureHealthCheck(obj *DiskPressureHealthCheck) {
+	if obj.Path == "" {
+		obj.Path = "/var/lib"
+	}
+	if obj.MaxUsedPercentage == nil {
+		obj.MaxUsedPercentage = ptr.To[int32](90)
+	}
+	if obj.MaxUsedInodesPercentage == nil {
+		obj.MaxUsedInodesPercentage = ptr.To[int32](90)
+	}
+}
+
+// SetDefaults_ClockSkewHealthCheck sets defaults for the ClockSkewHealthCheck object.
+func SetDefaults_ClockSkewHealthCheck(obj *ClockSkewHealthCheck) {
+	if obj.MaxSkew == nil {
+		obj.MaxSkew = &metav1.Duration{Duration: time.Second}
+	}
+}
+
+// SetDefaults_HealthCheckRemediation sets defaults for the HealthCheckRemediation object.
+func SetDefaults_HealthCheckRemediation(obj *HealthCheckRemediation) {
+	if obj.MinInterval == nil {
+		obj.MinInterval = &metav1.Duration{Duration: 30 * time.Minute}
+	}
+}
+
 // SetDefaults_ClientConnectionConfiguration sets defaults for the garden client connection.
 func SetDefaults_ClientConnectionConfiguration(obj *componentbaseconfigv1alpha1.ClientConnectionConfiguration) {
 	componentbaseconfigv1alpha1.RecommendedDefaultClientConnectionConfiguration(obj)
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/gardener/pkg/logger"
 	. "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
@@ -65,6 +66,105 @@ var _ = Describe("Defaults", func() {
 
 					Expect(obj.SyncPeriod).To(PointTo(Equal(metav1.Duration{Duration: time.Second})))
 				})
+
+				Describe("Drift detection", func() {
+					It("should default the object", func() {
+						obj := &DriftDetectionConfig{}
+
+						SetDefaults_DriftDetectionConfig(obj)
+
+						Expect(obj.Period).To(PointTo(Equal(metav1.Duration{Duration: 10 * time.Minute})))
+						Expect(obj.Remediate).To(BeNil())
+					})
+
+					It("should not overwrite existing values", func() {
+						obj := &DriftDetectionConfig{
+							Period: &metav1.Duration{Duration: time.Minute},
+						}
+
+						SetDefaults_DriftDetectionConfig(obj)
+
+						Expect(obj.Period).To(PointTo(Equal(metav1.Duration{Duration: time.Minute})))
+					})
+				})
+
+				Describe("Reboot", func() {
+					It("should default the object", func() {
+						obj := &RebootConfig{}
+
+						SetDefaults_RebootConfig(obj)
+
+						Expect(obj.MaxConcurrentReboots).To(PointTo(Equal(int32(1))))
+						Expect(obj.DrainTimeout).To(PointTo(Equal(metav1.Duration{Duration: 10 * time.Minute})))
+						Expect(obj.LeaseDuration).To(PointTo(Equal(metav1.Duration{Duration: time.Hour})))
+					})
+
+					It("should not overwrite existing values", func() {
+						obj := &RebootConfig{
+							MaxConcurrentReboots: ptr.To[int32](3),
+							DrainTimeout:         &metav1.Duration{Duration: time.Minute},
+							LeaseDuration:        &metav1.Duration{Duration: 2 * time.Hour},
+						}
+
+						SetDefaults_RebootConfig(obj)
+
+						Expect(obj.MaxConcurrentReboots).To(PointTo(Equal(int32(3))))
+						Expect(obj.DrainTimeout).To(PointTo(Equal(metav1.Duration{Duration: time.Minute})))
+						Expect(obj.LeaseDuration).To(PointTo(Equal(metav1.Duration{Duration: 2 * time.Hour})))
+					})
+				})
+
+				Describe("DirectImagePull", func() {
+					It("should default the object", func() {
+						obj := &DirectImagePullConfig{}
+
+						SetDefaults_DirectImagePullConfig(obj)
+
+						Expect(obj.RequireDigest).To(PointTo(BeTrue()))
+						Expect(obj.CacheMaxAge).To(PointTo(Equal(metav1.Duration{Duration: 168 * time.Hour})))
+					})
+
+					It("should not overwrite existing values", func() {
+						obj := &DirectImagePullConfig{RequireDigest: ptr.To(false), CacheMaxAge: &metav1.Duration{Duration: time.Hour}}
+
+						SetDefaults_DirectImagePullConfig(obj)
+
+						Expect(obj.RequireDigest).To(PointTo(BeFalse()))
+						Expect(obj.CacheMaxAge).To(PointTo(Equal(metav1.Duration{Duration: time.Hour})))
+					})
+				})
+
+				Describe("InPlaceUpdate", func() {
+					It("should default the object", func() {
+						obj := &InPlaceUpdateConfig{}
+
+						SetDefaults_InPlaceUpdateConfig(obj)
+
+						Expect(obj.DrainTimeout).To(PointTo(Equal(metav1.Duration{Duration: 10 * time.Minute})))
+						Expect(obj.KubeletHealthTimeout).To(PointTo(Equal(metav1.Duration{Duration: 5 * time.Minute})))
+					})
+
+					It("should not overwrite existing values", func() {
+						obj := &InPlaceUpdateConfig{
+							DrainTimeout:         &metav1.Duration{Duration: time.Minute},
+							KubeletHealthTimeout: &metav1.Duration{Duration: time.Hour},
+						}
+
+						SetDefaults_InPlaceUpdateConfig(obj)
+
+						Expect(obj.DrainTimeout).To(PointTo(Equal(metav1.Duration{Duration: time.Minute})))
+						Expect(obj.KubeletHealthTimeout).To(PointTo(Equal(metav1.Duration{Duration: time.Hour})))
+					})
+
+					It("should default the object as part of the configuration", func() {
+						obj := &NodeAgentConfiguration{}
+						obj.Controllers.OperatingSystemConfig.InPlaceUpdate = &InPlaceUpdateConfig{}
+
+						SetObjectDefaults_NodeAgentConfiguration(obj)
+
+						Expect(obj.Controllers.OperatingSystemConfig.InPlaceUpdate.KubeletHealthTimeout).To(PointTo(Equal(metav1.Duration{Duration: 5 * time.Minute})))
+					})
+				})
 			})
 
 			Describe("Token controller", func() {
@@ -85,6 +185,59 @@ var _ = Describe("Defaults", func() {
 
 					Expect(obj.SyncPeriod).To(PointTo(Equal(metav1.Duration{Duration: time.Second})))
 				})
+
+				It("should default the permissions of the sync configs", func() {
+					obj := &TokenSecretSyncConfig{}
+
+					SetDefaults_TokenSecretSyncConfig(obj)
+
+					Expect(obj.Permissions).To(PointTo(Equal(uint32(0600))))
+				})
+
+				It("should not overwrite the permissions of the sync configs", func() {
+					obj := &TokenSecretSyncConfig{Permissions: ptr.To[uint32](0640)}
+
+					SetDefaults_TokenSecretSyncConfig(obj)
+
+					Expect(obj.Permissions).To(PointTo(Equal(uint32(0640))))
+				})
+			})
+
+			Describe("Health check controller", func() {
+				It("should default the health checkers", func() {
+					obj.Controllers.HealthCheck = &HealthCheckControllerConfig{Checkers: []HealthCheckerConfig{
+						{
+							DiskPressure: &DiskPressureHealthCheck{},
+							Remediation:  &HealthCheckRemediation{Action: HealthCheckRemediationActionReboot},
+						},
+						{ClockSkew: &ClockSkewHealthCheck{}},
+					}}
+
+					SetObjectDefaults_NodeAgentConfiguration(obj)
+
+					checkers := obj.Controllers.HealthCheck.Checkers
+					Expect(checkers[0].Timeout).To(PointTo(Equal(metav1.Duration{Duration: 10 * time.Second})))
+					Expect(checkers[0].FailureDuration).To(PointTo(Equal(metav1.Duration{Duration: time.Minute})))
+					Expect(checkers[0].DiskPressure).To(Equal(&DiskPressureHealthCheck{
+						Path:                    "/var/lib",
+						MaxUsedPercentage:       ptr.To[int32](90),
+						MaxUsedInodesPercentage: ptr.To[int32](90),
+					}))
+					Expect(checkers[0].Remediation.MinInterval).To(PointTo(Equal(metav1.Duration{Duration: 30 * time.Minute})))
+					Expect(checkers[1].ClockSkew.MaxSkew).To(PointTo(Equal(metav1.Duration{Duration: time.Second})))
+				})
+
+				It("should not overwrite existing values", func() {
+					obj := &HealthCheckerConfig{
+						Timeout:         &metav1.Duration{Duration: time.Second},
+						FailureDuration: &metav1.Duration{Duration: time.Hour},
+					}
+
+					SetDefaults_HealthCheckerConfig(obj)
+
+					Expect(obj.Timeout).To(PointTo(Equal(metav1.Duration{Duration: time.Second})))
+					Expect(obj.FailureDuration).To(PointTo(Equal(metav1.Duration{Duration: time.Hour})))
+				})
 			})
 		})
 
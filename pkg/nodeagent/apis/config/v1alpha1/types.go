@@ -47,6 +47,15 @@ const (
 	// AnnotationKeyChecksumAppliedOperatingSystemConfig is a constant for an annotation key on a Node describing the
 	// checksum of the last applied operating system configuration.
 	AnnotationKeyChecksumAppliedOperatingSystemConfig = "checksum/cloud-config-data"
+	// AnnotationKeyOperatingSystemConfigApplyStatus is a constant for an annotation key on a Node containing the
+	// JSON-encoded OperatingSystemConfigApplyStatus of the last attempt to apply an operating system configuration.
+	AnnotationKeyOperatingSystemConfigApplyStatus = "node-agent.gardener.cloud/operating-system-config-apply-status"
+	// NodeConditionTypeOperatingSystemConfigRolledBack is a constant for a condition type on a Node indicating that
+	// applying an operating system configuration failed and was rolled back to the last applied configuration.
+	NodeConditionTypeOperatingSystemConfigRolledBack = "OperatingSystemConfigRolledBack"
+	// NodeConditionTypeOperatingSystemConfigDrifted is a constant for a condition type on a Node indicating that files
+	// or units on the node drifted from the last applied operating system configuration.
+	NodeConditionTypeOperatingSystemConfigDrifted = "OperatingSystemConfigDrifted"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -102,6 +111,9 @@ type ControllerConfiguration struct {
 	OperatingSystemConfig OperatingSystemConfigControllerConfig `json:"operatingSystemConfig"`
 	// Token is the configuration for the access token controller.
 	Token TokenControllerConfig `json:"token"`
+	// HealthCheck is the configuration for the health check controller.
+	// +optional
+	HealthCheck *HealthCheckControllerConfig `json:"healthCheck,omitempty"`
 }
 
 // OperatingSystemConfigControllerConfig defines the configuration of the operating system config controller.
@@ -115,6 +127,88 @@ type OperatingSystemConfigControllerConfig struct {
 	// KubernetesVersion contains the Kubernetes version of the kubelet, used for annotating the corresponding node
 	// resource with a kubernetes version annotation.
 	KubernetesVersion *semver.Version `json:"kubernetesVersion"`
+	// DryRun specifies whether the controller should only compute and log the changes which would be applied to the node
+	// (files, units, unit commands and containerd registries) instead of applying them. Defaults to false.
+	// +optional
+	DryRun *bool `json:"dryRun,omitempty"`
+	// RollbackAfterFailedAttempts is the number of failed attempts to apply an operating system config after which the
+	// files and units overwritten by it are restored to the state of the last applied operating system config. The
+	// rolled back operating system config is not applied again until it changes. If not set, no rollback is performed.
+	// +optional
+	RollbackAfterFailedAttempts *int32 `json:"rollbackAfterFailedAttempts,omitempty"`
+	// DriftDetection is the configuration for detecting drifts of the files and units on the node from the last applied
+	// operating system config. If not set, drifts are not detected.
+	// +optional
+	DriftDetection *DriftDetectionConfig `json:"driftDetection,omitempty"`
+	// Reboot is the configuration for reboots of the node which are required by changed files or units of the operating
+	// system config. If not set, such reboots are not performed.
+	// +optional
+	Reboot *RebootConfig `json:"reboot,omitempty"`
+	// DirectImagePull is the configuration for pulling the images of files with an image reference directly from the
+	// registry instead of via containerd. This allows extracting such files before containerd is running. If not set,
+	// the images are pulled via containerd.
+	// +optional
+	DirectImagePull *DirectImagePullConfig `json:"directImagePull,omitempty"`
+	// InPlaceUpdate is the configuration for in-place updates of the Kubernetes version of the node. If set, the node is
+	// cordoned and drained before the kubelet binary is replaced, and it is uncordoned as soon as the kubelet is healthy
+	// and the node is ready again. The updates are coordinated with the reboots of the worker pool, hence, the reboot
+	// configuration must be set as well. If not set, the kubelet binary is replaced without draining the node.
+	// +optional
+	InPlaceUpdate *InPlaceUpdateConfig `json:"inPlaceUpdate,omitempty"`
+}
+
+// DriftDetectionConfig defines the configuration of the drift detection of the operating system config controller.
+type DriftDetectionConfig struct {
+	// Period is the duration how often the files and units are checked for drifts.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+	// Remediate specifies whether drifted files and units should be re-applied. Defaults to false.
+	// +optional
+	Remediate *bool `json:"remediate,omitempty"`
+}
+
+// RebootConfig defines the configuration of the reboots performed by the operating system config controller. The
+// reboots are coordinated via leases in the shoot cluster, so that only a limited number of nodes of a worker pool
+// reboots at the same time.
+type RebootConfig struct {
+	// MaxConcurrentReboots is the maximum number of nodes of the worker pool which are rebooted at the same time.
+	// Defaults to 1.
+	// +optional
+	MaxConcurrentReboots *int32 `json:"maxConcurrentReboots,omitempty"`
+	// DrainTimeout is the maximum duration for evicting the pods from the node before it is rebooted. The node is
+	// rebooted even if not all pods could be evicted within this duration. Defaults to 10m.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// LeaseDuration is the duration after which a reboot lease which was not renewed by its holder is considered stale
+	// and can be taken over by another node. Defaults to 1h.
+	// +optional
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+}
+
+// DirectImagePullConfig defines the configuration for pulling images directly from the registry. The pulled blobs are
+// verified against their digests and kept in a local content-addressed cache, so that unchanged images are not
+// downloaded again.
+type DirectImagePullConfig struct {
+	// RequireDigest specifies whether image references must be pinned by a digest. Defaults to true.
+	// +optional
+	RequireDigest *bool `json:"requireDigest,omitempty"`
+	// CacheMaxAge is the duration after which blobs which were not used anymore are removed from the local cache.
+	// Defaults to 168h.
+	// +optional
+	CacheMaxAge *metav1.Duration `json:"cacheMaxAge,omitempty"`
+}
+
+// InPlaceUpdateConfig defines the configuration of the in-place updates of the Kubernetes version of the node.
+type InPlaceUpdateConfig struct {
+	// DrainTimeout is the maximum duration for evicting the pods from the node before the kubelet is updated. The kubelet
+	// is updated even if not all pods could be evicted within this duration. Defaults to 10m.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// KubeletHealthTimeout is the maximum duration for the kubelet to become healthy and the node to become ready after
+	// the update. If it is exceeded, the update is considered failed and the node stays cordoned, which blocks the
+	// updates of the other nodes of the worker pool. Defaults to 5m.
+	// +optional
+	KubeletHealthTimeout *metav1.Duration `json:"kubeletHealthTimeout,omitempty"`
 }
 
 // TokenControllerConfig defines the configuration of the access token controller.
@@ -134,6 +228,140 @@ type TokenSecretSyncConfig struct {
 	SecretName string `json:"secretName"`
 	// Path is the path on the machine where the access token content should be synced.
 	Path string `json:"path"`
+	// Permissions is the file mode of the token file. Defaults to 0600.
+	// +optional
+	Permissions *uint32 `json:"permissions,omitempty"`
+	// Owner is the owner of the token file. If not set, the file is owned by the user running gardener-node-agent.
+	// +optional
+	Owner *FileOwner `json:"owner,omitempty"`
+	// RestartUnit is the name of a systemd unit which is restarted after the token file was updated. This is useful for
+	// daemons which do not reload the token file on their own.
+	// +optional
+	RestartUnit *string `json:"restartUnit,omitempty"`
+}
+
+// FileOwner contains the owner of a file.
+type FileOwner struct {
+	// UserID is the ID of the user owning the file.
+	UserID int64 `json:"userID"`
+	// GroupID is the ID of the group owning the file.
+	GroupID int64 `json:"groupID"`
+}
+
+// HealthCheckControllerConfig defines the configuration of the health check controller.
+type HealthCheckControllerConfig struct {
+	// Checkers is the list of health checkers which are executed in addition to the built-in containerd and kubelet
+	// health checks. The result of each checker is reported as a condition on the Node.
+	// +optional
+	Checkers []HealthCheckerConfig `json:"checkers,omitempty"`
+}
+
+// HealthCheckerConfig defines a health checker. Exactly one of SystemdUnits, DiskPressure, ClockSkew, DNS, HTTP and
+// Exec must be set.
+type HealthCheckerConfig struct {
+	// Name is the name of the health checker.
+	Name string `json:"name"`
+	// ConditionType is the type of the condition on the Node reporting the result of the health checker. The condition
+	// status is True if the check fails and False otherwise.
+	ConditionType string `json:"conditionType"`
+	// Timeout is the maximum duration of a single check. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailureDuration is the duration the check must fail continuously before the remediation is performed. Defaults
+	// to 1m.
+	// +optional
+	FailureDuration *metav1.Duration `json:"failureDuration,omitempty"`
+	// SystemdUnits checks that systemd units are not in failed state.
+	// +optional
+	SystemdUnits *SystemdUnitsHealthCheck `json:"systemdUnits,omitempty"`
+	// DiskPressure checks the disk and inode usage of a file system.
+	// +optional
+	DiskPressure *DiskPressureHealthCheck `json:"diskPressure,omitempty"`
+	// ClockSkew checks that the system clock is synchronized.
+	// +optional
+	ClockSkew *ClockSkewHealthCheck `json:"clockSkew,omitempty"`
+	// DNS checks that host names can be resolved.
+	// +optional
+	DNS *DNSHealthCheck `json:"dns,omitempty"`
+	// HTTP checks that an HTTP endpoint responds successfully.
+	// +optional
+	HTTP *HTTPHealthCheck `json:"http,omitempty"`
+	// Exec checks that a command exits successfully.
+	// +optional
+	Exec *ExecHealthCheck `json:"exec,omitempty"`
+	// Remediation is the action performed when the check failed for longer than the failure duration. If not set, the
+	// result is only reported.
+	// +optional
+	Remediation *HealthCheckRemediation `json:"remediation,omitempty"`
+}
+
+// SystemdUnitsHealthCheck checks that systemd units are not in failed state.
+type SystemdUnitsHealthCheck struct {
+	// Units are the names of the units to check. If empty, the check fails if any unit is in failed state.
+	// +optional
+	Units []string `json:"units,omitempty"`
+}
+
+// DiskPressureHealthCheck checks the disk and inode usage of a file system.
+type DiskPressureHealthCheck struct {
+	// Path is a path on the file system to check. Defaults to the kubelet data volume /var/lib.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// MaxUsedPercentage is the maximum percentage of used disk space. Defaults to 90.
+	// +optional
+	MaxUsedPercentage *int32 `json:"maxUsedPercentage,omitempty"`
+	// MaxUsedInodesPercentage is the maximum percentage of used inodes. Defaults to 90.
+	// +optional
+	MaxUsedInodesPercentage *int32 `json:"maxUsedInodesPercentage,omitempty"`
+}
+
+// ClockSkewHealthCheck checks that the system clock is synchronized, i.e., that the kernel reports the clock as
+// synchronized (e.g., via NTP) with a maximum error below the configured skew.
+type ClockSkewHealthCheck struct {
+	// MaxSkew is the maximum tolerated error of the system clock. Defaults to 1s.
+	// +optional
+	MaxSkew *metav1.Duration `json:"maxSkew,omitempty"`
+}
+
+// DNSHealthCheck checks that host names can be resolved.
+type DNSHealthCheck struct {
+	// Hostnames are the host names to resolve.
+	Hostnames []string `json:"hostnames"`
+}
+
+// HTTPHealthCheck checks that an HTTP endpoint responds with a 2xx or 3xx status code.
+type HTTPHealthCheck struct {
+	// URL is the URL of the endpoint.
+	URL string `json:"url"`
+}
+
+// ExecHealthCheck checks that a command exits with code 0.
+type ExecHealthCheck struct {
+	// Command is the command to execute, including its arguments.
+	Command []string `json:"command"`
+}
+
+// HealthCheckRemediationAction is a type for the remediation action of a health checker.
+type HealthCheckRemediationAction string
+
+const (
+	// HealthCheckRemediationActionRestartUnit restarts a systemd unit.
+	HealthCheckRemediationActionRestartUnit HealthCheckRemediationAction = "RestartUnit"
+	// HealthCheckRemediationActionReboot reboots the node.
+	HealthCheckRemediationActionReboot HealthCheckRemediationAction = "Reboot"
+)
+
+// HealthCheckRemediation defines the remediation of a failing health checker.
+type HealthCheckRemediation struct {
+	// Action is the remediation action. Must be one of [RestartUnit,Reboot].
+	Action HealthCheckRemediationAction `json:"action"`
+	// UnitName is the name of the systemd unit to restart. Required if the action is RestartUnit.
+	// +optional
+	UnitName string `json:"unitName,omitempty"`
+	// MinInterval is the minimum duration between two remediations, it limits how often the remediation is performed.
+	// Defaults to 30m.
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
 }
 
 // ServerConfiguration contains details for the HTTP(S) servers.
@@ -144,6 +372,11 @@ type ServerConfiguration struct {
 	// Metrics is the configuration for serving the metrics endpoint.
 	// +optional
 	Metrics *Server `json:"metrics,omitempty"`
+	// EnableDebugHandlers enables serving the read-only debug endpoints on the metrics server. They expose the state of
+	// gardener-node-agent (e.g., the files and units of the last applied operating system config) as JSON. The contents
+	// of files and units are not exposed, only their hashes.
+	// +optional
+	EnableDebugHandlers *bool `json:"enableDebugHandlers,omitempty"`
 }
 
 // Server contains information for HTTP(S) server configuration.
@@ -153,3 +386,36 @@ type Server struct {
 	// Port is the port on which to serve requests.
 	Port int `json:"port"`
 }
+
+// OperatingSystemConfigApplyStatus is the result of the last attempt to apply an operating system configuration. It is
+// published by gardener-node-agent on its Node, see AnnotationKeyOperatingSystemConfigApplyStatus.
+type OperatingSystemConfigApplyStatus struct {
+	// Checksum is the checksum of the operating system configuration.
+	Checksum string `json:"checksum"`
+	// Time is the time when the attempt finished.
+	Time metav1.Time `json:"time"`
+	// Duration is the duration of the attempt.
+	Duration metav1.Duration `json:"duration"`
+	// FailedStep is the step in which the attempt failed. It is empty if the attempt succeeded.
+	// +optional
+	FailedStep OperatingSystemConfigApplyStep `json:"failedStep,omitempty"`
+	// Error is the error of the failed attempt. It is empty if the attempt succeeded.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// OperatingSystemConfigApplyStep is a step of applying an operating system configuration.
+type OperatingSystemConfigApplyStep string
+
+const (
+	// OperatingSystemConfigApplyStepFiles is the step of writing new or changed files.
+	OperatingSystemConfigApplyStepFiles OperatingSystemConfigApplyStep = "Files"
+	// OperatingSystemConfigApplyStepContainerdRegistries is the step of configuring the containerd registries.
+	OperatingSystemConfigApplyStepContainerdRegistries OperatingSystemConfigApplyStep = "ContainerdRegistries"
+	// OperatingSystemConfigApplyStepUnits is the step of writing new or changed units and removing deleted units.
+	OperatingSystemConfigApplyStepUnits OperatingSystemConfigApplyStep = "Units"
+	// OperatingSystemConfigApplyStepUnitCommands is the step of executing the unit commands.
+	OperatingSystemConfigApplyStepUnitCommands OperatingSystemConfigApplyStep = "UnitCommands"
+	// OperatingSystemConfigApplyStepDeletedFiles is the step of removing deleted files.
+	OperatingSystemConfigApplyStepDeletedFiles OperatingSystemConfigApplyStep = "DeletedFiles"
+)
@@ -5,10 +5,14 @@
 package validation
 
 import (
+	"fmt"
+	"net/url"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/gardener/gardener/pkg/logger"
@@ -45,6 +49,7 @@ func validateControllerConfiguration(conf nodeagentconfigv1alpha1.ControllerConf
 
 	allErrs = append(allErrs, validateOperatingSystemConfigControllerConfiguration(conf.OperatingSystemConfig, fldPath.Child("operatingSystemConfig"))...)
 	allErrs = append(allErrs, validateTokenControllerConfiguration(conf.Token, fldPath.Child("token"))...)
+	allErrs = append(allErrs, validateHealthCheckControllerConfiguration(conf.HealthCheck, conf.OperatingSystemConfig.Reboot != nil, fldPath.Child("healthCheck"))...)
 
 	return allErrs
 }
@@ -64,6 +69,62 @@ func validateOperatingSystemConfigControllerConfiguration(conf nodeagentconfigv1
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("kubernetesVersion"), conf.KubernetesVersion, err.Error()))
 	}
 
+	if conf.RollbackAfterFailedAttempts != nil && *conf.RollbackAfterFailedAttempts < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("rollbackAfterFailedAttempts"), *conf.RollbackAfterFailedAttempts, "must be at least 1"))
+	}
+
+	if conf.DriftDetection != nil {
+		if period := conf.DriftDetection.Period; period == nil || period.Duration < 15*time.Second {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("driftDetection", "period"), period, "must be at least 15s"))
+		}
+	}
+
+	if conf.Reboot != nil {
+		allErrs = append(allErrs, validateRebootConfig(*conf.Reboot, fldPath.Child("reboot"))...)
+	}
+
+	if conf.DirectImagePull != nil {
+		if cacheMaxAge := conf.DirectImagePull.CacheMaxAge; cacheMaxAge == nil || cacheMaxAge.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("directImagePull", "cacheMaxAge"), cacheMaxAge, "must be positive"))
+		}
+	}
+
+	if conf.InPlaceUpdate != nil {
+		allErrs = append(allErrs, validateInPlaceUpdateConfig(*conf.InPlaceUpdate, conf.Reboot != nil, fldPath.Child("inPlaceUpdate"))...)
+	}
+
+	return allErrs
+}
+
+func validateRebootConfig(conf nodeagentconfigv1alpha1.RebootConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if maxConcurrentReboots := conf.MaxConcurrentReboots; maxConcurrentReboots == nil || *maxConcurrentReboots < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentReboots"), maxConcurrentReboots, "must be at least 1"))
+	}
+	if drainTimeout := conf.DrainTimeout; drainTimeout == nil || drainTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("drainTimeout"), drainTimeout, "must not be negative"))
+	}
+	if leaseDuration := conf.LeaseDuration; leaseDuration == nil || leaseDuration.Duration < time.Minute {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("leaseDuration"), leaseDuration, "must be at least 1m"))
+	}
+
+	return allErrs
+}
+
+func validateInPlaceUpdateConfig(conf nodeagentconfigv1alpha1.InPlaceUpdateConfig, rebootConfigured bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !rebootConfigured {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "in-place updates require the reboot configuration since they are coordinated via the reboot leases"))
+	}
+	if drainTimeout := conf.DrainTimeout; drainTimeout == nil || drainTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("drainTimeout"), drainTimeout, "must not be negative"))
+	}
+	if kubeletHealthTimeout := conf.KubeletHealthTimeout; kubeletHealthTimeout == nil || kubeletHealthTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("kubeletHealthTimeout"), kubeletHealthTimeout, "must be positive"))
+	}
+
 	return allErrs
 }
 
@@ -88,6 +149,23 @@ func validateTokenControllerConfiguration(conf nodeagentconfigv1alpha1.TokenCont
 			}
 			paths.Insert(cfg.Path)
 		}
+
+		if cfg.Permissions != nil && *cfg.Permissions > 0777 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("permissions"), *cfg.Permissions, "must be a valid file mode (at most 0777)"))
+		}
+
+		if cfg.Owner != nil {
+			if cfg.Owner.UserID < 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("owner", "userID"), cfg.Owner.UserID, "must not be negative"))
+			}
+			if cfg.Owner.GroupID < 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("owner", "groupID"), cfg.Owner.GroupID, "must not be negative"))
+			}
+		}
+
+		if cfg.RestartUnit != nil && *cfg.RestartUnit == "" {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("restartUnit"), *cfg.RestartUnit, "must not be empty"))
+		}
 	}
 
 	allErrs = append(allErrs, validateSyncPeriod(conf.SyncPeriod, fldPath)...)
@@ -95,6 +173,156 @@ func validateTokenControllerConfiguration(conf nodeagentconfigv1alpha1.TokenCont
 	return allErrs
 }
 
+var (
+	// reservedNodeConditionTypes are the condition types managed by the kubelet and gardener-node-agent itself.
+	reservedNodeConditionTypes = sets.New(
+		string(corev1.NodeReady),
+		string(corev1.NodeMemoryPressure),
+		string(corev1.NodeDiskPressure),
+		string(corev1.NodePIDPressure),
+		string(corev1.NodeNetworkUnavailable),
+		nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigRolledBack,
+		nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigDrifted,
+	)
+
+	supportedHealthCheckRemediationActions = sets.New(
+		nodeagentconfigv1alpha1.HealthCheckRemediationActionRestartUnit,
+		nodeagentconfigv1alpha1.HealthCheckRemediationActionReboot,
+	)
+)
+
+func validateHealthCheckControllerConfiguration(conf *nodeagentconfigv1alpha1.HealthCheckControllerConfig, rebootsEnabled bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if conf == nil {
+		return allErrs
+	}
+
+	var (
+		names          = sets.New[string]()
+		conditionTypes = sets.New[string]()
+	)
+
+	for i, checker := range conf.Checkers {
+		idxPath := fldPath.Child("checkers").Index(i)
+
+		if checker.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name for the health checker"))
+		} else {
+			if names.Has(checker.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), checker.Name))
+			}
+			names.Insert(checker.Name)
+		}
+
+		if checker.ConditionType == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("conditionType"), "must provide the condition type for the health checker"))
+		} else {
+			for _, msg := range validation.IsQualifiedName(checker.ConditionType) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("conditionType"), checker.ConditionType, msg))
+			}
+			if reservedNodeConditionTypes.Has(checker.ConditionType) {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("conditionType"), fmt.Sprintf("condition type %q is reserved", checker.ConditionType)))
+			}
+			if conditionTypes.Has(checker.ConditionType) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("conditionType"), checker.ConditionType))
+			}
+			conditionTypes.Insert(checker.ConditionType)
+		}
+
+		if checker.Timeout == nil || checker.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("timeout"), checker.Timeout, "must be positive"))
+		}
+		if checker.FailureDuration == nil || checker.FailureDuration.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("failureDuration"), checker.FailureDuration, "must not be negative"))
+		}
+
+		allErrs = append(allErrs, validateHealthCheck(checker, idxPath)...)
+
+		if remediation := checker.Remediation; remediation != nil {
+			remediationPath := idxPath.Child("remediation")
+
+			if !supportedHealthCheckRemediationActions.Has(remediation.Action) {
+				allErrs = append(allErrs, field.NotSupported(remediationPath.Child("action"), remediation.Action, sets.List(supportedHealthCheckRemediationActions)))
+			}
+			if remediation.Action == nodeagentconfigv1alpha1.HealthCheckRemediationActionRestartUnit && remediation.UnitName == "" {
+				allErrs = append(allErrs, field.Required(remediationPath.Child("unitName"), "must provide the name of the unit to restart"))
+			}
+			// Reboots are coordinated with the other nodes of the worker pool by the operating system config controller.
+			if remediation.Action == nodeagentconfigv1alpha1.HealthCheckRemediationActionReboot && !rebootsEnabled {
+				allErrs = append(allErrs, field.Forbidden(remediationPath.Child("action"), "reboots must be enabled in the operating system config controller configuration"))
+			}
+			if remediation.MinInterval == nil || remediation.MinInterval.Duration < time.Minute {
+				allErrs = append(allErrs, field.Invalid(remediationPath.Child("minInterval"), remediation.MinInterval, "must be at least 1m"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateHealthCheck(checker nodeagentconfigv1alpha1.HealthCheckerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var checks []string
+
+	if checker.SystemdUnits != nil {
+		checks = append(checks, "systemdUnits")
+	}
+
+	if diskPressure := checker.DiskPressure; diskPressure != nil {
+		checks = append(checks, "diskPressure")
+
+		if diskPressure.Path == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("diskPressure", "path"), "must provide the path of the file system"))
+		}
+		if percentage := diskPressure.MaxUsedPercentage; percentage == nil || *percentage < 1 || *percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("diskPressure", "maxUsedPercentage"), percentage, "must be between 1 and 100"))
+		}
+		if percentage := diskPressure.MaxUsedInodesPercentage; percentage == nil || *percentage < 1 || *percentage > 100 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("diskPressure", "maxUsedInodesPercentage"), percentage, "must be between 1 and 100"))
+		}
+	}
+
+	if clockSkew := checker.ClockSkew; clockSkew != nil {
+		checks = append(checks, "clockSkew")
+
+		if clockSkew.MaxSkew == nil || clockSkew.MaxSkew.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clockSkew", "maxSkew"), clockSkew.MaxSkew, "must be positive"))
+		}
+	}
+
+	if dns := checker.DNS; dns != nil {
+		checks = append(checks, "dns")
+
+		if len(dns.Hostnames) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("dns", "hostnames"), "must provide at least one host name"))
+		}
+	}
+
+	if http := checker.HTTP; http != nil {
+		checks = append(checks, "http")
+
+		if u, err := url.Parse(http.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("http", "url"), http.URL, "must be a valid http or https URL"))
+		}
+	}
+
+	if exec := checker.Exec; exec != nil {
+		checks = append(checks, "exec")
+
+		if len(exec.Command) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("exec", "command"), "must provide the command to execute"))
+		}
+	}
+
+	if len(checks) != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, checks, "exactly one of systemdUnits, diskPressure, clockSkew, dns, http and exec must be set"))
+	}
+
+	return allErrs
+}
+
 func validateSyncPeriod(val *metav1.Duration, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	. "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
 	. "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1/validation"
@@ -77,6 +78,129 @@ var _ = Describe("#ValidateNodeAgentConfiguration", func() {
 				})),
 			))
 		})
+
+		It("should fail because the number of failed attempts before rollback is too small", func() {
+			config.Controllers.OperatingSystemConfig.RollbackAfterFailedAttempts = ptr.To[int32](0)
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.rollbackAfterFailedAttempts"),
+				})),
+			))
+		})
+
+		It("should fail because the drift detection period is too small", func() {
+			config.Controllers.OperatingSystemConfig.DriftDetection = &DriftDetectionConfig{Period: &metav1.Duration{Duration: time.Second}}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.driftDetection.period"),
+				})),
+			))
+		})
+
+		It("should pass if the drift detection period is valid", func() {
+			config.Controllers.OperatingSystemConfig.DriftDetection = &DriftDetectionConfig{Period: &metav1.Duration{Duration: time.Minute}}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
+
+		It("should fail because the reboot configuration is invalid", func() {
+			config.Controllers.OperatingSystemConfig.Reboot = &RebootConfig{
+				MaxConcurrentReboots: ptr.To[int32](0),
+				DrainTimeout:         &metav1.Duration{Duration: -time.Second},
+				LeaseDuration:        &metav1.Duration{Duration: time.Second},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.reboot.maxConcurrentReboots"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.reboot.drainTimeout"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.reboot.leaseDuration"),
+				})),
+			))
+		})
+
+		It("should pass if the reboot configuration is valid", func() {
+			config.Controllers.OperatingSystemConfig.Reboot = &RebootConfig{
+				MaxConcurrentReboots: ptr.To[int32](2),
+				DrainTimeout:         &metav1.Duration{},
+				LeaseDuration:        &metav1.Duration{Duration: time.Hour},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
+
+		It("should fail because the cache max age of the direct image pull configuration is not positive", func() {
+			config.Controllers.OperatingSystemConfig.DirectImagePull = &DirectImagePullConfig{CacheMaxAge: &metav1.Duration{}}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.directImagePull.cacheMaxAge"),
+				})),
+			))
+		})
+
+		It("should pass if the direct image pull configuration is valid", func() {
+			config.Controllers.OperatingSystemConfig.DirectImagePull = &DirectImagePullConfig{
+				RequireDigest: ptr.To(true),
+				CacheMaxAge:   &metav1.Duration{Duration: time.Hour},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
+
+		It("should fail because the in-place update configuration is invalid", func() {
+			config.Controllers.OperatingSystemConfig.InPlaceUpdate = &InPlaceUpdateConfig{
+				DrainTimeout:         &metav1.Duration{Duration: -time.Minute},
+				KubeletHealthTimeout: &metav1.Duration{},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("controllers.operatingSystemConfig.inPlaceUpdate"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.inPlaceUpdate.drainTimeout"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.operatingSystemConfig.inPlaceUpdate.kubeletHealthTimeout"),
+				})),
+			))
+		})
+
+		It("should pass if the in-place update configuration is valid", func() {
+			config.Controllers.OperatingSystemConfig.Reboot = &RebootConfig{
+				MaxConcurrentReboots: ptr.To[int32](1),
+				DrainTimeout:         &metav1.Duration{Duration: time.Minute},
+				LeaseDuration:        &metav1.Duration{Duration: time.Hour},
+			}
+			config.Controllers.OperatingSystemConfig.InPlaceUpdate = &InPlaceUpdateConfig{
+				DrainTimeout:         &metav1.Duration{Duration: time.Minute},
+				KubeletHealthTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
+
+		It("should pass if the number of failed attempts before rollback is valid", func() {
+			config.Controllers.OperatingSystemConfig.RollbackAfterFailedAttempts = ptr.To[int32](3)
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
 	})
 
 	Context("Token Controller", func() {
@@ -120,6 +244,39 @@ var _ = Describe("#ValidateNodeAgentConfiguration", func() {
 			))
 		})
 
+		It("should allow configuring permissions, owner and a unit to restart", func() {
+			config.Controllers.Token.SyncConfigs[0].Permissions = ptr.To[uint32](0640)
+			config.Controllers.Token.SyncConfigs[0].Owner = &FileOwner{UserID: 1000, GroupID: 1000}
+			config.Controllers.Token.SyncConfigs[0].RestartUnit = ptr.To("foo.service")
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
+
+		It("should fail because permissions, owner and unit to restart are invalid", func() {
+			config.Controllers.Token.SyncConfigs[0].Permissions = ptr.To[uint32](01000)
+			config.Controllers.Token.SyncConfigs[0].Owner = &FileOwner{UserID: -1, GroupID: -1}
+			config.Controllers.Token.SyncConfigs[0].RestartUnit = ptr.To("")
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.token.syncConfigs[0].permissions"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.token.syncConfigs[0].owner.userID"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.token.syncConfigs[0].owner.groupID"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.token.syncConfigs[0].restartUnit"),
+				})),
+			))
+		})
+
 		It("should fail because sync period is too small", func() {
 			config.Controllers.Token.SyncPeriod.Duration = 10 * time.Second
 
@@ -131,4 +288,183 @@ var _ = Describe("#ValidateNodeAgentConfiguration", func() {
 			))
 		})
 	})
+
+	Context("Health Check Controller", func() {
+		var checker *HealthCheckerConfig
+
+		BeforeEach(func() {
+			config.Controllers.HealthCheck = &HealthCheckControllerConfig{Checkers: []HealthCheckerConfig{{
+				Name:            "failed-units",
+				ConditionType:   "FailedSystemdUnits",
+				Timeout:         &metav1.Duration{Duration: 10 * time.Second},
+				FailureDuration: &metav1.Duration{Duration: time.Minute},
+				SystemdUnits:    &SystemdUnitsHealthCheck{},
+			}}}
+			checker = &config.Controllers.HealthCheck.Checkers[0]
+		})
+
+		It("should pass for a valid health checker", func() {
+			checker.Remediation = &HealthCheckRemediation{
+				Action:      HealthCheckRemediationActionRestartUnit,
+				UnitName:    "foo.service",
+				MinInterval: &metav1.Duration{Duration: 30 * time.Minute},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
+
+		It("should fail because name and condition type are not specified", func() {
+			checker.Name = ""
+			checker.ConditionType = ""
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.healthCheck.checkers[0].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.healthCheck.checkers[0].conditionType"),
+				})),
+			))
+		})
+
+		It("should fail because the condition type is reserved", func() {
+			checker.ConditionType = "DiskPressure"
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("controllers.healthCheck.checkers[0].conditionType"),
+				})),
+			))
+		})
+
+		It("should fail because name and condition type are duplicated", func() {
+			config.Controllers.HealthCheck = &HealthCheckControllerConfig{Checkers: []HealthCheckerConfig{*checker, *checker}}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("controllers.healthCheck.checkers[1].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("controllers.healthCheck.checkers[1].conditionType"),
+				})),
+			))
+		})
+
+		It("should fail because no check is specified", func() {
+			checker.SystemdUnits = nil
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.healthCheck.checkers[0]"),
+				})),
+			))
+		})
+
+		It("should fail because multiple checks are specified", func() {
+			checker.Exec = &ExecHealthCheck{Command: []string{"true"}}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.healthCheck.checkers[0]"),
+				})),
+			))
+		})
+
+		It("should fail because the checks are invalid", func() {
+			config.Controllers.HealthCheck = &HealthCheckControllerConfig{Checkers: []HealthCheckerConfig{
+				{Name: "disk", ConditionType: "Disk", Timeout: checker.Timeout, FailureDuration: checker.FailureDuration, DiskPressure: &DiskPressureHealthCheck{Path: "/var/lib", MaxUsedPercentage: ptr.To[int32](0), MaxUsedInodesPercentage: ptr.To[int32](101)}},
+				{Name: "clock", ConditionType: "Clock", Timeout: checker.Timeout, FailureDuration: checker.FailureDuration, ClockSkew: &ClockSkewHealthCheck{MaxSkew: &metav1.Duration{}}},
+				{Name: "dns", ConditionType: "DNS", Timeout: checker.Timeout, FailureDuration: checker.FailureDuration, DNS: &DNSHealthCheck{}},
+				{Name: "http", ConditionType: "HTTP", Timeout: checker.Timeout, FailureDuration: checker.FailureDuration, HTTP: &HTTPHealthCheck{URL: "ftp://foo"}},
+				{Name: "exec", ConditionType: "Exec", Timeout: checker.Timeout, FailureDuration: checker.FailureDuration, Exec: &ExecHealthCheck{}},
+			}}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.healthCheck.checkers[0].diskPressure.maxUsedPercentage"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.healthCheck.checkers[0].diskPressure.maxUsedInodesPercentage"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.healthCheck.checkers[1].clockSkew.maxSkew"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.healthCheck.checkers[2].dns.hostnames"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.healthCheck.checkers[3].http.url"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.healthCheck.checkers[4].exec.command"),
+				})),
+			))
+		})
+
+		It("should fail because the remediation is invalid", func() {
+			checker.Remediation = &HealthCheckRemediation{
+				Action:      HealthCheckRemediationActionRestartUnit,
+				MinInterval: &metav1.Duration{Duration: time.Second},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.healthCheck.checkers[0].remediation.unitName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.healthCheck.checkers[0].remediation.minInterval"),
+				})),
+			))
+		})
+
+		It("should fail because the remediation action is not supported", func() {
+			checker.Remediation = &HealthCheckRemediation{
+				Action:      "Foo",
+				MinInterval: &metav1.Duration{Duration: time.Hour},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("controllers.healthCheck.checkers[0].remediation.action"),
+				})),
+			))
+		})
+
+		It("should fail because the node shall be rebooted but reboots are not enabled", func() {
+			checker.Remediation = &HealthCheckRemediation{
+				Action:      HealthCheckRemediationActionReboot,
+				MinInterval: &metav1.Duration{Duration: time.Hour},
+			}
+
+			Expect(ValidateNodeAgentConfiguration(config)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("controllers.healthCheck.checkers[0].remediation.action"),
+				})),
+			))
+
+			config.Controllers.OperatingSystemConfig.Reboot = &RebootConfig{
+				MaxConcurrentReboots: ptr.To[int32](1),
+				DrainTimeout:         &metav1.Duration{Duration: time.Minute},
+				LeaseDuration:        &metav1.Duration{Duration: time.Hour},
+			}
+			Expect(ValidateNodeAgentConfiguration(config)).To(BeEmpty())
+		})
+	})
 })
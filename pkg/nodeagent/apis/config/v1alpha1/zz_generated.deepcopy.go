@@ -58,11 +58,37 @@ func (in *BootstrapConfiguration) DeepCopy() *BootstrapConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClockSkewHealthCheck) DeepCopyInto(out *ClockSkewHealthCheck) {
+	*out = *in
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClockSkewHealthCheck.
+func (in *ClockSkewHealthCheck) DeepCopy() *ClockSkewHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClockSkewHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
 	in.OperatingSystemConfig.DeepCopyInto(&out.OperatingSystemConfig)
 	in.Token.DeepCopyInto(&out.Token)
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheckControllerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -76,6 +102,289 @@ func (in *ControllerConfiguration) DeepCopy() *ControllerConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheck) DeepCopyInto(out *DNSHealthCheck) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheck.
+func (in *DNSHealthCheck) DeepCopy() *DNSHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectImagePullConfig) DeepCopyInto(out *DirectImagePullConfig) {
+	*out = *in
+	if in.RequireDigest != nil {
+		in, out := &in.RequireDigest, &out.RequireDigest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheMaxAge != nil {
+		in, out := &in.CacheMaxAge, &out.CacheMaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectImagePullConfig.
+func (in *DirectImagePullConfig) DeepCopy() *DirectImagePullConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectImagePullConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskPressureHealthCheck) DeepCopyInto(out *DiskPressureHealthCheck) {
+	*out = *in
+	if in.MaxUsedPercentage != nil {
+		in, out := &in.MaxUsedPercentage, &out.MaxUsedPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUsedInodesPercentage != nil {
+		in, out := &in.MaxUsedInodesPercentage, &out.MaxUsedInodesPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskPressureHealthCheck.
+func (in *DiskPressureHealthCheck) DeepCopy() *DiskPressureHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskPressureHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetectionConfig) DeepCopyInto(out *DriftDetectionConfig) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Remediate != nil {
+		in, out := &in.Remediate, &out.Remediate
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetectionConfig.
+func (in *DriftDetectionConfig) DeepCopy() *DriftDetectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecHealthCheck) DeepCopyInto(out *ExecHealthCheck) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecHealthCheck.
+func (in *ExecHealthCheck) DeepCopy() *ExecHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileOwner) DeepCopyInto(out *FileOwner) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileOwner.
+func (in *FileOwner) DeepCopy() *FileOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(FileOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHealthCheck) DeepCopyInto(out *HTTPHealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHealthCheck.
+func (in *HTTPHealthCheck) DeepCopy() *HTTPHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckControllerConfig) DeepCopyInto(out *HealthCheckControllerConfig) {
+	*out = *in
+	if in.Checkers != nil {
+		in, out := &in.Checkers, &out.Checkers
+		*out = make([]HealthCheckerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckControllerConfig.
+func (in *HealthCheckControllerConfig) DeepCopy() *HealthCheckControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckRemediation) DeepCopyInto(out *HealthCheckRemediation) {
+	*out = *in
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckRemediation.
+func (in *HealthCheckRemediation) DeepCopy() *HealthCheckRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckerConfig) DeepCopyInto(out *HealthCheckerConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailureDuration != nil {
+		in, out := &in.FailureDuration, &out.FailureDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SystemdUnits != nil {
+		in, out := &in.SystemdUnits, &out.SystemdUnits
+		*out = new(SystemdUnitsHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskPressure != nil {
+		in, out := &in.DiskPressure, &out.DiskPressure
+		*out = new(DiskPressureHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClockSkew != nil {
+		in, out := &in.ClockSkew, &out.ClockSkew
+		*out = new(ClockSkewHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPHealthCheck)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(HealthCheckRemediation)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckerConfig.
+func (in *HealthCheckerConfig) DeepCopy() *HealthCheckerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InPlaceUpdateConfig) DeepCopyInto(out *InPlaceUpdateConfig) {
+	*out = *in
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KubeletHealthTimeout != nil {
+		in, out := &in.KubeletHealthTimeout, &out.KubeletHealthTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InPlaceUpdateConfig.
+func (in *InPlaceUpdateConfig) DeepCopy() *InPlaceUpdateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InPlaceUpdateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeAgentConfiguration) DeepCopyInto(out *NodeAgentConfiguration) {
 	*out = *in
@@ -122,6 +431,24 @@ func (in *NodeAgentConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatingSystemConfigApplyStatus) DeepCopyInto(out *OperatingSystemConfigApplyStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatingSystemConfigApplyStatus.
+func (in *OperatingSystemConfigApplyStatus) DeepCopy() *OperatingSystemConfigApplyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatingSystemConfigApplyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatingSystemConfigControllerConfig) DeepCopyInto(out *OperatingSystemConfigControllerConfig) {
 	*out = *in
@@ -135,6 +462,36 @@ func (in *OperatingSystemConfigControllerConfig) DeepCopyInto(out *OperatingSyst
 		*out = new(v3.Version)
 		**out = **in
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RollbackAfterFailedAttempts != nil {
+		in, out := &in.RollbackAfterFailedAttempts, &out.RollbackAfterFailedAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reboot != nil {
+		in, out := &in.Reboot, &out.Reboot
+		*out = new(RebootConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DirectImagePull != nil {
+		in, out := &in.DirectImagePull, &out.DirectImagePull
+		*out = new(DirectImagePullConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InPlaceUpdate != nil {
+		in, out := &in.InPlaceUpdate, &out.InPlaceUpdate
+		*out = new(InPlaceUpdateConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -148,6 +505,37 @@ func (in *OperatingSystemConfigControllerConfig) DeepCopy() *OperatingSystemConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebootConfig) DeepCopyInto(out *RebootConfig) {
+	*out = *in
+	if in.MaxConcurrentReboots != nil {
+		in, out := &in.MaxConcurrentReboots, &out.MaxConcurrentReboots
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LeaseDuration != nil {
+		in, out := &in.LeaseDuration, &out.LeaseDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebootConfig.
+func (in *RebootConfig) DeepCopy() *RebootConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RebootConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Server) DeepCopyInto(out *Server) {
 	*out = *in
@@ -177,6 +565,11 @@ func (in *ServerConfiguration) DeepCopyInto(out *ServerConfiguration) {
 		*out = new(Server)
 		**out = **in
 	}
+	if in.EnableDebugHandlers != nil {
+		in, out := &in.EnableDebugHandlers, &out.EnableDebugHandlers
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -190,13 +583,36 @@ func (in *ServerConfiguration) DeepCopy() *ServerConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemdUnitsHealthCheck) DeepCopyInto(out *SystemdUnitsHealthCheck) {
+	*out = *in
+	if in.Units != nil {
+		in, out := &in.Units, &out.Units
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemdUnitsHealthCheck.
+func (in *SystemdUnitsHealthCheck) DeepCopy() *SystemdUnitsHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemdUnitsHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenControllerConfig) DeepCopyInto(out *TokenControllerConfig) {
 	*out = *in
 	if in.SyncConfigs != nil {
 		in, out := &in.SyncConfigs, &out.SyncConfigs
 		*out = make([]TokenSecretSyncConfig, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.SyncPeriod != nil {
 		in, out := &in.SyncPeriod, &out.SyncPeriod
@@ -219,6 +635,21 @@ func (in *TokenControllerConfig) DeepCopy() *TokenControllerConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenSecretSyncConfig) DeepCopyInto(out *TokenSecretSyncConfig) {
 	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(FileOwner)
+		**out = **in
+	}
+	if in.RestartUnit != nil {
+		in, out := &in.RestartUnit, &out.RestartUnit
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -26,5 +26,36 @@ func SetObjectDefaults_NodeAgentConfiguration(in *NodeAgentConfiguration) {
 	SetDefaults_ClientConnectionConfiguration(&in.ClientConnection)
 	SetDefaults_ServerConfiguration(&in.Server)
 	SetDefaults_OperatingSystemConfigControllerConfig(&in.Controllers.OperatingSystemConfig)
+	if in.Controllers.OperatingSystemConfig.DriftDetection != nil {
+		SetDefaults_DriftDetectionConfig(in.Controllers.OperatingSystemConfig.DriftDetection)
+	}
+	if in.Controllers.OperatingSystemConfig.Reboot != nil {
+		SetDefaults_RebootConfig(in.Controllers.OperatingSystemConfig.Reboot)
+	}
+	if in.Controllers.OperatingSystemConfig.DirectImagePull != nil {
+		SetDefaults_DirectImagePullConfig(in.Controllers.OperatingSystemConfig.DirectImagePull)
+	}
+	if in.Controllers.OperatingSystemConfig.InPlaceUpdate != nil {
+		SetDefaults_InPlaceUpdateConfig(in.Controllers.OperatingSystemConfig.InPlaceUpdate)
+	}
 	SetDefaults_TokenControllerConfig(&in.Controllers.Token)
+	for i := range in.Controllers.Token.SyncConfigs {
+		a := &in.Controllers.Token.SyncConfigs[i]
+		SetDefaults_TokenSecretSyncConfig(a)
+	}
+	if in.Controllers.HealthCheck != nil {
+		for i := range in.Controllers.HealthCheck.Checkers {
+			a := &in.Controllers.HealthCheck.Checkers[i]
+			SetDefaults_HealthCheckerConfig(a)
+			if a.DiskPressure != nil {
+				SetDefaults_DiskPressureHealthCheck(a.DiskPressure)
+			}
+			if a.ClockSkew != nil {
+				SetDefaults_ClockSkewHealthCheck(a.ClockSkew)
+			}
+			if a.Remediation != nil {
+				SetDefaults_HealthCheckRemediation(a.Remediation)
+			}
+		}
+	}
 }
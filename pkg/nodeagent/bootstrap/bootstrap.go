@@ -71,7 +71,13 @@ func Bootstrap(
 
 	log.Info("Disabling gardener-node-init unit")
 	if err := dbus.Disable(ctx, nodeagentconfigv1alpha1.InitUnitName); err != nil {
-		return fmt.Errorf("unable to disable system unit %q: %w", nodeagentconfigv1alpha1.InitUnitName, err)
+		// Machines which were not provisioned via gardener-node-init (e.g., joined via `gardenadm join`) do not have
+		// this unit, hence there is nothing to disable.
+		initUnitFilePath := path.Join("/", "etc", "systemd", "system", nodeagentconfigv1alpha1.InitUnitName)
+		if exists, existsErr := fs.Exists(initUnitFilePath); existsErr != nil || exists {
+			return fmt.Errorf("unable to disable system unit %q: %w", nodeagentconfigv1alpha1.InitUnitName, err)
+		}
+		log.Info("Unit file for gardener-node-init does not exist, nothing to disable", "path", initUnitFilePath)
 	}
 
 	// After this line, the execution of the gardener-node-agent bootstrap command terminates. It is not possible to
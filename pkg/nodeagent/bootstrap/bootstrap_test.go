@@ -6,6 +6,7 @@ package bootstrap_test
 
 import (
 	"context"
+	"fmt"
 	"io/fs"
 
 	"github.com/go-logr/logr"
@@ -72,6 +73,25 @@ WantedBy=multi-user.target`
 			})
 		})
 
+		When("disabling gardener-node-init fails", func() {
+			var failingDBus *failingDisableDBus
+
+			BeforeEach(func() {
+				failingDBus = &failingDisableDBus{DBus: fakeDBus}
+			})
+
+			It("should succeed if the unit file of gardener-node-init does not exist", func() {
+				Expect(Bootstrap(ctx, log, fakeFS, failingDBus, bootstrapConfig)).To(Succeed())
+				assertions()
+			})
+
+			It("should fail if the unit file of gardener-node-init exists", func() {
+				Expect(fakeFS.WriteFile("/etc/systemd/system/gardener-node-init.service", []byte("foo"), 0644)).To(Succeed())
+
+				Expect(Bootstrap(ctx, log, fakeFS, failingDBus, bootstrapConfig)).To(MatchError(ContainSubstring(`unable to disable system unit "gardener-node-init.service"`)))
+			})
+		})
+
 		When("kubelet data volume size is set", func() {
 			BeforeEach(func() {
 				bootstrapConfig.KubeletDataVolumeSize = ptr.To[int64](1234)
@@ -131,6 +151,15 @@ fi
 	})
 })
 
+type failingDisableDBus struct {
+	*fakedbus.DBus
+}
+
+func (d *failingDisableDBus) Disable(ctx context.Context, unitNames ...string) error {
+	_ = d.DBus.Disable(ctx, unitNames...)
+	return fmt.Errorf("fake")
+}
+
 func assertFileOnDisk(fakeFS afero.Afero, path, expectedContent string, fileMode uint32) {
 	description := "file path " + path
 
@@ -7,6 +7,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,8 +25,9 @@ import (
 	"github.com/gardener/gardener/pkg/nodeagent/controller/token"
 )
 
-// AddToManager adds all controllers to the given manager.
-func AddToManager(ctx context.Context, cancel context.CancelFunc, mgr manager.Manager, cfg *nodeagentconfigv1alpha1.NodeAgentConfiguration, hostName, machineName, nodeName string) error {
+// AddToManager adds all controllers to the given manager. If debugMux is not nil, the debug endpoints of the controllers
+// are registered at it.
+func AddToManager(ctx context.Context, cancel context.CancelFunc, mgr manager.Manager, cfg *nodeagentconfigv1alpha1.NodeAgentConfiguration, hostName, machineName, nodeName string, debugMux *http.ServeMux) error {
 	nodePredicate, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelHostname: hostName}})
 	if err != nil {
 		return fmt.Errorf("failed computing label selector predicate for node: %w", err)
@@ -44,18 +46,20 @@ func AddToManager(ctx context.Context, cancel context.CancelFunc, mgr manager.Ma
 		return fmt.Errorf("failed adding node controller: %w", err)
 	}
 
-	if err := (&operatingsystemconfig.Reconciler{
+	operatingSystemConfigReconciler := &operatingsystemconfig.Reconciler{
 		Config:        cfg.Controllers.OperatingSystemConfig,
 		HostName:      hostName,
 		NodeName:      nodeName,
 		CancelContext: cancel,
-	}).AddToManager(ctx, mgr); err != nil {
+	}
+	if err := operatingSystemConfigReconciler.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed adding operating system config controller: %w", err)
 	}
 
-	if err := (&token.Reconciler{
+	tokenReconciler := &token.Reconciler{
 		Config: cfg.Controllers.Token,
-	}).AddToManager(mgr); err != nil {
+	}
+	if err := tokenReconciler.AddToManager(mgr); err != nil {
 		return fmt.Errorf("failed adding token controller: %w", err)
 	}
 
@@ -67,7 +71,11 @@ func AddToManager(ctx context.Context, cancel context.CancelFunc, mgr manager.Ma
 		}
 	}
 
-	if err := (&healthcheck.Reconciler{}).AddToManager(mgr, nodePredicate); err != nil {
+	healthCheckReconciler := &healthcheck.Reconciler{
+		Config:          cfg.Controllers.HealthCheck,
+		RebootRequester: operatingSystemConfigReconciler,
+	}
+	if err := healthCheckReconciler.AddToManager(mgr, nodePredicate); err != nil {
 		return fmt.Errorf("failed adding health-check controller: %w", err)
 	}
 
@@ -78,5 +86,11 @@ func AddToManager(ctx context.Context, cancel context.CancelFunc, mgr manager.Ma
 		return fmt.Errorf("failed adding hostname-check controller: %w", err)
 	}
 
+	if debugMux != nil {
+		operatingSystemConfigReconciler.AddDebugHandlers(debugMux)
+		tokenReconciler.AddDebugHandlers(debugMux)
+		healthCheckReconciler.AddDebugHandlers(debugMux)
+	}
+
 	return nil
 }
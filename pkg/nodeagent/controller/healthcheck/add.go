@@ -12,6 +12,7 @@ import (
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/defaults"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -43,12 +44,30 @@ func (r *Reconciler) AddToManager(mgr manager.Manager, nodePredicate predicate.P
 		r.DBus = dbus.New(mgr.GetLogger().WithValues("controller", ControllerName))
 	}
 
+	if r.FS.Fs == nil {
+		r.FS = afero.Afero{Fs: afero.NewOsFs()}
+	}
+
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+
 	if len(r.HealthCheckers) == 0 {
 		if err := r.setDefaultHealthChecks(); err != nil {
 			return err
 		}
 	}
 
+	if r.Config != nil {
+		for _, config := range r.Config.Checkers {
+			healthChecker, err := NewConfigurableHealthChecker(r.Client, r.Clock, r.DBus, r.RebootRequester, r.Recorder, r.FS, config)
+			if err != nil {
+				return fmt.Errorf("failed creating health checker %q: %w", config.Name, err)
+			}
+			r.HealthCheckers = append(r.HealthCheckers, healthChecker)
+		}
+	}
+
 	if r.HealthCheckIntervalSeconds == 0 {
 		r.HealthCheckIntervalSeconds = defaultIntervalSeconds
 	}
@@ -62,8 +81,6 @@ func (r *Reconciler) AddToManager(mgr manager.Manager, nodePredicate predicate.P
 }
 
 func (r *Reconciler) setDefaultHealthChecks() error {
-	clock := clock.RealClock{}
-
 	address := os.Getenv("CONTAINERD_ADDRESS")
 	if address == "" {
 		address = defaults.DefaultAddress
@@ -79,9 +96,9 @@ func (r *Reconciler) setDefaultHealthChecks() error {
 		return fmt.Errorf("error creating containerd client: %w", err)
 	}
 
-	containerdHealthChecker := NewContainerdHealthChecker(r.Client, client, clock, r.DBus, r.Recorder)
+	containerdHealthChecker := NewContainerdHealthChecker(r.Client, client, r.Clock, r.DBus, r.Recorder)
 
-	kubeletHealthChecker := NewKubeletHealthChecker(r.Client, clock, r.DBus, r.Recorder, net.InterfaceAddrs)
+	kubeletHealthChecker := NewKubeletHealthChecker(r.Client, r.Clock, r.DBus, r.Recorder, net.InterfaceAddrs)
 	r.HealthCheckers = []HealthChecker{containerdHealthChecker, kubeletHealthChecker}
 	return nil
 }
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+)
+
+const (
+	// lastRemediationDir is the directory containing the timestamps of the last remediations of the configurable health
+	// checkers. They are persisted to limit the remediation rate across restarts of gardener-node-agent and reboots.
+	lastRemediationDir = nodeagentconfigv1alpha1.BaseDir + "/health-checks"
+
+	reasonHealthCheckSucceeded = "HealthCheckSucceeded"
+	reasonHealthCheckFailed    = "HealthCheckFailed"
+)
+
+type configurableHealthChecker struct {
+	config nodeagentconfigv1alpha1.HealthCheckerConfig
+	probe  probeFunc
+
+	client          client.Client
+	clock           clock.Clock
+	dbus            dbus.DBus
+	rebootRequester RebootRequester
+	recorder        record.EventRecorder
+	fs              afero.Afero
+	firstFailure    *time.Time
+}
+
+// NewConfigurableHealthChecker creates a new instance of a health checker defined in the gardener-node-agent's
+// component configuration. The result of the check is reported as a condition on the node. Reboots of the node are
+// requested via the given RebootRequester.
+func NewConfigurableHealthChecker(client client.Client, clock clock.Clock, dbus dbus.DBus, rebootRequester RebootRequester, recorder record.EventRecorder, fs afero.Afero, config nodeagentconfigv1alpha1.HealthCheckerConfig) (HealthChecker, error) {
+	probe, err := newProbe(config, dbus, &http.Client{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &configurableHealthChecker{
+		config:          config,
+		probe:           probe,
+		client:          client,
+		clock:           clock,
+		dbus:            dbus,
+		rebootRequester: rebootRequester,
+		recorder:        recorder,
+		fs:              fs,
+	}, nil
+}
+
+// Name returns the name of this health check.
+func (c *configurableHealthChecker) Name() string {
+	return c.config.Name
+}
+
+// Check executes the configured probe, reports its result as a condition on the node and performs the configured
+// remediation if the probe fails for longer than the configured failure duration.
+func (c *configurableHealthChecker) Check(ctx context.Context, node *corev1.Node) error {
+	log := logf.FromContext(ctx).WithName(c.Name())
+
+	probeCtx, cancel := context.WithTimeout(ctx, ptr.Deref(c.config.Timeout, metav1.Duration{Duration: 10 * time.Second}).Duration)
+	defer cancel()
+
+	probeErr := c.probe(probeCtx)
+	if probeErr == nil {
+		if c.firstFailure != nil {
+			log.Info("Health check succeeded again")
+			c.recorder.Eventf(node, corev1.EventTypeNormal, c.Name(), "Health check %q succeeded", c.Name())
+			c.firstFailure = nil
+		}
+		return c.updateCondition(ctx, node, corev1.ConditionFalse, reasonHealthCheckSucceeded, fmt.Sprintf("Health check %q succeeded", c.Name()))
+	}
+
+	if c.firstFailure == nil {
+		now := c.clock.Now()
+		c.firstFailure = &now
+
+		log.Info("Health check failed", "reason", probeErr.Error())
+		c.recorder.Eventf(node, corev1.EventTypeWarning, c.Name(), "Health check %q failed: %s", c.Name(), probeErr.Error())
+	}
+
+	if err := c.updateCondition(ctx, node, corev1.ConditionTrue, reasonHealthCheckFailed, probeErr.Error()); err != nil {
+		return err
+	}
+
+	failureDuration := ptr.Deref(c.config.FailureDuration, metav1.Duration{}).Duration
+	if c.config.Remediation == nil || c.clock.Since(*c.firstFailure) < failureDuration {
+		return nil
+	}
+
+	return c.remediate(ctx, node, probeErr, failureDuration)
+}
+
+func (c *configurableHealthChecker) remediate(ctx context.Context, node *corev1.Node, probeErr error, failureDuration time.Duration) error {
+	var (
+		log                 = logf.FromContext(ctx).WithName(c.Name())
+		remediation         = c.config.Remediation
+		lastRemediationPath = path.Join(lastRemediationDir, c.Name())
+	)
+
+	lastRemediation, err := c.lastRemediation(lastRemediationPath)
+	if err != nil {
+		return err
+	}
+	if minInterval := ptr.Deref(remediation.MinInterval, metav1.Duration{}).Duration; lastRemediation != nil && c.clock.Since(*lastRemediation) < minInterval {
+		log.V(1).Info("Skipping remediation because the last one was performed recently", "lastRemediation", lastRemediation, "minInterval", minInterval)
+		return nil
+	}
+
+	// The timestamp is persisted before the remediation, otherwise a reboot would not be rate limited.
+	if err := c.fs.MkdirAll(lastRemediationDir, 0755); err != nil {
+		return fmt.Errorf("unable to create directory %q: %w", lastRemediationDir, err)
+	}
+	if err := c.fs.WriteFile(lastRemediationPath, []byte(c.clock.Now().UTC().Format(time.RFC3339)), 0600); err != nil {
+		return fmt.Errorf("unable to persist time of remediation to %q: %w", lastRemediationPath, err)
+	}
+
+	switch remediation.Action {
+	case nodeagentconfigv1alpha1.HealthCheckRemediationActionRestartUnit:
+		log.Info("Health check failed for too long, restarting unit", "unitName", remediation.UnitName, "failureDuration", failureDuration)
+		c.recorder.Eventf(node, corev1.EventTypeWarning, c.Name(), "Health check %q failed for more than %s, restarting unit %s: %s", c.Name(), failureDuration, remediation.UnitName, probeErr.Error())
+		if err := c.dbus.Restart(ctx, c.recorder, node, remediation.UnitName); err != nil {
+			return fmt.Errorf("failed restarting unit %s: %w", remediation.UnitName, err)
+		}
+
+	case nodeagentconfigv1alpha1.HealthCheckRemediationActionReboot:
+		if c.rebootRequester == nil {
+			return fmt.Errorf("cannot reboot the node since reboots cannot be requested")
+		}
+		// The reboot is coordinated with the other nodes of the worker pool like reboots required by the operating system
+		// config, i.e., the node is cordoned and drained after acquiring a reboot lease.
+		log.Info("Health check failed for too long, requesting a reboot of the node", "failureDuration", failureDuration)
+		c.recorder.Eventf(node, corev1.EventTypeWarning, c.Name(), "Health check %q failed for more than %s, requesting a reboot of the node: %s", c.Name(), failureDuration, probeErr.Error())
+		c.rebootRequester.RequestReboot()
+
+	default:
+		return fmt.Errorf("unsupported remediation action %q", remediation.Action)
+	}
+
+	c.firstFailure = nil
+	return nil
+}
+
+func (c *configurableHealthChecker) lastRemediation(filePath string) (*time.Time, error) {
+	raw, err := c.fs.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read time of last remediation from %q: %w", filePath, err)
+	}
+
+	lastRemediation, err := time.Parse(time.RFC3339, string(raw))
+	if err != nil {
+		// A corrupt file must not disable the rate limit, hence fall back to the time the file was written.
+		info, err := c.fs.Stat(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check time of last remediation from %q: %w", filePath, err)
+		}
+		lastRemediation = info.ModTime()
+	}
+	return &lastRemediation, nil
+}
+
+// updateCondition sets the condition of this health checker on the node if its status, reason or message changed.
+func (c *configurableHealthChecker) updateCondition(ctx context.Context, node *corev1.Node, status corev1.ConditionStatus, reason, message string) error {
+	var (
+		now       = metav1.NewTime(c.clock.Now())
+		patch     = client.StrategicMergeFrom(node.DeepCopy())
+		condition = corev1.NodeCondition{
+			Type:               corev1.NodeConditionType(c.config.ConditionType),
+			Status:             status,
+			LastHeartbeatTime:  now,
+			LastTransitionTime: now,
+			Reason:             reason,
+			Message:            message,
+		}
+		found bool
+	)
+
+	for i, existing := range node.Status.Conditions {
+		if existing.Type != condition.Type {
+			continue
+		}
+
+		found = true
+		if existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+			return nil
+		}
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		node.Status.Conditions[i] = condition
+	}
+
+	if !found {
+		node.Status.Conditions = append(node.Status.Conditions, condition)
+	}
+
+	if err := c.client.Status().Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed setting condition %q on node: %w", condition.Type, err)
+	}
+	return nil
+}
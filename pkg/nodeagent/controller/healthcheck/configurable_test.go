@@ -0,0 +1,323 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/healthcheck"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	"github.com/gardener/gardener/pkg/utils/test"
+)
+
+var _ = Describe("Configurable health checker", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient      client.Client
+		fakeDBus        *fakedbus.DBus
+		fakeFS          afero.Afero
+		fakeClock       *testclock.FakeClock
+		recorder        *record.FakeRecorder
+		rebootRequester *fakeRebootRequester
+
+		node   *corev1.Node
+		config nodeagentconfigv1alpha1.HealthCheckerConfig
+
+		newHealthChecker = func() HealthChecker {
+			GinkgoHelper()
+			healthChecker, err := NewConfigurableHealthChecker(fakeClient, fakeClock, fakeDBus, rebootRequester, recorder, fakeFS, config)
+			Expect(err).NotTo(HaveOccurred())
+			return healthChecker
+		}
+
+		check = func(healthChecker HealthChecker) {
+			GinkgoHelper()
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(healthChecker.Check(ctx, node.DeepCopy())).To(Succeed())
+		}
+
+		condition = func() *corev1.NodeCondition {
+			GinkgoHelper()
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == "FailedUnits" {
+					return &condition
+				}
+			}
+			return nil
+		}
+
+		expectCondition = func(status corev1.ConditionStatus, messageMatcher types.GomegaMatcher) {
+			GinkgoHelper()
+			c := condition()
+			Expect(c).NotTo(BeNil())
+			Expect(c.Status).To(Equal(status))
+			Expect(c.Message).To(messageMatcher)
+		}
+	)
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node).
+			WithStatusSubresource(&corev1.Node{}).
+			Build()
+		fakeDBus = fakedbus.New()
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		fakeClock = testclock.NewFakeClock(time.Now())
+		recorder = record.NewFakeRecorder(32)
+		rebootRequester = &fakeRebootRequester{}
+
+		config = nodeagentconfigv1alpha1.HealthCheckerConfig{
+			Name:            "failed-units",
+			ConditionType:   "FailedUnits",
+			Timeout:         &metav1.Duration{Duration: 10 * time.Second},
+			FailureDuration: &metav1.Duration{Duration: time.Minute},
+			SystemdUnits:    &nodeagentconfigv1alpha1.SystemdUnitsHealthCheck{},
+		}
+	})
+
+	It("should fail if no check is configured", func() {
+		config.SystemdUnits = nil
+
+		_, err := NewConfigurableHealthChecker(fakeClient, fakeClock, fakeDBus, rebootRequester, recorder, fakeFS, config)
+		Expect(err).To(MatchError(ContainSubstring("no check configured")))
+	})
+
+	Describe("#Check", func() {
+		It("should report the result as node condition", func() {
+			healthChecker := newHealthChecker()
+			Expect(healthChecker.Name()).To(Equal("failed-units"))
+
+			check(healthChecker)
+			expectCondition(corev1.ConditionFalse, ContainSubstring("succeeded"))
+			transitionTime := condition().LastTransitionTime
+
+			By("Do not update the condition if nothing changed")
+			fakeClock.Step(time.Minute)
+			check(healthChecker)
+			Expect(condition().LastHeartbeatTime).To(Equal(transitionTime))
+
+			By("Report the failure")
+			fakeDBus.FailedUnits = []string{"foo.service", "bar.service"}
+			check(healthChecker)
+			expectCondition(corev1.ConditionTrue, Equal("units in failed state: bar.service, foo.service"))
+			Expect(condition().Reason).To(Equal("HealthCheckFailed"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Health check \"failed-units\" failed")))
+
+			By("Report the recovery")
+			fakeDBus.FailedUnits = nil
+			check(healthChecker)
+			expectCondition(corev1.ConditionFalse, ContainSubstring("succeeded"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("succeeded")))
+			Expect(fakeDBus.Actions).To(BeEmpty())
+		})
+
+		It("should only consider the configured units", func() {
+			config.SystemdUnits.Units = []string{"bar.service"}
+			fakeDBus.FailedUnits = []string{"foo.service"}
+
+			check(newHealthChecker())
+			expectCondition(corev1.ConditionFalse, ContainSubstring("succeeded"))
+		})
+
+		It("should restart the unit if the check fails for longer than the failure duration", func() {
+			config.Remediation = &nodeagentconfigv1alpha1.HealthCheckRemediation{
+				Action:      nodeagentconfigv1alpha1.HealthCheckRemediationActionRestartUnit,
+				UnitName:    "foo.service",
+				MinInterval: &metav1.Duration{Duration: 30 * time.Minute},
+			}
+			fakeDBus.FailedUnits = []string{"foo.service"}
+			healthChecker := newHealthChecker()
+
+			check(healthChecker)
+			Expect(fakeDBus.Actions).To(BeEmpty())
+
+			fakeClock.Step(time.Minute)
+			check(healthChecker)
+			Expect(fakeDBus.Actions).To(ConsistOf(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"foo.service"}}))
+
+			By("Do not restart the unit again before the minimum interval passed")
+			fakeDBus.Actions = nil
+			check(healthChecker)
+			fakeClock.Step(time.Minute)
+			check(healthChecker)
+			Expect(fakeDBus.Actions).To(BeEmpty())
+
+			fakeClock.Step(30 * time.Minute)
+			check(healthChecker)
+			Expect(fakeDBus.Actions).To(ConsistOf(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"foo.service"}}))
+		})
+
+		It("should limit the reboots across restarts of gardener-node-agent", func() {
+			config.FailureDuration = &metav1.Duration{}
+			config.Remediation = &nodeagentconfigv1alpha1.HealthCheckRemediation{
+				Action:      nodeagentconfigv1alpha1.HealthCheckRemediationActionReboot,
+				MinInterval: &metav1.Duration{Duration: time.Hour},
+			}
+			fakeDBus.FailedUnits = []string{"foo.service"}
+
+			check(newHealthChecker())
+			Expect(rebootRequester.requests).To(Equal(1))
+
+			By("Do not reboot again after gardener-node-agent was restarted")
+			fakeClock.Step(10 * time.Minute)
+			check(newHealthChecker())
+			Expect(rebootRequester.requests).To(Equal(1))
+
+			fakeClock.Step(time.Hour)
+			check(newHealthChecker())
+			Expect(rebootRequester.requests).To(Equal(2))
+		})
+
+		It("should request the reboot instead of rebooting the node directly", func() {
+			config.FailureDuration = &metav1.Duration{}
+			config.Remediation = &nodeagentconfigv1alpha1.HealthCheckRemediation{
+				Action:      nodeagentconfigv1alpha1.HealthCheckRemediationActionReboot,
+				MinInterval: &metav1.Duration{Duration: time.Hour},
+			}
+			fakeDBus.FailedUnits = []string{"foo.service"}
+
+			check(newHealthChecker())
+			Expect(rebootRequester.requests).To(Equal(1))
+			Expect(fakeDBus.Actions).NotTo(ContainElement(fakedbus.SystemdAction{Action: fakedbus.ActionReboot, UnitNames: []string{"reboot"}}))
+			Expect(recorder.Events).To(Receive(ContainSubstring("failed")))
+			Expect(recorder.Events).To(Receive(ContainSubstring("requesting a reboot of the node")))
+		})
+
+		It("should fail to reboot the node if reboots cannot be requested", func() {
+			config.FailureDuration = &metav1.Duration{}
+			config.Remediation = &nodeagentconfigv1alpha1.HealthCheckRemediation{
+				Action:      nodeagentconfigv1alpha1.HealthCheckRemediationActionReboot,
+				MinInterval: &metav1.Duration{Duration: time.Hour},
+			}
+			fakeDBus.FailedUnits = []string{"foo.service"}
+
+			healthChecker, err := NewConfigurableHealthChecker(fakeClient, fakeClock, fakeDBus, nil, recorder, fakeFS, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(healthChecker.Check(ctx, node)).To(MatchError(ContainSubstring("reboots cannot be requested")))
+			Expect(fakeDBus.Actions).To(BeEmpty())
+		})
+
+		It("should check the disk pressure", func() {
+			config.SystemdUnits = nil
+			config.DiskPressure = &nodeagentconfigv1alpha1.DiskPressureHealthCheck{
+				Path:                    "/var/lib",
+				MaxUsedPercentage:       ptr.To[int32](90),
+				MaxUsedInodesPercentage: ptr.To[int32](80),
+			}
+
+			usage := DiskUsage{UsedBytes: 50, AvailableBytes: 50, UsedInodes: 10, TotalInodes: 100}
+			DeferCleanup(test.WithVar(&GetDiskUsage, func(path string) (DiskUsage, error) {
+				Expect(path).To(Equal("/var/lib"))
+				return usage, nil
+			}))
+			healthChecker := newHealthChecker()
+
+			check(healthChecker)
+			expectCondition(corev1.ConditionFalse, ContainSubstring("succeeded"))
+
+			usage = DiskUsage{UsedBytes: 95, AvailableBytes: 5, UsedInodes: 85, TotalInodes: 100}
+			check(healthChecker)
+			expectCondition(corev1.ConditionTrue, Equal("file system of /var/lib is under pressure: 95% of disk space used, 85% of inodes used"))
+		})
+
+		It("should check the clock skew", func() {
+			config.SystemdUnits = nil
+			config.ClockSkew = &nodeagentconfigv1alpha1.ClockSkewHealthCheck{MaxSkew: &metav1.Duration{Duration: time.Second}}
+
+			status := ClockStatus{Synchronized: true, MaxError: 100 * time.Millisecond}
+			DeferCleanup(test.WithVar(&GetClockStatus, func() (ClockStatus, error) { return status, nil }))
+			healthChecker := newHealthChecker()
+
+			check(healthChecker)
+			expectCondition(corev1.ConditionFalse, ContainSubstring("succeeded"))
+
+			status = ClockStatus{Synchronized: true, MaxError: 2 * time.Second}
+			check(healthChecker)
+			expectCondition(corev1.ConditionTrue, Equal("maximum error of system clock is 2s, which exceeds 1s"))
+
+			status = ClockStatus{}
+			check(healthChecker)
+			expectCondition(corev1.ConditionTrue, Equal("system clock is not synchronized"))
+		})
+
+		It("should check the DNS resolution", func() {
+			config.SystemdUnits = nil
+			config.DNS = &nodeagentconfigv1alpha1.DNSHealthCheck{Hostnames: []string{"foo.example.com", "bar.example.com"}}
+
+			DeferCleanup(test.WithVar(&LookupHost, func(_ context.Context, host string) ([]string, error) {
+				if host == "bar.example.com" {
+					return nil, fmt.Errorf("lookup %s: no such host", host)
+				}
+				return []string{"10.0.0.1"}, nil
+			}))
+
+			check(newHealthChecker())
+			expectCondition(corev1.ConditionTrue, Equal("resolving host names failed: lookup bar.example.com: no such host"))
+		})
+
+		It("should check an HTTP endpoint", func() {
+			statusCode := http.StatusOK
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(statusCode) }))
+			DeferCleanup(server.Close)
+
+			config.SystemdUnits = nil
+			config.HTTP = &nodeagentconfigv1alpha1.HTTPHealthCheck{URL: server.URL}
+			healthChecker := newHealthChecker()
+
+			check(healthChecker)
+			expectCondition(corev1.ConditionFalse, ContainSubstring("succeeded"))
+
+			statusCode = http.StatusInternalServerError
+			check(healthChecker)
+			expectCondition(corev1.ConditionTrue, ContainSubstring("returned status code 500"))
+		})
+
+		It("should execute a command", func() {
+			config.SystemdUnits = nil
+			config.Exec = &nodeagentconfigv1alpha1.ExecHealthCheck{Command: []string{"true"}}
+
+			check(newHealthChecker())
+			expectCondition(corev1.ConditionFalse, ContainSubstring("succeeded"))
+
+			config.Exec.Command = []string{"sh", "-c", "echo broken; exit 1"}
+			check(newHealthChecker())
+			expectCondition(corev1.ConditionTrue, And(ContainSubstring("exit status 1"), ContainSubstring("output: broken")))
+		})
+	})
+})
+
+type fakeRebootRequester struct {
+	requests int
+}
+
+func (f *fakeRebootRequester) RequestReboot() {
+	f.requests++
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener/pkg/nodeagent/debug"
+)
+
+// Result is the result of the last execution of a health checker.
+type Result struct {
+	// Name is the name of the health checker.
+	Name string `json:"name"`
+	// LastCheckTime is the time when the health checker was executed last.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+	// Healthy states whether the checked component was healthy during the last check.
+	Healthy bool `json:"healthy"`
+	// UnhealthySince is the time since when the checked component is unhealthy. It is reset after successful
+	// remediation.
+	UnhealthySince *metav1.Time `json:"unhealthySince,omitempty"`
+	// Error is the error returned by the last check, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// failureTracker is implemented by health checkers which track since when the checked component is unhealthy.
+type failureTracker interface {
+	failingSince() *time.Time
+}
+
+func (c *containerdHealthChecker) failingSince() *time.Time   { return c.firstFailure }
+func (k *KubeletHealthChecker) failingSince() *time.Time      { return k.firstFailure }
+func (c *configurableHealthChecker) failingSince() *time.Time { return c.firstFailure }
+
+// AddDebugHandlers registers the debug endpoint of this controller at the given mux. It serves the results of the last
+// executions of the health checkers.
+func (r *Reconciler) AddDebugHandlers(mux *http.ServeMux) {
+	mux.Handle(debug.PathPrefix+"/healthchecks", debug.JSONHandler(func() (any, error) {
+		r.resultsMutex.RLock()
+		defer r.resultsMutex.RUnlock()
+
+		results := make([]Result, 0, len(r.results))
+		for _, result := range r.results {
+			results = append(results, result)
+		}
+		slices.SortFunc(results, func(a, b Result) int { return cmp.Compare(a.Name, b.Name) })
+		return results, nil
+	}))
+}
+
+func (r *Reconciler) recordResult(healthChecker HealthChecker, err error) {
+	result := Result{
+		Name:          healthChecker.Name(),
+		LastCheckTime: metav1.NewTime(r.Clock.Now()),
+	}
+
+	if tracker, ok := healthChecker.(failureTracker); ok {
+		if since := tracker.failingSince(); since != nil {
+			result.UnhealthySince = &metav1.Time{Time: *since}
+		}
+	}
+	result.Healthy = err == nil && result.UnhealthySince == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	r.resultsMutex.Lock()
+	defer r.resultsMutex.Unlock()
+
+	if r.results == nil {
+		r.results = make(map[string]Result)
+	}
+	r.results[result.Name] = result
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/healthcheck"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+)
+
+var _ = Describe("Debug", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeDBus  *fakedbus.DBus
+		fakeClock *testclock.FakeClock
+
+		node       *corev1.Node
+		reconciler *Reconciler
+		mux        *http.ServeMux
+
+		results = func() []Result {
+			GinkgoHelper()
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/healthchecks", nil))
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			var results []Result
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &results)).To(Succeed())
+			return results
+		}
+	)
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+
+		fakeClient := fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node).
+			WithStatusSubresource(&corev1.Node{}).
+			Build()
+		fakeDBus = fakedbus.New()
+		fakeClock = testclock.NewFakeClock(time.Now().Truncate(time.Second))
+		recorder := record.NewFakeRecorder(32)
+
+		healthChecker, err := NewConfigurableHealthChecker(fakeClient, fakeClock, fakeDBus, nil, recorder, afero.Afero{Fs: afero.NewMemMapFs()}, nodeagentconfigv1alpha1.HealthCheckerConfig{
+			Name:            "failed-units",
+			ConditionType:   "FailedUnits",
+			Timeout:         &metav1.Duration{Duration: 10 * time.Second},
+			FailureDuration: &metav1.Duration{Duration: time.Minute},
+			SystemdUnits:    &nodeagentconfigv1alpha1.SystemdUnitsHealthCheck{},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		reconciler = &Reconciler{
+			Client:         fakeClient,
+			Recorder:       recorder,
+			DBus:           fakeDBus,
+			HealthCheckers: []HealthChecker{healthChecker, &erroringHealthChecker{}},
+			Clock:          fakeClock,
+		}
+
+		mux = http.NewServeMux()
+		reconciler.AddDebugHandlers(mux)
+	})
+
+	It("should serve the results of the last health checks", func() {
+		Expect(results()).To(BeEmpty())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(node)})
+		Expect(err).To(MatchError(ContainSubstring("fake")))
+		Expect(results()).To(Equal([]Result{
+			{Name: "erroring", LastCheckTime: metav1.NewTime(fakeClock.Now()), Error: "fake"},
+			{Name: "failed-units", LastCheckTime: metav1.NewTime(fakeClock.Now()), Healthy: true},
+		}))
+
+		By("Report since when the component is unhealthy")
+		fakeDBus.FailedUnits = []string{"foo.service"}
+		fakeClock.Step(time.Second)
+		_, _ = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(node)})
+		Expect(results()).To(ContainElement(Result{
+			Name:           "failed-units",
+			LastCheckTime:  metav1.NewTime(fakeClock.Now()),
+			UnhealthySince: &metav1.Time{Time: fakeClock.Now()},
+		}))
+	})
+})
+
+type erroringHealthChecker struct{}
+
+func (*erroringHealthChecker) Name() string { return "erroring" }
+
+func (*erroringHealthChecker) Check(context.Context, *corev1.Node) error { return errors.New("fake") }
@@ -21,3 +21,10 @@ type HealthChecker interface {
 	// Check executes the health check.
 	Check(ctx context.Context, node *corev1.Node) error
 }
+
+// RebootRequester requests reboots of the node which are coordinated with the other nodes of the worker pool, i.e., the
+// node is only rebooted after acquiring a reboot lease and after it was cordoned and drained.
+type RebootRequester interface {
+	// RequestReboot requests a reboot of the node. It does not block until the node is rebooted.
+	RequestReboot()
+}
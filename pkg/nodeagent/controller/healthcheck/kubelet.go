@@ -95,18 +95,10 @@ func (k *KubeletHealthChecker) Check(ctx context.Context, node *corev1.Node) err
 		return err
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, k.kubeletHealthEndpoint, nil)
-	if err != nil {
-		log.Error(err, "Creating request to kubelet health endpoint failed")
-		return err
-	}
-	response, err := k.httpClient.Do(request)
-	if err != nil {
-		log.Error(err, "HTTP request to kubelet health endpoint failed")
-	}
-	if err == nil && response.StatusCode == http.StatusOK {
+	err := k.CheckHealthEndpoint(ctx)
+	if err == nil {
 		if k.firstFailure != nil {
-			log.Info("Kubelet is healthy again", "statusCode", response.StatusCode)
+			log.Info("Kubelet is healthy again")
 			k.recorder.Event(node, corev1.EventTypeNormal, "kubelet", "Kubelet is healthy")
 			k.firstFailure = nil
 		}
@@ -133,6 +125,26 @@ func (k *KubeletHealthChecker) Check(ctx context.Context, node *corev1.Node) err
 	return err
 }
 
+// CheckHealthEndpoint calls the health endpoint of the kubelet and returns an error if it does not report the kubelet
+// as healthy. In contrast to Check, it neither records failures nor restarts the kubelet.
+func (k *KubeletHealthChecker) CheckHealthEndpoint(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, k.kubeletHealthEndpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed creating request to kubelet health endpoint: %w", err)
+	}
+
+	response, err := k.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("HTTP request to kubelet health endpoint failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("kubelet health endpoint returned status code %d", response.StatusCode)
+	}
+	return nil
+}
+
 // ensureNodeInternalIP restores the internalIP of the node if this was initially set but lost in the process.
 // This happens if Kubelet runs into a timeout when contacting the cloud provider API during start-up, see https://github.com/gardener/gardener/commit/1311de43a1745cbc8cf65d57c72e9ed0a2c5e586#diff-738db1352694482843441061260a6f02.
 func (k *KubeletHealthChecker) ensureNodeInternalIP(ctx context.Context, node *corev1.Node) error {
@@ -5,6 +5,9 @@
 package healthcheck_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,6 +15,7 @@ import (
 	"k8s.io/utils/clock/testing"
 
 	. "github.com/gardener/gardener/pkg/nodeagent/controller/healthcheck"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
 )
 
 var _ = Describe("Kubelet", func() {
@@ -77,4 +81,32 @@ var _ = Describe("Kubelet", func() {
 			Expect(khc.KubeletReadinessToggles).To(BeEmpty())
 		})
 	})
+
+	Describe("#CheckHealthEndpoint", func() {
+		var (
+			ctx        = context.Background()
+			statusCode int
+		)
+
+		BeforeEach(func() {
+			statusCode = http.StatusOK
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(statusCode)
+			}))
+			DeferCleanup(server.Close)
+
+			checker := NewKubeletHealthChecker(nil, clock, fakedbus.New(), nil, nil)
+			checker.SetKubeletHealthEndpoint(server.URL)
+			khc = *checker
+		})
+
+		It("should succeed if the kubelet is healthy", func() {
+			Expect(khc.CheckHealthEndpoint(ctx)).To(Succeed())
+		})
+
+		It("should fail if the kubelet is unhealthy", func() {
+			statusCode = http.StatusInternalServerError
+			Expect(khc.CheckHealthEndpoint(ctx)).To(MatchError("kubelet health endpoint returned status code 500"))
+		})
+	})
 })
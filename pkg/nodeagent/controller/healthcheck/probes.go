@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+)
+
+// maxOutputLength is the maximum length of the command output which is included in the result of an exec probe.
+const maxOutputLength = 256
+
+// DiskUsage contains the disk and inode usage of a file system.
+type DiskUsage struct {
+	// UsedBytes is the number of used bytes.
+	UsedBytes uint64
+	// AvailableBytes is the number of bytes available to unprivileged users.
+	AvailableBytes uint64
+	// UsedInodes is the number of used inodes.
+	UsedInodes uint64
+	// TotalInodes is the total number of inodes.
+	TotalInodes uint64
+}
+
+// ClockStatus contains the synchronization status of the system clock as reported by the kernel.
+type ClockStatus struct {
+	// Synchronized is true if the system clock is synchronized, e.g., via NTP.
+	Synchronized bool
+	// MaxError is the maximum error of the system clock.
+	MaxError time.Duration
+}
+
+var (
+	// GetDiskUsage returns the disk usage of the file system containing the given path. Exposed for testing.
+	GetDiskUsage = getDiskUsage
+	// GetClockStatus returns the synchronization status of the system clock. Exposed for testing.
+	GetClockStatus = getClockStatus
+	// LookupHost resolves the given host name. Exposed for testing.
+	LookupHost = net.DefaultResolver.LookupHost
+)
+
+// probeFunc checks the health of a node component. It returns an error describing the problem if it is unhealthy.
+type probeFunc func(ctx context.Context) error
+
+func newProbe(config nodeagentconfigv1alpha1.HealthCheckerConfig, dbus dbus.DBus, httpClient *http.Client) (probeFunc, error) {
+	switch {
+	case config.SystemdUnits != nil:
+		return systemdUnitsProbe(dbus, config.SystemdUnits.Units), nil
+	case config.DiskPressure != nil:
+		return diskPressureProbe(*config.DiskPressure), nil
+	case config.ClockSkew != nil:
+		return clockSkewProbe(ptr.Deref(config.ClockSkew.MaxSkew, metav1.Duration{Duration: time.Second}).Duration), nil
+	case config.DNS != nil:
+		return dnsProbe(config.DNS.Hostnames), nil
+	case config.HTTP != nil:
+		return httpProbe(httpClient, config.HTTP.URL), nil
+	case config.Exec != nil:
+		return execProbe(config.Exec.Command), nil
+	}
+	return nil, fmt.Errorf("no check configured for health checker %q", config.Name)
+}
+
+func systemdUnitsProbe(dbus dbus.DBus, unitNames []string) probeFunc {
+	return func(ctx context.Context) error {
+		failedUnits, err := dbus.ListFailedUnits(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(unitNames) > 0 {
+			failedUnits = slices.DeleteFunc(failedUnits, func(unitName string) bool { return !slices.Contains(unitNames, unitName) })
+		}
+		if len(failedUnits) > 0 {
+			slices.Sort(failedUnits)
+			return fmt.Errorf("units in failed state: %s", strings.Join(failedUnits, ", "))
+		}
+		return nil
+	}
+}
+
+func diskPressureProbe(config nodeagentconfigv1alpha1.DiskPressureHealthCheck) probeFunc {
+	return func(_ context.Context) error {
+		usage, err := GetDiskUsage(config.Path)
+		if err != nil {
+			return err
+		}
+
+		var problems []string
+		if total := usage.UsedBytes + usage.AvailableBytes; total > 0 {
+			if percentage := usage.UsedBytes * 100 / total; percentage >= uint64(ptr.Deref(config.MaxUsedPercentage, 100)) { // #nosec G115 -- percentage is validated to be between 1 and 100.
+				problems = append(problems, fmt.Sprintf("%d%% of disk space used", percentage))
+			}
+		}
+		if usage.TotalInodes > 0 {
+			if percentage := usage.UsedInodes * 100 / usage.TotalInodes; percentage >= uint64(ptr.Deref(config.MaxUsedInodesPercentage, 100)) { // #nosec G115 -- percentage is validated to be between 1 and 100.
+				problems = append(problems, fmt.Sprintf("%d%% of inodes used", percentage))
+			}
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("file system of %s is under pressure: %s", config.Path, strings.Join(problems, ", "))
+		}
+		return nil
+	}
+}
+
+func clockSkewProbe(maxSkew time.Duration) probeFunc {
+	return func(_ context.Context) error {
+		status, err := GetClockStatus()
+		if err != nil {
+			return err
+		}
+
+		if !status.Synchronized {
+			return fmt.Errorf("system clock is not synchronized")
+		}
+		if status.MaxError > maxSkew {
+			return fmt.Errorf("maximum error of system clock is %s, which exceeds %s", status.MaxError, maxSkew)
+		}
+		return nil
+	}
+}
+
+func dnsProbe(hostnames []string) probeFunc {
+	return func(ctx context.Context) error {
+		var failed []string
+		for _, hostname := range hostnames {
+			if _, err := LookupHost(ctx, hostname); err != nil {
+				failed = append(failed, err.Error())
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("resolving host names failed: %s", strings.Join(failed, "; "))
+		}
+		return nil
+	}
+}
+
+func httpProbe(httpClient *http.Client, url string) probeFunc {
+	return func(ctx context.Context) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("creating request to %s failed: %w", url, err)
+		}
+
+		response, err := httpClient.Do(request)
+		if err != nil {
+			return fmt.Errorf("request to %s failed: %w", url, err)
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("request to %s returned status code %d", url, response.StatusCode)
+		}
+		return nil
+	}
+}
+
+func execProbe(command []string) probeFunc {
+	return func(ctx context.Context) error {
+		// #nosec G204 -- The command is configured by the operator in the gardener-node-agent's component configuration.
+		output, err := exec.CommandContext(ctx, command[0], command[1:]...).CombinedOutput()
+		if err != nil {
+			out := strings.TrimSpace(string(output))
+			if len(out) > maxOutputLength {
+				out = out[:maxOutputLength] + "..."
+			}
+			if out != "" {
+				return fmt.Errorf("command %q failed: %w, output: %s", strings.Join(command, " "), err, out)
+			}
+			return fmt.Errorf("command %q failed: %w", strings.Join(command, " "), err)
+		}
+		return nil
+	}
+}
@@ -6,25 +6,38 @@ package healthcheck
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
 	"github.com/gardener/gardener/pkg/nodeagent/dbus"
 	"github.com/gardener/gardener/pkg/utils/flow"
 )
 
-// Reconciler checks for containerd and kubelet health and restarts them if required.
+// Reconciler checks for containerd and kubelet health and restarts them if required. In addition, it executes the
+// health checkers defined in the configuration.
 type Reconciler struct {
 	Client                     client.Client
+	Config                     *nodeagentconfigv1alpha1.HealthCheckControllerConfig
 	Recorder                   record.EventRecorder
 	DBus                       dbus.DBus
+	FS                         afero.Afero
 	HealthCheckers             []HealthChecker
 	HealthCheckIntervalSeconds int32
+	Clock                      clock.Clock
+	// RebootRequester is used by the health checkers defined in the configuration to request reboots of the node.
+	RebootRequester RebootRequester
+
+	resultsMutex sync.RWMutex
+	results      map[string]Result
 }
 
 // Reconcile executes all defined health checks.
@@ -41,7 +54,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	for _, healthChecker := range r.HealthCheckers {
 		f := healthChecker
 
-		taskFns = append(taskFns, func(ctx context.Context) error { return f.Check(ctx, node.DeepCopy()) })
+		taskFns = append(taskFns, func(ctx context.Context) error {
+			err := f.Check(ctx, node.DeepCopy())
+			r.recordResult(f, err)
+			return err
+		})
 	}
 
 	if err := flow.Parallel(taskFns...)(ctx); err != nil {
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func getDiskUsage(path string) (DiskUsage, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return DiskUsage{}, fmt.Errorf("unable to get file system statistics for %q: %w", path, err)
+	}
+
+	blockSize := uint64(stat.Bsize) // #nosec G115 -- block size is always positive.
+	return DiskUsage{
+		UsedBytes:      (stat.Blocks - stat.Bfree) * blockSize,
+		AvailableBytes: stat.Bavail * blockSize,
+		UsedInodes:     stat.Files - stat.Ffree,
+		TotalInodes:    stat.Files,
+	}, nil
+}
+
+func getClockStatus() (ClockStatus, error) {
+	var timex unix.Timex
+	state, err := unix.Adjtimex(&timex)
+	if err != nil {
+		return ClockStatus{}, fmt.Errorf("unable to get kernel clock status: %w", err)
+	}
+
+	return ClockStatus{
+		Synchronized: state != unix.TIME_ERROR && timex.Status&unix.STA_UNSYNC == 0,
+		MaxError:     time.Duration(timex.Maxerror) * time.Microsecond,
+	}, nil
+}
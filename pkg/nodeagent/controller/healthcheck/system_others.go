@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package healthcheck
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func getDiskUsage(_ string) (DiskUsage, error) {
+	return DiskUsage{}, fmt.Errorf("disk usage checks are not supported on %s", runtime.GOOS)
+}
+
+func getClockStatus() (ClockStatus, error) {
+	return ClockStatus{}, fmt.Errorf("clock checks are not supported on %s", runtime.GOOS)
+}
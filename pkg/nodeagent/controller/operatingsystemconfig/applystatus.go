@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+)
+
+// maxApplyStatusErrorLength is the maximum length of the error reported in the apply status to keep the size of the
+// Node annotation bounded.
+const maxApplyStatusErrorLength = 1024
+
+// applyStepError is an error which occurred in a specific step of applying an operating system config.
+type applyStepError struct {
+	step nodeagentconfigv1alpha1.OperatingSystemConfigApplyStep
+	err  error
+}
+
+func (e *applyStepError) Error() string { return e.err.Error() }
+
+func (e *applyStepError) Unwrap() error { return e.err }
+
+func withApplyStep(step nodeagentconfigv1alpha1.OperatingSystemConfigApplyStep, err error) error {
+	return &applyStepError{step: step, err: err}
+}
+
+// computeApplyStatus computes the status of the attempt to apply the operating system config with the given checksum
+// which started at the given time.
+func (r *Reconciler) computeApplyStatus(checksum string, start time.Time, applyErr error) nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus {
+	now := r.Clock.Now()
+
+	status := nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus{
+		Checksum: checksum,
+		Time:     metav1.NewTime(now),
+		Duration: metav1.Duration{Duration: now.Sub(start).Round(time.Millisecond)},
+	}
+
+	if applyErr != nil {
+		status.Error = applyErr.Error()
+		if len(status.Error) > maxApplyStatusErrorLength {
+			status.Error = status.Error[:maxApplyStatusErrorLength-3] + "..."
+		}
+
+		var stepErr *applyStepError
+		if errors.As(applyErr, &stepErr) {
+			status.FailedStep = stepErr.step
+		}
+	}
+
+	return status
+}
+
+// setApplyStatusAnnotation sets the apply status annotation on the given node. The node must be patched afterward.
+func setApplyStatusAnnotation(node *corev1.Node, status nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus) error {
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed marshalling apply status: %w", err)
+	}
+
+	metav1.SetMetaDataAnnotation(&node.ObjectMeta, nodeagentconfigv1alpha1.AnnotationKeyOperatingSystemConfigApplyStatus, string(raw))
+	return nil
+}
+
+// reportFailedApply publishes the status of the failed attempt to apply the operating system config on the node.
+func (r *Reconciler) reportFailedApply(ctx context.Context, node *corev1.Node, checksum string, start time.Time, applyErr error) error {
+	if node == nil {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	if err := setApplyStatusAnnotation(node, r.computeApplyStatus(checksum, start, applyErr)); err != nil {
+		return err
+	}
+
+	if err := r.Client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("failed reporting apply status on node: %w", err)
+	}
+	return nil
+}
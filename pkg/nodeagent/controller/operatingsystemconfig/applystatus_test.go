@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+)
+
+var _ = Describe("ApplyStatus", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient client.Client
+		fakeDBus   *fakedbus.DBus
+		fakeClock  *testclock.FakeClock
+		reconciler *Reconciler
+
+		node   *corev1.Node
+		secret *corev1.Secret
+		osc    *extensionsv1alpha1.OperatingSystemConfig
+
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Name: "osc-secret", Namespace: metav1.NamespaceSystem}}
+
+		updateSecret = func(checksum string) {
+			GinkgoHelper()
+			raw, err := yaml.Marshal(osc)
+			Expect(err).NotTo(HaveOccurred())
+
+			patch := client.MergeFrom(secret.DeepCopy())
+			secret.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig] = checksum
+			secret.Data[nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig] = raw
+			Expect(fakeClient.Patch(ctx, secret, patch)).To(Succeed())
+		}
+
+		applyStatus = func() *nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus {
+			GinkgoHelper()
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			raw, ok := node.Annotations[nodeagentconfigv1alpha1.AnnotationKeyOperatingSystemConfigApplyStatus]
+			if !ok {
+				return nil
+			}
+
+			status := &nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus{}
+			Expect(json.Unmarshal([]byte(raw), status)).To(Succeed())
+			return status
+		}
+	)
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        request.Name,
+				Namespace:   request.Namespace,
+				Annotations: map[string]string{},
+			},
+			Data: map[string][]byte{},
+		}
+		osc = &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Units: []extensionsv1alpha1.Unit{{
+					Name:    "foo.service",
+					Command: ptr.To(extensionsv1alpha1.CommandRestart),
+					Enable:  ptr.To(true),
+					Content: ptr.To("foo"),
+				}},
+			},
+		}
+
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node, secret).
+			WithStatusSubresource(&corev1.Node{}).
+			Build()
+		fakeDBus = fakedbus.New()
+		fakeClock = testclock.NewFakeClock(time.Now().Truncate(time.Second))
+
+		reconciler = &Reconciler{
+			Client: fakeClient,
+			Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+				SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+				KubernetesVersion: semver.MustParse("1.31.1"),
+			},
+			Recorder:      record.NewFakeRecorder(32),
+			DBus:          fakeDBus,
+			FS:            afero.Afero{Fs: afero.NewMemMapFs()},
+			CancelContext: func() {},
+			NodeName:      node.Name,
+			Clock:         fakeClock,
+		}
+	})
+
+	It("should publish the status of the failed and successful attempts on the node", func() {
+		updateSecret("foo")
+		fakeDBus.InjectRestartFailure(fmt.Errorf("injected failure"), "foo.service")
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).To(MatchError(ContainSubstring("injected failure")))
+
+		status := applyStatus()
+		Expect(status).NotTo(BeNil())
+		Expect(status.Checksum).To(Equal("foo"))
+		Expect(status.Time.Time).To(Equal(fakeClock.Now()))
+		Expect(status.FailedStep).To(Equal(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepUnitCommands))
+		Expect(status.Error).To(ContainSubstring("injected failure"))
+		Expect(node.Annotations).NotTo(HaveKey(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig))
+
+		By("Report the successful attempt")
+		fakeDBus.InjectRestartFailure(nil, "foo.service")
+		fakeClock.Step(time.Minute)
+
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(applyStatus()).To(Equal(&nodeagentconfigv1alpha1.OperatingSystemConfigApplyStatus{
+			Checksum: "foo",
+			Time:     metav1.NewTime(fakeClock.Now()),
+		}))
+		Expect(node.Annotations).To(HaveKeyWithValue(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, "foo"))
+	})
+})
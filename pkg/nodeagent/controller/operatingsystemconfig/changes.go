@@ -22,6 +22,7 @@ import (
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
 	componentscontainerd "github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/containerd"
+	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/kubelet"
 	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
 )
 
@@ -48,11 +49,6 @@ func extractOSCFromSecret(secret *corev1.Secret) (*extensionsv1alpha1.OperatingS
 }
 
 func computeOperatingSystemConfigChanges(log logr.Logger, fs afero.Afero, newOSC *extensionsv1alpha1.OperatingSystemConfig, newOSCChecksum string) (*operatingSystemConfigChanges, error) {
-	changes := &operatingSystemConfigChanges{
-		fs:                            fs,
-		OperatingSystemConfigChecksum: newOSCChecksum,
-	}
-
 	oldChanges, err := loadOSCChanges(fs)
 	if err != nil {
 		if !errors.Is(err, afero.ErrFileNotFound) {
@@ -62,7 +58,7 @@ func computeOperatingSystemConfigChanges(log logr.Logger, fs afero.Afero, newOSC
 		oldChanges = &operatingSystemConfigChanges{}
 	}
 
-	if oldChanges.OperatingSystemConfigChecksum == changes.OperatingSystemConfigChecksum {
+	if oldChanges.OperatingSystemConfigChecksum == newOSCChecksum {
 		log.Info("Found previously computed OperatingSystemConfig changes on disk, remaining work",
 			"changedFiles", len(oldChanges.Files.Changed),
 			"deletedFiles", len(oldChanges.Files.Deleted),
@@ -76,6 +72,36 @@ func computeOperatingSystemConfigChanges(log logr.Logger, fs afero.Afero, newOSC
 
 	log.Info("OperatingSystemConfig changes checksum does not match, computing new changes")
 
+	changes, err := calculateOperatingSystemConfigChanges(fs, newOSC, newOSCChecksum)
+	if err != nil {
+		return nil, err
+	}
+
+	// A reboot which was required by the previous changes but not completed yet must not get lost, otherwise the node
+	// would neither be rebooted nor would it release its reboot lease or be uncordoned.
+	if oldChanges.Reboot.Required {
+		changes.Reboot = oldChanges.Reboot
+	}
+	// The same applies to an in-place update of the Kubernetes version. The kubelet must become healthy after the new
+	// changes were applied, hence the apply time is reset.
+	if oldChanges.InPlaceUpdate.Required {
+		changes.InPlaceUpdate = oldChanges.InPlaceUpdate
+		changes.InPlaceUpdate.ApplyTime = nil
+	}
+
+	changes.lock.Lock()
+	defer changes.lock.Unlock()
+	return changes, changes.persist()
+}
+
+// calculateOperatingSystemConfigChanges computes the changes between the last applied OSC on disk and the given new OSC.
+// In contrast to computeOperatingSystemConfigChanges, it neither considers nor persists previously computed changes.
+func calculateOperatingSystemConfigChanges(fs afero.Afero, newOSC *extensionsv1alpha1.OperatingSystemConfig, newOSCChecksum string) (*operatingSystemConfigChanges, error) {
+	changes := &operatingSystemConfigChanges{
+		fs:                            fs,
+		OperatingSystemConfigChecksum: newOSCChecksum,
+	}
+
 	// create copy so that we don't accidentally update the `newOSC` when items are removed from the
 	// `operatingSystemConfigChanges` when they are marked as completed.
 	newOSC = newOSC.DeepCopy()
@@ -118,9 +144,7 @@ func computeOperatingSystemConfigChanges(log logr.Logger, fs afero.Afero, newOSC
 		if extensionsv1alpha1helper.HasContainerdConfiguration(newOSC.Spec.CRIConfig) {
 			changes.Containerd.Registries.Desired = newOSC.Spec.CRIConfig.Containerd.Registries
 		}
-		changes.lock.Lock()
-		defer changes.lock.Unlock()
-		return changes, changes.persist()
+		return changes, nil
 	}
 
 	oldOSC := &extensionsv1alpha1.OperatingSystemConfig{}
@@ -162,10 +186,42 @@ func computeOperatingSystemConfigChanges(log logr.Logger, fs afero.Afero, newOSC
 		}
 	}
 	changes.Containerd.Registries = computeContainerdRegistryDiffs(newRegistries, oldRegistries)
+	changes.Reboot.Required = requiresReboot(changes)
+	changes.InPlaceUpdate.Required = requiresInPlaceUpdate(changes)
 
-	changes.lock.Lock()
-	defer changes.lock.Unlock()
-	return changes, changes.persist()
+	return changes, nil
+}
+
+// requiresReboot returns true if any of the changed or deleted files or units requires a reboot of the node. Changes
+// on new nodes (i.e., without a last applied OSC) never require a reboot.
+func requiresReboot(changes *operatingSystemConfigChanges) bool {
+	for _, file := range slices.Concat(changes.Files.Changed, changes.Files.Deleted) {
+		if ptr.Deref(file.RequiresReboot, false) {
+			return true
+		}
+	}
+
+	for _, unit := range changes.Units.Changed {
+		if ptr.Deref(unit.RequiresReboot, false) {
+			return true
+		}
+	}
+
+	for _, unit := range changes.Units.Deleted {
+		if ptr.Deref(unit.RequiresReboot, false) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requiresInPlaceUpdate returns true if the kubelet binary changes, i.e., if the Kubernetes version of the node is
+// updated. Changes on new nodes (i.e., without a last applied OSC) never require an in-place update.
+func requiresInPlaceUpdate(changes *operatingSystemConfigChanges) bool {
+	return slices.ContainsFunc(changes.Files.Changed, func(file extensionsv1alpha1.File) bool {
+		return file.Path == kubelet.PathKubeletBinary
+	})
 }
 
 // TODO(timuthy): Remove this block after Gardener v1.114 was released.
@@ -58,6 +58,14 @@ func (r *Reconciler) ReconcileContainerdConfig(ctx context.Context, log logr.Log
 	return nil
 }
 
+// registriesOf returns the registries configured for containerd in the given operating system config.
+func registriesOf(osc *extensionsv1alpha1.OperatingSystemConfig) []extensionsv1alpha1.RegistryConfig {
+	if !extensionsv1alpha1helper.HasContainerdConfiguration(osc.Spec.CRIConfig) {
+		return nil
+	}
+	return osc.Spec.CRIConfig.Containerd.Registries
+}
+
 // ReconcileContainerdRegistries configures desired registries for containerd and cleans up abandoned ones.
 // Registries without readiness probes are added synchronously and related errors are returned immediately.
 // Registries with configured readiness probes are added asynchronously and must be waited for by invoking the returned function.
@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
+	"github.com/gardener/gardener/pkg/nodeagent/debug"
+	"github.com/gardener/gardener/pkg/utils"
+)
+
+// UnitCommandResult is the result of the last command executed for a unit.
+type UnitCommandResult struct {
+	// Name is the name of the unit.
+	Name string `json:"name"`
+	// Command is the executed command.
+	Command extensionsv1alpha1.UnitCommand `json:"command"`
+	// Time is the time when the command was executed.
+	Time metav1.Time `json:"time"`
+	// Error is the error returned by the command, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// FileSummary is the representation of a file in the debug endpoints. The content is not served since it might
+// contain credentials, only its hash.
+type FileSummary struct {
+	// Path is the path of the file.
+	Path string `json:"path"`
+	// Permissions are the permissions of the file.
+	Permissions *uint32 `json:"permissions,omitempty"`
+	// ContentHash is the SHA-256 hash of the decoded inline content of the file.
+	ContentHash string `json:"contentHash,omitempty"`
+	// Image is the image the file is copied from.
+	Image string `json:"image,omitempty"`
+}
+
+// UnitSummary is the representation of a unit in the debug endpoints. The content of the unit and its drop-ins is not
+// served since it might contain credentials, only its hash.
+type UnitSummary struct {
+	// Name is the name of the unit.
+	Name string `json:"name"`
+	// Enable specifies whether the unit is enabled.
+	Enable *bool `json:"enable,omitempty"`
+	// Command is the command which is executed for the unit.
+	Command *extensionsv1alpha1.UnitCommand `json:"command,omitempty"`
+	// ContentHash is the SHA-256 hash of the content of the unit.
+	ContentHash string `json:"contentHash,omitempty"`
+	// DropIns are the drop-ins of the unit.
+	DropIns []DropInSummary `json:"dropIns,omitempty"`
+	// FilePaths are the paths of the files the unit depends on.
+	FilePaths []string `json:"filePaths,omitempty"`
+}
+
+// DropInSummary is the representation of a drop-in of a unit in the debug endpoints.
+type DropInSummary struct {
+	// Name is the name of the drop-in.
+	Name string `json:"name"`
+	// ContentHash is the SHA-256 hash of the content of the drop-in.
+	ContentHash string `json:"contentHash"`
+}
+
+// OperatingSystemConfigSummary is the representation of the last applied operating system config in the debug
+// endpoints.
+type OperatingSystemConfigSummary struct {
+	// Files are the files of the operating system config, including the ones added by extensions.
+	Files []FileSummary `json:"files"`
+	// Units are the units of the operating system config, including the ones added by extensions.
+	Units []UnitSummary `json:"units"`
+}
+
+// ChangesSummary is the representation of the last computed changes of the operating system config in the debug
+// endpoints. Changes which are already applied are not contained.
+type ChangesSummary struct {
+	// OperatingSystemConfigChecksum is the checksum of the operating system config the changes were computed for.
+	OperatingSystemConfigChecksum string `json:"operatingSystemConfigChecksum"`
+	// ChangedFiles are the files which are still to be written.
+	ChangedFiles []FileSummary `json:"changedFiles,omitempty"`
+	// DeletedFiles are the paths of the files which are still to be deleted.
+	DeletedFiles []string `json:"deletedFiles,omitempty"`
+	// ChangedUnits are the units which are still to be written.
+	ChangedUnits []UnitSummary `json:"changedUnits,omitempty"`
+	// DeletedUnits are the names of the units which are still to be deleted.
+	DeletedUnits []string `json:"deletedUnits,omitempty"`
+	// UnitCommands are the commands which are still to be executed for the units, keyed by the unit names.
+	UnitCommands map[string]extensionsv1alpha1.UnitCommand `json:"unitCommands,omitempty"`
+	// DesiredRegistries are the upstreams of the containerd registries which are still to be configured.
+	DesiredRegistries []string `json:"desiredRegistries,omitempty"`
+	// DeletedRegistries are the upstreams of the containerd registries which are still to be removed.
+	DeletedRegistries []string `json:"deletedRegistries,omitempty"`
+	// MustRestartNodeAgent is true if gardener-node-agent must restart itself to apply the changes.
+	MustRestartNodeAgent bool `json:"mustRestartNodeAgent,omitempty"`
+	// FailedAttempts is the number of failed attempts to apply the changes.
+	FailedAttempts int32 `json:"failedAttempts,omitempty"`
+	// RolledBack is true if the changes were rolled back after too many failed attempts.
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// RebootRequired is true if the node must be rebooted to complete the changes.
+	RebootRequired bool `json:"rebootRequired,omitempty"`
+	// InPlaceUpdateRequired is true if the changes update the Kubernetes version of the node in-place.
+	InPlaceUpdateRequired bool `json:"inPlaceUpdateRequired,omitempty"`
+}
+
+// AddDebugHandlers registers the debug endpoints of this controller at the given mux. They serve the files and units
+// of the last applied operating system config, the last computed changes which are (partly) still pending if the last
+// reconciliation failed, and the results of the last commands executed for the units. The contents of files and units
+// are not served, only their hashes.
+func (r *Reconciler) AddDebugHandlers(mux *http.ServeMux) {
+	mux.Handle(debug.PathPrefix+"/operatingsystemconfig/last-applied", debug.JSONHandler(r.lastAppliedOperatingSystemConfigSummary))
+	mux.Handle(debug.PathPrefix+"/operatingsystemconfig/changes", debug.JSONHandler(r.changesSummary))
+	mux.Handle(debug.PathPrefix+"/operatingsystemconfig/units", debug.JSONHandler(func() (any, error) {
+		results := []UnitCommandResult{}
+		r.unitCommandResults.Range(func(_, value any) bool {
+			results = append(results, value.(UnitCommandResult))
+			return true
+		})
+		slices.SortFunc(results, func(a, b UnitCommandResult) int { return cmp.Compare(a.Name, b.Name) })
+		return results, nil
+	}))
+}
+
+func (r *Reconciler) lastAppliedOperatingSystemConfigSummary() (any, error) {
+	oscRaw, err := r.FS.ReadFile(lastAppliedOperatingSystemConfigFilePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, fmt.Errorf("last applied operating system config: %w", debug.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed reading last applied operating system config: %w", err)
+	}
+
+	osc := &extensionsv1alpha1.OperatingSystemConfig{}
+	if err := runtime.DecodeInto(decoder, oscRaw, osc); err != nil {
+		return nil, fmt.Errorf("failed decoding last applied operating system config: %w", err)
+	}
+
+	summary := OperatingSystemConfigSummary{Files: []FileSummary{}, Units: []UnitSummary{}}
+	for _, file := range collectAllFiles(osc) {
+		fileSummary, err := summarizeFile(file)
+		if err != nil {
+			return nil, err
+		}
+		summary.Files = append(summary.Files, fileSummary)
+	}
+	for _, unit := range mergeUnits(osc.Spec.Units, osc.Status.ExtensionUnits) {
+		summary.Units = append(summary.Units, summarizeUnit(unit))
+	}
+	return summary, nil
+}
+
+func (r *Reconciler) changesSummary() (any, error) {
+	changes, err := loadOSCChanges(r.FS)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, fmt.Errorf("operating system config changes: %w", debug.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	summary := ChangesSummary{
+		OperatingSystemConfigChecksum: changes.OperatingSystemConfigChecksum,
+		MustRestartNodeAgent:          changes.MustRestartNodeAgent,
+		FailedAttempts:                changes.FailedAttempts,
+		RolledBack:                    changes.RolledBack,
+		RebootRequired:                changes.Reboot.Required,
+		InPlaceUpdateRequired:         changes.InPlaceUpdate.Required,
+	}
+
+	for _, file := range changes.Files.Changed {
+		fileSummary, err := summarizeFile(file)
+		if err != nil {
+			return nil, err
+		}
+		summary.ChangedFiles = append(summary.ChangedFiles, fileSummary)
+	}
+	for _, file := range changes.Files.Deleted {
+		summary.DeletedFiles = append(summary.DeletedFiles, file.Path)
+	}
+	for _, unit := range changes.Units.Changed {
+		summary.ChangedUnits = append(summary.ChangedUnits, summarizeUnit(unit.Unit))
+	}
+	for _, unit := range changes.Units.Deleted {
+		summary.DeletedUnits = append(summary.DeletedUnits, unit.Name)
+	}
+	for _, command := range changes.Units.Commands {
+		if summary.UnitCommands == nil {
+			summary.UnitCommands = make(map[string]extensionsv1alpha1.UnitCommand)
+		}
+		summary.UnitCommands[command.Name] = command.Command
+	}
+	for _, registry := range changes.Containerd.Registries.Desired {
+		summary.DesiredRegistries = append(summary.DesiredRegistries, registry.Upstream)
+	}
+	for _, registry := range changes.Containerd.Registries.Deleted {
+		summary.DeletedRegistries = append(summary.DeletedRegistries, registry.Upstream)
+	}
+
+	return summary, nil
+}
+
+func summarizeFile(file extensionsv1alpha1.File) (FileSummary, error) {
+	summary := FileSummary{Path: file.Path, Permissions: file.Permissions}
+	if file.Content.Inline != nil {
+		data, err := extensionsv1alpha1helper.Decode(file.Content.Inline.Encoding, []byte(file.Content.Inline.Data))
+		if err != nil {
+			return FileSummary{}, fmt.Errorf("failed decoding content of file %q: %w", file.Path, err)
+		}
+		summary.ContentHash = utils.ComputeSHA256Hex(data)
+	}
+	if file.Content.ImageRef != nil {
+		summary.Image = file.Content.ImageRef.Image
+	}
+	return summary, nil
+}
+
+func summarizeUnit(unit extensionsv1alpha1.Unit) UnitSummary {
+	summary := UnitSummary{Name: unit.Name, Enable: unit.Enable, Command: unit.Command, FilePaths: unit.FilePaths}
+	if unit.Content != nil {
+		summary.ContentHash = utils.ComputeSHA256Hex([]byte(*unit.Content))
+	}
+	for _, dropIn := range unit.DropIns {
+		summary.DropIns = append(summary.DropIns, DropInSummary{Name: dropIn.Name, ContentHash: utils.ComputeSHA256Hex([]byte(dropIn.Content))})
+	}
+	return summary
+}
+
+func (r *Reconciler) recordUnitCommandResult(unitName string, command extensionsv1alpha1.UnitCommand, err error) {
+	result := UnitCommandResult{Name: unitName, Command: command, Time: metav1.NewTime(r.Clock.Now())}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	r.unitCommandResults.Store(unitName, result)
+}
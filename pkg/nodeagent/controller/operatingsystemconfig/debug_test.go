@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	"github.com/gardener/gardener/pkg/utils"
+)
+
+var _ = Describe("Debug", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient client.Client
+		fakeDBus   *fakedbus.DBus
+		fakeClock  *testclock.FakeClock
+		reconciler *Reconciler
+		mux        *http.ServeMux
+
+		secret *corev1.Secret
+		osc    *extensionsv1alpha1.OperatingSystemConfig
+
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Name: "osc-secret", Namespace: metav1.NamespaceSystem}}
+
+		updateSecret = func(checksum string) {
+			GinkgoHelper()
+			raw, err := yaml.Marshal(osc)
+			Expect(err).NotTo(HaveOccurred())
+
+			patch := client.MergeFrom(secret.DeepCopy())
+			secret.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig] = checksum
+			secret.Data[nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig] = raw
+			Expect(fakeClient.Patch(ctx, secret, patch)).To(Succeed())
+		}
+
+		get = func(path string, statusCode int, into any) string {
+			GinkgoHelper()
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			Expect(recorder.Code).To(Equal(statusCode))
+			if into != nil {
+				Expect(json.Unmarshal(recorder.Body.Bytes(), into)).To(Succeed())
+			}
+			return recorder.Body.String()
+		}
+	)
+
+	BeforeEach(func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        request.Name,
+				Namespace:   request.Namespace,
+				Annotations: map[string]string{},
+			},
+			Data: map[string][]byte{},
+		}
+		osc = &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Units: []extensionsv1alpha1.Unit{{
+					Name:      "foo.service",
+					Command:   ptr.To(extensionsv1alpha1.CommandRestart),
+					Enable:    ptr.To(true),
+					Content:   ptr.To("foo"),
+					FilePaths: []string{"/var/lib/foo/credentials"},
+				}},
+				Files: []extensionsv1alpha1.File{{
+					Path:        "/var/lib/foo/credentials",
+					Permissions: ptr.To[uint32](0600),
+					Content:     extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "top-secret"}},
+				}, {
+					Path:    "/var/lib/foo/config",
+					Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Encoding: "b64", Data: base64.StdEncoding.EncodeToString([]byte("config"))}},
+				}},
+			},
+		}
+
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node, secret).
+			WithStatusSubresource(&corev1.Node{}).
+			Build()
+		fakeDBus = fakedbus.New()
+		fakeClock = testclock.NewFakeClock(time.Now().Truncate(time.Second))
+
+		reconciler = &Reconciler{
+			Client: fakeClient,
+			Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+				SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+				KubernetesVersion: semver.MustParse("1.31.1"),
+			},
+			Recorder:      record.NewFakeRecorder(32),
+			DBus:          fakeDBus,
+			FS:            afero.Afero{Fs: afero.NewMemMapFs()},
+			CancelContext: func() {},
+			NodeName:      node.Name,
+			Clock:         fakeClock,
+		}
+
+		mux = http.NewServeMux()
+		reconciler.AddDebugHandlers(mux)
+	})
+
+	It("should serve the last applied operating system config, the changes, and the unit command results without contents", func() {
+		get("/debug/operatingsystemconfig/last-applied", http.StatusNotFound, nil)
+		get("/debug/operatingsystemconfig/changes", http.StatusNotFound, nil)
+
+		var units []UnitCommandResult
+		get("/debug/operatingsystemconfig/units", http.StatusOK, &units)
+		Expect(units).To(BeEmpty())
+
+		updateSecret("foo")
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(get("/debug/operatingsystemconfig/last-applied", http.StatusOK, nil)).NotTo(ContainSubstring("top-secret"))
+		lastApplied := &OperatingSystemConfigSummary{}
+		get("/debug/operatingsystemconfig/last-applied", http.StatusOK, lastApplied)
+		Expect(lastApplied.Files).To(ConsistOf(FileSummary{
+			Path:        "/var/lib/foo/credentials",
+			Permissions: ptr.To[uint32](0600),
+			ContentHash: utils.ComputeSHA256Hex([]byte("top-secret")),
+		}, FileSummary{
+			Path:        "/var/lib/foo/config",
+			ContentHash: utils.ComputeSHA256Hex([]byte("config")),
+		}))
+		Expect(lastApplied.Units).To(ConsistOf(UnitSummary{
+			Name:        "foo.service",
+			Enable:      ptr.To(true),
+			Command:     ptr.To(extensionsv1alpha1.CommandRestart),
+			ContentHash: utils.ComputeSHA256Hex([]byte("foo")),
+			FilePaths:   []string{"/var/lib/foo/credentials"},
+		}))
+
+		get("/debug/operatingsystemconfig/units", http.StatusOK, &units)
+		Expect(units).To(ContainElement(UnitCommandResult{
+			Name:    "foo.service",
+			Command: extensionsv1alpha1.CommandRestart,
+			Time:    metav1.NewTime(fakeClock.Now()),
+		}))
+
+		By("Report the failure of unit commands and the pending changes")
+		fakeClock.Step(time.Minute)
+		osc.Spec.Units[0].Content = ptr.To("new-foo")
+		osc.Spec.Files[0].Content.Inline.Data = "new-top-secret"
+		updateSecret("new-foo")
+		fakeDBus.InjectRestartFailure(fmt.Errorf("injected failure"), "foo.service")
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).To(MatchError(ContainSubstring("injected failure")))
+
+		get("/debug/operatingsystemconfig/units", http.StatusOK, &units)
+		Expect(units).To(ContainElement(UnitCommandResult{
+			Name:    "foo.service",
+			Command: extensionsv1alpha1.CommandRestart,
+			Time:    metav1.NewTime(fakeClock.Now()),
+			Error:   "injected failure",
+		}))
+
+		Expect(get("/debug/operatingsystemconfig/changes", http.StatusOK, nil)).NotTo(ContainSubstring("top-secret"))
+		changes := &ChangesSummary{}
+		get("/debug/operatingsystemconfig/changes", http.StatusOK, changes)
+		Expect(changes.OperatingSystemConfigChecksum).To(Equal("new-foo"))
+		Expect(changes.UnitCommands).To(HaveKeyWithValue("foo.service", extensionsv1alpha1.CommandRestart))
+	})
+})
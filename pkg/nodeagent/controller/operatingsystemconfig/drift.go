@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/metrics"
+)
+
+const (
+	driftKindFile   = "file"
+	driftKindUnit   = "unit"
+	driftKindDropIn = "drop-in"
+
+	// maxDriftedPathsInMessage is the maximum number of drifted paths listed in the message of the node condition.
+	maxDriftedPathsInMessage = 10
+)
+
+// drift describes a file or unit on the node which differs from the last applied operating system config.
+type drift struct {
+	// Kind is the kind of the drifted item, i.e. file, unit or drop-in.
+	Kind string
+	// Path is the path of the drifted item on the node.
+	Path string
+	// Reason describes how the item drifted.
+	Reason string
+	// File is the drifted file, if Kind is file.
+	File *extensionsv1alpha1.File
+	// Unit is the drifted unit (or the unit of the drifted drop-in), if Kind is unit or drop-in.
+	Unit *extensionsv1alpha1.Unit
+}
+
+func (d drift) String() string {
+	return fmt.Sprintf("%s (%s)", d.Path, d.Reason)
+}
+
+// detectDrift compares the files and units on the node with the given operating system config. The content of files
+// and units is compared by their hashes. Files from container images are only checked for existence and permissions
+// since their content is not part of the operating system config.
+func (r *Reconciler) detectDrift(osc *extensionsv1alpha1.OperatingSystemConfig) ([]drift, error) {
+	var drifts []drift
+
+	for _, file := range collectAllFiles(osc) {
+		var desiredContent []byte
+		if file.Content.Inline != nil {
+			data, err := extensionsv1alpha1helper.Decode(file.Content.Inline.Encoding, []byte(file.Content.Inline.Data))
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode data of file %q: %w", file.Path, err)
+			}
+			desiredContent = data
+		}
+
+		reason, err := r.fileDrift(file.Path, desiredContent, file.Content.Inline != nil, getFilePermissions(file))
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			drifts = append(drifts, drift{Kind: driftKindFile, Path: file.Path, Reason: reason, File: &file})
+		}
+	}
+
+	for _, unit := range mergeUnits(osc.Spec.Units, osc.Status.ExtensionUnits) {
+		unitFilePath := path.Join(etcSystemdSystem, unit.Name)
+
+		if unit.Content != nil {
+			reason, err := r.fileDrift(unitFilePath, []byte(*unit.Content), true, defaultFilePermissions)
+			if err != nil {
+				return nil, err
+			}
+			if reason != "" {
+				drifts = append(drifts, drift{Kind: driftKindUnit, Path: unitFilePath, Reason: reason, Unit: &unit})
+			}
+		}
+
+		for _, dropIn := range unit.DropIns {
+			dropInFilePath := path.Join(unitFilePath+".d", dropIn.Name)
+			reason, err := r.fileDrift(dropInFilePath, []byte(dropIn.Content), true, defaultFilePermissions)
+			if err != nil {
+				return nil, err
+			}
+			if reason != "" {
+				drifts = append(drifts, drift{Kind: driftKindDropIn, Path: dropInFilePath, Reason: reason, Unit: &unit})
+			}
+		}
+	}
+
+	return drifts, nil
+}
+
+// fileDrift returns a reason if the file at the given path does not exist, has different permissions or (if
+// compareContent is true) a different content than desired. It returns an empty string if the file did not drift.
+func (r *Reconciler) fileDrift(filePath string, desiredContent []byte, compareContent bool, desiredPermissions os.FileMode) (string, error) {
+	info, err := r.FS.Stat(filePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return "missing", nil
+		}
+		return "", fmt.Errorf("unable to check file %q: %w", filePath, err)
+	}
+
+	if compareContent {
+		content, err := r.FS.ReadFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("unable to read file %q: %w", filePath, err)
+		}
+
+		if actual, desired := sha256.Sum256(content), sha256.Sum256(desiredContent); !bytes.Equal(actual[:], desired[:]) {
+			return "content changed", nil
+		}
+	}
+
+	if info.Mode().Perm() != desiredPermissions {
+		return fmt.Sprintf("permissions changed from %04o to %04o", desiredPermissions, info.Mode().Perm()), nil
+	}
+
+	return "", nil
+}
+
+// reconcileDrift checks the files and units on the node for drifts from the last applied operating system config,
+// reports them via the node condition and metrics, and re-applies them if remediation is enabled.
+func (r *Reconciler) reconcileDrift(ctx context.Context, log logr.Logger, node *corev1.Node) (reconcile.Result, error) {
+	result := reconcile.Result{RequeueAfter: r.Config.DriftDetection.Period.Duration}
+
+	oscRaw, err := r.FS.ReadFile(lastAppliedOperatingSystemConfigFilePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			log.Info("No last applied OperatingSystemConfig found, skipping drift detection")
+			return result, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error reading last applied OSC from file path %s: %w", lastAppliedOperatingSystemConfigFilePath, err)
+	}
+
+	osc := &extensionsv1alpha1.OperatingSystemConfig{}
+	if err := runtime.DecodeInto(decoder, oscRaw, osc); err != nil {
+		return reconcile.Result{}, fmt.Errorf("unable to decode the last applied OSC read from file path %s: %w", lastAppliedOperatingSystemConfigFilePath, err)
+	}
+
+	drifts, err := r.detectDrift(osc)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed detecting drift: %w", err)
+	}
+
+	metrics.OperatingSystemConfigDrift.Reset()
+	for _, d := range drifts {
+		metrics.OperatingSystemConfigDrift.WithLabelValues(d.Kind, d.Path).Set(1)
+	}
+
+	if len(drifts) == 0 {
+		log.Info("Configuration on this node is up to date and did not drift, nothing to be done")
+		return result, r.setNodeCondition(ctx, node, nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigDrifted, corev1.ConditionFalse, "NoDrift", "Files and units match the applied operating system config")
+	}
+
+	log.Info("Detected drift of files or units from the last applied OperatingSystemConfig", "drifts", driftStrings(drifts))
+
+	if !ptr.Deref(r.Config.DriftDetection.Remediate, false) {
+		return result, r.setNodeCondition(ctx, node, nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigDrifted, corev1.ConditionTrue, "DriftDetected", driftMessage("Files or units drifted from the applied operating system config", drifts))
+	}
+
+	log.Info("Re-applying drifted files and units")
+	mustRestartNodeAgent, err := r.remediateDrift(ctx, log, node, osc, drifts)
+	if err != nil {
+		metrics.OperatingSystemConfigDriftRemediations.WithLabelValues("error").Inc()
+		return reconcile.Result{}, errors.Join(
+			fmt.Errorf("failed re-applying drifted files and units: %w", err),
+			r.setNodeCondition(ctx, node, nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigDrifted, corev1.ConditionTrue, "RemediationFailed", driftMessage(fmt.Sprintf("Re-applying drifted files or units failed: %v", err), drifts)),
+		)
+	}
+	metrics.OperatingSystemConfigDriftRemediations.WithLabelValues("success").Inc()
+	metrics.OperatingSystemConfigDrift.Reset()
+
+	r.Recorder.Event(node, corev1.EventTypeNormal, "OSCDriftRemediated", driftMessage("Re-applied files or units which drifted from the applied operating system config", drifts))
+	if err := r.setNodeCondition(ctx, node, nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigDrifted, corev1.ConditionFalse, "DriftRemediated", driftMessage("Re-applied drifted files or units", drifts)); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if mustRestartNodeAgent {
+		log.Info("Must restart myself (gardener-node-agent unit) after re-applying its unit, canceling the context to initiate graceful shutdown")
+		r.CancelContext()
+		return reconcile.Result{}, nil
+	}
+
+	return result, nil
+}
+
+// remediateDrift re-applies the drifted files and units and restarts or stops the affected units. The returned bool is
+// true if gardener-node-agent must be restarted.
+func (r *Reconciler) remediateDrift(ctx context.Context, log logr.Logger, node *corev1.Node, osc *extensionsv1alpha1.OperatingSystemConfig, drifts []drift) (bool, error) {
+	// The changes are only used to drive the regular apply functions, hence they must not be persisted on disk to keep
+	// the state of the last computed changes untouched.
+	changes := &operatingSystemConfigChanges{fs: afero.Afero{Fs: afero.NewMemMapFs()}}
+
+	var (
+		driftedFilePaths = sets.New[string]()
+		units            = make(map[string]extensionsv1alpha1.Unit)
+	)
+
+	for _, d := range drifts {
+		switch d.Kind {
+		case driftKindFile:
+			changes.Files.Changed = append(changes.Files.Changed, *d.File)
+			driftedFilePaths.Insert(d.File.Path)
+		case driftKindUnit, driftKindDropIn:
+			units[d.Unit.Name] = *d.Unit
+		}
+	}
+
+	// Units referring to drifted files must be restarted, too.
+	for _, unit := range mergeUnits(osc.Spec.Units, osc.Status.ExtensionUnits) {
+		if slices.ContainsFunc(unit.FilePaths, driftedFilePaths.Has) {
+			if _, ok := units[unit.Name]; !ok {
+				changes.Units.Commands = append(changes.Units.Commands, unitCommand{Name: unit.Name, Command: getCommandToExecute(unit)})
+			}
+		}
+	}
+
+	for _, name := range sets.List(sets.KeySet(units)) {
+		unit := units[name]
+		changes.Units.Changed = append(changes.Units.Changed, changedUnit{Unit: unit, DropInsChanges: dropIns{Changed: unit.DropIns}})
+		changes.Units.Commands = append(changes.Units.Commands, unitCommand{Name: unit.Name, Command: getCommandToExecute(unit)})
+	}
+
+	if err := r.applyChangedInlineFiles(log, changes); err != nil {
+		return false, fmt.Errorf("failed applying drifted inline files: %w", err)
+	}
+	if err := r.applyChangedImageRefFiles(ctx, log, changes, registriesOf(osc)); err != nil {
+		return false, fmt.Errorf("failed applying drifted imageRef files: %w", err)
+	}
+	if err := r.applyChangedUnits(ctx, log, changes); err != nil {
+		return false, fmt.Errorf("failed applying drifted units: %w", err)
+	}
+	if err := r.DBus.DaemonReload(ctx); err != nil {
+		return false, fmt.Errorf("failed reloading systemd daemon: %w", err)
+	}
+	if err := r.executeUnitCommands(ctx, log, node, changes); err != nil {
+		return false, fmt.Errorf("failed executing unit commands: %w", err)
+	}
+
+	return changes.MustRestartNodeAgent, nil
+}
+
+func driftStrings(drifts []drift) []string {
+	out := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		out = append(out, d.String())
+	}
+	return out
+}
+
+func driftMessage(prefix string, drifts []drift) string {
+	paths := driftStrings(drifts)
+	if len(paths) > maxDriftedPathsInMessage {
+		paths = append(paths[:maxDriftedPathsInMessage], fmt.Sprintf("and %d more", len(drifts)-maxDriftedPathsInMessage))
+	}
+	return fmt.Sprintf("%s: %s", prefix, strings.Join(paths, ", "))
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	"github.com/gardener/gardener/pkg/nodeagent/metrics"
+)
+
+var _ = Describe("Drift", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient client.Client
+		fakeDBus   *fakedbus.DBus
+		fakeFS     afero.Afero
+		recorder   *record.FakeRecorder
+		reconciler *Reconciler
+
+		node *corev1.Node
+		osc  *extensionsv1alpha1.OperatingSystemConfig
+
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Name: "osc-secret", Namespace: metav1.NamespaceSystem}}
+
+		expectFile = func(path, content string) {
+			GinkgoHelper()
+			data, err := fakeFS.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(content))
+		}
+
+		driftedCondition = func() *corev1.NodeCondition {
+			GinkgoHelper()
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigDrifted {
+					return &condition
+				}
+			}
+			return nil
+		}
+	)
+
+	BeforeEach(func() {
+		osc = &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Units: []extensionsv1alpha1.Unit{
+					{
+						Name:      "foo.service",
+						Command:   ptr.To(extensionsv1alpha1.CommandRestart),
+						Enable:    ptr.To(true),
+						Content:   ptr.To("foo-unit"),
+						FilePaths: []string{"/etc/foo"},
+					},
+					{
+						Name:    "bar.service",
+						Command: ptr.To(extensionsv1alpha1.CommandRestart),
+						Enable:  ptr.To(true),
+						Content: ptr.To("bar-unit"),
+						DropIns: []extensionsv1alpha1.DropIn{{Name: "10-bar.conf", Content: "bar-drop-in"}},
+					},
+				},
+				Files: []extensionsv1alpha1.File{{
+					Path:        "/etc/foo",
+					Permissions: ptr.To[uint32](0644),
+					Content:     extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "foo"}},
+				}},
+			},
+		}
+		oscRaw, err := yaml.Marshal(osc)
+		Expect(err).NotTo(HaveOccurred())
+
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:        "node",
+			Annotations: map[string]string{nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig: "abc"},
+		}}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        request.Name,
+				Namespace:   request.Namespace,
+				Annotations: map[string]string{nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig: "abc"},
+			},
+			Data: map[string][]byte{nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig: oscRaw},
+		}
+
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node, secret).
+			WithStatusSubresource(&corev1.Node{}).
+			Build()
+		fakeDBus = fakedbus.New()
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		recorder = record.NewFakeRecorder(32)
+
+		reconciler = &Reconciler{
+			Client: fakeClient,
+			Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+				SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+				KubernetesVersion: semver.MustParse("1.31.1"),
+				DriftDetection:    &nodeagentconfigv1alpha1.DriftDetectionConfig{Period: &metav1.Duration{Duration: 5 * time.Minute}},
+			},
+			Recorder:      recorder,
+			DBus:          fakeDBus,
+			FS:            fakeFS,
+			CancelContext: func() {},
+			NodeName:      node.Name,
+			Clock:         testclock.NewFakeClock(time.Now()),
+		}
+
+		// The node is in the state of the applied operating system config.
+		Expect(fakeFS.WriteFile(nodeagentconfigv1alpha1.BaseDir+"/last-applied-osc.yaml", oscRaw, 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/foo", []byte("foo"), 0644)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/systemd/system/foo.service", []byte("foo-unit"), 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/systemd/system/bar.service", []byte("bar-unit"), 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/systemd/system/bar.service.d/10-bar.conf", []byte("bar-drop-in"), 0600)).To(Succeed())
+	})
+
+	It("should not report anything if nothing drifted", func() {
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: 5 * time.Minute}))
+
+		Expect(driftedCondition()).To(BeNil())
+		Expect(fakeDBus.Actions).To(BeEmpty())
+	})
+
+	It("should not check for drifts if drift detection is disabled", func() {
+		reconciler.Config.DriftDetection = nil
+		Expect(fakeFS.WriteFile("/etc/foo", []byte("changed"), 0644)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+
+		Expect(driftedCondition()).To(BeNil())
+	})
+
+	It("should report drifted files and units", func() {
+		Expect(fakeFS.WriteFile("/etc/foo", []byte("changed"), 0644)).To(Succeed())
+		Expect(fakeFS.Chmod("/etc/systemd/system/foo.service", 0644)).To(Succeed())
+		Expect(fakeFS.Remove("/etc/systemd/system/bar.service.d/10-bar.conf")).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: 5 * time.Minute}))
+
+		condition := driftedCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("DriftDetected"))
+		Expect(condition.Message).To(And(
+			ContainSubstring("/etc/foo (content changed)"),
+			ContainSubstring("/etc/systemd/system/foo.service (permissions changed from 0600 to 0644)"),
+			ContainSubstring("/etc/systemd/system/bar.service.d/10-bar.conf (missing)"),
+		))
+
+		Expect(testutil.ToFloat64(metrics.OperatingSystemConfigDrift.WithLabelValues("file", "/etc/foo"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(metrics.OperatingSystemConfigDrift.WithLabelValues("unit", "/etc/systemd/system/foo.service"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(metrics.OperatingSystemConfigDrift.WithLabelValues("drop-in", "/etc/systemd/system/bar.service.d/10-bar.conf"))).To(Equal(1.0))
+
+		By("Do not touch the node")
+		expectFile("/etc/foo", "changed")
+		Expect(fakeFS.Exists("/etc/systemd/system/bar.service.d/10-bar.conf")).To(BeFalse())
+		Expect(fakeDBus.Actions).To(BeEmpty())
+	})
+
+	It("should re-apply drifted files and units if remediation is enabled", func() {
+		Expect(fakeFS.WriteFile("/etc/foo", []byte("changed"), 0644)).To(Succeed())
+		Expect(fakeFS.Remove("/etc/systemd/system/bar.service.d/10-bar.conf")).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driftedCondition().Status).To(Equal(corev1.ConditionTrue))
+
+		By("Enable remediation")
+		reconciler.Config.DriftDetection.Remediate = ptr.To(true)
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: 5 * time.Minute}))
+
+		expectFile("/etc/foo", "foo")
+		expectFile("/etc/systemd/system/bar.service.d/10-bar.conf", "bar-drop-in")
+
+		Expect(fakeDBus.Actions).To(ConsistOf(
+			fakedbus.SystemdAction{Action: fakedbus.ActionEnable, UnitNames: []string{"bar.service"}},
+			fakedbus.SystemdAction{Action: fakedbus.ActionDaemonReload},
+			fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"foo.service"}},
+			fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"bar.service"}},
+		))
+		Expect(recorder.Events).To(Receive(ContainSubstring("OSCDriftRemediated")))
+		Expect(testutil.ToFloat64(metrics.OperatingSystemConfigDrift.WithLabelValues("file", "/etc/foo"))).To(BeZero())
+
+		condition := driftedCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("DriftRemediated"))
+
+		By("Report that nothing drifted anymore")
+		fakeDBus.Actions = nil
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeDBus.Actions).To(BeEmpty())
+		condition = driftedCondition()
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("NoDrift"))
+	})
+})
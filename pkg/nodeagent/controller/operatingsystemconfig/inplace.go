@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// KubeletHealthChecker checks the health of the kubelet.
+type KubeletHealthChecker interface {
+	// CheckHealthEndpoint returns an error if the health endpoint of the kubelet does not report it as healthy.
+	CheckHealthEndpoint(ctx context.Context) error
+}
+
+// inPlaceUpdatesEnabled returns true if the Kubernetes version of the node is updated in-place, i.e., the node is
+// cordoned and drained in coordination with the other nodes of the worker pool before the kubelet is updated.
+func (r *Reconciler) inPlaceUpdatesEnabled() bool {
+	return r.Config.InPlaceUpdate != nil && r.Config.Reboot != nil
+}
+
+// prepareInPlaceUpdate prepares the node for the in-place update of its Kubernetes version. A reboot lease of the worker
+// pool is acquired to limit the number of nodes which are updated at the same time, and the node is cordoned and
+// drained. It returns true if the changes can be applied.
+func (r *Reconciler) prepareInPlaceUpdate(ctx context.Context, log logr.Logger, node *corev1.Node, changes *operatingSystemConfigChanges) (bool, reconcile.Result, error) {
+	if !r.inPlaceUpdatesEnabled() {
+		// Without in-place updates, the kubelet is updated without further coordination (e.g., for patch versions).
+		log.V(1).Info("Changes update the Kubernetes version of the node, but in-place updates are not enabled, applying them without draining the node")
+		if err := r.uncordonNode(ctx, log, node, "in-place update", changes.InPlaceUpdate.disruption); err != nil {
+			return false, reconcile.Result{}, fmt.Errorf("failed uncordoning node: %w", err)
+		}
+		return true, reconcile.Result{}, changes.completedInPlaceUpdate()
+	}
+
+	if changes.InPlaceUpdate.Prepared {
+		return true, reconcile.Result{}, nil
+	}
+
+	lease, err := r.acquireRebootLease(ctx, log, node)
+	if err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed acquiring reboot lease: %w", err)
+	}
+	if lease == nil {
+		log.Info("Changes update the Kubernetes version of the node in-place, waiting for other nodes of the worker pool to complete their updates or reboots")
+		return false, reconcile.Result{RequeueAfter: rebootLeaseRetryInterval}, nil
+	}
+
+	if err := r.cordonNode(ctx, log, node, "in-place update", changes.updateInPlaceUpdateDisruption); err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed cordoning node: %w", err)
+	}
+
+	drained, err := r.drainNode(ctx, log, node, "in-place update", &changes.InPlaceUpdate.disruption, changes.updateInPlaceUpdateDisruption, ptr.Deref(r.Config.InPlaceUpdate.DrainTimeout, metav1.Duration{}).Duration)
+	if err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed draining node: %w", err)
+	}
+	if !drained {
+		return false, reconcile.Result{RequeueAfter: rebootProgressInterval}, nil
+	}
+
+	if err := changes.updateInPlaceUpdate(func(inPlaceUpdate *inPlaceUpdate) { inPlaceUpdate.Prepared = true }); err != nil {
+		return false, reconcile.Result{}, err
+	}
+
+	log.Info("Updating Kubernetes version of the node in-place", "kubernetesVersion", r.Config.KubernetesVersion, "lease", lease.Name)
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, "InPlaceUpdating", "Updating Kubernetes version of the node in-place to %s (reboot lease %s)", r.Config.KubernetesVersion, lease.Name)
+	return true, reconcile.Result{}, nil
+}
+
+// completeInPlaceUpdate waits for the kubelet to become healthy and for the node to become ready after the changes were
+// applied. Afterwards, the node is uncordoned and the lease is released. If the kubelet does not become healthy within
+// the configured timeout, the node remains cordoned and the lease is kept to prevent updating further nodes of the
+// worker pool. It returns true if the in-place update is completed.
+func (r *Reconciler) completeInPlaceUpdate(ctx context.Context, log logr.Logger, node *corev1.Node, changes *operatingSystemConfigChanges) (bool, reconcile.Result, error) {
+	if !r.inPlaceUpdatesEnabled() {
+		log.Info("In-place updates were disabled while updating the Kubernetes version of the node, uncordoning it")
+		if err := r.uncordonNode(ctx, log, node, "in-place update", changes.InPlaceUpdate.disruption); err != nil {
+			return false, reconcile.Result{}, fmt.Errorf("failed uncordoning node: %w", err)
+		}
+		return true, reconcile.Result{}, changes.completedInPlaceUpdate()
+	}
+
+	healthErr := r.KubeletHealthChecker.CheckHealthEndpoint(ctx)
+	if healthErr == nil && !isNodeReadySince(node, changes.InPlaceUpdate.ApplyTime) {
+		healthErr = errors.New("node is not ready yet")
+	}
+
+	if healthErr != nil {
+		// Renew the lease to prevent other nodes from taking it over while this node is not healthy yet.
+		if _, err := r.acquireRebootLease(ctx, log, node); err != nil {
+			return false, reconcile.Result{}, fmt.Errorf("failed renewing reboot lease: %w", err)
+		}
+
+		kubeletHealthTimeout := r.Config.InPlaceUpdate.KubeletHealthTimeout.Duration
+		if applyTime := changes.InPlaceUpdate.ApplyTime; applyTime != nil && r.Clock.Since(applyTime.Time) >= kubeletHealthTimeout {
+			message := fmt.Sprintf("Kubelet did not become healthy within %s after updating the Kubernetes version of the node in-place to %s, keeping the node cordoned: %v", kubeletHealthTimeout, r.Config.KubernetesVersion, healthErr)
+			r.Recorder.Event(node, corev1.EventTypeWarning, "InPlaceUpdateFailed", message)
+			return false, reconcile.Result{}, errors.New(message)
+		}
+
+		log.Info("Kubernetes version of the node was updated in-place, waiting for the kubelet to become healthy", "reason", healthErr.Error())
+		return false, reconcile.Result{RequeueAfter: rebootProgressInterval}, nil
+	}
+
+	if err := r.uncordonNode(ctx, log, node, "in-place update", changes.InPlaceUpdate.disruption); err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed uncordoning node: %w", err)
+	}
+
+	if err := r.releaseRebootLeases(ctx, log, node); err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed releasing reboot lease: %w", err)
+	}
+
+	if err := changes.completedInPlaceUpdate(); err != nil {
+		return false, reconcile.Result{}, err
+	}
+
+	log.Info("Kubernetes version of the node was updated in-place successfully", "kubernetesVersion", r.Config.KubernetesVersion)
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, "InPlaceUpdated", "Kubernetes version of the node was updated in-place to %s successfully", r.Config.KubernetesVersion)
+	return true, reconcile.Result{}, nil
+}
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	fakeregistry "github.com/gardener/gardener/pkg/nodeagent/registry/fake"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+)
+
+var _ = Describe("InPlaceUpdate", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient    client.Client
+		fakeDBus      *fakedbus.DBus
+		fakeFS        afero.Afero
+		fakeClock     *testclock.FakeClock
+		recorder      *record.FakeRecorder
+		healthChecker *fakeKubeletHealthChecker
+		reconciler    *Reconciler
+
+		node   *corev1.Node
+		pod    *corev1.Pod
+		secret *corev1.Secret
+
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Name: "osc-secret", Namespace: metav1.NamespaceSystem}}
+
+		kubeletBinaryPath    = "/opt/bin/kubelet"
+		restartKubeletAction = fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"kubelet.service"}}
+
+		encode = func(osc *extensionsv1alpha1.OperatingSystemConfig) []byte {
+			GinkgoHelper()
+			raw, err := yaml.Marshal(osc)
+			Expect(err).NotTo(HaveOccurred())
+			return raw
+		}
+
+		leaseHolder = func() string {
+			GinkgoHelper()
+			lease := &coordinationv1.Lease{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "gardener-node-agent-reboot-worker-a-0", Namespace: metav1.NamespaceSystem}, lease)).To(Succeed())
+			return ptr.Deref(lease.Spec.HolderIdentity, "")
+		}
+
+		kubeletBinary = func() string {
+			GinkgoHelper()
+			data, err := fakeFS.ReadFile(kubeletBinaryPath)
+			Expect(err).NotTo(HaveOccurred())
+			return string(data)
+		}
+
+		reconcileNode = func(expectedResult reconcile.Result) {
+			GinkgoHelper()
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expectedResult))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+		}
+
+		setNodeReady = func() {
+			GinkgoHelper()
+			patch := client.MergeFrom(node.DeepCopy())
+			node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastHeartbeatTime: metav1.NewTime(fakeClock.Now())}}
+			Expect(fakeClient.Status().Patch(ctx, node, patch)).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:        "node",
+			Labels:      map[string]string{"worker.gardener.cloud/pool": "worker-a", "worker.gardener.cloud/kubernetes-version": "1.31.1"},
+			Annotations: map[string]string{},
+		}}
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: node.Name},
+		}
+
+		oldOSC := &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Units: []extensionsv1alpha1.Unit{{
+					Name:      "kubelet.service",
+					Enable:    ptr.To(true),
+					Content:   ptr.To("kubelet"),
+					FilePaths: []string{kubeletBinaryPath},
+				}},
+				Files: []extensionsv1alpha1.File{{
+					Path:        kubeletBinaryPath,
+					Permissions: ptr.To[uint32](0755),
+					Content:     extensionsv1alpha1.FileContent{ImageRef: &extensionsv1alpha1.FileContentImageRef{Image: "hyperkube:v1.31.1", FilePathInImage: "/kubelet"}},
+				}},
+			},
+		}
+		newOSC := oldOSC.DeepCopy()
+		newOSC.Spec.Files[0].Content.ImageRef.Image = "hyperkube:v1.32.0"
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        request.Name,
+				Namespace:   request.Namespace,
+				Annotations: map[string]string{nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig: "new"},
+			},
+			Data: map[string][]byte{nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig: encode(newOSC)},
+		}
+
+		fakeDBus = fakedbus.New()
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		fakeClock = testclock.NewFakeClock(time.Now().Truncate(time.Second))
+		recorder = record.NewFakeRecorder(32)
+		healthChecker = &fakeKubeletHealthChecker{}
+
+		// The node is in the state of the old operating system config.
+		Expect(fakeFS.WriteFile(nodeagentconfigv1alpha1.BaseDir+"/last-applied-osc.yaml", encode(oldOSC), 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile(kubeletBinaryPath, []byte("kubelet-v1.31.1"), 0755)).To(Succeed())
+		Expect(fakeFS.WriteFile("/images/kubelet", []byte("kubelet-v1.32.0"), 0755)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node, secret, pod).
+			WithStatusSubresource(&corev1.Node{}).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Pod).Spec.NodeName}
+			}).
+			Build()
+
+		reconciler = &Reconciler{
+			Client:    fakeClient,
+			APIReader: fakeClient,
+			Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+				SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+				KubernetesVersion: semver.MustParse("1.32.0"),
+				Reboot: &nodeagentconfigv1alpha1.RebootConfig{
+					MaxConcurrentReboots: ptr.To[int32](1),
+					DrainTimeout:         &metav1.Duration{Duration: 10 * time.Minute},
+					LeaseDuration:        &metav1.Duration{Duration: time.Hour},
+				},
+				InPlaceUpdate: &nodeagentconfigv1alpha1.InPlaceUpdateConfig{
+					DrainTimeout:         &metav1.Duration{Duration: 10 * time.Minute},
+					KubeletHealthTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			Recorder:             recorder,
+			DBus:                 fakeDBus,
+			FS:                   fakeFS,
+			Extractor:            fakeregistry.NewExtractor(fakeFS, "/images"),
+			CancelContext:        func() {},
+			NodeName:             node.Name,
+			Clock:                fakeClock,
+			KubeletHealthChecker: healthChecker,
+		}
+	})
+
+	It("should cordon and drain the node before updating the kubelet and uncordon it when it is healthy again", func() {
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(leaseHolder()).To(Equal(node.Name))
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(BeNotFoundError())
+		Expect(kubeletBinary()).To(Equal("kubelet-v1.31.1"))
+		Expect(fakeDBus.Actions).NotTo(ContainElement(restartKubeletAction))
+
+		By("Update the kubelet after the node was drained")
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(kubeletBinary()).To(Equal("kubelet-v1.32.0"))
+		Expect(fakeDBus.Actions).To(ContainElement(restartKubeletAction))
+		Expect(recorder.Events).To(Receive(ContainSubstring("InPlaceUpdating")))
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(node.Labels).To(HaveKeyWithValue("worker.gardener.cloud/kubernetes-version", "1.31.1"))
+
+		By("Uncordon the node and release the lease when the node is ready")
+		fakeClock.Step(time.Minute)
+		setNodeReady()
+		reconcileNode(reconcile.Result{RequeueAfter: time.Minute})
+		Expect(recorder.Events).To(Receive(ContainSubstring("InPlaceUpdated")))
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(leaseHolder()).To(BeEmpty())
+		Expect(node.Labels).To(HaveKeyWithValue("worker.gardener.cloud/kubernetes-version", "1.32.0"))
+		Expect(node.Annotations).To(HaveKeyWithValue(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, "new"))
+	})
+
+	It("should wait until a reboot lease of the worker pool is free", func() {
+		Expect(fakeClient.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "gardener-node-agent-reboot-worker-a-0", Namespace: metav1.NamespaceSystem},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To("other-node"),
+				LeaseDurationSeconds: ptr.To[int32](3600),
+				RenewTime:            &metav1.MicroTime{Time: fakeClock.Now()},
+			},
+		})).To(Succeed())
+
+		reconcileNode(reconcile.Result{RequeueAfter: 30 * time.Second})
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+		Expect(kubeletBinary()).To(Equal("kubelet-v1.31.1"))
+	})
+
+	It("should keep the node cordoned if the kubelet does not become healthy", func() {
+		healthChecker.err = errors.New("kubelet is unhealthy")
+
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(kubeletBinary()).To(Equal("kubelet-v1.32.0"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("InPlaceUpdating")))
+
+		fakeClock.Step(5 * time.Minute)
+		setNodeReady()
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).To(MatchError(ContainSubstring("Kubelet did not become healthy within 5m0s")))
+		Expect(recorder.Events).To(Receive(ContainSubstring("InPlaceUpdateFailed")))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(leaseHolder()).To(Equal(node.Name))
+		Expect(node.Annotations).NotTo(HaveKey(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig))
+	})
+
+	It("should update the kubelet without draining the node if in-place updates are not enabled", func() {
+		reconciler.Config.InPlaceUpdate = nil
+
+		reconcileNode(reconcile.Result{RequeueAfter: time.Minute})
+		Expect(kubeletBinary()).To(Equal("kubelet-v1.32.0"))
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+		Expect(node.Annotations).To(HaveKeyWithValue(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, "new"))
+	})
+})
+
+type fakeKubeletHealthChecker struct {
+	err error
+}
+
+func (f *fakeKubeletHealthChecker) CheckHealthEndpoint(context.Context) error {
+	return f.err
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
@@ -24,6 +25,46 @@ type operatingSystemConfigChanges struct {
 	Files                         files      `json:"files"`
 	Containerd                    containerd `json:"containerd"`
 	MustRestartNodeAgent          bool       `json:"mustRestartNodeAgent"`
+	// FailedAttempts is the number of failed attempts to apply the changes.
+	FailedAttempts int32 `json:"failedAttempts,omitempty"`
+	// RolledBack is true if the changes were rolled back after too many failed attempts.
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// Reboot tracks the state of the reboot of the node which is required by the changes.
+	Reboot reboot `json:"reboot,omitempty"`
+	// InPlaceUpdate tracks the state of the in-place update of the Kubernetes version of the node which is required by
+	// the changes.
+	InPlaceUpdate inPlaceUpdate `json:"inPlaceUpdate,omitempty"`
+}
+
+// disruption tracks the preparation of the node for a disruptive operation, i.e., cordoning and draining it.
+type disruption struct {
+	// Cordoned is true if the node was cordoned by gardener-node-agent for the operation.
+	Cordoned bool `json:"cordoned,omitempty"`
+	// DrainStartTime is the time when evicting the pods from the node was started.
+	DrainStartTime *metav1.Time `json:"drainStartTime,omitempty"`
+}
+
+type reboot struct {
+	disruption `json:",inline"`
+
+	// Required is true if the changes require a reboot of the node which was not completed yet.
+	Required bool `json:"required,omitempty"`
+	// BootID is the ID of the boot of the node before the reboot was triggered.
+	BootID string `json:"bootID,omitempty"`
+	// TriggerTime is the time when the reboot was triggered.
+	TriggerTime *metav1.Time `json:"triggerTime,omitempty"`
+}
+
+type inPlaceUpdate struct {
+	disruption `json:",inline"`
+
+	// Required is true if the changes update the Kubernetes version of the node in-place and the update was not
+	// completed yet.
+	Required bool `json:"required,omitempty"`
+	// Prepared is true if the node was cordoned and drained, i.e., the changes can be applied.
+	Prepared bool `json:"prepared,omitempty"`
+	// ApplyTime is the time when the changes were applied, i.e., when the kubelet was restarted with the new version.
+	ApplyTime *metav1.Time `json:"applyTime,omitempty"`
 }
 
 type units struct {
@@ -83,6 +124,62 @@ func (o *operatingSystemConfigChanges) setMustRestartNodeAgent(restart bool) err
 	return o.persist()
 }
 
+func (o *operatingSystemConfigChanges) failedAttempt() (int32, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.FailedAttempts++
+	return o.FailedAttempts, o.persist()
+}
+
+func (o *operatingSystemConfigChanges) setRolledBack() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.RolledBack = true
+	return o.persist()
+}
+
+func (o *operatingSystemConfigChanges) updateReboot(mutate func(*reboot)) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	mutate(&o.Reboot)
+	return o.persist()
+}
+
+func (o *operatingSystemConfigChanges) completedReboot() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.Reboot = reboot{}
+	return o.persist()
+}
+
+func (o *operatingSystemConfigChanges) updateRebootDisruption(mutate func(*disruption)) error {
+	return o.updateReboot(func(reboot *reboot) { mutate(&reboot.disruption) })
+}
+
+func (o *operatingSystemConfigChanges) updateInPlaceUpdate(mutate func(*inPlaceUpdate)) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	mutate(&o.InPlaceUpdate)
+	return o.persist()
+}
+
+func (o *operatingSystemConfigChanges) updateInPlaceUpdateDisruption(mutate func(*disruption)) error {
+	return o.updateInPlaceUpdate(func(inPlaceUpdate *inPlaceUpdate) { mutate(&inPlaceUpdate.disruption) })
+}
+
+func (o *operatingSystemConfigChanges) completedInPlaceUpdate() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.InPlaceUpdate = inPlaceUpdate{}
+	return o.persist()
+}
+
 func (o *operatingSystemConfigChanges) completedUnitCommand(name string) error {
 	o.lock.Lock()
 	defer o.lock.Unlock()
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// Plan describes the changes which would be applied to the node when the operating system config is reconciled.
+type Plan struct {
+	// OperatingSystemConfigChecksum is the checksum of the operating system config the plan was computed for.
+	OperatingSystemConfigChecksum string `json:"operatingSystemConfigChecksum"`
+	// Files contains the files which would be changed or deleted.
+	Files PlanFiles `json:"files"`
+	// Units contains the units which would be changed or deleted.
+	Units PlanUnits `json:"units"`
+	// UnitCommands contains the commands (start/restart/stop) which would be executed for the units.
+	UnitCommands []PlanUnitCommand `json:"unitCommands,omitempty"`
+	// Containerd contains the changes of the containerd configuration.
+	Containerd PlanContainerd `json:"containerd"`
+	// RebootRequired is true if the changes would require a reboot of the node.
+	RebootRequired bool `json:"rebootRequired,omitempty"`
+}
+
+// PlanFiles contains the paths of files which would be changed or deleted.
+type PlanFiles struct {
+	// Changed are the paths of the files which would be created or updated.
+	Changed []string `json:"changed,omitempty"`
+	// Deleted are the paths of the files which would be removed.
+	Deleted []string `json:"deleted,omitempty"`
+}
+
+// PlanUnits contains the units which would be changed or deleted.
+type PlanUnits struct {
+	// Changed are the units which would be created or updated.
+	Changed []PlanChangedUnit `json:"changed,omitempty"`
+	// Deleted are the names of the units which would be removed.
+	Deleted []string `json:"deleted,omitempty"`
+}
+
+// PlanChangedUnit describes a unit which would be created or updated.
+type PlanChangedUnit struct {
+	// Name is the name of the unit.
+	Name string `json:"name"`
+	// ChangedDropIns are the names of the drop-ins which would be created or updated.
+	ChangedDropIns []string `json:"changedDropIns,omitempty"`
+	// DeletedDropIns are the names of the drop-ins which would be removed.
+	DeletedDropIns []string `json:"deletedDropIns,omitempty"`
+}
+
+// PlanUnitCommand describes a command which would be executed for a unit.
+type PlanUnitCommand struct {
+	// Name is the name of the unit.
+	Name string `json:"name"`
+	// Command is the command which would be executed.
+	Command extensionsv1alpha1.UnitCommand `json:"command"`
+}
+
+// PlanContainerd contains the changes of the containerd configuration.
+type PlanContainerd struct {
+	// ConfigFileChanged is true if the containerd config file would be changed.
+	ConfigFileChanged bool `json:"configFileChanged"`
+	// DesiredRegistries are the upstreams of the registries which would be configured.
+	DesiredRegistries []string `json:"desiredRegistries,omitempty"`
+	// DeletedRegistries are the upstreams of the registries which would be removed.
+	DeletedRegistries []string `json:"deletedRegistries,omitempty"`
+}
+
+// ComputePlan computes the changes between the last applied operating system config on disk and the operating system
+// config contained in the given secret. It neither touches the node nor persists any state.
+func ComputePlan(fs afero.Afero, secret *corev1.Secret) (*Plan, error) {
+	osc, oscChecksum, err := extractOSCFromSecret(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed extracting OSC from secret: %w", err)
+	}
+
+	changes, err := calculateOperatingSystemConfigChanges(fs, osc, oscChecksum)
+	if err != nil {
+		return nil, fmt.Errorf("failed calculating the OSC changes: %w", err)
+	}
+
+	return newPlan(changes), nil
+}
+
+func newPlan(changes *operatingSystemConfigChanges) *Plan {
+	plan := &Plan{
+		OperatingSystemConfigChecksum: changes.OperatingSystemConfigChecksum,
+		Containerd:                    PlanContainerd{ConfigFileChanged: changes.Containerd.ConfigFileChanged},
+		RebootRequired:                changes.Reboot.Required,
+	}
+
+	for _, file := range changes.Files.Changed {
+		plan.Files.Changed = append(plan.Files.Changed, file.Path)
+	}
+	for _, file := range changes.Files.Deleted {
+		plan.Files.Deleted = append(plan.Files.Deleted, file.Path)
+	}
+
+	for _, unit := range changes.Units.Changed {
+		changedUnit := PlanChangedUnit{Name: unit.Name}
+		for _, dropIn := range unit.DropInsChanges.Changed {
+			changedUnit.ChangedDropIns = append(changedUnit.ChangedDropIns, dropIn.Name)
+		}
+		for _, dropIn := range unit.DropInsChanges.Deleted {
+			changedUnit.DeletedDropIns = append(changedUnit.DeletedDropIns, dropIn.Name)
+		}
+		plan.Units.Changed = append(plan.Units.Changed, changedUnit)
+	}
+	for _, unit := range changes.Units.Deleted {
+		plan.Units.Deleted = append(plan.Units.Deleted, unit.Name)
+	}
+	for _, command := range changes.Units.Commands {
+		plan.UnitCommands = append(plan.UnitCommands, PlanUnitCommand{Name: command.Name, Command: command.Command})
+	}
+
+	for _, registry := range changes.Containerd.Registries.Desired {
+		plan.Containerd.DesiredRegistries = append(plan.Containerd.DesiredRegistries, registry.Upstream)
+	}
+	for _, registry := range changes.Containerd.Registries.Deleted {
+		plan.Containerd.DeletedRegistries = append(plan.Containerd.DeletedRegistries, registry.Upstream)
+	}
+
+	return plan
+}
+
+// IsEmpty returns true if the plan does not contain any changes.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Files.Changed) == 0 &&
+		len(p.Files.Deleted) == 0 &&
+		len(p.Units.Changed) == 0 &&
+		len(p.Units.Deleted) == 0 &&
+		len(p.UnitCommands) == 0 &&
+		!p.Containerd.ConfigFileChanged &&
+		len(p.Containerd.DesiredRegistries) == 0 &&
+		len(p.Containerd.DeletedRegistries) == 0
+}
+
+// WriteText writes a human-readable representation of the plan to the given writer.
+func (p *Plan) WriteText(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Operating system config checksum: %s\n", p.OperatingSystemConfigChecksum)
+	if p.IsEmpty() {
+		b.WriteString("No changes. The node is up to date.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	writeSection := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, line := range lines {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+
+	var lines []string
+	for _, path := range p.Files.Changed {
+		lines = append(lines, "~ "+path)
+	}
+	for _, path := range p.Files.Deleted {
+		lines = append(lines, "- "+path)
+	}
+	writeSection("Files", lines)
+
+	lines = nil
+	for _, unit := range p.Units.Changed {
+		lines = append(lines, "~ "+unit.Name)
+		for _, dropIn := range unit.ChangedDropIns {
+			lines = append(lines, "    ~ drop-in "+dropIn)
+		}
+		for _, dropIn := range unit.DeletedDropIns {
+			lines = append(lines, "    - drop-in "+dropIn)
+		}
+	}
+	for _, name := range p.Units.Deleted {
+		lines = append(lines, "- "+name)
+	}
+	writeSection("Units", lines)
+
+	lines = nil
+	for _, command := range p.UnitCommands {
+		lines = append(lines, fmt.Sprintf("%s %s", command.Command, command.Name))
+	}
+	writeSection("Unit commands", lines)
+
+	lines = nil
+	if p.Containerd.ConfigFileChanged {
+		lines = append(lines, "~ config file")
+	}
+	for _, upstream := range p.Containerd.DesiredRegistries {
+		lines = append(lines, "~ registry "+upstream)
+	}
+	for _, upstream := range p.Containerd.DeletedRegistries {
+		lines = append(lines, "- registry "+upstream)
+	}
+	writeSection("Containerd", lines)
+
+	if p.RebootRequired {
+		b.WriteString("\nThe changes require a reboot of the node.\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+)
+
+var _ = Describe("Plan", func() {
+	var (
+		fs  afero.Afero
+		osc *extensionsv1alpha1.OperatingSystemConfig
+
+		lastAppliedOSCPath = nodeagentconfigv1alpha1.BaseDir + "/last-applied-osc.yaml"
+		lastComputedPath   = nodeagentconfigv1alpha1.BaseDir + "/last-computed-osc-changes.yaml"
+
+		encode = func(osc *extensionsv1alpha1.OperatingSystemConfig) []byte {
+			GinkgoHelper()
+			raw, err := yaml.Marshal(osc)
+			Expect(err).NotTo(HaveOccurred())
+			return raw
+		}
+	)
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+
+		osc = &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Units: []extensionsv1alpha1.Unit{
+					{
+						Name:    "kubelet.service",
+						Command: ptr.To(extensionsv1alpha1.CommandRestart),
+						Enable:  ptr.To(true),
+						Content: ptr.To("[Unit]"),
+						DropIns: []extensionsv1alpha1.DropIn{{Name: "10-config.conf", Content: "[Service]"}},
+					},
+				},
+				Files: []extensionsv1alpha1.File{
+					{Path: "/etc/kubernetes/kubelet.conf", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "foo"}}},
+				},
+			},
+		}
+	})
+
+	newSecret := func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "osc-secret",
+				Namespace:   "kube-system",
+				Annotations: map[string]string{nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig: "new-checksum"},
+			},
+			Data: map[string][]byte{nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig: encode(osc)},
+		}
+	}
+
+	Describe("#ComputePlan", func() {
+		It("should fail if the secret does not contain an operating system config", func() {
+			secret := newSecret()
+			delete(secret.Data, nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig)
+
+			_, err := ComputePlan(fs, secret)
+			Expect(err).To(MatchError(ContainSubstring("failed extracting OSC from secret")))
+		})
+
+		It("should consider everything as changed if there is no last applied operating system config", func() {
+			plan, err := ComputePlan(fs, newSecret())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.IsEmpty()).To(BeFalse())
+			Expect(plan.OperatingSystemConfigChecksum).To(Equal("new-checksum"))
+			Expect(plan.Files.Changed).To(ConsistOf("/etc/kubernetes/kubelet.conf"))
+			Expect(plan.Units.Changed).To(ConsistOf(PlanChangedUnit{Name: "kubelet.service", ChangedDropIns: []string{"10-config.conf"}}))
+			Expect(plan.UnitCommands).To(ConsistOf(PlanUnitCommand{Name: "kubelet.service", Command: extensionsv1alpha1.CommandRestart}))
+			Expect(plan.Containerd.ConfigFileChanged).To(BeTrue())
+			Expect(plan.RebootRequired).To(BeFalse())
+		})
+
+		It("should compute the differences to the last applied operating system config", func() {
+			oldOSC := osc.DeepCopy()
+			oldOSC.Spec.Units = append(oldOSC.Spec.Units, extensionsv1alpha1.Unit{Name: "old.service", Content: ptr.To("[Unit]")})
+			oldOSC.Spec.Files = append(oldOSC.Spec.Files, extensionsv1alpha1.File{Path: "/etc/old", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "old"}}})
+			Expect(fs.WriteFile(lastAppliedOSCPath, encode(oldOSC), 0600)).To(Succeed())
+
+			osc.Spec.Files[0].Content.Inline.Data = "bar"
+			osc.Spec.CRIConfig = &extensionsv1alpha1.CRIConfig{
+				Name: extensionsv1alpha1.CRINameContainerD,
+				Containerd: &extensionsv1alpha1.ContainerdConfig{
+					Registries: []extensionsv1alpha1.RegistryConfig{{Upstream: "docker.io"}},
+				},
+			}
+
+			plan, err := ComputePlan(fs, newSecret())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Files.Changed).To(ConsistOf("/etc/kubernetes/kubelet.conf"))
+			Expect(plan.Files.Deleted).To(ConsistOf("/etc/old"))
+			Expect(plan.Units.Changed).To(BeEmpty())
+			Expect(plan.Units.Deleted).To(ConsistOf("old.service"))
+			Expect(plan.Containerd.ConfigFileChanged).To(BeTrue())
+			Expect(plan.Containerd.DesiredRegistries).To(ConsistOf("docker.io"))
+			Expect(plan.RebootRequired).To(BeFalse())
+		})
+
+		It("should report that a reboot is required", func() {
+			oldOSC := osc.DeepCopy()
+			oldOSC.Spec.Files = append(oldOSC.Spec.Files, extensionsv1alpha1.File{Path: "/etc/modules-load.d/old.conf", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "old"}}, RequiresReboot: ptr.To(true)})
+			Expect(fs.WriteFile(lastAppliedOSCPath, encode(oldOSC), 0600)).To(Succeed())
+
+			plan, err := ComputePlan(fs, newSecret())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Files.Deleted).To(ConsistOf("/etc/modules-load.d/old.conf"))
+			Expect(plan.RebootRequired).To(BeTrue())
+		})
+
+		It("should return an empty plan if nothing changed", func() {
+			Expect(fs.WriteFile(lastAppliedOSCPath, encode(osc), 0600)).To(Succeed())
+
+			plan, err := ComputePlan(fs, newSecret())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.IsEmpty()).To(BeTrue())
+		})
+
+		It("should not touch the file system", func() {
+			_, err := ComputePlan(fs, newSecret())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fs.Exists(lastComputedPath)).To(BeFalse())
+			Expect(fs.Exists(lastAppliedOSCPath)).To(BeFalse())
+		})
+	})
+
+	Describe("#WriteText", func() {
+		It("should report that there are no changes", func() {
+			var out bytes.Buffer
+			Expect((&Plan{OperatingSystemConfigChecksum: "abc"}).WriteText(&out)).To(Succeed())
+			Expect(out.String()).To(Equal("Operating system config checksum: abc\nNo changes. The node is up to date.\n"))
+		})
+
+		It("should print all changes", func() {
+			plan := &Plan{
+				OperatingSystemConfigChecksum: "abc",
+				Files:                         PlanFiles{Changed: []string{"/etc/foo"}, Deleted: []string{"/etc/bar"}},
+				Units: PlanUnits{
+					Changed: []PlanChangedUnit{{Name: "foo.service", ChangedDropIns: []string{"a.conf"}, DeletedDropIns: []string{"b.conf"}}},
+					Deleted: []string{"bar.service"},
+				},
+				UnitCommands: []PlanUnitCommand{{Name: "foo.service", Command: extensionsv1alpha1.CommandRestart}},
+				Containerd:   PlanContainerd{ConfigFileChanged: true, DesiredRegistries: []string{"docker.io"}, DeletedRegistries: []string{"quay.io"}},
+			}
+
+			var out bytes.Buffer
+			Expect(plan.WriteText(&out)).To(Succeed())
+			Expect(out.String()).To(Equal(`Operating system config checksum: abc
+
+Files:
+  ~ /etc/foo
+  - /etc/bar
+
+Units:
+  ~ foo.service
+      ~ drop-in a.conf
+      - drop-in b.conf
+  - bar.service
+
+Unit commands:
+  restart foo.service
+
+Containerd:
+  ~ config file
+  ~ registry docker.io
+  - registry quay.io
+`))
+		})
+
+		It("should print that a reboot is required", func() {
+			plan := &Plan{
+				OperatingSystemConfigChecksum: "abc",
+				Files:                         PlanFiles{Changed: []string{"/etc/foo"}},
+				RebootRequired:                true,
+			}
+
+			var out bytes.Buffer
+			Expect(plan.WriteText(&out)).To(Succeed())
+			Expect(out.String()).To(Equal(`Operating system config checksum: abc
+
+Files:
+  ~ /etc/foo
+
+The changes require a reboot of the node.
+`))
+		})
+	})
+})
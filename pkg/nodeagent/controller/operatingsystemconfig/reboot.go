@@ -0,0 +1,355 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+)
+
+const (
+	// bootIDFilePath is the path of the file containing the ID of the current boot of the node. It changes with every
+	// reboot.
+	bootIDFilePath = "/proc/sys/kernel/random/boot_id"
+
+	// rebootLeaseRetryInterval is the interval in which acquiring a reboot lease is retried if all leases of the worker
+	// pool are held by other nodes.
+	rebootLeaseRetryInterval = 30 * time.Second
+	// rebootProgressInterval is the interval in which the progress of draining and rebooting the node is checked.
+	rebootProgressInterval = 10 * time.Second
+)
+
+// RequestReboot requests a reboot of the node, e.g., as remediation of a failing health check. The reboot is performed
+// by the next reconciliation in coordination with the other nodes of the worker pool, i.e., the same way as reboots
+// required by the operating system config.
+func (r *Reconciler) RequestReboot() {
+	r.rebootRequested.Store(true)
+
+	select {
+	case r.rebootRequests <- event.GenericEvent{Object: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: r.Config.SecretName, Namespace: metav1.NamespaceSystem}}}:
+	default:
+		// A reconciliation is already pending.
+	}
+}
+
+// reconcileReboot reboots the node in coordination with the other nodes of the worker pool. Before the reboot, a reboot
+// lease of the worker pool is acquired, and the node is cordoned and drained. After the reboot, the node is uncordoned
+// and the lease is released as soon as the node is ready again. It returns true if the reboot is completed.
+func (r *Reconciler) reconcileReboot(ctx context.Context, log logr.Logger, node *corev1.Node, changes *operatingSystemConfigChanges) (bool, reconcile.Result, error) {
+	if r.Config.Reboot == nil {
+		log.Info("Changes require a reboot of the node, but reboots are not enabled, skipping reboot")
+		r.Recorder.Event(node, corev1.EventTypeWarning, "RebootSkipped", "Operating system config requires a reboot of the node, but reboots are not enabled")
+		return true, reconcile.Result{}, changes.completedReboot()
+	}
+
+	bootID, err := r.bootID()
+	if err != nil {
+		return false, reconcile.Result{}, err
+	}
+
+	if changes.Reboot.BootID != "" && changes.Reboot.BootID != bootID {
+		return r.completeReboot(ctx, log, node, changes)
+	}
+
+	lease, err := r.acquireRebootLease(ctx, log, node)
+	if err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed acquiring reboot lease: %w", err)
+	}
+	if lease == nil {
+		log.Info("Changes require a reboot of the node, waiting for other nodes of the worker pool to complete their reboots")
+		return false, reconcile.Result{RequeueAfter: rebootLeaseRetryInterval}, nil
+	}
+
+	if changes.Reboot.BootID == "" {
+		if err := r.cordonNode(ctx, log, node, "reboot", changes.updateRebootDisruption); err != nil {
+			return false, reconcile.Result{}, fmt.Errorf("failed cordoning node: %w", err)
+		}
+
+		drained, err := r.drainNode(ctx, log, node, "reboot", &changes.Reboot.disruption, changes.updateRebootDisruption, ptr.Deref(r.Config.Reboot.DrainTimeout, metav1.Duration{}).Duration)
+		if err != nil {
+			return false, reconcile.Result{}, fmt.Errorf("failed draining node: %w", err)
+		}
+		if !drained {
+			return false, reconcile.Result{RequeueAfter: rebootProgressInterval}, nil
+		}
+
+		if err := changes.updateReboot(func(reboot *reboot) {
+			reboot.BootID = bootID
+			reboot.TriggerTime = ptr.To(metav1.NewTime(r.Clock.Now()))
+		}); err != nil {
+			return false, reconcile.Result{}, err
+		}
+	} else {
+		log.Info("Node was not rebooted yet although the reboot was already triggered, triggering it again")
+	}
+
+	log.Info("Rebooting the node to apply the operating system config", "lease", lease.Name)
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, "Rebooting", "Rebooting the node since the operating system config requires it (reboot lease %s)", lease.Name)
+	if err := r.DBus.Reboot(); err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed rebooting the node: %w", err)
+	}
+
+	return false, reconcile.Result{RequeueAfter: rebootProgressInterval}, nil
+}
+
+func (r *Reconciler) completeReboot(ctx context.Context, log logr.Logger, node *corev1.Node, changes *operatingSystemConfigChanges) (bool, reconcile.Result, error) {
+	if !isNodeReadySince(node, changes.Reboot.TriggerTime) {
+		log.Info("Node was rebooted, waiting for it to become ready")
+		// Renew the lease to prevent other nodes from taking it over while this node is not ready yet.
+		if _, err := r.acquireRebootLease(ctx, log, node); err != nil {
+			return false, reconcile.Result{}, fmt.Errorf("failed renewing reboot lease: %w", err)
+		}
+		return false, reconcile.Result{RequeueAfter: rebootProgressInterval}, nil
+	}
+
+	if err := r.uncordonNode(ctx, log, node, "reboot", changes.Reboot.disruption); err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed uncordoning node: %w", err)
+	}
+
+	if err := r.releaseRebootLeases(ctx, log, node); err != nil {
+		return false, reconcile.Result{}, fmt.Errorf("failed releasing reboot lease: %w", err)
+	}
+
+	if err := changes.completedReboot(); err != nil {
+		return false, reconcile.Result{}, err
+	}
+
+	log.Info("Node was rebooted successfully")
+	r.Recorder.Event(node, corev1.EventTypeNormal, "Rebooted", "Node was rebooted successfully")
+	return true, reconcile.Result{}, nil
+}
+
+func (r *Reconciler) bootID() (string, error) {
+	bootID, err := r.FS.ReadFile(bootIDFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed reading boot ID from %q: %w", bootIDFilePath, err)
+	}
+	return strings.TrimSpace(string(bootID)), nil
+}
+
+// acquireRebootLease acquires one of the reboot leases of the node's worker pool or renews it if the node already holds
+// one. Leases which are not held by any node or which were not renewed within the configured lease duration can be
+// acquired. It returns nil if all leases are held by other nodes.
+func (r *Reconciler) acquireRebootLease(ctx context.Context, log logr.Logger, node *corev1.Node) (*coordinationv1.Lease, error) {
+	workerPoolName := node.Labels[v1beta1constants.LabelWorkerPool]
+	if workerPoolName == "" {
+		return nil, fmt.Errorf("node does not have the %q label", v1beta1constants.LabelWorkerPool)
+	}
+
+	var (
+		now                  = metav1.NewMicroTime(r.Clock.Now())
+		leaseDuration        = ptr.Deref(r.Config.Reboot.LeaseDuration, metav1.Duration{Duration: time.Hour}).Duration
+		maxConcurrentReboots = ptr.Deref(r.Config.Reboot.MaxConcurrentReboots, 1)
+		candidates           []*coordinationv1.Lease
+	)
+
+	for slot := range maxConcurrentReboots {
+		lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: gardenerutils.NodeAgentRebootLeaseName(workerPoolName, slot), Namespace: metav1.NamespaceSystem}}
+		if err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(lease), lease); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed reading reboot lease %q: %w", lease.Name, err)
+			}
+			candidates = append(candidates, lease)
+			continue
+		}
+
+		switch holder := ptr.Deref(lease.Spec.HolderIdentity, ""); {
+		case holder == node.Name:
+			lease.Spec.RenewTime = &now
+			if err := r.Client.Update(ctx, lease); err != nil {
+				return nil, fmt.Errorf("failed renewing reboot lease %q: %w", lease.Name, err)
+			}
+			return lease, nil
+		case holder == "":
+			candidates = append(candidates, lease)
+		case lease.Spec.RenewTime == nil || lease.Spec.RenewTime.Add(time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0))*time.Second).Before(now.Time):
+			log.Info("Reboot lease was not renewed by its holder in time, considering it stale", "lease", lease.Name, "holder", holder)
+			candidates = append(candidates, lease)
+		}
+	}
+
+	for _, lease := range candidates {
+		lease.Spec.HolderIdentity = &node.Name
+		lease.Spec.LeaseDurationSeconds = ptr.To(int32(leaseDuration.Seconds()))
+		lease.Spec.AcquireTime = &now
+		lease.Spec.RenewTime = &now
+
+		var err error
+		if lease.ResourceVersion == "" {
+			err = r.Client.Create(ctx, lease)
+		} else {
+			// The update fails with a conflict if another node acquired the lease in the meantime.
+			err = r.Client.Update(ctx, lease)
+		}
+		if err != nil {
+			if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed acquiring reboot lease %q: %w", lease.Name, err)
+		}
+
+		log.Info("Acquired reboot lease", "lease", lease.Name)
+		return lease, nil
+	}
+
+	return nil, nil
+}
+
+// releaseRebootLeases releases all reboot leases of the node's worker pool held by the node.
+func (r *Reconciler) releaseRebootLeases(ctx context.Context, log logr.Logger, node *corev1.Node) error {
+	workerPoolName := node.Labels[v1beta1constants.LabelWorkerPool]
+	if workerPoolName == "" {
+		return fmt.Errorf("node does not have the %q label", v1beta1constants.LabelWorkerPool)
+	}
+
+	for slot := range ptr.Deref(r.Config.Reboot.MaxConcurrentReboots, 1) {
+		lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: gardenerutils.NodeAgentRebootLeaseName(workerPoolName, slot), Namespace: metav1.NamespaceSystem}}
+		if err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(lease), lease); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed reading reboot lease %q: %w", lease.Name, err)
+		}
+
+		if ptr.Deref(lease.Spec.HolderIdentity, "") != node.Name {
+			continue
+		}
+
+		lease.Spec.HolderIdentity = nil
+		lease.Spec.AcquireTime = nil
+		if err := r.Client.Update(ctx, lease); err != nil {
+			return fmt.Errorf("failed releasing reboot lease %q: %w", lease.Name, err)
+		}
+		log.Info("Released reboot lease", "lease", lease.Name)
+	}
+
+	return nil
+}
+
+// cordonNode cordons the node before the given operation and records this with the given update function.
+func (r *Reconciler) cordonNode(ctx context.Context, log logr.Logger, node *corev1.Node, operation string, update func(func(*disruption)) error) error {
+	// Nodes which were already cordoned (e.g., by an operator) are not uncordoned after the operation.
+	if node.Spec.Unschedulable {
+		return nil
+	}
+
+	log.Info("Cordoning node before " + operation)
+	if err := update(func(d *disruption) { d.Cordoned = true }); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = true
+	return r.Client.Patch(ctx, node, patch)
+}
+
+// uncordonNode uncordons the node after the given operation if it was cordoned by gardener-node-agent.
+func (r *Reconciler) uncordonNode(ctx context.Context, log logr.Logger, node *corev1.Node, operation string, state disruption) error {
+	if !state.Cordoned {
+		return nil
+	}
+
+	log.Info("Uncordoning node after " + operation)
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = false
+	return r.Client.Patch(ctx, node, patch)
+}
+
+// drainNode evicts the pods from the node before the given operation. It returns true if all pods were evicted or if
+// the drain timeout is exceeded. Pods managed by DaemonSets and static pods are not evicted since they would be
+// recreated on the node anyway.
+func (r *Reconciler) drainNode(ctx context.Context, log logr.Logger, node *corev1.Node, operation string, state *disruption, update func(func(*disruption)) error, drainTimeout time.Duration) (bool, error) {
+	if state.DrainStartTime == nil {
+		log.Info("Draining node before " + operation)
+		if err := update(func(d *disruption) { d.DrainStartTime = ptr.To(metav1.NewTime(r.Clock.Now())) }); err != nil {
+			return false, err
+		}
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.APIReader.List(ctx, podList, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+		return false, fmt.Errorf("failed listing pods on node: %w", err)
+	}
+
+	var remainingPods []string
+	for _, pod := range podList.Items {
+		if !mustEvictPod(&pod) {
+			continue
+		}
+
+		remainingPods = append(remainingPods, client.ObjectKeyFromObject(&pod).String())
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		if err := r.Client.SubResource("eviction").Create(ctx, &pod, &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}); err != nil {
+			if apierrors.IsNotFound(err) {
+				remainingPods = remainingPods[:len(remainingPods)-1]
+				continue
+			}
+			// Evictions are rejected with 'Too Many Requests' if they would violate a PodDisruptionBudget.
+			if apierrors.IsTooManyRequests(err) {
+				log.Info("Eviction of pod is not allowed yet, retrying", "pod", client.ObjectKeyFromObject(&pod), "reason", err.Error())
+				continue
+			}
+			return false, fmt.Errorf("failed evicting pod %q: %w", client.ObjectKeyFromObject(&pod), err)
+		}
+	}
+
+	if len(remainingPods) == 0 {
+		log.Info("Node was drained successfully")
+		return true, nil
+	}
+
+	if r.Clock.Since(state.DrainStartTime.Time) >= drainTimeout {
+		log.Info("Draining node did not complete within the drain timeout, continuing with "+operation+" anyway", "drainTimeout", drainTimeout, "remainingPods", remainingPods)
+		return true, nil
+	}
+
+	log.Info("Waiting for pods to be evicted from node", "remainingPods", remainingPods)
+	return false, nil
+}
+
+func mustEvictPod(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+
+	if _, isMirrorPod := pod.Annotations[corev1.MirrorPodAnnotationKey]; isMirrorPod {
+		return false
+	}
+
+	if ownerRef := metav1.GetControllerOf(pod); ownerRef != nil && ownerRef.Kind == "DaemonSet" {
+		return false
+	}
+
+	return true
+}
+
+// isNodeReadySince returns true if the node is ready and the kubelet reported its status after the given time.
+func isNodeReadySince(node *corev1.Node, since *metav1.Time) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue && (since == nil || condition.LastHeartbeatTime.After(since.Time))
+		}
+	}
+	return false
+}
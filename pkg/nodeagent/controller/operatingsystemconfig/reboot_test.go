@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+)
+
+var _ = Describe("Reboot", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient client.Client
+		fakeDBus   *fakedbus.DBus
+		fakeFS     afero.Afero
+		fakeClock  *testclock.FakeClock
+		recorder   *record.FakeRecorder
+		reconciler *Reconciler
+
+		blockEvictions bool
+
+		node   *corev1.Node
+		pod    *corev1.Pod
+		dsPod  *corev1.Pod
+		secret *corev1.Secret
+
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Name: "osc-secret", Namespace: metav1.NamespaceSystem}}
+
+		bootIDPath   = "/proc/sys/kernel/random/boot_id"
+		rebootAction = fakedbus.SystemdAction{Action: fakedbus.ActionReboot, UnitNames: []string{"reboot"}}
+
+		encode = func(osc *extensionsv1alpha1.OperatingSystemConfig) []byte {
+			GinkgoHelper()
+			raw, err := yaml.Marshal(osc)
+			Expect(err).NotTo(HaveOccurred())
+			return raw
+		}
+
+		newLease = func(slot, holder string, renewTime time.Time) *coordinationv1.Lease {
+			return &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: "gardener-node-agent-reboot-worker-a-" + slot, Namespace: metav1.NamespaceSystem},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       &holder,
+					LeaseDurationSeconds: ptr.To[int32](3600),
+					RenewTime:            &metav1.MicroTime{Time: renewTime},
+				},
+			}
+		}
+
+		leaseHolder = func(slot string) string {
+			GinkgoHelper()
+			lease := &coordinationv1.Lease{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "gardener-node-agent-reboot-worker-a-" + slot, Namespace: metav1.NamespaceSystem}, lease)).To(Succeed())
+			return ptr.Deref(lease.Spec.HolderIdentity, "")
+		}
+
+		reconcileNode = func(expectedResult reconcile.Result) {
+			GinkgoHelper()
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expectedResult))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+		}
+
+		setNodeReady = func() {
+			GinkgoHelper()
+			patch := client.MergeFrom(node.DeepCopy())
+			node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastHeartbeatTime: metav1.NewTime(fakeClock.Now())}}
+			Expect(fakeClient.Status().Patch(ctx, node, patch)).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		blockEvictions = false
+
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:        "node",
+			Labels:      map[string]string{"worker.gardener.cloud/pool": "worker-a"},
+			Annotations: map[string]string{},
+		}}
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: node.Name},
+		}
+		dsPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "daemon",
+				Namespace:       "kube-system",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "DaemonSet", Name: "daemon", UID: "1", Controller: ptr.To(true)}},
+			},
+			Spec: corev1.PodSpec{NodeName: node.Name},
+		}
+
+		oldOSC := &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Files: []extensionsv1alpha1.File{{
+					Path:           "/etc/sysctl.d/99-foo.conf",
+					Content:        extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "old"}},
+					RequiresReboot: ptr.To(true),
+				}},
+			},
+		}
+		newOSC := oldOSC.DeepCopy()
+		newOSC.Spec.Files[0].Content.Inline.Data = "new"
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        request.Name,
+				Namespace:   request.Namespace,
+				Annotations: map[string]string{nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig: "new"},
+			},
+			Data: map[string][]byte{nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig: encode(newOSC)},
+		}
+
+		fakeDBus = fakedbus.New()
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		fakeClock = testclock.NewFakeClock(time.Now().Truncate(time.Second))
+		recorder = record.NewFakeRecorder(32)
+
+		// The node is in the state of the old operating system config.
+		Expect(fakeFS.WriteFile(nodeagentconfigv1alpha1.BaseDir+"/last-applied-osc.yaml", encode(oldOSC), 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/sysctl.d/99-foo.conf", []byte("old"), 0644)).To(Succeed())
+		Expect(fakeFS.WriteFile(bootIDPath, []byte("boot-1\n"), 0444)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node, secret, pod, dsPod).
+			WithStatusSubresource(&corev1.Node{}).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Pod).Spec.NodeName}
+			}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+					if blockEvictions {
+						return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+					}
+					return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+				},
+			}).
+			Build()
+
+		reconciler = &Reconciler{
+			Client:    fakeClient,
+			APIReader: fakeClient,
+			Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+				SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+				KubernetesVersion: semver.MustParse("1.31.1"),
+				Reboot: &nodeagentconfigv1alpha1.RebootConfig{
+					MaxConcurrentReboots: ptr.To[int32](2),
+					DrainTimeout:         &metav1.Duration{Duration: 10 * time.Minute},
+					LeaseDuration:        &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			Recorder:      recorder,
+			DBus:          fakeDBus,
+			FS:            fakeFS,
+			CancelContext: func() {},
+			NodeName:      node.Name,
+			Clock:         fakeClock,
+		}
+	})
+
+	It("should cordon, drain and reboot the node after acquiring a reboot lease", func() {
+		Expect(fakeClient.Create(ctx, newLease("0", "other-node", fakeClock.Now()))).To(Succeed())
+
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		data, err := fakeFS.ReadFile("/etc/sysctl.d/99-foo.conf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("new"))
+		Expect(leaseHolder("0")).To(Equal("other-node"))
+		Expect(leaseHolder("1")).To(Equal(node.Name))
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(BeNotFoundError())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(dsPod), dsPod)).To(Succeed())
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+
+		By("Reboot the node after it was drained")
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(fakeDBus.Actions).To(ContainElement(rebootAction))
+		Expect(recorder.Events).To(Receive(ContainSubstring("Rebooting")))
+		Expect(node.Annotations).NotTo(HaveKey(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig))
+
+		By("Wait for the node to become ready after the reboot")
+		fakeDBus.Actions = nil
+		Expect(fakeFS.WriteFile(bootIDPath, []byte("boot-2\n"), 0444)).To(Succeed())
+		fakeClock.Step(time.Minute)
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(leaseHolder("1")).To(Equal(node.Name))
+
+		By("Uncordon the node and release the lease when the node is ready")
+		setNodeReady()
+		reconcileNode(reconcile.Result{RequeueAfter: time.Minute})
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(leaseHolder("0")).To(Equal("other-node"))
+		Expect(leaseHolder("1")).To(BeEmpty())
+		Expect(node.Annotations).To(HaveKeyWithValue(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, "new"))
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+	})
+
+	It("should wait until a reboot lease of the worker pool is free", func() {
+		Expect(fakeClient.Create(ctx, newLease("0", "other-node", fakeClock.Now()))).To(Succeed())
+		Expect(fakeClient.Create(ctx, newLease("1", "another-node", fakeClock.Now().Add(-2*time.Hour)))).To(Succeed())
+		reconciler.Config.Reboot.MaxConcurrentReboots = ptr.To[int32](1)
+
+		reconcileNode(reconcile.Result{RequeueAfter: 30 * time.Second})
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+
+		By("Take over the lease when it is not renewed by its holder")
+		fakeClock.Step(2 * time.Hour)
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(leaseHolder("0")).To(Equal(node.Name))
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+	})
+
+	It("should reboot the node if draining does not complete within the drain timeout", func() {
+		blockEvictions = true
+
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+
+		fakeClock.Step(10 * time.Minute)
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(fakeDBus.Actions).To(ContainElement(rebootAction))
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+	})
+
+	It("should not uncordon the node if it was already cordoned before", func() {
+		patch := client.MergeFrom(node.DeepCopy())
+		node.Spec.Unschedulable = true
+		Expect(fakeClient.Patch(ctx, node, patch)).To(Succeed())
+		Expect(fakeClient.Delete(ctx, pod)).To(Succeed())
+
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(fakeDBus.Actions).To(ContainElement(rebootAction))
+
+		Expect(fakeFS.WriteFile(bootIDPath, []byte("boot-2\n"), 0444)).To(Succeed())
+		fakeClock.Step(time.Minute)
+		setNodeReady()
+		reconcileNode(reconcile.Result{RequeueAfter: time.Minute})
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(leaseHolder("0")).To(BeEmpty())
+	})
+
+	It("should reboot the node in coordination with the worker pool if a reboot was requested", func() {
+		node.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig] = "new"
+		Expect(fakeFS.WriteFile(nodeagentconfigv1alpha1.BaseDir+"/last-applied-osc.yaml", secret.Data[nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig], 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/sysctl.d/99-foo.conf", []byte("new"), 0644)).To(Succeed())
+		Expect(fakeClient.Update(ctx, node)).To(Succeed())
+
+		reconcileNode(reconcile.Result{})
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+
+		By("Wait until a reboot lease of the worker pool is free")
+		Expect(fakeClient.Create(ctx, newLease("0", "other-node", fakeClock.Now()))).To(Succeed())
+		Expect(fakeClient.Create(ctx, newLease("1", "another-node", fakeClock.Now()))).To(Succeed())
+		reconciler.RequestReboot()
+		reconcileNode(reconcile.Result{RequeueAfter: 30 * time.Second})
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+
+		By("Cordon and drain the node after acquiring a reboot lease")
+		Expect(fakeClient.Delete(ctx, newLease("1", "", fakeClock.Now()))).To(Succeed())
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(leaseHolder("1")).To(Equal(node.Name))
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(BeNotFoundError())
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+
+		By("Reboot the node after it was drained")
+		reconcileNode(reconcile.Result{RequeueAfter: 10 * time.Second})
+		Expect(fakeDBus.Actions).To(ContainElement(rebootAction))
+
+		By("Uncordon the node and release the lease when the node is ready")
+		fakeDBus.Actions = nil
+		Expect(fakeFS.WriteFile(bootIDPath, []byte("boot-2\n"), 0444)).To(Succeed())
+		fakeClock.Step(time.Minute)
+		setNodeReady()
+		reconcileNode(reconcile.Result{})
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(leaseHolder("1")).To(BeEmpty())
+		Expect(recorder.Events).To(Receive(ContainSubstring("Rebooting")))
+
+		By("Do not reboot the node again")
+		reconcileNode(reconcile.Result{})
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+	})
+
+	It("should not reboot the node if reboots are not enabled", func() {
+		reconciler.Config.Reboot = nil
+
+		reconcileNode(reconcile.Result{RequeueAfter: time.Minute})
+		Expect(fakeDBus.Actions).NotTo(ContainElement(rebootAction))
+		Expect(recorder.Events).To(Receive(ContainSubstring("RebootSkipped")))
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+		Expect(node.Annotations).To(HaveKeyWithValue(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, "new"))
+	})
+})
@@ -14,6 +14,8 @@ import (
 	"path"
 	"path/filepath"
 	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -27,8 +29,10 @@ import (
 	jsonserializer "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -64,6 +68,7 @@ func init() {
 // node.
 type Reconciler struct {
 	Client        client.Client
+	APIReader     client.Reader
 	Config        nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig
 	Recorder      record.EventRecorder
 	DBus          dbus.DBus
@@ -72,6 +77,18 @@ type Reconciler struct {
 	CancelContext context.CancelFunc
 	HostName      string
 	NodeName      string
+	Clock         clock.Clock
+	// KubeletHealthChecker is used to verify that the kubelet is healthy after an in-place update of the Kubernetes
+	// version of the node.
+	KubeletHealthChecker KubeletHealthChecker
+
+	// unitCommandResults contains the results of the last commands executed for the units, see UnitCommandResult.
+	unitCommandResults sync.Map
+	// rebootRequested is true if a reboot of the node was requested which was not recorded in the changes yet, see
+	// RequestReboot.
+	rebootRequested atomic.Bool
+	// rebootRequests triggers a reconciliation when a reboot of the node was requested.
+	rebootRequests chan event.GenericEvent
 }
 
 // Reconcile decodes the OperatingSystemConfig resources from secrets and applies the systemd units and files to the
@@ -107,6 +124,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, fmt.Errorf("failed extracting OSC from secret: %w", err)
 	}
 
+	if ptr.Deref(r.Config.DryRun, false) {
+		return r.reconcileDryRun(log, node, secret)
+	}
+
 	log.Info("Applying containerd configuration")
 	if err := r.ReconcileContainerdConfig(ctx, log, osc); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed reconciling containerd configuration: %w", err)
@@ -117,61 +138,69 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, fmt.Errorf("failed calculating the OSC changes: %w", err)
 	}
 
-	if node != nil && node.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig] == oscChecksum {
-		log.Info("Configuration on this node is up to date, nothing to be done")
-		return reconcile.Result{}, nil
+	if r.rebootRequested.Swap(false) {
+		log.Info("Reboot of the node was requested")
+		if err := oscChanges.updateReboot(func(reboot *reboot) { reboot.Required = true }); err != nil {
+			r.rebootRequested.Store(true)
+			return reconcile.Result{}, err
+		}
 	}
 
-	log.Info("Applying new or changed inline files")
-	if err := r.applyChangedInlineFiles(log, oscChanges); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed applying changed inline files: %w", err)
-	}
+	if node != nil && node.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig] == oscChecksum {
+		// Reboots requested while the configuration is up to date are performed the same way as reboots required by the
+		// changes of the configuration.
+		if oscChanges.Reboot.Required {
+			if rebooted, result, err := r.reconcileReboot(ctx, log, node, oscChanges); err != nil || !rebooted {
+				return result, err
+			}
+		}
 
-	log.Info("Applying containerd registries")
-	waitForRegistries, err := r.ReconcileContainerdRegistries(ctx, log, oscChanges)
-	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed reconciling containerd registries: %w", err)
-	}
+		if r.Config.DriftDetection != nil {
+			return r.reconcileDrift(ctx, log, node)
+		}
 
-	log.Info("Applying new or changed imageRef files")
-	if err := r.applyChangedImageRefFiles(ctx, log, oscChanges); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed applying changed imageRef files: %w", err)
+		log.Info("Configuration on this node is up to date, nothing to be done")
+		return reconcile.Result{}, nil
 	}
 
-	log.Info("Applying new or changed units", "changedUnits", len(oscChanges.Units.Changed))
-	if err := r.applyChangedUnits(ctx, log, oscChanges); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed applying changed units: %w", err)
+	if oscChanges.RolledBack {
+		log.Info("OperatingSystemConfig was rolled back after too many failed attempts, not applying it again until it changes", "failedAttempts", oscChanges.FailedAttempts)
+		return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
 	}
 
-	log.Info("Removing no longer needed units", "deletedUnits", len(oscChanges.Units.Deleted))
-	if err := r.removeDeletedUnits(ctx, log, node, oscChanges); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed removing deleted units: %w", err)
+	if oscChanges.InPlaceUpdate.Required && node != nil {
+		if prepared, result, err := r.prepareInPlaceUpdate(ctx, log, node, oscChanges); err != nil || !prepared {
+			return result, err
+		}
 	}
 
-	log.Info("Reloading systemd daemon")
-	if err := r.DBus.DaemonReload(ctx); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed reloading systemd daemon: %w", err)
+	if r.Config.RollbackAfterFailedAttempts != nil {
+		if err := r.snapshotForRollback(log, oscChanges); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed storing snapshot for rollback: %w", err)
+		}
 	}
 
-	log.Info("Executing unit commands (start/stop)", "unitCommands", len(oscChanges.Units.Commands))
-	if err := r.executeUnitCommands(ctx, log, node, oscChanges); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed executing unit commands: %w", err)
+	applyStart := r.Clock.Now()
+	if err := r.applyChanges(ctx, log, node, osc, oscChanges); err != nil {
+		if reportErr := r.reportFailedApply(ctx, node, oscChecksum, applyStart, err); reportErr != nil {
+			log.Error(reportErr, "Failed reporting apply status")
+		}
+		return r.handleFailedAttempt(ctx, log, node, oscChanges, err)
 	}
 
-	// After the node is prepared, we can wait for the registries to be configured.
-	// The ones with readiness probes should also succeed here since their cache/mirror pods
-	// can now start as workload in the cluster.
-	log.Info("Waiting for containerd registries to be configured")
-	if err := waitForRegistries(); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed configuring containerd registries: %w", err)
-	}
+	log.Info("Successfully applied operating system config")
 
-	log.Info("Removing no longer needed files")
-	if err := r.removeDeletedFiles(log, oscChanges); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed removing deleted files: %w", err)
+	if oscChanges.InPlaceUpdate.Prepared && oscChanges.InPlaceUpdate.ApplyTime == nil {
+		if err := oscChanges.updateInPlaceUpdate(func(inPlaceUpdate *inPlaceUpdate) {
+			inPlaceUpdate.ApplyTime = ptr.To(metav1.NewTime(r.Clock.Now()))
+		}); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
-	log.Info("Successfully applied operating system config")
+	if err := r.FS.RemoveAll(rollbackDir); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed removing rollback snapshot %q: %w", rollbackDir, err)
+	}
 
 	log.Info("Persisting current operating system config as 'last-applied' file to the disk", "path", lastAppliedOperatingSystemConfigFilePath)
 	oscRaw, err := runtime.Encode(codec, osc)
@@ -205,14 +234,164 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, fmt.Errorf("failed removing bootstrap token file %q: %w", nodeagentconfigv1alpha1.BootstrapTokenFilePath, err)
 	}
 
+	if oscChanges.Reboot.Required {
+		if rebooted, result, err := r.reconcileReboot(ctx, log, node, oscChanges); err != nil || !rebooted {
+			return result, err
+		}
+	}
+
+	if oscChanges.InPlaceUpdate.Prepared {
+		if updated, result, err := r.completeInPlaceUpdate(ctx, log, node, oscChanges); err != nil || !updated {
+			return result, err
+		}
+	}
+
+	if err := r.setNodeCondition(ctx, node, nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigRolledBack, corev1.ConditionFalse, "OperatingSystemConfigApplied", "Operating system config has been applied successfully"); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed resetting rollback condition on node: %w", err)
+	}
+
 	r.Recorder.Event(node, corev1.EventTypeNormal, "OSCApplied", "Operating system config has been applied successfully")
 	patch := client.MergeFrom(node.DeepCopy())
 	metav1.SetMetaDataLabel(&node.ObjectMeta, v1beta1constants.LabelWorkerKubernetesVersion, r.Config.KubernetesVersion.String())
 	metav1.SetMetaDataAnnotation(&node.ObjectMeta, nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, oscChecksum)
+	if err := setApplyStatusAnnotation(node, r.computeApplyStatus(oscChecksum, applyStart, nil)); err != nil {
+		return reconcile.Result{}, err
+	}
 
 	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, r.Client.Patch(ctx, node, patch)
 }
 
+// applyChanges applies the given changes of the given operating system config to the node.
+func (r *Reconciler) applyChanges(ctx context.Context, log logr.Logger, node *corev1.Node, osc *extensionsv1alpha1.OperatingSystemConfig, changes *operatingSystemConfigChanges) error {
+	log.Info("Applying new or changed inline files")
+	if err := r.applyChangedInlineFiles(log, changes); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepFiles, fmt.Errorf("failed applying changed inline files: %w", err))
+	}
+
+	log.Info("Applying containerd registries")
+	waitForRegistries, err := r.ReconcileContainerdRegistries(ctx, log, changes)
+	if err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepContainerdRegistries, fmt.Errorf("failed reconciling containerd registries: %w", err))
+	}
+
+	log.Info("Applying new or changed imageRef files")
+	if err := r.applyChangedImageRefFiles(ctx, log, changes, registriesOf(osc)); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepFiles, fmt.Errorf("failed applying changed imageRef files: %w", err))
+	}
+
+	log.Info("Applying new or changed units", "changedUnits", len(changes.Units.Changed))
+	if err := r.applyChangedUnits(ctx, log, changes); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepUnits, fmt.Errorf("failed applying changed units: %w", err))
+	}
+
+	log.Info("Removing no longer needed units", "deletedUnits", len(changes.Units.Deleted))
+	if err := r.removeDeletedUnits(ctx, log, node, changes); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepUnits, fmt.Errorf("failed removing deleted units: %w", err))
+	}
+
+	log.Info("Reloading systemd daemon")
+	if err := r.DBus.DaemonReload(ctx); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepUnits, fmt.Errorf("failed reloading systemd daemon: %w", err))
+	}
+
+	log.Info("Executing unit commands (start/stop)", "unitCommands", len(changes.Units.Commands))
+	if err := r.executeUnitCommands(ctx, log, node, changes); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepUnitCommands, fmt.Errorf("failed executing unit commands: %w", err))
+	}
+
+	// After the node is prepared, we can wait for the registries to be configured.
+	// The ones with readiness probes should also succeed here since their cache/mirror pods
+	// can now start as workload in the cluster.
+	log.Info("Waiting for containerd registries to be configured")
+	if err := waitForRegistries(); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepContainerdRegistries, fmt.Errorf("failed configuring containerd registries: %w", err))
+	}
+
+	log.Info("Removing no longer needed files")
+	if err := r.removeDeletedFiles(log, changes); err != nil {
+		return withApplyStep(nodeagentconfigv1alpha1.OperatingSystemConfigApplyStepDeletedFiles, fmt.Errorf("failed removing deleted files: %w", err))
+	}
+
+	return nil
+}
+
+// handleFailedAttempt counts the failed attempt to apply the given changes and rolls them back if the configured number
+// of failed attempts is reached.
+func (r *Reconciler) handleFailedAttempt(ctx context.Context, log logr.Logger, node *corev1.Node, changes *operatingSystemConfigChanges, applyErr error) (reconcile.Result, error) {
+	failedAttempts, err := changes.failedAttempt()
+	if err != nil {
+		return reconcile.Result{}, errors.Join(applyErr, err)
+	}
+
+	if r.Config.RollbackAfterFailedAttempts == nil || failedAttempts < *r.Config.RollbackAfterFailedAttempts {
+		return reconcile.Result{}, applyErr
+	}
+
+	if snapshotExists, err := r.FS.Exists(rollbackSnapshotFilePath); err != nil {
+		return reconcile.Result{}, errors.Join(applyErr, fmt.Errorf("failed checking whether rollback snapshot %q exists: %w", rollbackSnapshotFilePath, err))
+	} else if !snapshotExists {
+		log.Info("Failed applying OperatingSystemConfig too often, but there is no snapshot to roll back to", "failedAttempts", failedAttempts)
+		return reconcile.Result{}, applyErr
+	}
+
+	log.Info("Failed applying OperatingSystemConfig too often, rolling back to last applied OperatingSystemConfig", "failedAttempts", failedAttempts, "error", applyErr.Error())
+	mustRestartNodeAgent, err := r.rollback(ctx, log, node, changes)
+	if err != nil {
+		message := fmt.Sprintf("Rolling back operating system config with checksum %s after %d failed attempts failed: %v", changes.OperatingSystemConfigChecksum, failedAttempts, err)
+		if node != nil {
+			r.Recorder.Event(node, corev1.EventTypeWarning, "OSCRollbackFailed", message)
+		}
+		return reconcile.Result{}, errors.Join(applyErr, err, r.setNodeCondition(ctx, node, nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigRolledBack, corev1.ConditionTrue, "RollbackFailed", message))
+	}
+
+	if err := changes.setRolledBack(); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	message := fmt.Sprintf("Operating system config with checksum %s was rolled back to the last applied one after %d failed attempts: %v", changes.OperatingSystemConfigChecksum, failedAttempts, applyErr)
+	if node != nil {
+		r.Recorder.Event(node, corev1.EventTypeWarning, "OSCRolledBack", message)
+	}
+	if err := r.setNodeCondition(ctx, node, nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigRolledBack, corev1.ConditionTrue, "RollbackSucceeded", message); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed setting rollback condition on node: %w", err)
+	}
+
+	if mustRestartNodeAgent {
+		log.Info("Must restart myself (gardener-node-agent unit) to complete the rollback, canceling the context to initiate graceful shutdown")
+		r.CancelContext()
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+}
+
+func (r *Reconciler) reconcileDryRun(log logr.Logger, node *corev1.Node, secret *corev1.Secret) (reconcile.Result, error) {
+	plan, err := ComputePlan(r.FS, secret)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if plan.IsEmpty() {
+		log.Info("Dry run: Configuration on this node is up to date, nothing would be done")
+		return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+	}
+
+	log.Info("Dry run: Not applying operating system config, computed changes",
+		"changedFiles", plan.Files.Changed,
+		"deletedFiles", plan.Files.Deleted,
+		"changedUnits", plan.Units.Changed,
+		"deletedUnits", plan.Units.Deleted,
+		"unitCommands", plan.UnitCommands,
+		"containerd", plan.Containerd,
+	)
+
+	if node != nil {
+		r.Recorder.Event(node, corev1.EventTypeNormal, "OSCDryRun", "Operating system config has not been applied since dry run is enabled")
+	}
+
+	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+}
+
 func (r *Reconciler) getNode(ctx context.Context) (*corev1.Node, bool, error) {
 	if r.NodeName != "" {
 		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: r.NodeName}}
@@ -250,13 +429,16 @@ func getFilePermissions(file extensionsv1alpha1.File) os.FileMode {
 	return permissions
 }
 
-func (r *Reconciler) applyChangedImageRefFiles(ctx context.Context, log logr.Logger, changes *operatingSystemConfigChanges) error {
+// applyChangedImageRefFiles copies the changed files from their images. The given registries are used to pull the
+// images via their mirrors. They must be taken from the operating system config instead of the changes since the
+// registries are removed from the changes as soon as they were configured in containerd.
+func (r *Reconciler) applyChangedImageRefFiles(ctx context.Context, log logr.Logger, changes *operatingSystemConfigChanges, registries []extensionsv1alpha1.RegistryConfig) error {
 	for _, file := range slices.Clone(changes.Files.Changed) {
 		if file.Content.ImageRef == nil {
 			continue
 		}
 
-		if err := r.Extractor.CopyFromImage(ctx, file.Content.ImageRef.Image, file.Content.ImageRef.FilePathInImage, file.Path, getFilePermissions(file)); err != nil {
+		if err := r.Extractor.CopyFromImage(ctx, file.Content.ImageRef.Image, file.Content.ImageRef.FilePathInImage, file.Path, getFilePermissions(file), registries); err != nil {
 			return fmt.Errorf("unable to copy file %q from image %q to %q: %w", file.Content.ImageRef.FilePathInImage, file.Content.ImageRef.Image, file.Path, err)
 		}
 
@@ -490,7 +672,9 @@ func (r *Reconciler) executeUnitCommands(ctx context.Context, log logr.Logger, n
 		fns []flow.TaskFn
 
 		restart = func(ctx context.Context, unitName string) error {
-			if err := r.DBus.Restart(ctx, r.Recorder, node, unitName); err != nil {
+			err := r.DBus.Restart(ctx, r.Recorder, node, unitName)
+			r.recordUnitCommandResult(unitName, extensionsv1alpha1.CommandRestart, err)
+			if err != nil {
 				return fmt.Errorf("unable to restart unit %q: %w", unitName, err)
 			}
 			log.Info("Successfully restarted unit", "unitName", unitName)
@@ -505,7 +689,9 @@ func (r *Reconciler) executeUnitCommands(ctx context.Context, log logr.Logger, n
 		}
 
 		stop = func(ctx context.Context, unitName string) error {
-			if err := r.DBus.Stop(ctx, r.Recorder, node, unitName); err != nil {
+			err := r.DBus.Stop(ctx, r.Recorder, node, unitName)
+			r.recordUnitCommandResult(unitName, extensionsv1alpha1.CommandStop, err)
+			if err != nil {
 				return fmt.Errorf("unable to stop unit %q: %w", unitName, err)
 			}
 			log.Info("Successfully stopped unit", "unitName", unitName)
@@ -549,3 +735,46 @@ func (r *Reconciler) executeUnitCommands(ctx context.Context, log logr.Logger, n
 
 	return flow.Parallel(fns...)(ctx)
 }
+
+// setNodeCondition sets the condition with the given type on the node. A condition with status False is only added
+// if the condition already exists to avoid reporting conditions which never applied.
+func (r *Reconciler) setNodeCondition(ctx context.Context, node *corev1.Node, conditionType corev1.NodeConditionType, status corev1.ConditionStatus, reason, message string) error {
+	if node == nil {
+		return nil
+	}
+
+	var (
+		now       = metav1.NewTime(r.Clock.Now())
+		patch     = client.StrategicMergeFrom(node.DeepCopy())
+		condition = corev1.NodeCondition{
+			Type:               conditionType,
+			Status:             status,
+			LastHeartbeatTime:  now,
+			LastTransitionTime: now,
+			Reason:             reason,
+			Message:            message,
+		}
+		found bool
+	)
+
+	for i, c := range node.Status.Conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+
+		found = true
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+		node.Status.Conditions[i] = condition
+	}
+
+	if !found {
+		if status == corev1.ConditionFalse {
+			return nil
+		}
+		node.Status.Conditions = append(node.Status.Conditions, condition)
+	}
+
+	return r.Client.Status().Patch(ctx, node, patch)
+}
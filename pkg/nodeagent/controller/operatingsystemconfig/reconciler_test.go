@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient client.Client
+		fakeFS     afero.Afero
+		extractor  *recordingExtractor
+		reconciler *Reconciler
+
+		node     *corev1.Node
+		secret   *corev1.Secret
+		registry extensionsv1alpha1.RegistryConfig
+
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Name: "osc-secret", Namespace: metav1.NamespaceSystem}}
+	)
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}}
+
+		registry = extensionsv1alpha1.RegistryConfig{
+			Upstream: "registry.example.com",
+			Server:   ptr.To("https://registry.example.com"),
+			Hosts:    []extensionsv1alpha1.RegistryHost{{URL: "https://mirror.example.com"}},
+		}
+
+		osc := &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				CRIConfig: &extensionsv1alpha1.CRIConfig{
+					Name:       extensionsv1alpha1.CRINameContainerD,
+					Containerd: &extensionsv1alpha1.ContainerdConfig{Registries: []extensionsv1alpha1.RegistryConfig{registry}},
+				},
+				Files: []extensionsv1alpha1.File{{
+					Path: "/opt/bin/foo",
+					Content: extensionsv1alpha1.FileContent{ImageRef: &extensionsv1alpha1.FileContentImageRef{
+						Image:           "registry.example.com/foo:v1",
+						FilePathInImage: "/foo",
+					}},
+				}},
+			},
+		}
+		raw, err := yaml.Marshal(osc)
+		Expect(err).NotTo(HaveOccurred())
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        request.Name,
+				Namespace:   request.Namespace,
+				Annotations: map[string]string{nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig: "checksum"},
+			},
+			Data: map[string][]byte{nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig: raw},
+		}
+
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node, secret).
+			WithStatusSubresource(&corev1.Node{}).
+			Build()
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		extractor = &recordingExtractor{fs: fakeFS}
+
+		// Prevent invoking the containerd binary for generating the default configuration.
+		Expect(fakeFS.WriteFile("/etc/containerd/config.toml", nil, 0644)).To(Succeed())
+
+		reconciler = &Reconciler{
+			Client:    fakeClient,
+			APIReader: fakeClient,
+			Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+				SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+				KubernetesVersion: semver.MustParse("1.31.1"),
+			},
+			Recorder:      record.NewFakeRecorder(32),
+			DBus:          fakedbus.New(),
+			FS:            fakeFS,
+			Extractor:     extractor,
+			CancelContext: func() {},
+			NodeName:      node.Name,
+			Clock:         testclock.NewFakeClock(time.Now()),
+		}
+	})
+
+	It("should pull the files from images via the configured registry mirrors", func() {
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeFS.ReadFile("/opt/bin/foo")).To(Equal([]byte("registry.example.com/foo:v1")))
+		Expect(fakeFS.ReadFile("/etc/containerd/certs.d/registry.example.com/hosts.toml")).To(ContainSubstring("mirror.example.com"))
+		Expect(extractor.registries).To(ConsistOf(registry))
+	})
+})
+
+// recordingExtractor writes the image reference to the destination and records the registries used for pulling the
+// image.
+type recordingExtractor struct {
+	fs         afero.Afero
+	registries []extensionsv1alpha1.RegistryConfig
+}
+
+func (e *recordingExtractor) CopyFromImage(_ context.Context, imageRef string, _ string, destination string, permissions os.FileMode, registries []extensionsv1alpha1.RegistryConfig) error {
+	e.registries = registries
+	return e.fs.WriteFile(destination, []byte(imageRef), permissions)
+}
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	filespkg "github.com/gardener/gardener/pkg/nodeagent/files"
+)
+
+const (
+	rollbackDir                  = nodeagentconfigv1alpha1.BaseDir + "/rollback"
+	rollbackSnapshotFilePath     = rollbackDir + "/snapshot.yaml"
+	rollbackSnapshotContentsPath = rollbackDir + "/files"
+)
+
+// rollbackSnapshot contains the state of the files and units on the node before an operating system config is applied.
+type rollbackSnapshot struct {
+	// OperatingSystemConfigChecksum is the checksum of the operating system config which is about to be applied.
+	OperatingSystemConfigChecksum string `json:"operatingSystemConfigChecksum"`
+	// Files are the files which are about to be overwritten or removed.
+	Files []snapshotFile `json:"files,omitempty"`
+	// Units are the units which are about to be changed or removed.
+	Units []snapshotUnit `json:"units,omitempty"`
+}
+
+type snapshotFile struct {
+	// Path is the path of the file on the node.
+	Path string `json:"path"`
+	// Existed is true if the file existed before. Its content is stored below rollbackSnapshotContentsPath.
+	Existed bool `json:"existed"`
+	// Permissions are the permissions of the file.
+	Permissions os.FileMode `json:"permissions,omitempty"`
+}
+
+type snapshotUnit struct {
+	// Name is the name of the unit.
+	Name string `json:"name"`
+	// Existed is true if the unit is part of the last applied operating system config.
+	Existed bool `json:"existed"`
+	// Enable is true if the unit was enabled according to the last applied operating system config.
+	Enable bool `json:"enable,omitempty"`
+	// Command is the command of the unit according to the last applied operating system config.
+	Command extensionsv1alpha1.UnitCommand `json:"command,omitempty"`
+}
+
+// snapshotForRollback stores the current state of all files and units which are affected by the given changes, unless
+// there is already a snapshot for the same operating system config. Nothing is stored if there is no last applied
+// operating system config, since there is nothing to roll back to in this case.
+func (r *Reconciler) snapshotForRollback(log logr.Logger, changes *operatingSystemConfigChanges) error {
+	if snapshot, err := loadRollbackSnapshot(r.FS); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+		return err
+	} else if snapshot != nil && snapshot.OperatingSystemConfigChecksum == changes.OperatingSystemConfigChecksum {
+		return nil
+	}
+
+	if err := r.FS.RemoveAll(rollbackDir); err != nil {
+		return fmt.Errorf("failed removing old rollback snapshot %q: %w", rollbackDir, err)
+	}
+
+	oldOSCRaw, err := r.FS.ReadFile(lastAppliedOperatingSystemConfigFilePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil
+		}
+		return fmt.Errorf("error reading last applied OSC from file path %s: %w", lastAppliedOperatingSystemConfigFilePath, err)
+	}
+
+	oldOSC := &extensionsv1alpha1.OperatingSystemConfig{}
+	if err := runtime.DecodeInto(decoder, oldOSCRaw, oldOSC); err != nil {
+		return fmt.Errorf("unable to decode the old OSC read from file path %s: %w", lastAppliedOperatingSystemConfigFilePath, err)
+	}
+
+	oldUnits := make(map[string]extensionsv1alpha1.Unit)
+	for _, unit := range mergeUnits(oldOSC.Spec.Units, oldOSC.Status.ExtensionUnits) {
+		oldUnits[unit.Name] = unit
+	}
+
+	var (
+		snapshot = &rollbackSnapshot{OperatingSystemConfigChecksum: changes.OperatingSystemConfigChecksum}
+		paths    = sets.New[string]()
+		units    = sets.New[string]()
+	)
+
+	for _, file := range slices.Concat(changes.Files.Changed, changes.Files.Deleted) {
+		paths.Insert(file.Path)
+	}
+
+	addUnit := func(unit extensionsv1alpha1.Unit) error {
+		units.Insert(unit.Name)
+
+		unitFilePath := path.Join(etcSystemdSystem, unit.Name)
+		paths.Insert(unitFilePath)
+		for _, dropIn := range unit.DropIns {
+			paths.Insert(path.Join(unitFilePath+".d", dropIn.Name))
+		}
+
+		existingDropIns, err := r.FS.ReadDir(unitFilePath + ".d")
+		if err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			return fmt.Errorf("unable to read drop-in directory of unit %q: %w", unit.Name, err)
+		}
+		for _, dropIn := range existingDropIns {
+			if !dropIn.IsDir() {
+				paths.Insert(path.Join(unitFilePath+".d", dropIn.Name()))
+			}
+		}
+		return nil
+	}
+
+	for _, unit := range changes.Units.Changed {
+		if err := addUnit(unit.Unit); err != nil {
+			return err
+		}
+	}
+	for _, unit := range changes.Units.Deleted {
+		if err := addUnit(unit); err != nil {
+			return err
+		}
+	}
+
+	for _, filePath := range sets.List(paths) {
+		file := snapshotFile{Path: filePath}
+
+		info, err := r.FS.Stat(filePath)
+		if err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			return fmt.Errorf("unable to check whether file %q exists: %w", filePath, err)
+		}
+
+		if err == nil && info.Mode().IsRegular() {
+			file.Existed = true
+			file.Permissions = info.Mode().Perm()
+			if err := r.copyFile(filePath, path.Join(rollbackSnapshotContentsPath, filePath), file.Permissions); err != nil {
+				return fmt.Errorf("unable to store snapshot of file %q: %w", filePath, err)
+			}
+		}
+
+		snapshot.Files = append(snapshot.Files, file)
+	}
+
+	for _, name := range sets.List(units) {
+		unit := snapshotUnit{Name: name}
+		if oldUnit, ok := oldUnits[name]; ok {
+			unit.Existed = true
+			unit.Enable = ptr.Deref(oldUnit.Enable, true)
+			unit.Command = ptr.Deref(oldUnit.Command, "")
+		}
+		snapshot.Units = append(snapshot.Units, unit)
+	}
+
+	out, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed marshalling the rollback snapshot into YAML: %w", err)
+	}
+	if err := r.FS.MkdirAll(rollbackDir, defaultDirPermissions); err != nil {
+		return fmt.Errorf("unable to create directory %q: %w", rollbackDir, err)
+	}
+	if err := r.FS.WriteFile(rollbackSnapshotFilePath, out, 0600); err != nil {
+		return fmt.Errorf("unable to write rollback snapshot to %q: %w", rollbackSnapshotFilePath, err)
+	}
+
+	log.Info("Stored snapshot of files and units for rollback", "files", len(snapshot.Files), "units", len(snapshot.Units))
+	return nil
+}
+
+func loadRollbackSnapshot(fs afero.Afero) (*rollbackSnapshot, error) {
+	raw, err := fs.ReadFile(rollbackSnapshotFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &rollbackSnapshot{}
+	if err := yaml.Unmarshal(raw, snapshot); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling rollback snapshot from %q: %w", rollbackSnapshotFilePath, err)
+	}
+	return snapshot, nil
+}
+
+// rollback restores the files and units stored in the rollback snapshot of the given changes. It stops and disables
+// units which did not exist before and restarts the ones which existed. It continues on errors and returns all of them.
+// The returned bool is true if gardener-node-agent must be restarted to complete the rollback.
+func (r *Reconciler) rollback(ctx context.Context, log logr.Logger, node client.Object, changes *operatingSystemConfigChanges) (bool, error) {
+	snapshot, err := loadRollbackSnapshot(r.FS)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return false, fmt.Errorf("no rollback snapshot found")
+		}
+		return false, err
+	}
+	if snapshot.OperatingSystemConfigChecksum != changes.OperatingSystemConfigChecksum {
+		return false, fmt.Errorf("rollback snapshot belongs to a different operating system config (checksum %q)", snapshot.OperatingSystemConfigChecksum)
+	}
+
+	var (
+		errs                 []error
+		mustRestartNodeAgent bool
+	)
+
+	for _, unit := range snapshot.Units {
+		if unit.Existed || unit.Name == nodeagentconfigv1alpha1.UnitName {
+			continue
+		}
+
+		log.Info("Stopping and disabling unit which did not exist before", "unitName", unit.Name)
+		if err := r.DBus.Stop(ctx, r.Recorder, node, unit.Name); err != nil {
+			errs = append(errs, fmt.Errorf("unable to stop unit %q: %w", unit.Name, err))
+		}
+		if err := r.DBus.Disable(ctx, unit.Name); err != nil {
+			errs = append(errs, fmt.Errorf("unable to disable unit %q: %w", unit.Name, err))
+		}
+	}
+
+	for _, file := range snapshot.Files {
+		if !file.Existed {
+			if err := r.FS.Remove(file.Path); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+				errs = append(errs, fmt.Errorf("unable to remove file %q: %w", file.Path, err))
+			}
+			continue
+		}
+
+		if err := r.copyFile(path.Join(rollbackSnapshotContentsPath, file.Path), file.Path, file.Permissions); err != nil {
+			errs = append(errs, fmt.Errorf("unable to restore file %q: %w", file.Path, err))
+			continue
+		}
+		log.Info("Restored file", "path", file.Path)
+	}
+
+	log.Info("Reloading systemd daemon")
+	if err := r.DBus.DaemonReload(ctx); err != nil {
+		return false, errors.Join(append(errs, fmt.Errorf("failed reloading systemd daemon: %w", err))...)
+	}
+
+	for _, unit := range snapshot.Units {
+		if !unit.Existed {
+			continue
+		}
+
+		if unit.Name == nodeagentconfigv1alpha1.UnitName {
+			mustRestartNodeAgent = true
+			continue
+		}
+
+		if unit.Enable {
+			if err := r.DBus.Enable(ctx, unit.Name); err != nil {
+				errs = append(errs, fmt.Errorf("unable to enable unit %q: %w", unit.Name, err))
+			}
+		} else if err := r.DBus.Disable(ctx, unit.Name); err != nil {
+			errs = append(errs, fmt.Errorf("unable to disable unit %q: %w", unit.Name, err))
+		}
+
+		switch unit.Command {
+		case extensionsv1alpha1.CommandStop:
+			if err := r.DBus.Stop(ctx, r.Recorder, node, unit.Name); err != nil {
+				errs = append(errs, fmt.Errorf("unable to stop unit %q: %w", unit.Name, err))
+			}
+		default:
+			if err := r.DBus.Restart(ctx, r.Recorder, node, unit.Name); err != nil {
+				errs = append(errs, fmt.Errorf("unable to restart unit %q: %w", unit.Name, err))
+			}
+		}
+		log.Info("Restored unit", "unitName", unit.Name)
+	}
+
+	return mustRestartNodeAgent, errors.Join(errs...)
+}
+
+// copyFile copies the source file to the destination. In contrast to filespkg.Copy, missing parent directories are
+// created with the default directory permissions instead of the file permissions.
+func (r *Reconciler) copyFile(source, destination string, permissions os.FileMode) error {
+	if err := r.FS.MkdirAll(filepath.Dir(destination), defaultDirPermissions); err != nil {
+		return fmt.Errorf("unable to create directory %q: %w", filepath.Dir(destination), err)
+	}
+	return filespkg.Copy(r.FS, source, destination, permissions)
+}
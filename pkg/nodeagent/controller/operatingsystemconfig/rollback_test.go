@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+)
+
+var _ = Describe("Rollback", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+		fakeClient client.Client
+		fakeDBus   *fakedbus.DBus
+		fakeFS     afero.Afero
+		recorder   *record.FakeRecorder
+		reconciler *Reconciler
+
+		node   *corev1.Node
+		secret *corev1.Secret
+		oldOSC *extensionsv1alpha1.OperatingSystemConfig
+		newOSC *extensionsv1alpha1.OperatingSystemConfig
+
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Name: "osc-secret", Namespace: metav1.NamespaceSystem}}
+
+		lastAppliedOSCPath = nodeagentconfigv1alpha1.BaseDir + "/last-applied-osc.yaml"
+
+		encode = func(osc *extensionsv1alpha1.OperatingSystemConfig) []byte {
+			GinkgoHelper()
+			raw, err := yaml.Marshal(osc)
+			Expect(err).NotTo(HaveOccurred())
+			return raw
+		}
+
+		updateSecret = func(osc *extensionsv1alpha1.OperatingSystemConfig, checksum string) {
+			GinkgoHelper()
+			patch := client.MergeFrom(secret.DeepCopy())
+			secret.Annotations[nodeagentconfigv1alpha1.AnnotationKeyChecksumDownloadedOperatingSystemConfig] = checksum
+			secret.Data[nodeagentconfigv1alpha1.DataKeyOperatingSystemConfig] = encode(osc)
+			Expect(fakeClient.Patch(ctx, secret, patch)).To(Succeed())
+		}
+
+		expectFile = func(path, content string) {
+			GinkgoHelper()
+			data, err := fakeFS.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(content))
+		}
+
+		rolledBackCondition = func() *corev1.NodeCondition {
+			GinkgoHelper()
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == nodeagentconfigv1alpha1.NodeConditionTypeOperatingSystemConfigRolledBack {
+					return &condition
+				}
+			}
+			return nil
+		}
+	)
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node", Annotations: map[string]string{}}}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        request.Name,
+				Namespace:   request.Namespace,
+				Annotations: map[string]string{},
+			},
+			Data: map[string][]byte{},
+		}
+
+		oldOSC = &extensionsv1alpha1.OperatingSystemConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(), Kind: "OperatingSystemConfig"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Units: []extensionsv1alpha1.Unit{{
+					Name:    "foo.service",
+					Command: ptr.To(extensionsv1alpha1.CommandRestart),
+					Enable:  ptr.To(true),
+					Content: ptr.To("old-unit"),
+				}},
+				Files: []extensionsv1alpha1.File{{
+					Path:    "/etc/foo",
+					Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "old"}},
+				}},
+			},
+		}
+
+		newOSC = oldOSC.DeepCopy()
+		newOSC.Spec.Units[0].Content = ptr.To("new-unit")
+		newOSC.Spec.Units = append(newOSC.Spec.Units, extensionsv1alpha1.Unit{
+			Name:    "bar.service",
+			Command: ptr.To(extensionsv1alpha1.CommandRestart),
+			Enable:  ptr.To(true),
+			Content: ptr.To("bar-unit"),
+		})
+		newOSC.Spec.Files[0].Content.Inline.Data = "new"
+		newOSC.Spec.Files = append(newOSC.Spec.Files, extensionsv1alpha1.File{
+			Path:    "/etc/bar",
+			Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "bar"}},
+		})
+
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithObjects(node, secret).
+			WithStatusSubresource(&corev1.Node{}).
+			Build()
+		fakeDBus = fakedbus.New()
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		recorder = record.NewFakeRecorder(32)
+
+		reconciler = &Reconciler{
+			Client: fakeClient,
+			Config: nodeagentconfigv1alpha1.OperatingSystemConfigControllerConfig{
+				SyncPeriod:                  &metav1.Duration{Duration: time.Minute},
+				KubernetesVersion:           semver.MustParse("1.31.1"),
+				RollbackAfterFailedAttempts: ptr.To[int32](1),
+			},
+			Recorder:      recorder,
+			DBus:          fakeDBus,
+			FS:            fakeFS,
+			CancelContext: func() {},
+			NodeName:      node.Name,
+			Clock:         testclock.NewFakeClock(time.Now()),
+		}
+
+		// The node is in the state of the old operating system config.
+		Expect(fakeFS.WriteFile(lastAppliedOSCPath, encode(oldOSC), 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/systemd/system/foo.service", []byte("old-unit"), 0600)).To(Succeed())
+		Expect(fakeFS.WriteFile("/etc/foo", []byte("old"), 0644)).To(Succeed())
+
+		updateSecret(newOSC, "new")
+		fakeDBus.InjectRestartFailure(fmt.Errorf("injected failure"), "foo.service")
+	})
+
+	It("should roll back the files and units after the configured number of failed attempts", func() {
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Minute}))
+
+		expectFile("/etc/foo", "old")
+		expectFile("/etc/systemd/system/foo.service", "old-unit")
+		Expect(fakeFS.Exists("/etc/bar")).To(BeFalse())
+		Expect(fakeFS.Exists("/etc/systemd/system/bar.service")).To(BeFalse())
+
+		Expect(fakeDBus.Actions).To(ContainElements(
+			fakedbus.SystemdAction{Action: fakedbus.ActionStop, UnitNames: []string{"bar.service"}},
+			fakedbus.SystemdAction{Action: fakedbus.ActionDisable, UnitNames: []string{"bar.service"}},
+		))
+		Expect(fakeDBus.Actions[len(fakeDBus.Actions)-1]).To(Equal(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"foo.service"}}))
+
+		condition := rolledBackCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("RollbackSucceeded"))
+		Expect(condition.Message).To(ContainSubstring("injected failure"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("OSCRolledBack")))
+		Expect(node.Annotations).NotTo(HaveKey(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig))
+
+		By("Do not apply the rolled back operating system config again")
+		fakeDBus.Actions = nil
+		result, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Minute}))
+		Expect(fakeDBus.Actions).To(BeEmpty())
+		expectFile("/etc/foo", "old")
+
+		By("Apply a changed operating system config and reset the condition")
+		updateSecret(newOSC, "newer")
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		expectFile("/etc/foo", "new")
+		expectFile("/etc/bar", "bar")
+		expectFile("/etc/systemd/system/foo.service", "new-unit")
+		Expect(fakeFS.Exists(nodeagentconfigv1alpha1.BaseDir + "/rollback")).To(BeFalse())
+
+		condition = rolledBackCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(node.Annotations).To(HaveKeyWithValue(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, "newer"))
+	})
+
+	It("should not roll back before the configured number of failed attempts is reached", func() {
+		reconciler.Config.RollbackAfterFailedAttempts = ptr.To[int32](2)
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).To(MatchError(ContainSubstring("injected failure")))
+
+		expectFile("/etc/bar", "bar")
+		expectFile("/etc/systemd/system/foo.service", "new-unit")
+		Expect(rolledBackCondition()).To(BeNil())
+
+		By("Succeed on the second attempt")
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		expectFile("/etc/foo", "new")
+		Expect(rolledBackCondition()).To(BeNil())
+		Expect(node.Annotations).To(HaveKeyWithValue(nodeagentconfigv1alpha1.AnnotationKeyChecksumAppliedOperatingSystemConfig, "new"))
+	})
+
+	It("should not roll back if rollback is disabled", func() {
+		reconciler.Config.RollbackAfterFailedAttempts = nil
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).To(MatchError(ContainSubstring("injected failure")))
+
+		expectFile("/etc/foo", "new")
+		Expect(fakeFS.Exists(nodeagentconfigv1alpha1.BaseDir + "/rollback")).To(BeFalse())
+		Expect(rolledBackCondition()).To(BeNil())
+	})
+})
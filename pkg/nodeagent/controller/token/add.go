@@ -11,11 +11,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
 )
 
 // ControllerName is the name of this controller.
@@ -31,9 +34,12 @@ func (r *Reconciler) AddToManager(mgr manager.Manager) error {
 		r.FS = afero.Afero{Fs: afero.NewOsFs()}
 	}
 
-	r.secretNameToPath = make(map[string]string, len(r.Config.SyncConfigs))
-	for _, config := range r.Config.SyncConfigs {
-		r.secretNameToPath[config.SecretName] = config.Path
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+
+	if r.DBus == nil {
+		r.DBus = dbus.New(mgr.GetLogger().WithValues("controller", ControllerName))
 	}
 
 	return builder.
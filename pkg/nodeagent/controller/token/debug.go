@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package token
+
+import (
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener/pkg/nodeagent/debug"
+	"github.com/gardener/gardener/pkg/nodeagent/metrics"
+)
+
+// SyncStatus is the status of the synchronization of a token. It never contains the token itself.
+type SyncStatus struct {
+	// SecretName is the name of the secret containing the token.
+	SecretName string `json:"secretName"`
+	// Path is the path of the file the token is written to.
+	Path string `json:"path"`
+	// LastSyncTime is the time when the token was synced successfully for the last time.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// LastAttemptTime is the time when the token sync was attempted for the last time.
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// ExpirationTime is the expiration time of the token, if it expires.
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// Error is the error of the last sync attempt, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// AddDebugHandlers registers the debug endpoint of this controller at the given mux. It serves the sync status of the
+// configured tokens.
+func (r *Reconciler) AddDebugHandlers(mux *http.ServeMux) {
+	mux.Handle(debug.PathPrefix+"/tokens", debug.JSONHandler(func() (any, error) {
+		r.statusesMutex.RLock()
+		defer r.statusesMutex.RUnlock()
+
+		statuses := make([]SyncStatus, 0, len(r.Config.SyncConfigs))
+		for _, config := range r.Config.SyncConfigs {
+			status, ok := r.statuses[config.SecretName]
+			if !ok {
+				status = SyncStatus{SecretName: config.SecretName, Path: config.Path}
+			}
+			statuses = append(statuses, status)
+		}
+		return statuses, nil
+	}))
+}
+
+func (r *Reconciler) recordSyncStatus(secretName string, expirationTime *time.Time, err error) {
+	r.statusesMutex.Lock()
+	defer r.statusesMutex.Unlock()
+
+	if r.statuses == nil {
+		r.statuses = make(map[string]SyncStatus)
+	}
+
+	status := r.statuses[secretName]
+	status.SecretName = secretName
+	config, _ := r.syncConfig(secretName)
+	status.Path = config.Path
+
+	now := metav1.NewTime(r.Clock.Now())
+	status.LastAttemptTime = &now
+	status.Error = ""
+	if err != nil {
+		status.Error = err.Error()
+		metrics.TokenSyncFailed.WithLabelValues(secretName, status.Path).Set(1)
+	} else {
+		status.LastSyncTime = &now
+		metrics.TokenSyncFailed.WithLabelValues(secretName, status.Path).Set(0)
+	}
+
+	if expirationTime != nil {
+		status.ExpirationTime = &metav1.Time{Time: *expirationTime}
+		metrics.TokenExpirationTimestamp.WithLabelValues(secretName, status.Path).Set(float64(expirationTime.Unix()))
+	}
+
+	r.statuses[secretName] = status
+}
@@ -7,12 +7,21 @@ package token
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -20,6 +29,23 @@ import (
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	"github.com/gardener/gardener/pkg/controllerutils"
 	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+)
+
+const (
+	// defaultPermissions are the permissions of the token files if they are not configured.
+	defaultPermissions os.FileMode = 0600
+	// refreshLifetimeFraction is the fraction of the lifetime of an expiring token after which the token file is
+	// refreshed. The token-requestor of gardener-resource-manager renews tokens after 80% of their lifetime, hence the
+	// renewed token should be available in the secret at this point in time.
+	refreshLifetimeFraction = 0.9
+	// expiringTokenRetryInterval is the interval after which the secret is fetched again if the token in it was not
+	// renewed in time.
+	expiringTokenRetryInterval = 10 * time.Second
+	// pendingRestartsDir is the directory containing a marker file for each secret whose token was updated on the disk
+	// while the restart of the configured unit did not succeed yet. The markers are persisted so that the restart is not
+	// lost if gardener-node-agent is restarted in between.
+	pendingRestartsDir = nodeagentconfigv1alpha1.BaseDir + "/token-restarts-pending"
 )
 
 // Reconciler fetches the shoot access token for gardener-node-agent and writes it to disk.
@@ -27,12 +53,21 @@ type Reconciler struct {
 	APIReader client.Reader
 	Config    nodeagentconfigv1alpha1.TokenControllerConfig
 	FS        afero.Afero
+	Clock     clock.Clock
+	DBus      dbus.DBus
 
-	secretNameToPath map[string]string
+	statusesMutex sync.RWMutex
+	statuses      map[string]SyncStatus
 }
 
 // Reconcile fetches the shoot access token for gardener-node-agent and writes it to disk.
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	result, expirationTime, err := r.reconcile(ctx, request)
+	r.recordSyncStatus(request.Name, expirationTime, err)
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, *time.Time, error) {
 	log := logf.FromContext(ctx)
 
 	ctx, cancel := controllerutils.GetMainReconciliationContext(ctx, controllerutils.DefaultReconciliationTimeout)
@@ -42,37 +77,184 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err := r.APIReader.Get(ctx, request.NamespacedName, secret); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.V(1).Info("Object not found")
-			return reconcile.Result{}, fmt.Errorf("secret %s not found: %w", request.NamespacedName, err)
+			return reconcile.Result{}, nil, fmt.Errorf("secret %s not found: %w", request.NamespacedName, err)
 		}
 
-		return reconcile.Result{}, fmt.Errorf("error retrieving object from store: %w", err)
+		return reconcile.Result{}, nil, fmt.Errorf("error retrieving object from store: %w", err)
 	}
 
-	path, ok := r.secretNameToPath[secret.Name]
+	config, ok := r.syncConfig(secret.Name)
 	if !ok {
-		return reconcile.Result{}, fmt.Errorf("failed determining the path where to sync the token to (unknown secret name %q)", secret.Name)
+		return reconcile.Result{}, nil, fmt.Errorf("failed determining the path where to sync the token to (unknown secret name %q)", secret.Name)
 	}
+	path := config.Path
 
 	token := secret.Data[resourcesv1alpha1.DataKeyToken]
 	if len(token) == 0 {
-		return reconcile.Result{}, fmt.Errorf("secret key %q does not exist or is empty", resourcesv1alpha1.DataKeyToken)
+		return reconcile.Result{}, nil, fmt.Errorf("secret key %q does not exist or is empty", resourcesv1alpha1.DataKeyToken)
+	}
+
+	issuedAt, expirationTime := tokenLifetime(token)
+	if expirationTime != nil && !r.Clock.Now().Before(*expirationTime) {
+		return reconcile.Result{}, expirationTime, fmt.Errorf("token in secret %s expired at %s and was not renewed yet", request.NamespacedName, expirationTime.UTC().Format(time.RFC3339))
 	}
 
 	currentToken, err := r.FS.ReadFile(path)
 	if err != nil && !errors.Is(err, afero.ErrFileNotFound) {
-		return reconcile.Result{}, fmt.Errorf("failed reading token file %s: %w", path, err)
+		return reconcile.Result{}, expirationTime, fmt.Errorf("failed reading token file %s: %w", path, err)
 	}
 
+	permissions := os.FileMode(ptr.Deref(config.Permissions, uint32(defaultPermissions)))
+
 	if !bytes.Equal(currentToken, token) {
 		log.Info("Access token differs from the one currently stored on the disk, updating it", "path", path)
 
-		if err := r.FS.WriteFile(path, token, 0600); err != nil {
-			return reconcile.Result{}, fmt.Errorf("unable to write access token to %s: %w", path, err)
+		// The unit is only restarted if an existing token was replaced. If the token file was created, the unit is
+		// expected to read it when it is started. The restart is marked as pending before the token is written so that
+		// it is not lost if gardener-node-agent terminates before the unit was restarted.
+		if config.RestartUnit != nil && len(currentToken) > 0 {
+			if err := r.setRestartPending(secret.Name, true); err != nil {
+				return reconcile.Result{}, expirationTime, err
+			}
+		}
+
+		if err := r.FS.WriteFile(path, token, permissions); err != nil {
+			return reconcile.Result{}, expirationTime, fmt.Errorf("unable to write access token to %s: %w", path, err)
 		}
 
 		log.Info("Updated token written to disk")
 	}
 
-	log.Info("Token sync completed, requeuing for next sync", "requeueAfter", r.Config.SyncPeriod.Duration)
-	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+	// The permissions and the owner are always applied since the file might have been changed on the disk.
+	if err := r.FS.Chmod(path, permissions); err != nil {
+		return reconcile.Result{}, expirationTime, fmt.Errorf("failed changing permissions of token file %s: %w", path, err)
+	}
+
+	if config.Owner != nil {
+		if err := r.FS.Chown(path, int(config.Owner.UserID), int(config.Owner.GroupID)); err != nil {
+			return reconcile.Result{}, expirationTime, fmt.Errorf("failed changing owner of token file %s: %w", path, err)
+		}
+	}
+
+	if config.RestartUnit != nil {
+		pending, err := r.isRestartPending(secret.Name)
+		if err != nil {
+			return reconcile.Result{}, expirationTime, err
+		}
+
+		if pending {
+			log.Info("Restarting unit after token was updated", "unitName", *config.RestartUnit)
+			if err := r.DBus.Restart(ctx, nil, nil, *config.RestartUnit); err != nil {
+				return reconcile.Result{}, expirationTime, fmt.Errorf("failed restarting unit %s after token was updated: %w", *config.RestartUnit, err)
+			}
+			if err := r.setRestartPending(secret.Name, false); err != nil {
+				return reconcile.Result{}, expirationTime, err
+			}
+		}
+	}
+
+	requeueAfter := r.Config.SyncPeriod.Duration
+	if expirationTime != nil {
+		if refreshAfter := r.refreshAfter(issuedAt, *expirationTime); refreshAfter < requeueAfter {
+			requeueAfter = refreshAfter
+		}
+	}
+
+	log.Info("Token sync completed, requeuing for next sync", "requeueAfter", requeueAfter)
+	return reconcile.Result{RequeueAfter: requeueAfter}, expirationTime, nil
+}
+
+// syncConfig returns the sync config for the secret with the given name.
+func (r *Reconciler) syncConfig(secretName string) (nodeagentconfigv1alpha1.TokenSecretSyncConfig, bool) {
+	for _, config := range r.Config.SyncConfigs {
+		if config.SecretName == secretName {
+			return config, true
+		}
+	}
+	return nodeagentconfigv1alpha1.TokenSecretSyncConfig{}, false
+}
+
+// refreshAfter returns the duration after which the token file of an expiring token should be refreshed.
+func (r *Reconciler) refreshAfter(issuedAt *time.Time, expirationTime time.Time) time.Duration {
+	now := r.Clock.Now()
+
+	// Without an issue time, the remaining lifetime is considered as the lifetime of the token.
+	start := now
+	if issuedAt != nil && issuedAt.Before(expirationTime) {
+		start = *issuedAt
+	}
+
+	refreshTime := start.Add(time.Duration(float64(expirationTime.Sub(start)) * refreshLifetimeFraction))
+	if refreshAfter := refreshTime.Sub(now); refreshAfter > expiringTokenRetryInterval {
+		return refreshAfter
+	}
+	return expiringTokenRetryInterval
+}
+
+func (r *Reconciler) isRestartPending(secretName string) (bool, error) {
+	exists, err := r.FS.Exists(pendingRestartMarkerPath(secretName))
+	if err != nil {
+		return false, fmt.Errorf("failed checking whether restart is pending for token of secret %s: %w", secretName, err)
+	}
+	return exists, nil
+}
+
+func (r *Reconciler) setRestartPending(secretName string, pending bool) error {
+	markerPath := pendingRestartMarkerPath(secretName)
+
+	if !pending {
+		if err := r.FS.Remove(markerPath); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			return fmt.Errorf("failed removing pending restart marker %s: %w", markerPath, err)
+		}
+		return nil
+	}
+
+	if err := r.FS.MkdirAll(pendingRestartsDir, 0700); err != nil {
+		return fmt.Errorf("failed creating directory %s: %w", pendingRestartsDir, err)
+	}
+	if err := r.FS.WriteFile(markerPath, nil, 0600); err != nil {
+		return fmt.Errorf("failed writing pending restart marker %s: %w", markerPath, err)
+	}
+	return nil
+}
+
+func pendingRestartMarkerPath(secretName string) string {
+	return filepath.Join(pendingRestartsDir, secretName)
+}
+
+// tokenLifetime returns the issue and expiration time of the given token if it is a JWT containing the respective
+// claims. The signature of the token is not verified since the claims are only used for refreshing the token file in
+// time. Tokens which are no JWTs are considered to not expire.
+func tokenLifetime(token []byte) (issuedAt, expirationTime *time.Time) {
+	parts := strings.Split(string(bytes.TrimSpace(token)), ".")
+	if len(parts) != 3 {
+		return nil, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, nil
+	}
+
+	var claims struct {
+		IssuedAt       *json.Number `json:"iat"`
+		ExpirationTime *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, nil
+	}
+
+	return numericDate(claims.IssuedAt), numericDate(claims.ExpirationTime)
+}
+
+func numericDate(n *json.Number) *time.Time {
+	if n == nil {
+		return nil
+	}
+
+	seconds, err := n.Float64()
+	if err != nil {
+		return nil
+	}
+	return ptr.To(time.Unix(int64(seconds), 0))
 }
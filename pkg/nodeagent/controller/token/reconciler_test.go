@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package token_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/token"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		ctx = context.Background()
+
+		fakeClient client.Client
+		fakeFS     *ownerRecordingFs
+		fakeDBus   *fakedbus.DBus
+		fakeClock  *testclock.FakeClock
+		reconciler *Reconciler
+
+		path       = "/var/lib/foo/token"
+		syncPeriod = time.Hour
+		secret     *corev1.Secret
+		request    reconcile.Request
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		fakeFS = &ownerRecordingFs{Fs: afero.NewMemMapFs()}
+		fakeDBus = fakedbus.New()
+		fakeClock = testclock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceSystem},
+			Data:       map[string][]byte{resourcesv1alpha1.DataKeyToken: []byte("token")},
+		}
+		Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+
+		reconciler = &Reconciler{
+			APIReader: fakeClient,
+			Config: nodeagentconfigv1alpha1.TokenControllerConfig{
+				SyncConfigs: []nodeagentconfigv1alpha1.TokenSecretSyncConfig{{SecretName: secret.Name, Path: path}},
+				SyncPeriod:  &metav1.Duration{Duration: syncPeriod},
+			},
+			FS:    afero.Afero{Fs: fakeFS},
+			Clock: fakeClock,
+			DBus:  fakeDBus,
+		}
+	})
+
+	updateToken := func(token []byte) {
+		GinkgoHelper()
+
+		secret.Data[resourcesv1alpha1.DataKeyToken] = token
+		Expect(fakeClient.Update(ctx, secret)).To(Succeed())
+	}
+
+	expectFile := func(content string, permissions os.FileMode) {
+		GinkgoHelper()
+
+		data, err := afero.ReadFile(fakeFS, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+
+		info, err := fakeFS.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(permissions))
+	}
+
+	Describe("#Reconcile", func() {
+		It("should write the token with the default permissions", func() {
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+			expectFile("token", 0600)
+			Expect(fakeFS.owners).To(BeEmpty())
+		})
+
+		It("should fail if the secret does not contain a token", func() {
+			updateToken(nil)
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring(`secret key "token" does not exist or is empty`)))
+		})
+
+		It("should apply the configured permissions and owner", func() {
+			reconciler.Config.SyncConfigs[0].Permissions = ptr.To[uint32](0640)
+			reconciler.Config.SyncConfigs[0].Owner = &nodeagentconfigv1alpha1.FileOwner{UserID: 65532, GroupID: 65533}
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectFile("token", 0640)
+			Expect(fakeFS.owners).To(HaveKeyWithValue(path, [2]int{65532, 65533}))
+
+			By("Restore permissions changed on the disk")
+			Expect(fakeFS.Chmod(path, 0644)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			expectFile("token", 0640)
+		})
+
+		Context("unit restart", func() {
+			BeforeEach(func() {
+				reconciler.Config.SyncConfigs[0].RestartUnit = ptr.To("foo.service")
+			})
+
+			It("should not restart the unit when the token file is created or unchanged", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeDBus.Actions).To(BeEmpty())
+			})
+
+			It("should restart the unit when the token was updated", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				updateToken([]byte("new-token"))
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectFile("new-token", 0600)
+				Expect(fakeDBus.Actions).To(ConsistOf(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"foo.service"}}))
+			})
+
+			It("should retry restarting the unit if it failed", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				updateToken([]byte("new-token"))
+				fakeDBus.InjectRestartFailure(errors.New("fake"), "foo.service")
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).To(MatchError(ContainSubstring("failed restarting unit foo.service")))
+				expectFile("new-token", 0600)
+
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeDBus.Actions).To(HaveLen(2))
+
+				By("Do not restart the unit again")
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeDBus.Actions).To(HaveLen(2))
+			})
+
+			It("should restart the unit if the restart was lost before gardener-node-agent was restarted", func() {
+				pendingRestartMarkerPath := nodeagentconfigv1alpha1.BaseDir + "/token-restarts-pending/foo"
+
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				updateToken([]byte("new-token"))
+				fakeDBus.InjectRestartFailure(errors.New("fake"), "foo.service")
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).To(MatchError(ContainSubstring("failed restarting unit foo.service")))
+				expectFile("new-token", 0600)
+				Expect(afero.Exists(fakeFS, pendingRestartMarkerPath)).To(BeTrue())
+
+				By("Reconcile with a new reconciler instance")
+				reconciler = &Reconciler{
+					APIReader: fakeClient,
+					Config:    reconciler.Config,
+					FS:        afero.Afero{Fs: fakeFS},
+					Clock:     fakeClock,
+					DBus:      fakeDBus,
+				}
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeDBus.Actions).To(HaveLen(2))
+
+				Expect(afero.Exists(fakeFS, pendingRestartMarkerPath)).To(BeFalse())
+			})
+		})
+
+		Context("expiring tokens", func() {
+			It("should requeue before the token expires", func() {
+				updateToken(jwt(fakeClock.Now(), fakeClock.Now().Add(10*time.Minute)))
+
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: 9 * time.Minute}))
+			})
+
+			It("should requeue after the sync period if the token expires later", func() {
+				updateToken(jwt(fakeClock.Now(), fakeClock.Now().Add(24*time.Hour)))
+
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+			})
+
+			It("should retry shortly if the token was not renewed in time", func() {
+				updateToken(jwt(fakeClock.Now(), fakeClock.Now().Add(10*time.Minute)))
+				fakeClock.Step(9*time.Minute + 30*time.Second)
+
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: 10 * time.Second}))
+			})
+
+			It("should fail if the token already expired", func() {
+				updateToken(jwt(fakeClock.Now().Add(-time.Hour), fakeClock.Now().Add(-time.Minute)))
+
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).To(MatchError(ContainSubstring("expired at 2023-12-31T23:59:00Z")))
+
+				_, err = afero.ReadFile(fakeFS, path)
+				Expect(err).To(MatchError(afero.ErrFileNotFound))
+			})
+		})
+	})
+})
+
+func jwt(issuedAt, expirationTime time.Time) []byte {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	return []byte(encode(`{"alg":"RS256"}`) + "." + encode(fmt.Sprintf(`{"iat":%d,"exp":%d}`, issuedAt.Unix(), expirationTime.Unix())) + "." + encode("signature"))
+}
+
+// ownerRecordingFs records the owners of files since afero.MemMapFs does not expose them.
+type ownerRecordingFs struct {
+	afero.Fs
+	owners map[string][2]int
+}
+
+func (f *ownerRecordingFs) Chown(name string, uid, gid int) error {
+	if f.owners == nil {
+		f.owners = make(map[string][2]int)
+	}
+	f.owners[name] = [2]int{uid, gid}
+	return f.Fs.Chown(name, uid, gid)
+}
@@ -34,6 +34,8 @@ type DBus interface {
 	Restart(ctx context.Context, recorder record.EventRecorder, node runtime.Object, unitName string) error
 	// Reboot this machines, is the same as executing "systemctl reboot".
 	Reboot() error
+	// ListFailedUnits returns the names of all units in failed state, same as executing "systemctl list-units --state=failed".
+	ListFailedUnits(ctx context.Context) ([]string, error)
 }
 
 type db struct {
@@ -107,6 +109,25 @@ func (d *db) Restart(ctx context.Context, recorder record.EventRecorder, node ru
 	return d.runCommand(ctx, recorder, node, unitName, dbc.RestartUnitContext, "SystemDUnitRestart", "restart")
 }
 
+func (_ *db) ListFailedUnits(ctx context.Context) ([]string, error) {
+	dbc, err := dbus.NewWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to dbus: %w", err)
+	}
+	defer dbc.Close()
+
+	units, err := dbc.ListUnitsFilteredContext(ctx, []string{"failed"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list failed units: %w", err)
+	}
+
+	names := make([]string, 0, len(units))
+	for _, unit := range units {
+		names = append(names, unit.Name)
+	}
+	return names, nil
+}
+
 func (_ *db) DaemonReload(ctx context.Context) error {
 	dbc, err := dbus.NewWithContext(ctx)
 	if err != nil {
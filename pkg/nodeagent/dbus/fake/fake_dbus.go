@@ -44,7 +44,10 @@ type SystemdAction struct {
 
 // DBus is a fake implementation for the dbus.DBus interface.
 type DBus struct {
-	Actions  []SystemdAction
+	Actions []SystemdAction
+	// FailedUnits are the unit names returned by ListFailedUnits.
+	FailedUnits []string
+
 	failures map[string]error
 
 	mutex sync.Mutex
@@ -142,6 +145,14 @@ func (d *DBus) Reboot() error {
 	return nil
 }
 
+// ListFailedUnits implements dbus.DBus.
+func (d *DBus) ListFailedUnits(_ context.Context) ([]string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]string{}, d.FailedUnits...), nil
+}
+
 // Start implements dbus.DBus.
 func (d *DBus) Start(_ context.Context, _ record.EventRecorder, _ runtime.Object, unitName string) error {
 	d.mutex.Lock()
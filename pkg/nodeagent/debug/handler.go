@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PathPrefix is the prefix of the paths of all debug endpoints of gardener-node-agent.
+const PathPrefix = "/debug"
+
+// ErrNotFound can be wrapped by the errors returned to JSONHandler to respond with status code 404.
+var ErrNotFound = errors.New("not found")
+
+// JSONHandler returns a read-only handler which serves the object returned by the given function as JSON.
+func JSONHandler(get func() (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		obj, err := get()
+		if err != nil {
+			statusCode := http.StatusInternalServerError
+			if errors.Is(err, ErrNotFound) {
+				statusCode = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed marshalling response: %s", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(append(data, '\n'))
+	})
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package debug_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener/pkg/nodeagent/debug"
+)
+
+var _ = Describe("Handler", func() {
+	var recorder *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		recorder = httptest.NewRecorder()
+	})
+
+	Describe("#JSONHandler", func() {
+		It("should serve the object as JSON", func() {
+			JSONHandler(func() (any, error) {
+				return map[string]string{"foo": "bar"}, nil
+			}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/foo", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(recorder.Body.String()).To(MatchJSON(`{"foo":"bar"}`))
+		})
+
+		It("should reject other methods than GET", func() {
+			JSONHandler(func() (any, error) {
+				Fail("should not be called")
+				return nil, nil
+			}).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/debug/foo", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should respond with 404 if the object was not found", func() {
+			JSONHandler(func() (any, error) {
+				return nil, fmt.Errorf("foo: %w", ErrNotFound)
+			}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/foo", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should respond with 500 if the object cannot be determined", func() {
+			JSONHandler(func() (any, error) {
+				return nil, errors.New("fake")
+			}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/foo", nil))
+
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			Expect(recorder.Body.String()).To(ContainSubstring("fake"))
+		})
+	})
+})
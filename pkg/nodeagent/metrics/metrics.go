@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	runtimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Namespace is the metric namespace for the gardener-node-agent.
+const Namespace = "gardener_node_agent"
+
+var (
+	// Factory is used for registering metrics in the controller-runtime metrics registry.
+	Factory = promauto.With(runtimemetrics.Registry)
+
+	// OperatingSystemConfigDrift defines the gauge operating_system_config_drift. It is 1 for every file or unit which
+	// drifted from the last applied operating system config at the time of the last drift check.
+	OperatingSystemConfigDrift = Factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "operating_system_config_drift",
+			Help:      "Files and units which drifted from the last applied operating system config.",
+		},
+		[]string{
+			"kind",
+			"path",
+		},
+	)
+
+	// OperatingSystemConfigDriftRemediations defines the counter operating_system_config_drift_remediations_total.
+	OperatingSystemConfigDriftRemediations = Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "operating_system_config_drift_remediations_total",
+			Help:      "Total number of remediations of drifted files and units.",
+		},
+		[]string{
+			"result",
+		},
+	)
+
+	// TokenSyncFailed defines the gauge token_sync_failed. It is 1 for every token whose last sync to the disk failed.
+	TokenSyncFailed = Factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "token_sync_failed",
+			Help:      "Tokens whose last sync to the disk failed.",
+		},
+		[]string{
+			"secret_name",
+			"path",
+		},
+	)
+
+	// TokenExpirationTimestamp defines the gauge token_expiration_timestamp_seconds. It contains the expiration time of
+	// every token synced to the disk as a Unix timestamp, if the token expires.
+	TokenExpirationTimestamp = Factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "token_expiration_timestamp_seconds",
+			Help:      "Expiration time of the tokens synced to the disk as a Unix timestamp.",
+		},
+		[]string{
+			"secret_name",
+			"path",
+		},
+	)
+)
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/afero"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
 	"github.com/gardener/gardener/pkg/nodeagent/files"
 )
@@ -33,8 +34,9 @@ func NewExtractor() Extractor {
 	return &containerdExtractor{}
 }
 
-// CopyFromImage copies a file from a given image reference to the destination file.
-func (e *containerdExtractor) CopyFromImage(ctx context.Context, imageRef string, filePathInImage string, destination string, permissions os.FileMode) error {
+// CopyFromImage copies a file from a given image reference to the destination file. The registry configurations are
+// not considered since containerd is already configured to use them.
+func (e *containerdExtractor) CopyFromImage(ctx context.Context, imageRef string, filePathInImage string, destination string, permissions os.FileMode, _ []extensionsv1alpha1.RegistryConfig) error {
 	fs := afero.Afero{Fs: afero.NewOsFs()}
 
 	address := os.Getenv("CONTAINERD_ADDRESS")
@@ -12,6 +12,7 @@ import (
 
 	"github.com/spf13/afero"
 
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/nodeagent/files"
 	"github.com/gardener/gardener/pkg/nodeagent/registry"
 )
@@ -29,7 +30,7 @@ func NewExtractor(fakeFS afero.Afero, sourceDirectory string) registry.Extractor
 }
 
 // CopyFromImage copies a file from a given image reference to the destination file.
-func (e *fakeRegistryExtractor) CopyFromImage(_ context.Context, _ string, filePathInImage string, destination string, permissions fs.FileMode) error {
+func (e *fakeRegistryExtractor) CopyFromImage(_ context.Context, _ string, filePathInImage string, destination string, permissions fs.FileMode, _ []extensionsv1alpha1.RegistryConfig) error {
 	source := path.Join(e.sourceDirectory, filePathInImage)
 	if err := files.Copy(e.fakeFS, source, destination, permissions); err != nil {
 		return fmt.Errorf("error copying file %s to %s: %w", source, destination, err)
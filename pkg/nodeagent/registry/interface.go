@@ -7,10 +7,13 @@ package registry
 import (
 	"context"
 	"os"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 )
 
 // Extractor is an interface for extracting files from a container image.
 type Extractor interface {
-	// CopyFromImage copies a file from a given image reference to the destination file.
-	CopyFromImage(ctx context.Context, imageRef string, filePathInImage string, destination string, permissions os.FileMode) error
+	// CopyFromImage copies a file from a given image reference to the destination file. The given registry configurations
+	// are used for pulling the image from mirrors of the upstream registry.
+	CopyFromImage(ctx context.Context, imageRef string, filePathInImage string, destination string, permissions os.FileMode, registries []extensionsv1alpha1.RegistryConfig) error
 }
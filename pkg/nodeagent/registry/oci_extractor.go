@@ -0,0 +1,446 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/klauspost/compress/zstd"
+	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	"github.com/gardener/gardener/pkg/nodeagent/files"
+)
+
+const (
+	// CacheDir is the directory containing the blobs pulled by the OCI extractor. The blobs are stored by their digest,
+	// e.g. blobs/sha256/<hex>.
+	CacheDir = nodeagentconfigv1alpha1.BaseDir + "/image-cache"
+
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = whiteoutPrefix + whiteoutPrefix + ".opq"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type ociExtractor struct {
+	fs            afero.Afero
+	clock         clock.Clock
+	requireDigest bool
+	cacheMaxAge   time.Duration
+}
+
+// NewOCIExtractor creates a new instance of an extractor which pulls the images directly from the registry (or its
+// mirrors) without involving containerd. References pinned by a digest are verified, and the pulled layers are kept in
+// a local content-addressed cache.
+func NewOCIExtractor(fs afero.Afero, clock clock.Clock, config nodeagentconfigv1alpha1.DirectImagePullConfig) Extractor {
+	return &ociExtractor{
+		fs:            fs,
+		clock:         clock,
+		requireDigest: ptr.Deref(config.RequireDigest, true),
+		cacheMaxAge:   ptr.Deref(config.CacheMaxAge, metav1.Duration{Duration: 7 * 24 * time.Hour}).Duration,
+	}
+}
+
+// CopyFromImage copies a file from a given image reference to the destination file. The configured hosts of the
+// registry configuration matching the upstream of the image are tried first before falling back to the upstream
+// registry itself.
+func (e *ociExtractor) CopyFromImage(ctx context.Context, imageRef string, filePathInImage string, destination string, permissions os.FileMode, registries []extensionsv1alpha1.RegistryConfig) error {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return fmt.Errorf("error parsing image reference %q: %w", imageRef, err)
+	}
+	if _, ok := ref.(name.Digest); !ok && e.requireDigest {
+		return fmt.Errorf("image reference %q is not pinned by a digest", imageRef)
+	}
+
+	endpoints, err := endpointsFor(ref, registries)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, endpoint := range endpoints {
+		layers, err := e.pullLayers(ctx, ref, endpoint)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error pulling image from %s: %w", endpoint.url, err))
+			continue
+		}
+
+		if err := e.extractFile(layers, filePathInImage, destination, permissions); err != nil {
+			return err
+		}
+
+		return e.pruneCache()
+	}
+
+	return errors.Join(errs...)
+}
+
+type endpoint struct {
+	url     *url.URL
+	caCerts []string
+	// resolve is true if the endpoint can be used for resolving tags.
+	resolve bool
+}
+
+// endpointsFor returns the endpoints to pull the image from. Similar to containerd, the hosts of the registry
+// configuration are tried in order, followed by the server (defaults to the upstream registry).
+func endpointsFor(ref name.Reference, registries []extensionsv1alpha1.RegistryConfig) ([]endpoint, error) {
+	upstream := ref.Context().RegistryStr()
+	server := &url.URL{Scheme: ref.Context().Scheme(), Host: upstream}
+
+	var endpoints []endpoint
+	for _, registryConfig := range registries {
+		registry, err := name.NewRegistry(registryConfig.Upstream)
+		if err != nil || registry.RegistryStr() != upstream {
+			continue
+		}
+
+		for _, host := range registryConfig.Hosts {
+			capabilities := host.Capabilities
+			if len(capabilities) == 0 {
+				capabilities = []extensionsv1alpha1.RegistryCapability{extensionsv1alpha1.PullCapability, extensionsv1alpha1.ResolveCapability}
+			}
+			if !slices.Contains(capabilities, extensionsv1alpha1.PullCapability) {
+				continue
+			}
+
+			u, err := url.Parse(host.URL)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing URL of host %q of registry %q: %w", host.URL, registryConfig.Upstream, err)
+			}
+			endpoints = append(endpoints, endpoint{url: u, caCerts: host.CACerts, resolve: slices.Contains(capabilities, extensionsv1alpha1.ResolveCapability)})
+		}
+
+		if registryConfig.Server != nil {
+			u, err := url.Parse(*registryConfig.Server)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing server URL %q of registry %q: %w", *registryConfig.Server, registryConfig.Upstream, err)
+			}
+			server = u
+		}
+		break
+	}
+
+	endpoints = append(endpoints, endpoint{url: server, resolve: true})
+
+	// Tags can only be resolved by hosts having the resolve capability.
+	if _, ok := ref.(name.Digest); !ok {
+		endpoints = slices.DeleteFunc(endpoints, func(e endpoint) bool { return !e.resolve })
+	}
+
+	return endpoints, nil
+}
+
+// pullLayers resolves the image at the given endpoint and returns the paths of its cached layers from bottom to top.
+// The manifest digest of references pinned by a digest is verified by go-containerregistry, the layers are verified
+// when they are written to the cache.
+func (e *ociExtractor) pullLayers(ctx context.Context, ref name.Reference, endpoint endpoint) ([]string, error) {
+	var opts []name.Option
+	if endpoint.url.Scheme == "http" {
+		opts = append(opts, name.Insecure)
+	}
+	registry, err := name.NewRegistry(endpoint.url.Host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing registry host: %w", err)
+	}
+
+	repository := ref.Context()
+	repository.Registry = registry
+
+	var endpointRef name.Reference = repository.Tag(ref.Identifier())
+	if digest, ok := ref.(name.Digest); ok {
+		endpointRef = repository.Digest(digest.DigestStr())
+	}
+
+	transport, err := e.transport(endpoint.caCerts)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := remote.Image(endpointRef,
+		remote.WithContext(ctx),
+		remote.WithTransport(transport),
+		remote.WithPlatform(gcrv1.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("error reading layers: %w", err)
+	}
+
+	var blobPaths []string
+	for _, layer := range layers {
+		blobPath, err := e.cacheLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		blobPaths = append(blobPaths, blobPath)
+	}
+
+	return blobPaths, nil
+}
+
+func (e *ociExtractor) transport(caCerts []string) (http.RoundTripper, error) {
+	transport := remote.DefaultTransport.(*http.Transport).Clone()
+	if len(caCerts) == 0 {
+		return transport, nil
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	for _, caCert := range caCerts {
+		pem, err := e.fs.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA certificate %q: %w", caCert, err)
+		}
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid CA certificate found in %q", caCert)
+		}
+	}
+
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	return transport, nil
+}
+
+// cacheLayer downloads the given layer into the cache unless it is already present, and returns the path of the
+// cached blob.
+func (e *ociExtractor) cacheLayer(layer gcrv1.Layer) (string, error) {
+	digest, err := layer.Digest()
+	if err != nil {
+		return "", fmt.Errorf("error reading layer digest: %w", err)
+	}
+	if digest.Algorithm != "sha256" {
+		return "", fmt.Errorf("unsupported digest algorithm %q of layer %s", digest.Algorithm, digest)
+	}
+
+	var (
+		blobDir  = path.Join(CacheDir, "blobs", digest.Algorithm)
+		blobPath = path.Join(blobDir, digest.Hex)
+	)
+
+	if exists, err := e.fs.Exists(blobPath); err != nil {
+		return "", fmt.Errorf("error checking cached blob %q: %w", blobPath, err)
+	} else if exists {
+		return blobPath, e.markUsed(blobPath)
+	}
+
+	if err := e.fs.MkdirAll(blobDir, 0700); err != nil {
+		return "", fmt.Errorf("error creating cache directory %q: %w", blobDir, err)
+	}
+
+	compressed, err := layer.Compressed()
+	if err != nil {
+		return "", fmt.Errorf("error fetching layer %s: %w", digest, err)
+	}
+	defer compressed.Close()
+
+	tmpFile, err := e.fs.TempFile(blobDir, digest.Hex+".tmp-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary file for layer %s: %w", digest, err)
+	}
+	tmpFilePath := tmpFile.Name()
+	defer func() {
+		if err := e.fs.Remove(tmpFilePath); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			utilruntime.HandleError(err)
+		}
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmpFile, hash), compressed); err != nil {
+		utilruntime.HandleError(tmpFile.Close())
+		return "", fmt.Errorf("error downloading layer %s: %w", digest, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", fmt.Errorf("error closing temporary file for layer %s: %w", digest, err)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != digest.Hex {
+		return "", fmt.Errorf("digest of downloaded layer sha256:%s does not match expected digest %s", actual, digest)
+	}
+
+	if err := e.fs.Rename(tmpFilePath, blobPath); err != nil {
+		return "", fmt.Errorf("error moving layer %s into cache: %w", digest, err)
+	}
+
+	return blobPath, e.markUsed(blobPath)
+}
+
+// markUsed updates the modification time of the given blob, so that it is not pruned from the cache.
+func (e *ociExtractor) markUsed(blobPath string) error {
+	now := e.clock.Now()
+	if err := e.fs.Chtimes(blobPath, now, now); err != nil {
+		return fmt.Errorf("error updating modification time of cached blob %q: %w", blobPath, err)
+	}
+	return nil
+}
+
+// extractFile searches the given file in the layers, starting with the top-most one, and copies it to the destination.
+func (e *ociExtractor) extractFile(blobPaths []string, filePathInImage, destination string, permissions os.FileMode) error {
+	target := strings.TrimPrefix(path.Clean("/"+filePathInImage), "/")
+
+	if err := e.fs.MkdirAll(nodeagentconfigv1alpha1.TempDir, 0755); err != nil {
+		return fmt.Errorf("error creating directory %q: %w", nodeagentconfigv1alpha1.TempDir, err)
+	}
+	tmpDir, err := e.fs.TempDir(nodeagentconfigv1alpha1.TempDir, "extract-image-")
+	if err != nil {
+		return fmt.Errorf("error creating temp directory: %w", err)
+	}
+	defer func() { utilruntime.HandleError(e.fs.RemoveAll(tmpDir)) }()
+
+	source := path.Join(tmpDir, path.Base(target))
+	for i := len(blobPaths) - 1; i >= 0; i-- {
+		found, masked, err := e.extractFileFromLayer(blobPaths[i], target, source)
+		if err != nil {
+			return err
+		}
+		if found {
+			if err := files.Copy(e.fs, source, destination, permissions); err != nil {
+				return fmt.Errorf("error copying file %s to %s: %w", source, destination, err)
+			}
+			return nil
+		}
+		if masked {
+			break
+		}
+	}
+
+	return fmt.Errorf("file %q not found in image", filePathInImage)
+}
+
+// extractFileFromLayer writes the target file of the given layer to the destination if found. The returned masked
+// value is true if the layer contains a whiteout for the target file, i.e., it must not be searched in lower layers.
+func (e *ociExtractor) extractFileFromLayer(blobPath, target, destination string) (found, masked bool, err error) {
+	blob, err := e.fs.Open(blobPath)
+	if err != nil {
+		return false, false, fmt.Errorf("error opening cached blob %q: %w", blobPath, err)
+	}
+	defer blob.Close()
+
+	uncompressed, err := decompress(blob)
+	if err != nil {
+		return false, false, fmt.Errorf("error decompressing cached blob %q: %w", blobPath, err)
+	}
+	defer uncompressed.Close()
+
+	var (
+		dir, base = path.Split(target)
+		whiteouts = []string{path.Join(dir, whiteoutPrefix+base)}
+	)
+	for d := path.Clean(dir); d != "." && d != "/"; d = path.Dir(d) {
+		whiteouts = append(whiteouts, path.Join(d, whiteoutOpaque))
+	}
+
+	tarReader := tar.NewReader(uncompressed)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return false, masked, nil
+		}
+		if err != nil {
+			return false, false, fmt.Errorf("error reading cached blob %q: %w", blobPath, err)
+		}
+
+		entry := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if slices.Contains(whiteouts, entry) {
+			masked = true
+			continue
+		}
+		if entry != target {
+			continue
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			return false, false, fmt.Errorf("%q is not a regular file in the image", target)
+		}
+		if err := e.fs.WriteReader(destination, tarReader); err != nil {
+			return false, false, fmt.Errorf("error writing file %q: %w", destination, err)
+		}
+		return true, false, nil
+	}
+}
+
+// pruneCache removes the blobs which were not used for longer than the configured maximum age.
+func (e *ociExtractor) pruneCache() error {
+	blobDir := path.Join(CacheDir, "blobs", "sha256")
+
+	entries, err := e.fs.ReadDir(blobDir)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil
+		}
+		return fmt.Errorf("error reading cache directory %q: %w", blobDir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || e.clock.Since(entry.ModTime()) < e.cacheMaxAge {
+			continue
+		}
+		if err := e.fs.Remove(path.Join(blobDir, entry.Name())); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			return fmt.Errorf("error removing unused blob %q from cache: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// decompress returns a reader for the uncompressed content of the given (gzip- or zstd-compressed or uncompressed)
+// layer blob.
+func decompress(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(buffered)
+	case bytes.HasPrefix(magic, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	default:
+		return io.NopCloser(buffered), nil
+	}
+}
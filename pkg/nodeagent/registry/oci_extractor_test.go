@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	nodeagentconfigv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/registry"
+)
+
+var _ = Describe("OCIExtractor", func() {
+	var (
+		ctx = context.Background()
+
+		fakeFS    afero.Afero
+		fakeClock *testclock.FakeClock
+		config    nodeagentconfigv1alpha1.DirectImagePullConfig
+
+		server       *httptest.Server
+		host         string
+		blobRequests atomic.Int32
+
+		destination = "/opt/bin/foo"
+	)
+
+	BeforeEach(func() {
+		fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+		fakeClock = testclock.NewFakeClock(time.Now())
+		config = nodeagentconfigv1alpha1.DirectImagePullConfig{RequireDigest: ptr.To(false), CacheMaxAge: &metav1.Duration{Duration: time.Hour}}
+
+		blobRequests.Store(0)
+		registryHandler := ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0)))
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/blobs/") {
+				blobRequests.Add(1)
+			}
+			registryHandler.ServeHTTP(w, r)
+		}))
+		DeferCleanup(server.Close)
+		host = strings.TrimPrefix(server.URL, "http://")
+	})
+
+	push := func(repository, tag string, layers ...map[string]string) gcrv1.Hash {
+		GinkgoHelper()
+
+		image := empty.Image
+		for _, layer := range layers {
+			var err error
+			image, err = mutate.AppendLayers(image, static.NewLayer(layerBlob(layer), types.OCILayer))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		ref, err := name.NewTag(host + "/" + repository + ":" + tag)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Write(ref, image)).To(Succeed())
+
+		digest, err := image.Digest()
+		Expect(err).NotTo(HaveOccurred())
+		return digest
+	}
+
+	expectFile := func(content string) {
+		GinkgoHelper()
+
+		data, err := fakeFS.ReadFile(destination)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(content))
+
+		info, err := fakeFS.Stat(destination)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0755)))
+	}
+
+	Describe("#CopyFromImage", func() {
+		It("should copy the file from the top-most layer containing it", func() {
+			push("foo", "v1",
+				map[string]string{"bin/foo": "old", "bin/bar": "bar"},
+				map[string]string{"bin/foo": "new"},
+			)
+
+			Expect(NewOCIExtractor(fakeFS, fakeClock, config).CopyFromImage(ctx, host+"/foo:v1", "/bin/foo", destination, 0755, nil)).To(Succeed())
+			expectFile("new")
+
+			entries, err := fakeFS.ReadDir(path.Join(CacheDir, "blobs", "sha256"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+		})
+
+		It("should not find files which were deleted in an upper layer", func() {
+			push("foo", "v1",
+				map[string]string{"bin/foo": "foo"},
+				map[string]string{"bin/.wh.foo": ""},
+			)
+
+			Expect(NewOCIExtractor(fakeFS, fakeClock, config).CopyFromImage(ctx, host+"/foo:v1", "/bin/foo", destination, 0755, nil)).To(MatchError(ContainSubstring(`file "/bin/foo" not found in image`)))
+		})
+
+		It("should not download cached layers again", func() {
+			push("foo", "v1", map[string]string{"bin/foo": "foo"})
+			extractor := NewOCIExtractor(fakeFS, fakeClock, config)
+
+			Expect(extractor.CopyFromImage(ctx, host+"/foo:v1", "bin/foo", destination, 0755, nil)).To(Succeed())
+			Expect(blobRequests.Load()).To(BeEquivalentTo(1))
+
+			Expect(fakeFS.Remove(destination)).To(Succeed())
+			Expect(extractor.CopyFromImage(ctx, host+"/foo:v1", "bin/foo", destination, 0755, nil)).To(Succeed())
+			Expect(blobRequests.Load()).To(BeEquivalentTo(1))
+			expectFile("foo")
+		})
+
+		It("should prune blobs which were not used for longer than the maximum age", func() {
+			push("foo", "v1", map[string]string{"bin/foo": "foo"})
+			push("bar", "v1", map[string]string{"bin/foo": "bar"})
+			extractor := NewOCIExtractor(fakeFS, fakeClock, config)
+
+			Expect(extractor.CopyFromImage(ctx, host+"/foo:v1", "bin/foo", destination, 0755, nil)).To(Succeed())
+
+			fakeClock.Step(2 * time.Hour)
+			Expect(extractor.CopyFromImage(ctx, host+"/bar:v1", "bin/foo", destination, 0755, nil)).To(Succeed())
+			expectFile("bar")
+
+			entries, err := fakeFS.ReadDir(path.Join(CacheDir, "blobs", "sha256"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+
+		It("should verify the digest of the image", func() {
+			digest := push("foo", "v1", map[string]string{"bin/foo": "foo"})
+			config.RequireDigest = nil
+			extractor := NewOCIExtractor(fakeFS, fakeClock, config)
+
+			Expect(extractor.CopyFromImage(ctx, host+"/foo:v1", "bin/foo", destination, 0755, nil)).To(MatchError(ContainSubstring("is not pinned by a digest")))
+			Expect(extractor.CopyFromImage(ctx, host+"/foo:v1@sha256:"+strings.Repeat("0", 64), "bin/foo", destination, 0755, nil)).To(HaveOccurred())
+			Expect(extractor.CopyFromImage(ctx, host+"/foo:v1@"+digest.String(), "bin/foo", destination, 0755, nil)).To(Succeed())
+			expectFile("foo")
+		})
+
+		It("should pull the image from a mirror of the upstream registry", func() {
+			digest := push("foo", "v1", map[string]string{"bin/foo": "foo"})
+			extractor := NewOCIExtractor(fakeFS, fakeClock, config)
+
+			registries := []extensionsv1alpha1.RegistryConfig{{
+				// Nothing is listening on this port, i.e., pulling from the upstream fails.
+				Upstream: "127.0.0.1:1",
+				Hosts: []extensionsv1alpha1.RegistryHost{{
+					URL:          server.URL,
+					Capabilities: []extensionsv1alpha1.RegistryCapability{extensionsv1alpha1.PullCapability},
+				}},
+			}}
+
+			By("Do not resolve tags via hosts without resolve capability")
+			Expect(extractor.CopyFromImage(ctx, "127.0.0.1:1/foo:v1", "bin/foo", destination, 0755, registries)).To(MatchError(ContainSubstring("127.0.0.1:1")))
+			Expect(blobRequests.Load()).To(BeZero())
+
+			By("Pull images pinned by a digest from the mirror")
+			Expect(extractor.CopyFromImage(ctx, "127.0.0.1:1/foo@"+digest.String(), "bin/foo", destination, 0755, registries)).To(Succeed())
+			expectFile("foo")
+
+			By("Resolve tags via hosts with resolve capability")
+			Expect(fakeFS.Remove(destination)).To(Succeed())
+			registries[0].Hosts[0].Capabilities = nil
+			Expect(extractor.CopyFromImage(ctx, "127.0.0.1:1/foo:v1", "bin/foo", destination, 0755, registries)).To(Succeed())
+			expectFile("foo")
+		})
+	})
+})
+
+func layerBlob(files map[string]string) []byte {
+	GinkgoHelper()
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for filePath, content := range files {
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: filePath, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := io.Copy(tarWriter, strings.NewReader(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+	return buf.Bytes()
+}
@@ -63,6 +63,7 @@ func AddToManager(ctx context.Context, mgr manager.Manager, sourceCluster, targe
 		ClassFilter:               resourcemanagerpredicate.NewClassFilter(*cfg.Controllers.ResourceClass),
 		ClusterID:                 *cfg.Controllers.ClusterID,
 		GarbageCollectorActivated: cfg.Controllers.GarbageCollector.Enabled,
+		SourceNamespaces:          cfg.SourceClientConnection.Namespaces,
 	}).AddToManager(mgr, sourceCluster, targetCluster); err != nil {
 		return fmt.Errorf("failed adding managed resource controller: %w", err)
 	}
@@ -142,7 +142,10 @@ func InjectAnnotations(obj runtime.Object, additional ...string) error {
 		o.Spec.JobTemplate.Spec.Template.Annotations = mergeAnnotations(o.Spec.JobTemplate.Spec.Template.Annotations, referenceAnnotations)
 
 	case *resourcesv1alpha1.ManagedResource:
-		referenceAnnotations := computeAnnotationsFromLocalObjRefs(o.Spec.SecretRefs, KindSecret, additional...)
+		referenceAnnotations := utils.MergeStringMaps(
+			computeAnnotationsFromLocalObjRefs(o.Spec.SecretRefs, KindSecret, additional...),
+			computeAnnotationsFromLocalObjRefs(o.Spec.ConfigMapRefs, KindConfigMap),
+		)
 		o.Annotations = mergeAnnotations(o.Annotations, referenceAnnotations)
 
 	default:
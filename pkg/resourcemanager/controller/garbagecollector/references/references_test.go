@@ -357,6 +357,9 @@ var _ = Describe("References", func() {
 					SecretRefs: []corev1.LocalObjectReference{
 						{Name: secret2}, {Name: secret5},
 					},
+					ConfigMapRefs: []corev1.LocalObjectReference{
+						{Name: configMap1},
+					},
 				},
 			}
 		)
@@ -463,11 +466,12 @@ var _ = Describe("References", func() {
 				managedResourceV1alpha1,
 				func() {
 					Expect(managedResourceV1alpha1.Annotations).To(Equal(map[string]string{
-						"some-existing":                    "annotation",
-						AnnotationKey(KindSecret, secret2): secret2,
-						AnnotationKey(KindSecret, secret5): secret5,
-						additionalAnnotation1:              "",
-						additionalAnnotation2:              "",
+						"some-existing":                          "annotation",
+						AnnotationKey(KindSecret, secret2):       secret2,
+						AnnotationKey(KindSecret, secret5):       secret5,
+						AnnotationKey(KindConfigMap, configMap1): configMap1,
+						additionalAnnotation1:                    "",
+						additionalAnnotation2:                    "",
 					}))
 				},
 			),
@@ -23,6 +23,7 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	resourcesv1alpha1helper "github.com/gardener/gardener/pkg/apis/resources/v1alpha1/helper"
 	"github.com/gardener/gardener/pkg/controllerutils"
 	"github.com/gardener/gardener/pkg/resourcemanager/apis/config"
 	"github.com/gardener/gardener/pkg/resourcemanager/controller/health/utils"
@@ -97,11 +98,18 @@ func (r *Reconciler) executeHealthChecks(ctx context.Context, log logr.Logger, m
 
 	var (
 		conditionResourcesHealthy = v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesHealthy)
-		oldCondition              = conditionResourcesHealthy.DeepCopy()
+		oldStatus                 = mr.Status.DeepCopy()
+
+		unhealthyObjects int
+		reason, message  string
+		// firstFailedObject is the first object which failed its health check. Its events are consulted for more
+		// information about the failure.
+		firstFailedObject client.Object
 	)
 
-	for _, ref := range mr.Status.Resources {
+	for i := range mr.Status.Resources {
 		var (
+			ref       = &mr.Status.Resources[i]
 			objectGVK = ref.GroupVersionKind()
 			objectKey = client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
 			objectLog = log.WithValues("object", objectKey, "objectGVK", objectGVK)
@@ -117,75 +125,104 @@ func (r *Reconciler) executeHealthChecks(ctx context.Context, log logr.Logger, m
 			return reconcile.Result{}, err
 		}
 
-		if err := r.TargetClient.Get(healthCheckCtx, objectKey, obj); err != nil {
-			if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
-				return reconcile.Result{}, err
-			}
-
-			var (
-				reason  = ref.Kind + "Missing"
-				message = fmt.Sprintf("Required %s %q in namespace %q is missing", ref.Kind, ref.Name, ref.Namespace)
-			)
-			if meta.IsNoMatchError(err) {
-				message = fmt.Sprintf("%s: %v", message, err)
-			}
-			objectLog.Info("Finished ManagedResource health checks", "status", "unhealthy", "reason", reason, "message", message)
-
-			conditionResourcesHealthy = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesHealthy, gardencorev1beta1.ConditionFalse, reason, message)
-			mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, conditionResourcesHealthy)
-			if err := r.SourceClient.Status().Update(ctx, mr); err != nil {
-				return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
-			}
+		objectReason, objectMessage, checked, err := r.checkHealth(healthCheckCtx, objectLog, ref, obj)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
 
-			return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+		if objectReason == "" {
+			ref.Healthy = resourcesv1alpha1helper.UpdatedObjectCondition(r.Clock, ref.Healthy, gardencorev1beta1.ConditionTrue, "", "")
+			continue
 		}
 
-		if checked, err := utils.CheckHealth(obj); err != nil {
-			var (
-				reason  = ref.Kind + "Unhealthy"
-				message = fmt.Sprintf("%s %q is unhealthy: %v", ref.Kind, objectKey.String(), err)
-			)
+		ref.Healthy = resourcesv1alpha1helper.UpdatedObjectCondition(r.Clock, ref.Healthy, gardencorev1beta1.ConditionFalse, objectReason, objectMessage)
 
+		unhealthyObjects++
+		if unhealthyObjects == 1 {
+			reason, message = objectReason, objectMessage
 			if checked {
-				// consult object's events for more information if sensible
-				additionalMessage, err := utils.FetchAdditionalFailureMessage(ctx, r.TargetClient, obj)
-				if err != nil {
-					objectLog.Error(err, "Failed to read events for more information about unhealthy object")
-				} else if additionalMessage != "" {
-					message += "\n\n" + additionalMessage
-				}
-
-				objectLog.Info("Finished ManagedResource health checks", "status", "unhealthy", "reason", reason, "message", message)
-			} else {
-				// there was an error executing the health check (which is different from a failed health check)
-				// handle it separately and log it prominently
-				reason = "HealthCheckError"
-				message = fmt.Sprintf("Error executing health check for %s %q: %v", ref.Kind, objectKey.String(), err)
-				objectLog.Error(err, "Error executing health check for object")
+				firstFailedObject = obj
 			}
+		}
+	}
 
-			conditionResourcesHealthy = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesHealthy, gardencorev1beta1.ConditionFalse, reason, message)
-			mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, conditionResourcesHealthy)
-			if err := r.SourceClient.Status().Update(ctx, mr); err != nil {
-				return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
-			}
+	if unhealthyObjects == 0 {
+		conditionResourcesHealthy = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesHealthy, gardencorev1beta1.ConditionTrue, "ResourcesHealthy", "All resources are healthy.")
+	} else {
+		if unhealthyObjects > 1 {
+			message += fmt.Sprintf(" (%d more resources are unhealthy, see .status.resources for details)", unhealthyObjects-1)
+		}
 
-			return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+		if firstFailedObject != nil {
+			// consult object's events for more information if sensible
+			additionalMessage, err := utils.FetchAdditionalFailureMessage(ctx, r.TargetClient, firstFailedObject)
+			if err != nil {
+				log.Error(err, "Failed to read events for more information about unhealthy object", "object", client.ObjectKeyFromObject(firstFailedObject))
+			} else if additionalMessage != "" {
+				message += "\n\n" + additionalMessage
+			}
 		}
+
+		conditionResourcesHealthy = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesHealthy, gardencorev1beta1.ConditionFalse, reason, message)
 	}
 
-	conditionResourcesHealthy = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesHealthy, gardencorev1beta1.ConditionTrue, "ResourcesHealthy", "All resources are healthy.")
-	if !apiequality.Semantic.DeepEqual(oldCondition, conditionResourcesHealthy) {
-		mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, conditionResourcesHealthy)
+	mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, conditionResourcesHealthy)
+	mr.Status.HealthSummary = resourcesv1alpha1helper.HealthSummary(mr.Status.Resources)
+	if !apiequality.Semantic.DeepEqual(oldStatus, &mr.Status) {
 		if err := r.SourceClient.Status().Update(ctx, mr); err != nil {
 			return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
 		}
 	}
 
-	log.Info("Finished ManagedResource health checks", "status", "healthy")
+	if unhealthyObjects > 0 {
+		log.Info("Finished ManagedResource health checks", "status", "unhealthy", "unhealthyObjects", unhealthyObjects, "reason", reason)
+	} else {
+		log.Info("Finished ManagedResource health checks", "status", "healthy")
+	}
 	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
 }
 
+// checkHealth checks the health of the object referenced by ref. It returns an empty reason if the object is healthy,
+// otherwise the reason and message for its unhealthy state. checked indicates that the object was read and failed its
+// health check, as opposed to being missing or the health check failing to execute. An error is returned if the object
+// cannot be read.
+func (r *Reconciler) checkHealth(ctx context.Context, log logr.Logger, ref *resourcesv1alpha1.ObjectReference, obj client.Object) (reason, message string, checked bool, err error) {
+	objectKey := client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+
+	if err := r.TargetClient.Get(ctx, objectKey, obj); err != nil {
+		if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return "", "", false, err
+		}
+
+		reason = ref.Kind + "Missing"
+		message = fmt.Sprintf("Required %s %q in namespace %q is missing", ref.Kind, ref.Name, ref.Namespace)
+		if meta.IsNoMatchError(err) {
+			message = fmt.Sprintf("%s: %v", message, err)
+		}
+		log.Info("Object is unhealthy", "reason", reason, "message", message)
+
+		return reason, message, false, nil
+	}
+
+	checked, err = utils.CheckHealth(obj)
+	if err == nil {
+		return "", "", false, nil
+	}
+
+	if !checked {
+		// there was an error executing the health check (which is different from a failed health check)
+		// handle it separately and log it prominently
+		log.Error(err, "Error executing health check for object")
+		return "HealthCheckError", fmt.Sprintf("Error executing health check for %s %q: %v", ref.Kind, objectKey.String(), err), false, nil
+	}
+
+	reason = ref.Kind + "Unhealthy"
+	message = fmt.Sprintf("%s %q is unhealthy: %v", ref.Kind, objectKey.String(), err)
+	log.Info("Object is unhealthy", "reason", reason, "message", message)
+
+	return reason, message, true, nil
+}
+
 func newObjectForHealthCheck(log logr.Logger, scheme *runtime.Scheme, gvk schema.GroupVersionKind) (client.Object, error) {
 	// Create a typed object if GVK is registered in scheme. This object will be fully watched in the target cluster.
 	// If we don't know the GVK, we definitely don't have a dedicated health check for it.
@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -24,6 +25,7 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	resourcesv1alpha1helper "github.com/gardener/gardener/pkg/apis/resources/v1alpha1/helper"
 	"github.com/gardener/gardener/pkg/controllerutils"
 	"github.com/gardener/gardener/pkg/resourcemanager/apis/config"
 	"github.com/gardener/gardener/pkg/resourcemanager/controller/health/utils"
@@ -93,9 +95,17 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 	checkCtx, cancel := controllerutils.GetChildReconciliationContext(ctx, r.Config.SyncPeriod.Duration)
 	defer cancel()
 
-	conditionResourcesProgressing := v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesProgressing)
+	var (
+		conditionResourcesProgressing = v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesProgressing)
+		oldStatus                     = mr.Status.DeepCopy()
+
+		progressingObjects int
+		reason, message    string
+	)
+
+	for i := range mr.Status.Resources {
+		ref := &mr.Status.Resources[i]
 
-	for _, ref := range mr.Status.Resources {
 		// Skip API groups that are irrelevant for progressing checks.
 		if !sets.New(appsv1.GroupName, monitoring.GroupName, certv1alpha1.GroupName).Has(ref.GroupVersionKind().Group) {
 			continue
@@ -135,40 +145,56 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 			return reconcile.Result{}, err
 		}
 
-		if progressing, description, err := r.checkProgressing(ctx, obj); err != nil {
+		progressing, description, err := r.checkProgressing(ctx, obj)
+		if err != nil {
 			return reconcile.Result{}, err
-		} else if progressing {
-			var (
-				reason  = ref.Kind + "Progressing"
-				message = fmt.Sprintf("%s %q is progressing: %s", ref.Kind, objectKey.String(), description)
-			)
-
-			objectLog.Info("ManagedResource rollout is progressing, detected progressing object", "status", "progressing", "reason", reason, "message", message)
-
-			conditionResourcesProgressing = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesProgressing, gardencorev1beta1.ConditionTrue, reason, message)
-			mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, conditionResourcesProgressing)
-			if err := r.SourceClient.Status().Update(ctx, mr); err != nil {
-				return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
-			}
+		}
 
-			return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+		if !progressing {
+			ref.Progressing = resourcesv1alpha1helper.UpdatedObjectCondition(r.Clock, ref.Progressing, gardencorev1beta1.ConditionFalse, "", "")
+			continue
 		}
-	}
 
-	b, err := v1beta1helper.NewConditionBuilder(resourcesv1alpha1.ResourcesProgressing)
-	if err != nil {
-		return reconcile.Result{}, err
+		var (
+			objectReason  = ref.Kind + "Progressing"
+			objectMessage = fmt.Sprintf("%s %q is progressing: %s", ref.Kind, objectKey.String(), description)
+		)
+
+		objectLog.Info("ManagedResource rollout is progressing, detected progressing object", "status", "progressing", "reason", objectReason, "message", objectMessage)
+		ref.Progressing = resourcesv1alpha1helper.UpdatedObjectCondition(r.Clock, ref.Progressing, gardencorev1beta1.ConditionTrue, objectReason, objectMessage)
+
+		progressingObjects++
+		if progressingObjects == 1 {
+			reason, message = objectReason, objectMessage
+		}
 	}
 
-	var needsUpdate bool
-	conditionResourcesProgressing, needsUpdate = b.WithOldCondition(conditionResourcesProgressing).
-		WithStatus(gardencorev1beta1.ConditionFalse).WithReason("ResourcesRolledOut").
-		WithMessage("All resources have been fully rolled out.").
-		Build()
+	if progressingObjects > 0 {
+		if progressingObjects > 1 {
+			message += fmt.Sprintf(" (%d more resources are progressing, see .status.resources for details)", progressingObjects-1)
+		}
+
+		conditionResourcesProgressing = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesProgressing, gardencorev1beta1.ConditionTrue, reason, message)
+	} else {
+		b, err := v1beta1helper.NewConditionBuilder(resourcesv1alpha1.ResourcesProgressing)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		var needsUpdate bool
+		conditionResourcesProgressing, needsUpdate = b.WithOldCondition(conditionResourcesProgressing).
+			WithStatus(gardencorev1beta1.ConditionFalse).WithReason("ResourcesRolledOut").
+			WithMessage("All resources have been fully rolled out.").
+			Build()
+
+		if needsUpdate {
+			log.Info("ManagedResource has been fully rolled out", "status", "rolled out")
+		}
+	}
 
-	if needsUpdate {
-		log.Info("ManagedResource has been fully rolled out", "status", "rolled out")
-		mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, conditionResourcesProgressing)
+	mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, conditionResourcesProgressing)
+	mr.Status.HealthSummary = resourcesv1alpha1helper.HealthSummary(mr.Status.Resources)
+	if !apiequality.Semantic.DeepEqual(oldStatus, &mr.Status) {
 		if err := r.SourceClient.Status().Update(ctx, mr); err != nil {
 			return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
 		}
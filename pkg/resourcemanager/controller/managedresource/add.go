@@ -6,13 +6,16 @@ package managedresource
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -20,11 +23,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	predicateutils "github.com/gardener/gardener/pkg/controllerutils/predicate"
 	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	resourcemanagerpredicate "github.com/gardener/gardener/pkg/resourcemanager/predicate"
+	"github.com/gardener/gardener/pkg/utils/oci"
 )
 
 // ControllerName is the name of the controller.
@@ -50,6 +55,29 @@ func (r *Reconciler) AddToManager(mgr manager.Manager, sourceCluster, targetClus
 	if r.RequeueAfterOnDeletionPending == nil {
 		r.RequeueAfterOnDeletionPending = ptr.To(5 * time.Second)
 	}
+	if r.RequeueAfterOnApplyPhasePending == nil {
+		r.RequeueAfterOnApplyPhasePending = ptr.To(5 * time.Second)
+	}
+	if r.Registry == nil {
+		var err error
+		r.Registry, err = oci.NewManifestRegistry()
+		if err != nil {
+			return fmt.Errorf("failed creating manifest registry: %w", err)
+		}
+	}
+
+	// ConfigMaps are watched with a dedicated cache which only contains the ConfigMaps labeled as sources of
+	// ManagedResources. This way, not all ConfigMaps of the source cluster are cached.
+	configMapCache, err := r.newConfigMapCache(sourceCluster)
+	if err != nil {
+		return fmt.Errorf("failed creating cache for ConfigMaps: %w", err)
+	}
+	if err := mgr.Add(configMapCache); err != nil {
+		return fmt.Errorf("failed adding cache for ConfigMaps to manager: %w", err)
+	}
+	if r.ConfigMapReader == nil {
+		r.ConfigMapReader = configMapCache
+	}
 
 	return builder.
 		ControllerManagedBy(mgr).
@@ -66,6 +94,7 @@ func (r *Reconciler) AddToManager(mgr manager.Manager, sourceCluster, targetClus
 				resourcemanagerpredicate.NoLongerIgnored(),
 				// we need to reconcile once if the ManagedResource got marked as ignored in order to update the conditions
 				resourcemanagerpredicate.GotMarkedAsIgnored(),
+				resourcemanagerpredicate.PreviewChanged(),
 			),
 			// TODO: refactor this predicate chain into a single predicate.Funcs that can be properly tested as a whole
 			predicate.Or(
@@ -85,6 +114,17 @@ func (r *Reconciler) AddToManager(mgr manager.Manager, sourceCluster, targetClus
 				),
 			)),
 		).
+		WatchesRawSource(
+			source.Kind[client.Object](configMapCache, &corev1.ConfigMap{},
+				handler.EnqueueRequestsFromMapFunc(r.MapConfigMapToManagedResources(
+					r.ClassFilter,
+					predicate.Or(
+						resourcemanagerpredicate.NotIgnored(),
+						predicateutils.IsDeleting(),
+					),
+				)),
+			),
+		).
 		Complete(reconcilerutils.OperationAnnotationWrapper(
 			mgr,
 			func() client.Object { return &resourcesv1alpha1.ManagedResource{} },
@@ -92,40 +132,81 @@ func (r *Reconciler) AddToManager(mgr manager.Manager, sourceCluster, targetClus
 		))
 }
 
+func (r *Reconciler) newConfigMapCache(sourceCluster cluster.Cluster) (cache.Cache, error) {
+	var namespaces map[string]cache.Config
+	if len(r.SourceNamespaces) > 0 {
+		namespaces = make(map[string]cache.Config, len(r.SourceNamespaces))
+		for _, namespace := range r.SourceNamespaces {
+			namespaces[namespace] = cache.Config{}
+		}
+	}
+
+	return cache.New(sourceCluster.GetConfig(), cache.Options{
+		HTTPClient:        sourceCluster.GetHTTPClient(),
+		Scheme:            sourceCluster.GetScheme(),
+		Mapper:            sourceCluster.GetRESTMapper(),
+		DefaultNamespaces: namespaces,
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {Label: labels.SelectorFromSet(labels.Set{resourcesv1alpha1.ManagedResourceSource: "true"})},
+		},
+	})
+}
+
 // MapSecretToManagedResources maps secrets to relevant ManagedResources.
 func (r *Reconciler) MapSecretToManagedResources(managedResourcePredicates ...predicate.Predicate) handler.MapFunc {
 	return func(ctx context.Context, obj client.Object) []reconcile.Request {
-		if obj == nil {
-			return nil
-		}
-
 		secret, ok := obj.(*corev1.Secret)
 		if !ok {
 			return nil
 		}
 
-		managedResourceList := &resourcesv1alpha1.ManagedResourceList{}
-		if err := r.SourceClient.List(ctx, managedResourceList, client.InNamespace(secret.Namespace)); err != nil {
+		return r.mapToManagedResources(ctx, secret, func(mr resourcesv1alpha1.ManagedResource) []corev1.LocalObjectReference {
+			return mr.Spec.SecretRefs
+		}, managedResourcePredicates...)
+	}
+}
+
+// MapConfigMapToManagedResources maps config maps to relevant ManagedResources.
+func (r *Reconciler) MapConfigMapToManagedResources(managedResourcePredicates ...predicate.Predicate) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		configMap, ok := obj.(*corev1.ConfigMap)
+		if !ok {
 			return nil
 		}
 
-		var requests []reconcile.Request
-		for _, mr := range managedResourceList.Items {
-			if !predicateutils.EvalGeneric(&mr, managedResourcePredicates...) {
-				continue
-			}
+		return r.mapToManagedResources(ctx, configMap, func(mr resourcesv1alpha1.ManagedResource) []corev1.LocalObjectReference {
+			return mr.Spec.ConfigMapRefs
+		}, managedResourcePredicates...)
+	}
+}
+
+func (r *Reconciler) mapToManagedResources(
+	ctx context.Context,
+	obj client.Object,
+	refsOf func(resourcesv1alpha1.ManagedResource) []corev1.LocalObjectReference,
+	managedResourcePredicates ...predicate.Predicate,
+) []reconcile.Request {
+	managedResourceList := &resourcesv1alpha1.ManagedResourceList{}
+	if err := r.SourceClient.List(ctx, managedResourceList, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, mr := range managedResourceList.Items {
+		if !predicateutils.EvalGeneric(&mr, managedResourcePredicates...) {
+			continue
+		}
 
-			for _, secretRef := range mr.Spec.SecretRefs {
-				if secretRef.Name == secret.Name {
-					requests = append(requests, reconcile.Request{
-						NamespacedName: types.NamespacedName{
-							Namespace: mr.Namespace,
-							Name:      mr.Name,
-						},
-					})
-				}
+		for _, ref := range refsOf(mr) {
+			if ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: mr.Namespace,
+						Name:      mr.Name,
+					},
+				})
 			}
 		}
-		return requests
 	}
+	return requests
 }
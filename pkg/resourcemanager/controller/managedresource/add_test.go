@@ -123,3 +123,76 @@ var _ = Describe("#MapSecretToManagedResources", func() {
 		))
 	})
 })
+
+var _ = Describe("#MapConfigMapToManagedResources", func() {
+	var (
+		ctx       = context.TODO()
+		c         *mockclient.MockClient
+		ctrl      *gomock.Controller
+		m         handler.MapFunc
+		configMap *corev1.ConfigMap
+		filter    *predicate.ClassFilter
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mr-configmap",
+				Namespace: "mr-namespace",
+			},
+		}
+
+		filter = predicate.NewClassFilter("seed")
+
+		m = (&Reconciler{SourceClient: c}).MapConfigMapToManagedResources(filter)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should do nothing, if Object is not a ConfigMap", func() {
+		requests := m(ctx, &corev1.Secret{})
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("should correctly map to ManagedResources that reference the config map", func() {
+		mr := resourcesv1alpha1.ManagedResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mr",
+				Namespace: configMap.Namespace,
+			},
+			Spec: resourcesv1alpha1.ManagedResourceSpec{
+				Class:         ptr.To(filter.ResourceClass()),
+				ConfigMapRefs: []corev1.LocalObjectReference{{Name: configMap.Name}},
+			},
+		}
+		mrReferencingSecret := resourcesv1alpha1.ManagedResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mr-secret",
+				Namespace: configMap.Namespace,
+			},
+			Spec: resourcesv1alpha1.ManagedResourceSpec{
+				Class:      ptr.To(filter.ResourceClass()),
+				SecretRefs: []corev1.LocalObjectReference{{Name: configMap.Name}},
+			},
+		}
+
+		c.EXPECT().List(ctx, gomock.AssignableToTypeOf(&resourcesv1alpha1.ManagedResourceList{}), client.InNamespace(configMap.Namespace)).
+			DoAndReturn(func(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+				list.(*resourcesv1alpha1.ManagedResourceList).Items = []resourcesv1alpha1.ManagedResource{mr, mrReferencingSecret}
+				return nil
+			})
+
+		requests := m(ctx, configMap)
+		Expect(requests).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      mr.Name,
+				Namespace: mr.Namespace,
+			}},
+		))
+	})
+})
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	healthutils "github.com/gardener/gardener/pkg/resourcemanager/controller/health/utils"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+)
+
+// applyPhase is a group of objects which are applied together.
+type applyPhase struct {
+	number  int
+	objects []object
+}
+
+// applyPhaseNotReadyError is returned if the objects of an apply phase are not ready yet, so that the objects of the
+// next phase cannot be applied.
+type applyPhaseNotReadyError struct {
+	phase     int
+	nextPhase int
+	reason    error
+}
+
+func (e *applyPhaseNotReadyError) Error() string {
+	return fmt.Sprintf("waiting for apply phase %d to become ready before applying phase %d: %v", e.phase, e.nextPhase, e.reason)
+}
+
+// groupByApplyPhase groups the given objects by their apply phase. The phases are sorted in ascending order.
+func groupByApplyPhase(objects []object) ([]applyPhase, error) {
+	objectsByPhase := map[int][]object{}
+	for _, obj := range objects {
+		phase, err := applyPhaseOf(obj)
+		if err != nil {
+			return nil, err
+		}
+		objectsByPhase[phase] = append(objectsByPhase[phase], obj)
+	}
+
+	phases := make([]applyPhase, 0, len(objectsByPhase))
+	for number, phaseObjects := range objectsByPhase {
+		phases = append(phases, applyPhase{number: number, objects: phaseObjects})
+	}
+	slices.SortFunc(phases, func(a, b applyPhase) int { return a.number - b.number })
+
+	return phases, nil
+}
+
+func applyPhaseOf(obj object) (int, error) {
+	value, ok := obj.obj.GetAnnotations()[resourcesv1alpha1.ApplyPhase]
+	if !ok {
+		return 0, nil
+	}
+
+	phase, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of annotation %s on object %q: %w", value, resourcesv1alpha1.ApplyPhase, unstructuredToString(obj.obj), err)
+	}
+	return phase, nil
+}
+
+// appliedObjectReferences returns the references of the objects which are applied while the given apply phase is not
+// ready yet, i.e., of the objects of this and all previous phases, and of the objects which have been applied in
+// previous reconciliations.
+func appliedObjectReferences(objects []object, references []resourcesv1alpha1.ObjectReference, pendingPhase int, existingResourcesIndex *objectIndex) []resourcesv1alpha1.ObjectReference {
+	appliedObjects := sets.New[string]()
+	for _, obj := range objects {
+		if phase, err := applyPhaseOf(obj); err == nil && phase <= pendingPhase {
+			appliedObjects.Insert(objectKeyFromUnstructured(obj.obj))
+		}
+	}
+
+	var appliedReferences []resourcesv1alpha1.ObjectReference
+	for _, ref := range references {
+		if appliedObjects.Has(objectKeyByReference(ref)) || existingResourcesIndex.Found(ref) {
+			appliedReferences = append(appliedReferences, ref)
+		}
+	}
+	return appliedReferences
+}
+
+// checkApplyPhaseReady returns an error if any object of the given apply phase is not ready, i.e., it is missing,
+// unhealthy, or its rollout is still progressing. Objects without dedicated health checks or with the skip-health-check
+// annotation are ready as soon as they exist.
+func (r *Reconciler) checkApplyPhaseReady(ctx context.Context, phase applyPhase) error {
+	for _, o := range phase.objects {
+		if ignore(o.obj) {
+			continue
+		}
+
+		var (
+			gvk      = o.obj.GroupVersionKind()
+			resource = unstructuredToString(o.obj)
+			obj      client.Object
+		)
+
+		if typedObject, err := r.TargetScheme.New(gvk); err == nil {
+			obj = typedObject.(client.Object)
+		} else if runtime.IsNotRegisteredError(err) {
+			metadata := &metav1.PartialObjectMetadata{}
+			metadata.SetGroupVersionKind(gvk)
+			obj = metadata
+		} else {
+			return err
+		}
+
+		if err := r.TargetClient.Get(ctx, client.ObjectKeyFromObject(o.obj), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("object %q is missing", resource)
+			}
+			return fmt.Errorf("error getting object %q: %w", resource, err)
+		}
+
+		if skipHealthCheck(o.obj) || skipHealthCheck(obj) {
+			continue
+		}
+
+		if _, err := healthutils.CheckHealth(obj); err != nil {
+			return fmt.Errorf("object %q is unhealthy: %w", resource, err)
+		}
+
+		if progressing, reason := isProgressing(obj); progressing {
+			return fmt.Errorf("object %q is progressing: %s", resource, reason)
+		}
+	}
+
+	return nil
+}
+
+func skipHealthCheck(obj client.Object) bool {
+	return obj.GetAnnotations()[resourcesv1alpha1.SkipHealthCheck] == "true"
+}
+
+func isProgressing(obj client.Object) (bool, string) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return health.IsDeploymentProgressing(o)
+	case *appsv1.StatefulSet:
+		return health.IsStatefulSetProgressing(o)
+	case *appsv1.DaemonSet:
+		return health.IsDaemonSetProgressing(o)
+	}
+
+	return false, ""
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+)
+
+var _ = Describe("apply phases", func() {
+	var (
+		ctx = context.Background()
+
+		targetClient client.Client
+		reconciler   *Reconciler
+
+		deployment *appsv1.Deployment
+		configMap  object
+	)
+
+	newObject := func(apiVersion, kind, name string, phase *string) object {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+		}}
+		if phase != nil {
+			obj.SetAnnotations(map[string]string{resourcesv1alpha1.ApplyPhase: *phase})
+		}
+		return object{obj: obj}
+	}
+
+	BeforeEach(func() {
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "default", Generation: 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				Replicas:           1,
+				UpdatedReplicas:    1,
+				AvailableReplicas:  1,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+					{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+				},
+			},
+		}
+		configMap = newObject("v1", "ConfigMap", "config", ptr.To("1"))
+
+		targetClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		reconciler = &Reconciler{TargetClient: targetClient, TargetScheme: scheme.Scheme}
+	})
+
+	Describe("#groupByApplyPhase", func() {
+		It("should group the objects by their apply phase in ascending order", func() {
+			var (
+				obj1 = newObject("v1", "ConfigMap", "obj1", ptr.To("2"))
+				obj2 = newObject("v1", "ConfigMap", "obj2", nil)
+				obj3 = newObject("v1", "ConfigMap", "obj3", ptr.To("-1"))
+				obj4 = newObject("v1", "ConfigMap", "obj4", ptr.To("0"))
+			)
+
+			phases, err := groupByApplyPhase([]object{obj1, obj2, obj3, obj4})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phases).To(Equal([]applyPhase{
+				{number: -1, objects: []object{obj3}},
+				{number: 0, objects: []object{obj2, obj4}},
+				{number: 2, objects: []object{obj1}},
+			}))
+		})
+
+		It("should fail for invalid apply phases", func() {
+			_, err := groupByApplyPhase([]object{newObject("v1", "ConfigMap", "obj", ptr.To("first"))})
+			Expect(err).To(MatchError(ContainSubstring(`invalid value "first" of annotation resources.gardener.cloud/apply-phase on object "v1/ConfigMap/default/obj"`)))
+		})
+	})
+
+	Describe("#appliedObjectReferences", func() {
+		It("should return the references of the objects of the applied phases and of the previously applied objects", func() {
+			var (
+				obj1 = newObject("v1", "ConfigMap", "obj1", nil)
+				obj2 = newObject("v1", "ConfigMap", "obj2", ptr.To("1"))
+				obj3 = newObject("v1", "ConfigMap", "obj3", ptr.To("1"))
+
+				referenceOf = func(o object) resourcesv1alpha1.ObjectReference {
+					return resourcesv1alpha1.ObjectReference{ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Name: o.obj.GetName(), Namespace: "default"}}
+				}
+				references = []resourcesv1alpha1.ObjectReference{referenceOf(obj1), referenceOf(obj2), referenceOf(obj3)}
+
+				existingResourcesIndex = NewObjectIndex([]resourcesv1alpha1.ObjectReference{referenceOf(obj3)}, nil)
+			)
+
+			for _, ref := range references {
+				existingResourcesIndex.Lookup(ref)
+			}
+
+			Expect(appliedObjectReferences([]object{obj1, obj2, obj3}, references, 0, existingResourcesIndex)).To(ConsistOf(referenceOf(obj1), referenceOf(obj3)))
+			Expect(appliedObjectReferences([]object{obj1, obj2, obj3}, references, 1, existingResourcesIndex)).To(ConsistOf(references))
+		})
+	})
+
+	Describe("#checkApplyPhaseReady", func() {
+		var phase applyPhase
+
+		BeforeEach(func() {
+			phase = applyPhase{objects: []object{newObject("apps/v1", "Deployment", "webhook", nil)}}
+		})
+
+		It("should fail if an object is missing", func() {
+			Expect(reconciler.checkApplyPhaseReady(ctx, phase)).To(MatchError(`object "apps/v1/Deployment/default/webhook" is missing`))
+		})
+
+		It("should fail if an object is unhealthy", func() {
+			deployment.Status.AvailableReplicas = 0
+			deployment.Status.Conditions[0].Status = corev1.ConditionFalse
+			Expect(targetClient.Create(ctx, deployment)).To(Succeed())
+
+			Expect(reconciler.checkApplyPhaseReady(ctx, phase)).To(MatchError(ContainSubstring(`object "apps/v1/Deployment/default/webhook" is unhealthy`)))
+		})
+
+		It("should fail if the rollout of an object is progressing", func() {
+			deployment.Status.Conditions[1].Reason = "ReplicaSetUpdated"
+			Expect(targetClient.Create(ctx, deployment)).To(Succeed())
+
+			Expect(reconciler.checkApplyPhaseReady(ctx, phase)).To(MatchError(ContainSubstring(`object "apps/v1/Deployment/default/webhook" is progressing`)))
+		})
+
+		It("should succeed if all objects are ready", func() {
+			Expect(targetClient.Create(ctx, deployment)).To(Succeed())
+			Expect(targetClient.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"}})).To(Succeed())
+			phase.objects = append(phase.objects, configMap)
+
+			Expect(reconciler.checkApplyPhaseReady(ctx, phase)).To(Succeed())
+		})
+
+		It("should skip the health checks of objects with the skip-health-check annotation", func() {
+			phase.objects[0].obj.SetAnnotations(map[string]string{resourcesv1alpha1.SkipHealthCheck: "true"})
+			deployment.Status.AvailableReplicas = 0
+			deployment.Status.Conditions[0].Status = corev1.ConditionFalse
+			deployment.Status.Conditions[1].Reason = "ReplicaSetUpdated"
+			Expect(targetClient.Create(ctx, deployment)).To(Succeed())
+
+			Expect(reconciler.checkApplyPhaseReady(ctx, phase)).To(Succeed())
+		})
+
+		It("should fail if an object with the skip-health-check annotation is missing", func() {
+			phase.objects[0].obj.SetAnnotations(map[string]string{resourcesv1alpha1.SkipHealthCheck: "true"})
+
+			Expect(reconciler.checkApplyPhaseReady(ctx, phase)).To(MatchError(`object "apps/v1/Deployment/default/webhook" is missing`))
+		})
+
+		It("should skip ignored objects", func() {
+			phase.objects[0].obj.SetAnnotations(map[string]string{resourcesv1alpha1.Ignore: "true"})
+
+			Expect(reconciler.checkApplyPhaseReady(ctx, phase)).To(Succeed())
+		})
+	})
+
+	Describe("#applyNewResources", func() {
+		var webhook object
+
+		BeforeEach(func() {
+			webhook = newObject("apps/v1", "Deployment", "webhook", nil)
+			Expect(unstructured.SetNestedField(webhook.obj.Object, int64(1), "spec", "replicas")).To(Succeed())
+		})
+
+		It("should not apply the next phase if the previous phase is not ready", func() {
+			err := reconciler.applyNewResources(ctx, logr.Discard(), "origin", []object{configMap, webhook}, nil, nil)
+
+			phaseErr := &applyPhaseNotReadyError{}
+			Expect(errors.As(err, &phaseErr)).To(BeTrue())
+			Expect(phaseErr.phase).To(Equal(0))
+			Expect(phaseErr.nextPhase).To(Equal(1))
+			Expect(err).To(MatchError(ContainSubstring(`waiting for apply phase 0 to become ready before applying phase 1: object "apps/v1/Deployment/default/webhook" is unhealthy`)))
+
+			Expect(targetClient.Get(ctx, client.ObjectKey{Name: "webhook", Namespace: "default"}, &appsv1.Deployment{})).To(Succeed())
+			Expect(targetClient.Get(ctx, client.ObjectKey{Name: "config", Namespace: "default"}, &corev1.ConfigMap{})).To(BeNotFoundError())
+		})
+
+		It("should apply the next phase if the previous phase is ready", func() {
+			Expect(targetClient.Create(ctx, deployment)).To(Succeed())
+
+			Expect(reconciler.applyNewResources(ctx, logr.Discard(), "origin", []object{configMap, webhook}, nil, nil)).To(Succeed())
+
+			Expect(targetClient.Get(ctx, client.ObjectKey{Name: "config", Namespace: "default"}, &corev1.ConfigMap{})).To(Succeed())
+		})
+	})
+})
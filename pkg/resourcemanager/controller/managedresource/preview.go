@@ -0,0 +1,324 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/controllerutils"
+)
+
+// maxPreviewFieldsPerObject is the maximum number of changed fields which are listed per object in the preview.
+const maxPreviewFieldsPerObject = 20
+
+var (
+	// ignoredPreviewFields are the fields which are not considered when computing the changed fields of an object since
+	// they are maintained by the API server.
+	ignoredPreviewFields = [][]string{
+		{"metadata", "creationTimestamp"},
+		{"metadata", "generation"},
+		{"metadata", "managedFields"},
+		{"metadata", "resourceVersion"},
+		{"metadata", "uid"},
+		{"status"},
+	}
+
+	simpleFieldName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+// preview computes the changes which would be made to the target cluster if the resources of the ManagedResource were
+// applied and writes them to its status. All requests modifying objects in the target cluster are sent with dry-run,
+// i.e., they run through admission and validation without being persisted.
+func (r *Reconciler) preview(
+	ctx context.Context,
+	log logr.Logger,
+	mr *resourcesv1alpha1.ManagedResource,
+	origin string,
+	newResourcesObjects []object,
+	existingResourcesIndex *objectIndex,
+	labelsToInject map[string]string,
+	equivalences Equivalences,
+	secretsDataChecksum string,
+) (reconcile.Result, error) {
+	log.Info("Computing preview of ManagedResource instead of applying its resources")
+
+	changes, err := r.previewNewResources(ctx, log, origin, newResourcesObjects, labelsToInject, equivalences)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not compute preview of new resources: %w", err)
+	}
+
+	deletions, err := r.previewOldResources(ctx, existingResourcesIndex)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not compute preview of old resources: %w", err)
+	}
+	changes = append(changes, deletions...)
+
+	mr.Status.Preview = &resourcesv1alpha1.ManagedResourcePreview{
+		SecretsDataChecksum: secretsDataChecksum,
+		LastUpdateTime:      metav1.NewTime(r.Clock.Now()),
+		Summary:             previewSummary(changes, len(newResourcesObjects)),
+		Changes:             changes,
+	}
+	if err := r.SourceClient.Status().Update(ctx, mr); err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
+	}
+
+	log.Info("Finished to compute preview of ManagedResource", "summary", mr.Status.Preview.Summary)
+	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+}
+
+// previewNewResources returns the changes which would be made when applying the given objects. Unchanged objects are
+// not contained.
+func (r *Reconciler) previewNewResources(ctx context.Context, log logr.Logger, origin string, newResourcesObjects []object, labelsToInject map[string]string, equivalences Equivalences) ([]resourcesv1alpha1.ObjectChange, error) {
+	horizontallyScaledObjects, err := computeHorizontallyScaledObjectKeys(ctx, r.TargetClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute all HPA target ref object keys: %w", err)
+	}
+
+	var (
+		dryRunClient = client.NewDryRunClient(r.TargetClient)
+		changes      []resourcesv1alpha1.ObjectChange
+	)
+
+	for _, obj := range sortByKind(newResourcesObjects) {
+		var (
+			resourceLogger     = log.WithValues("resource", unstructuredToString(obj.obj))
+			scaledHorizontally = isScaled(obj.obj, horizontallyScaledObjects, equivalences)
+			change             *resourcesv1alpha1.ObjectChange
+		)
+
+		if serverSideApplyMode(obj.obj) && !ignore(obj.obj) {
+			change, err = r.previewServerSideApply(ctx, resourceLogger, dryRunClient, origin, obj, labelsToInject, scaledHorizontally)
+		} else {
+			change, err = r.previewCreateOrUpdate(ctx, dryRunClient, origin, obj, labelsToInject, scaledHorizontally)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	return changes, nil
+}
+
+// previewCreateOrUpdate returns the change which would be made when creating or updating the given object with the
+// default mode, or nil if the object would not be changed.
+func (r *Reconciler) previewCreateOrUpdate(ctx context.Context, dryRunClient client.Client, origin string, obj object, labelsToInject map[string]string, scaledHorizontally bool) (*resourcesv1alpha1.ObjectChange, error) {
+	var (
+		current  = obj.obj.DeepCopy()
+		existing *unstructured.Unstructured
+	)
+
+	operationResult, err := controllerutils.TypedCreateOrUpdate(ctx, dryRunClient, r.TargetScheme, current, ptr.Deref(r.Config.AlwaysUpdate, false), func() error {
+		existing = current.DeepCopy()
+		return mutate(origin, obj, current, labelsToInject, scaledHorizontally)
+	})
+
+	switch operationResult {
+	case controllerutil.OperationResultCreated:
+		return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationCreate, nil, err), nil
+	case controllerutil.OperationResultUpdated:
+		if err != nil {
+			return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationUpdate, nil, err), nil
+		}
+		if fields := changedFields(existing, current); len(fields) > 0 {
+			return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationUpdate, fields, nil), nil
+		}
+		return nil, nil
+	default:
+		if meta.IsNoMatchError(err) {
+			// The kind of the object is not known yet, e.g., because its CustomResourceDefinition is part of the
+			// ManagedResource as well.
+			return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationCreate, nil, nil), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error computing changes of object %q: %w", unstructuredToString(obj.obj), err)
+		}
+		return nil, nil
+	}
+}
+
+// previewServerSideApply returns the change which would be made when applying the given object with server-side apply,
+// or nil if the object would not be changed.
+func (r *Reconciler) previewServerSideApply(ctx context.Context, log logr.Logger, dryRunClient client.Client, origin string, obj object, labelsToInject map[string]string, scaledHorizontally bool) (*resourcesv1alpha1.ObjectChange, error) {
+	desired, err := desiredForServerSideApply(origin, obj, labelsToInject)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := r.getCurrent(ctx, desired)
+	if meta.IsNoMatchError(err) {
+		return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationCreate, nil, nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := patchServerSide(ctx, log, dryRunClient, desired, current, scaledHorizontally)
+	if current == nil {
+		return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationCreate, nil, err), nil
+	}
+	if err != nil {
+		return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationUpdate, nil, err), nil
+	}
+	if fields := changedFields(current, applied); len(fields) > 0 {
+		return newObjectChange(obj.obj, resourcesv1alpha1.ObjectChangeOperationUpdate, fields, nil), nil
+	}
+	return nil, nil
+}
+
+// previewOldResources returns the deletions of the objects which are no longer part of the ManagedResource. Objects
+// which are already gone or which would be kept are not contained.
+func (r *Reconciler) previewOldResources(ctx context.Context, index *objectIndex) ([]resourcesv1alpha1.ObjectChange, error) {
+	var changes []resourcesv1alpha1.ObjectChange
+
+	for _, oldResource := range index.Objects() {
+		if index.Found(oldResource) {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(oldResource.APIVersion)
+		obj.SetKind(oldResource.Kind)
+		obj.SetNamespace(oldResource.Namespace)
+		obj.SetName(oldResource.Name)
+
+		if err := r.TargetClient.Get(ctx, client.ObjectKey{Namespace: oldResource.Namespace, Name: oldResource.Name}, obj); err != nil {
+			if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				return nil, fmt.Errorf("error getting object %q: %w", unstructuredToString(obj), err)
+			}
+			continue
+		}
+
+		if keepObject(obj) || (r.GarbageCollectorActivated && isGarbageCollectableResource(obj)) {
+			continue
+		}
+
+		changes = append(changes, *newObjectChange(obj, resourcesv1alpha1.ObjectChangeOperationDelete, nil, nil))
+	}
+
+	return changes, nil
+}
+
+func newObjectChange(obj *unstructured.Unstructured, operation resourcesv1alpha1.ObjectChangeOperation, fields []string, err error) *resourcesv1alpha1.ObjectChange {
+	change := &resourcesv1alpha1.ObjectChange{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Operation:  operation,
+		Fields:     fields,
+	}
+	if err != nil {
+		change.Error = err.Error()
+	}
+	return change
+}
+
+func previewSummary(changes []resourcesv1alpha1.ObjectChange, numberOfNewResources int) string {
+	counts := map[resourcesv1alpha1.ObjectChangeOperation]int{}
+	failed := 0
+	for _, change := range changes {
+		counts[change.Operation]++
+		if change.Error != "" {
+			failed++
+		}
+	}
+
+	unchanged := numberOfNewResources - counts[resourcesv1alpha1.ObjectChangeOperationCreate] - counts[resourcesv1alpha1.ObjectChangeOperationUpdate]
+	summary := fmt.Sprintf("%d to create, %d to update, %d to delete, %d unchanged",
+		counts[resourcesv1alpha1.ObjectChangeOperationCreate],
+		counts[resourcesv1alpha1.ObjectChangeOperationUpdate],
+		counts[resourcesv1alpha1.ObjectChangeOperationDelete],
+		unchanged,
+	)
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+	return summary
+}
+
+// changedFields returns the paths of the fields which differ between the given objects. Only the paths are returned
+// since the values might be confidential (e.g., the data of secrets).
+func changedFields(oldObj, newObj *unstructured.Unstructured) []string {
+	oldContent, newContent := oldObj.DeepCopy().Object, newObj.DeepCopy().Object
+	for _, fields := range ignoredPreviewFields {
+		unstructured.RemoveNestedField(oldContent, fields...)
+		unstructured.RemoveNestedField(newContent, fields...)
+	}
+
+	var paths []string
+	collectChangedFields("", oldContent, newContent, &paths)
+	slices.Sort(paths)
+
+	if len(paths) > maxPreviewFieldsPerObject {
+		paths = append(paths[:maxPreviewFieldsPerObject], fmt.Sprintf("... and %d more", len(paths)-maxPreviewFieldsPerObject))
+	}
+	return paths
+}
+
+func collectChangedFields(path string, oldValue, newValue any, paths *[]string) {
+	if apiequality.Semantic.DeepEqual(oldValue, newValue) {
+		return
+	}
+
+	switch oldTyped := oldValue.(type) {
+	case map[string]any:
+		newTyped, ok := newValue.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := make(map[string]struct{}, len(oldTyped)+len(newTyped))
+		for key := range oldTyped {
+			keys[key] = struct{}{}
+		}
+		for key := range newTyped {
+			keys[key] = struct{}{}
+		}
+		for key := range keys {
+			collectChangedFields(fieldPath(path, key), oldTyped[key], newTyped[key], paths)
+		}
+		return
+
+	case []any:
+		newTyped, ok := newValue.([]any)
+		if !ok || len(oldTyped) != len(newTyped) {
+			break
+		}
+
+		for i := range oldTyped {
+			collectChangedFields(fmt.Sprintf("%s[%d]", path, i), oldTyped[i], newTyped[i], paths)
+		}
+		return
+	}
+
+	*paths = append(*paths, path)
+}
+
+func fieldPath(path, key string) string {
+	if simpleFieldName.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
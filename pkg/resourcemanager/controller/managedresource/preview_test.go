@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/resourcemanager/apis/config"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+)
+
+var _ = Describe("preview", func() {
+	var (
+		ctx = context.Background()
+
+		sourceClient client.Client
+		targetClient client.Client
+		fakeClock    *testclock.FakeClock
+		reconciler   *Reconciler
+
+		mr        *resourcesv1alpha1.ManagedResource
+		configMap *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		mr = &resourcesv1alpha1.ManagedResource{ObjectMeta: metav1.ObjectMeta{Name: "mr", Namespace: "garden"}}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Data:       map[string]string{"foo": "bar"},
+		}
+
+		sourceClient = fake.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(mr).WithStatusSubresource(mr).Build()
+		targetClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap.DeepCopy()).Build()
+		fakeClock = testclock.NewFakeClock(time.Now().Round(time.Second))
+
+		reconciler = &Reconciler{
+			SourceClient: sourceClient,
+			TargetClient: targetClient,
+			TargetScheme: scheme.Scheme,
+			Clock:        fakeClock,
+			Config:       config.ManagedResourceControllerConfig{SyncPeriod: &metav1.Duration{Duration: time.Minute}},
+		}
+	})
+
+	newObject := func(name string, data map[string]any) object {
+		return object{obj: &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+			"data":       data,
+		}}}
+	}
+
+	Describe("#preview", func() {
+		It("should write the changes to the status without modifying the target objects", func() {
+			existingResourcesIndex := NewObjectIndex([]resourcesv1alpha1.ObjectReference{
+				{ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "foo"}},
+			}, nil)
+
+			result, err := reconciler.preview(ctx, logr.Discard(), mr, "origin", []object{newObject("bar", map[string]any{"bar": "baz"})}, existingResourcesIndex, nil, nil, "checksum")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			Expect(sourceClient.Get(ctx, client.ObjectKeyFromObject(mr), mr)).To(Succeed())
+			Expect(mr.Status.Preview).To(Equal(&resourcesv1alpha1.ManagedResourcePreview{
+				SecretsDataChecksum: "checksum",
+				LastUpdateTime:      metav1.NewTime(fakeClock.Now()),
+				Summary:             "1 to create, 0 to update, 1 to delete, 0 unchanged",
+				Changes: []resourcesv1alpha1.ObjectChange{
+					{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "bar", Operation: resourcesv1alpha1.ObjectChangeOperationCreate},
+					{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "foo", Operation: resourcesv1alpha1.ObjectChangeOperationDelete},
+				},
+			}))
+			Expect(mr.Status.Conditions).To(BeEmpty())
+
+			Expect(targetClient.Get(ctx, client.ObjectKey{Name: "bar", Namespace: "default"}, &corev1.ConfigMap{})).To(BeNotFoundError())
+			Expect(targetClient.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})).To(Succeed())
+		})
+	})
+
+	Describe("#previewNewResources", func() {
+		It("should report the changed fields of updated objects", func() {
+			changes, err := reconciler.previewNewResources(ctx, logr.Discard(), "origin", []object{newObject("foo", map[string]any{"foo": "baz", "bar": "baz"})}, map[string]string{"app.kubernetes.io/name": "foo"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(ConsistOf(resourcesv1alpha1.ObjectChange{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Namespace:  "default",
+				Name:       "foo",
+				Operation:  resourcesv1alpha1.ObjectChangeOperationUpdate,
+				Fields: []string{
+					".data.bar",
+					".data.foo",
+					".metadata.annotations",
+					".metadata.labels",
+				},
+			}))
+
+			current := &corev1.ConfigMap{}
+			Expect(targetClient.Get(ctx, client.ObjectKeyFromObject(configMap), current)).To(Succeed())
+			Expect(current.Data).To(Equal(configMap.Data))
+		})
+
+		It("should not report unchanged objects", func() {
+			changes, err := reconciler.previewNewResources(ctx, logr.Discard(), "origin", []object{newObject("foo", map[string]any{"foo": "baz"})}, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(HaveLen(1))
+
+			current := &corev1.ConfigMap{}
+			Expect(targetClient.Get(ctx, client.ObjectKeyFromObject(configMap), current)).To(Succeed())
+			current.Data["foo"] = "baz"
+			metav1.SetMetaDataAnnotation(&current.ObjectMeta, resourcesv1alpha1.OriginAnnotation, "origin")
+			metav1.SetMetaDataAnnotation(&current.ObjectMeta, descriptionAnnotation, descriptionAnnotationText)
+			Expect(targetClient.Update(ctx, current)).To(Succeed())
+
+			changes, err = reconciler.previewNewResources(ctx, logr.Discard(), "origin", []object{newObject("foo", map[string]any{"foo": "baz"})}, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+		})
+	})
+
+	Describe("#previewOldResources", func() {
+		It("should not report objects which are kept or already gone", func() {
+			kept := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kept", Namespace: "default", Annotations: map[string]string{resourcesv1alpha1.KeepObject: "true"}}}
+			Expect(targetClient.Create(ctx, kept)).To(Succeed())
+
+			index := NewObjectIndex([]resourcesv1alpha1.ObjectReference{
+				{ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "kept"}},
+				{ObjectReference: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "gone"}},
+			}, nil)
+
+			changes, err := reconciler.previewOldResources(ctx, index)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+		})
+	})
+
+	Describe("#changedFields", func() {
+		It("should return the paths of the changed fields without server-maintained fields", func() {
+			oldObj := &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{"resourceVersion": "1", "labels": map[string]any{"app.kubernetes.io/name": "foo"}},
+				"spec": map[string]any{
+					"replicas":   int64(1),
+					"containers": []any{map[string]any{"name": "foo", "image": "foo:v1"}},
+					"ports":      []any{int64(80)},
+				},
+				"status": map[string]any{"ready": true},
+			}}
+			newObj := &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{"resourceVersion": "2", "labels": map[string]any{"app.kubernetes.io/name": "bar"}},
+				"spec": map[string]any{
+					"replicas":   int64(1),
+					"containers": []any{map[string]any{"name": "foo", "image": "foo:v2"}},
+					"ports":      []any{int64(80), int64(443)},
+					"paused":     true,
+				},
+				"status": map[string]any{"ready": false},
+			}}
+
+			Expect(changedFields(oldObj, newObj)).To(Equal([]string{
+				`.metadata.labels["app.kubernetes.io/name"]`,
+				".spec.containers[0].image",
+				".spec.paused",
+				".spec.ports",
+			}))
+		})
+
+		It("should limit the number of reported fields", func() {
+			oldObj := &unstructured.Unstructured{Object: map[string]any{"data": map[string]any{}}}
+			data := map[string]any{}
+			for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v"} {
+				data[key] = "foo"
+			}
+			newObj := &unstructured.Unstructured{Object: map[string]any{"data": data}}
+
+			fields := changedFields(oldObj, newObj)
+			Expect(fields).To(HaveLen(maxPreviewFieldsPerObject + 1))
+			Expect(fields[maxPreviewFieldsPerObject]).To(Equal("... and 2 more"))
+		})
+	})
+})
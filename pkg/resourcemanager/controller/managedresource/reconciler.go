@@ -54,6 +54,7 @@ import (
 	errorsutils "github.com/gardener/gardener/pkg/utils/errors"
 	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
 	utilclient "github.com/gardener/gardener/pkg/utils/kubernetes/client"
+	"github.com/gardener/gardener/pkg/utils/oci"
 )
 
 var (
@@ -63,16 +64,24 @@ var (
 
 // Reconciler manages the resources reference by ManagedResources.
 type Reconciler struct {
-	SourceClient                  client.Client
-	TargetClient                  client.Client
-	TargetScheme                  *runtime.Scheme
-	TargetRESTMapper              meta.RESTMapper
-	Config                        config.ManagedResourceControllerConfig
-	Clock                         clock.Clock
-	ClassFilter                   *resourcemanagerpredicate.ClassFilter
-	ClusterID                     string
-	GarbageCollectorActivated     bool
-	RequeueAfterOnDeletionPending *time.Duration
+	SourceClient                    client.Client
+	TargetClient                    client.Client
+	TargetScheme                    *runtime.Scheme
+	TargetRESTMapper                meta.RESTMapper
+	Config                          config.ManagedResourceControllerConfig
+	Clock                           clock.Clock
+	ClassFilter                     *resourcemanagerpredicate.ClassFilter
+	ClusterID                       string
+	GarbageCollectorActivated       bool
+	RequeueAfterOnDeletionPending   *time.Duration
+	RequeueAfterOnApplyPhasePending *time.Duration
+	Registry                        oci.Interface
+	// ConfigMapReader is used for reading the ConfigMaps referenced by ManagedResources. If it is not set, a dedicated
+	// cache of the source cluster is used which only contains ConfigMaps labeled with
+	// `resources.gardener.cloud/managed-resource-source=true`.
+	ConfigMapReader client.Reader
+	// SourceNamespaces are the namespaces in the source cluster which are watched. If empty, all namespaces are watched.
+	SourceNamespaces []string
 }
 
 // Reconcile manages the resources reference by ManagedResources.
@@ -156,27 +165,32 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 	// Initialize condition based on the current status.
 	conditionResourcesApplied := v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesApplied)
 
-	for _, ref := range mr.Spec.SecretRefs {
-		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: mr.Namespace}}
-		if err := r.SourceClient.Get(reconcileCtx, client.ObjectKeyFromObject(secret), secret); err != nil {
-			conditionResourcesApplied = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesApplied, gardencorev1beta1.ConditionFalse, "CannotReadSecret", err.Error())
-			if err := updateConditions(ctx, r.SourceClient, mr, conditionResourcesApplied); err != nil {
-				return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
-			}
+	sources, err := r.readManifestSources(reconcileCtx, mr)
+	if err != nil {
+		reason, message := "CannotReadSources", err.Error()
+		if readErr := (&sourceReadError{}); errors.As(err, &readErr) {
+			reason, message = readErr.reason, readErr.err.Error()
+		}
 
-			return reconcile.Result{}, fmt.Errorf("could not read secret '%s': %+v", secret.Name, err)
+		conditionResourcesApplied = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesApplied, gardencorev1beta1.ConditionFalse, reason, message)
+		if err := updateConditions(ctx, r.SourceClient, mr, conditionResourcesApplied); err != nil {
+			return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
 		}
 
-		// Sort secret's data key to keep consistent ordering while calculating checksum
-		secretKeys := make([]string, 0, len(secret.Data))
-		for secretKey := range secret.Data {
-			secretKeys = append(secretKeys, secretKey)
+		return reconcile.Result{}, err
+	}
+
+	for _, source := range sources {
+		// Sort source's data key to keep consistent ordering while calculating checksum
+		keys := make([]string, 0, len(source.data))
+		for key := range source.data {
+			keys = append(keys, key)
 		}
-		slices.Sort(secretKeys)
+		slices.Sort(keys)
 
-		for _, secretKey := range secretKeys {
-			var reader io.Reader = bytes.NewReader(secret.Data[secretKey])
-			if strings.HasSuffix(secretKey, resourcesv1alpha1.BrotliCompressionSuffix) {
+		for _, key := range keys {
+			var reader io.Reader = bytes.NewReader(source.data[key])
+			if strings.HasSuffix(key, resourcesv1alpha1.BrotliCompressionSuffix) {
 				reader = brotli.NewReader(reader)
 			}
 
@@ -186,7 +200,7 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 			)
 
 			for indexInFile := 0; true; indexInFile++ {
-				objLog := log.WithValues("secret", client.ObjectKeyFromObject(secret), "secretKey", secretKey, "indexInFile", indexInFile)
+				objLog := log.WithValues(source.logKeysAndValues...).WithValues("key", key, "indexInFile", indexInFile)
 
 				err := decoder.Decode(&decodedObj)
 				if err == io.EOF {
@@ -195,8 +209,8 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 				if err != nil {
 					dErr := &decodingError{
 						err:         err,
-						secret:      client.ObjectKeyFromObject(secret),
-						secretKey:   secretKey,
+						source:      source.description,
+						key:         key,
 						indexInFile: indexInFile,
 					}
 					decodingErrors = append(decodingErrors, dErr)
@@ -272,33 +286,51 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 					continue
 				}
 
-				hash.Write(secret.Data[secretKey])
+				if found {
+					// keep the per-object health information which is maintained by the health and progressing controllers
+					objectReference.Healthy = newObj.oldInformation.Healthy
+					objectReference.Progressing = newObj.oldInformation.Progressing
+				}
+
+				hash.Write(source.data[key])
 				newResourcesObjects = append(newResourcesObjects, newObj)
 				newResourcesObjectReferences = append(newResourcesObjectReferences, objectReference)
 			}
 		}
 	}
 
-	// calculate the checksum for the referenced secrets data.
+	// calculate the checksum for the data of the referenced sources.
 	secretsDataChecksum := hex.EncodeToString(hash.Sum(nil))
 
 	// sort object references before updating status, to keep consistent ordering
 	// (otherwise, the order will be different on each update)
 	sortObjectReferences(newResourcesObjectReferences)
 
+	injectLabels := mergeMaps(mr.Spec.InjectLabels, map[string]string{resourcesv1alpha1.ManagedBy: *r.Config.ManagedByLabelValue})
+
+	if resourcemanagerpredicate.IsPreview(mr) {
+		return r.preview(reconcileCtx, log, mr, origin, newResourcesObjects, existingResourcesIndex, injectLabels, equivalences, secretsDataChecksum)
+	}
+
 	// invalidate conditions, if resources have been added/removed from the managed resource
-	if !apiequality.Semantic.DeepEqual(mr.Status.Resources, newResourcesObjectReferences) || mr.Status.SecretsDataChecksum == nil || *mr.Status.SecretsDataChecksum != secretsDataChecksum {
-		conditionResourcesHealthy := v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesHealthy)
-		conditionResourcesHealthy = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesHealthy, gardencorev1beta1.ConditionUnknown,
-			resourcesv1alpha1.ConditionChecksPending, "The health checks have not yet been executed for the current set of resources.")
-		conditionResourcesProgressing := v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesProgressing)
-		conditionResourcesProgressing = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesProgressing, gardencorev1beta1.ConditionUnknown,
-			resourcesv1alpha1.ConditionChecksPending, "Checks have not yet been executed for the current set of resources.")
+	applyPhasePending := conditionResourcesApplied.Reason == resourcesv1alpha1.ConditionApplyPhasePending
+	resourcesChanged := mr.Status.SecretsDataChecksum == nil || *mr.Status.SecretsDataChecksum != secretsDataChecksum
+	if !applyPhasePending {
+		resourcesChanged = resourcesChanged || !apiequality.Semantic.DeepEqual(mr.Status.Resources, newResourcesObjectReferences)
+	} else {
+		// While an apply phase is pending, the status only lists the resources which have been applied so far. Hence,
+		// they only changed here if some of them were removed. Resources of later phases invalidate the conditions as
+		// soon as they are applied, see below.
+		resourcesChanged = resourcesChanged || !isSubsetOf(mr.Status.Resources, newResourcesObjectReferences)
+	}
+
+	if resourcesChanged {
+		conditionResourcesHealthy, conditionResourcesProgressing := r.checksPendingConditions(mr)
 
 		reason := resourcesv1alpha1.ConditionApplyProgressing
 		msg := "The resources are currently being reconciled."
 		switch conditionResourcesApplied.Reason {
-		case resourcesv1alpha1.ConditionApplyFailed, resourcesv1alpha1.ConditionDeletionFailed, resourcesv1alpha1.ConditionDeletionPending:
+		case resourcesv1alpha1.ConditionApplyFailed, resourcesv1alpha1.ConditionApplyConflict, resourcesv1alpha1.ConditionApplyPhasePending, resourcesv1alpha1.ConditionDeletionFailed, resourcesv1alpha1.ConditionDeletionPending:
 			// keep condition reason and message if last reconciliation failed
 			reason = conditionResourcesApplied.Reason
 			msg = conditionResourcesApplied.Message
@@ -346,9 +378,41 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 		return reconcile.Result{}, fmt.Errorf("could not release all orphaned resources: %+v", err)
 	}
 
-	injectLabels := mergeMaps(mr.Spec.InjectLabels, map[string]string{resourcesv1alpha1.ManagedBy: *r.Config.ManagedByLabelValue})
 	if err := r.applyNewResources(reconcileCtx, log, origin, newResourcesObjects, injectLabels, equivalences); err != nil {
-		conditionResourcesApplied = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesApplied, gardencorev1beta1.ConditionFalse, resourcesv1alpha1.ConditionApplyFailed, err.Error())
+		if phaseErr := (&applyPhaseNotReadyError{}); errors.As(err, &phaseErr) {
+			log.Info("Apply phase is not ready yet, waiting before applying the next phase", "phase", phaseErr.phase, "reason", phaseErr.reason.Error())
+
+			conditionResourcesApplied = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesApplied, gardencorev1beta1.ConditionProgressing, resourcesv1alpha1.ConditionApplyPhasePending, err.Error())
+			conditions := []gardencorev1beta1.Condition{conditionResourcesApplied}
+
+			// The objects of the phases which are already applied must be part of the status, so that they are cleaned up
+			// if they are removed from the ManagedResource or if the ManagedResource is deleted in the meantime. The objects
+			// of the pending phases are only added once they are applied, so that the status does not claim resources which
+			// do not exist yet. The health controller skips the ManagedResource while it is progressing, hence the health
+			// conditions are invalidated whenever further objects are applied.
+			// The checksum is stored as well, so that the conditions are not invalidated again when requeueing.
+			appliedResourcesObjectReferences := appliedObjectReferences(newResourcesObjects, newResourcesObjectReferences, phaseErr.phase, existingResourcesIndex)
+			if !resourcesChanged && !isSubsetOf(appliedResourcesObjectReferences, mr.Status.Resources) {
+				conditionResourcesHealthy, conditionResourcesProgressing := r.checksPendingConditions(mr)
+				conditions = append(conditions, conditionResourcesHealthy, conditionResourcesProgressing)
+			}
+
+			mr.Status.SecretsDataChecksum = &secretsDataChecksum
+			mr.Status.Resources = appliedResourcesObjectReferences
+			mr.Status.HealthSummary = resourcesv1alpha1helper.HealthSummary(appliedResourcesObjectReferences)
+			if err := updateConditions(ctx, r.SourceClient, mr, conditions...); err != nil {
+				return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
+			}
+
+			return reconcile.Result{RequeueAfter: *r.RequeueAfterOnApplyPhasePending}, nil
+		}
+
+		reason := resourcesv1alpha1.ConditionApplyFailed
+		if conflictErr := (&fieldOwnershipConflictError{}); errors.As(err, &conflictErr) {
+			reason = resourcesv1alpha1.ConditionApplyConflict
+		}
+
+		conditionResourcesApplied = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesApplied, gardencorev1beta1.ConditionFalse, reason, err.Error())
 		if err := updateConditions(ctx, r.SourceClient, mr, conditionResourcesApplied); err != nil {
 			return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
 		}
@@ -362,7 +426,14 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, mr *resourc
 		conditionResourcesApplied = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesApplied, gardencorev1beta1.ConditionTrue, resourcesv1alpha1.ConditionApplySucceeded, "All resources are applied.")
 	}
 
-	if err := updateManagedResourceStatus(ctx, r.SourceClient, mr, &secretsDataChecksum, newResourcesObjectReferences, conditionResourcesApplied); err != nil {
+	conditions := []gardencorev1beta1.Condition{conditionResourcesApplied}
+	if applyPhasePending && !resourcesChanged && !isSubsetOf(newResourcesObjectReferences, mr.Status.Resources) {
+		// The objects of the last pending phases have been applied, hence the health conditions must be invalidated.
+		conditionResourcesHealthy, conditionResourcesProgressing := r.checksPendingConditions(mr)
+		conditions = append(conditions, conditionResourcesHealthy, conditionResourcesProgressing)
+	}
+
+	if err := updateManagedResourceStatus(ctx, r.SourceClient, mr, &secretsDataChecksum, newResourcesObjectReferences, conditions...); err != nil {
 		return reconcile.Result{}, fmt.Errorf("could not update the ManagedResource status: %w", err)
 	}
 
@@ -466,7 +537,10 @@ func (r *Reconciler) updateConditionsForDeletion(ctx context.Context, mr *resour
 }
 
 func (r *Reconciler) applyNewResources(ctx context.Context, log logr.Logger, origin string, newResourcesObjects []object, labelsToInject map[string]string, equivalences Equivalences) error {
-	newResourcesObjects = sortByKind(newResourcesObjects)
+	phases, err := groupByApplyPhase(newResourcesObjects)
+	if err != nil {
+		return err
+	}
 
 	// get all HPA targetRefs to check if we should prevent overwriting replicas.
 	// VPAs don't have to be checked, as they don't update the spec directly and only mutate Pods via a MutatingWebhook
@@ -476,7 +550,24 @@ func (r *Reconciler) applyNewResources(ctx context.Context, log logr.Logger, ori
 		return fmt.Errorf("failed to compute all HPA target ref object keys: %w", err)
 	}
 
-	for _, obj := range newResourcesObjects {
+	for i, phase := range phases {
+		if i > 0 {
+			// only apply the objects of the next phase if all objects of the previous phase are ready
+			if err := r.checkApplyPhaseReady(ctx, phases[i-1]); err != nil {
+				return &applyPhaseNotReadyError{phase: phases[i-1].number, nextPhase: phase.number, reason: err}
+			}
+		}
+
+		if err := r.applyObjects(ctx, log, origin, sortByKind(phase.objects), labelsToInject, horizontallyScaledObjects, equivalences); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) applyObjects(ctx context.Context, log logr.Logger, origin string, objects []object, labelsToInject map[string]string, horizontallyScaledObjects sets.Set[string], equivalences Equivalences) error {
+	for _, obj := range objects {
 		var (
 			current            = obj.obj.DeepCopy()
 			resource           = unstructuredToString(obj.obj)
@@ -487,25 +578,17 @@ func (r *Reconciler) applyNewResources(ctx context.Context, log logr.Logger, ori
 
 		resourceLogger.V(1).Info("Applying")
 
-		operationResult, err := controllerutils.TypedCreateOrUpdate(ctx, r.TargetClient, r.TargetScheme, current, ptr.Deref(r.Config.AlwaysUpdate, false), func() error {
-			metadata, err := meta.Accessor(obj.obj)
+		if serverSideApplyMode(obj.obj) && !ignore(obj.obj) {
+			operationResult, err := r.applyServerSide(ctx, resourceLogger, origin, obj, labelsToInject, scaledHorizontally)
 			if err != nil {
-				return fmt.Errorf("error getting metadata of object %q: %s", resource, err)
-			}
-
-			// if the ignore annotation is set to false, do nothing (ignore the resource)
-			if ignore(metadata) {
-				annotations := current.GetAnnotations()
-				delete(annotations, descriptionAnnotation)
-				current.SetAnnotations(annotations)
-				return nil
-			}
-
-			if err := injectLabels(obj.obj, labelsToInject); err != nil {
-				return fmt.Errorf("error injecting labels into object %q: %s", resource, err)
+				return err
 			}
+			logOperationResult(resourceLogger, operationResult)
+			continue
+		}
 
-			return merge(origin, obj.obj, current, obj.forceOverwriteLabels, obj.oldInformation.Labels, obj.forceOverwriteAnnotations, obj.oldInformation.Annotations, scaledHorizontally)
+		operationResult, err := controllerutils.TypedCreateOrUpdate(ctx, r.TargetClient, r.TargetScheme, current, ptr.Deref(r.Config.AlwaysUpdate, false), func() error {
+			return mutate(origin, obj, current, labelsToInject, scaledHorizontally)
 		})
 		if err != nil {
 			if apierrors.IsConflict(err) {
@@ -523,19 +606,48 @@ func (r *Reconciler) applyNewResources(ctx context.Context, log logr.Logger, ori
 			return fmt.Errorf("error during apply of object %q: %s", resource, err)
 		}
 
-		switch operationResult {
-		case controllerutil.OperationResultCreated:
-			resourceLogger.Info("Created resource because it was not existing before")
-		case controllerutil.OperationResultUpdated:
-			resourceLogger.Info("Updated resource because its actual state differed from the desired state")
-		case controllerutil.OperationResultNone:
-			resourceLogger.V(1).Info("Resource was neither created nor updated because its actual state matches with the desired state")
-		}
+		logOperationResult(resourceLogger, operationResult)
 	}
 
 	return nil
 }
 
+// mutate merges the desired state of the given object into the current object. If the object is ignored, only the
+// description annotation is removed from the current object.
+func mutate(origin string, obj object, current *unstructured.Unstructured, labelsToInject map[string]string, scaledHorizontally bool) error {
+	resource := unstructuredToString(obj.obj)
+
+	metadata, err := meta.Accessor(obj.obj)
+	if err != nil {
+		return fmt.Errorf("error getting metadata of object %q: %s", resource, err)
+	}
+
+	// if the ignore annotation is set to false, do nothing (ignore the resource)
+	if ignore(metadata) {
+		annotations := current.GetAnnotations()
+		delete(annotations, descriptionAnnotation)
+		current.SetAnnotations(annotations)
+		return nil
+	}
+
+	if err := injectLabels(obj.obj, labelsToInject); err != nil {
+		return fmt.Errorf("error injecting labels into object %q: %s", resource, err)
+	}
+
+	return merge(origin, obj.obj, current, obj.forceOverwriteLabels, obj.oldInformation.Labels, obj.forceOverwriteAnnotations, obj.oldInformation.Annotations, scaledHorizontally)
+}
+
+func logOperationResult(log logr.Logger, operationResult controllerutil.OperationResult) {
+	switch operationResult {
+	case controllerutil.OperationResultCreated:
+		log.Info("Created resource because it was not existing before")
+	case controllerutil.OperationResultUpdated:
+		log.Info("Updated resource because its actual state differed from the desired state")
+	case controllerutil.OperationResultNone:
+		log.V(1).Info("Resource was neither created nor updated because its actual state matches with the desired state")
+	}
+}
+
 // computeHorizontallyScaledObjectKeys returns a set of object keys (in the form `Group/Kind/Namespace/Name`)
 // to objects that are horizontally scaled by HPA.
 // VPAs are not checked, as they don't update the spec of Deployments/StatefulSets/... and only mutate resource
@@ -856,6 +968,33 @@ func eventsForObject(ctx context.Context, scheme *runtime.Scheme, c client.Clien
 	return "", nil
 }
 
+// checksPendingConditions returns the ResourcesHealthy and ResourcesProgressing conditions of the given
+// ManagedResource invalidated, i.e., with status Unknown until the checks are executed for the current set of resources.
+func (r *Reconciler) checksPendingConditions(mr *resourcesv1alpha1.ManagedResource) (gardencorev1beta1.Condition, gardencorev1beta1.Condition) {
+	conditionResourcesHealthy := v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesHealthy)
+	conditionResourcesHealthy = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesHealthy, gardencorev1beta1.ConditionUnknown,
+		resourcesv1alpha1.ConditionChecksPending, "The health checks have not yet been executed for the current set of resources.")
+	conditionResourcesProgressing := v1beta1helper.GetOrInitConditionWithClock(r.Clock, mr.Status.Conditions, resourcesv1alpha1.ResourcesProgressing)
+	conditionResourcesProgressing = v1beta1helper.UpdatedConditionWithClock(r.Clock, conditionResourcesProgressing, gardencorev1beta1.ConditionUnknown,
+		resourcesv1alpha1.ConditionChecksPending, "Checks have not yet been executed for the current set of resources.")
+	return conditionResourcesHealthy, conditionResourcesProgressing
+}
+
+// isSubsetOf returns true if all of the given references are contained in the given other references.
+func isSubsetOf(references, otherReferences []resourcesv1alpha1.ObjectReference) bool {
+	keys := sets.New[string]()
+	for _, ref := range otherReferences {
+		keys.Insert(objectKeyByReference(ref))
+	}
+
+	for _, ref := range references {
+		if !keys.Has(objectKeyByReference(ref)) {
+			return false
+		}
+	}
+	return true
+}
+
 func updateManagedResourceStatus(
 	ctx context.Context,
 	c client.Client,
@@ -867,7 +1006,9 @@ func updateManagedResourceStatus(
 	mr.Status.Conditions = v1beta1helper.MergeConditions(mr.Status.Conditions, updatedConditions...)
 	mr.Status.SecretsDataChecksum = secretsDataChecksum
 	mr.Status.Resources = resources
+	mr.Status.HealthSummary = resourcesv1alpha1helper.HealthSummary(resources)
 	mr.Status.ObservedGeneration = mr.Generation
+	mr.Status.Preview = nil
 	return c.Status().Update(ctx, mr)
 }
 
@@ -987,13 +1128,13 @@ type object struct {
 
 type decodingError struct {
 	err         error
-	secret      client.ObjectKey
-	secretKey   string
+	source      string
+	key         string
 	indexInFile int
 }
 
 func (d *decodingError) StringShort() string {
-	return fmt.Sprintf("Could not decode resource at index %d in '%s' in secret '%s'", d.indexInFile, d.secretKey, d.secret.String())
+	return fmt.Sprintf("Could not decode resource at index %d in '%s' in %s", d.indexInFile, d.key, d.source)
 }
 
 func (d *decodingError) String() string {
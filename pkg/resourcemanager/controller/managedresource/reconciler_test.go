@@ -5,12 +5,311 @@
 package managedresource
 
 import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/resourcemanager/apis/config"
+	resourcemanagerpredicate "github.com/gardener/gardener/pkg/resourcemanager/predicate"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
 )
 
 var _ = Describe("Controller", func() {
+	Describe("#Reconcile", func() {
+		var (
+			ctx = logf.IntoContext(context.Background(), logr.Discard())
+
+			fakeClock    *testclock.FakeClock
+			sourceClient client.Client
+			targetClient client.Client
+			reconciler   *Reconciler
+
+			secret          *corev1.Secret
+			managedResource *resourcesv1alpha1.ManagedResource
+
+			// appliedConditions records the ResourcesApplied condition of every status update of the ManagedResource.
+			appliedConditions []gardencorev1beta1.Condition
+		)
+
+		BeforeEach(func() {
+			fakeClock = testclock.NewFakeClock(time.Now())
+			appliedConditions = nil
+
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mr-secret", Namespace: "garden"},
+				Data: map[string][]byte{"configmap.yaml": []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+data:
+  foo: bar
+`)},
+			}
+			managedResource = &resourcesv1alpha1.ManagedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "mr", Namespace: "garden"},
+				Spec:       resourcesv1alpha1.ManagedResourceSpec{SecretRefs: []corev1.LocalObjectReference{{Name: secret.Name}}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			sourceClient = fakeclient.NewClientBuilder().
+				WithScheme(kubernetes.SeedScheme).
+				WithObjects(secret, managedResource).
+				WithStatusSubresource(managedResource).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if mr, ok := obj.(*resourcesv1alpha1.ManagedResource); ok {
+							if condition := v1beta1helper.GetCondition(mr.Status.Conditions, resourcesv1alpha1.ResourcesApplied); condition != nil {
+								appliedConditions = append(appliedConditions, *condition)
+							}
+						}
+						return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			targetClient = fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&appsv1.Deployment{}).Build()
+
+			reconciler = &Reconciler{
+				SourceClient:     sourceClient,
+				TargetClient:     targetClient,
+				TargetScheme:     scheme.Scheme,
+				TargetRESTMapper: targetClient.RESTMapper(),
+				Config: config.ManagedResourceControllerConfig{
+					SyncPeriod:          &metav1.Duration{Duration: time.Minute},
+					ManagedByLabelValue: ptr.To("gardener"),
+				},
+				Clock:                           fakeClock,
+				ClassFilter:                     resourcemanagerpredicate.NewClassFilter(""),
+				RequeueAfterOnDeletionPending:   ptr.To(5 * time.Second),
+				RequeueAfterOnApplyPhasePending: ptr.To(5 * time.Second),
+			}
+		})
+
+		It("should apply the resources", func() {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(managedResource)})
+			Expect(err).NotTo(HaveOccurred())
+
+			configMap := &corev1.ConfigMap{}
+			Expect(targetClient.Get(ctx, client.ObjectKey{Name: "config", Namespace: "default"}, configMap)).To(Succeed())
+			Expect(configMap.Data).To(HaveKeyWithValue("foo", "bar"))
+
+			Expect(sourceClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			Expect(v1beta1helper.GetCondition(managedResource.Status.Conditions, resourcesv1alpha1.ResourcesApplied)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Status": Equal(gardencorev1beta1.ConditionTrue),
+				"Reason": Equal(resourcesv1alpha1.ConditionApplySucceeded),
+			})))
+		})
+
+		Context("when the last reconciliation failed with a conflict", func() {
+			BeforeEach(func() {
+				managedResource.Status.Conditions = []gardencorev1beta1.Condition{{
+					Type:    resourcesv1alpha1.ResourcesApplied,
+					Status:  gardencorev1beta1.ConditionFalse,
+					Reason:  resourcesv1alpha1.ConditionApplyConflict,
+					Message: "conflict with field manager foo",
+				}}
+			})
+
+			It("should keep the reason and message while the changed resources are reconciled", func() {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(managedResource)})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appliedConditions).NotTo(BeEmpty())
+				Expect(appliedConditions[0]).To(MatchFields(IgnoreExtras, Fields{
+					"Status":  Equal(gardencorev1beta1.ConditionProgressing),
+					"Reason":  Equal(resourcesv1alpha1.ConditionApplyConflict),
+					"Message": Equal("conflict with field manager foo"),
+				}))
+			})
+		})
+
+		Context("when an apply phase is pending", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					"deployment.yaml": []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webhook
+  namespace: default
+spec:
+  replicas: 1
+`),
+					"configmap.yaml": []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+  annotations:
+    resources.gardener.cloud/apply-phase: "1"
+`),
+				}
+			})
+
+			It("should only list the applied resources and keep the conditions stable across requeues", func() {
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(managedResource)}
+
+				result, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(5 * time.Second))
+
+				Expect(sourceClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+				Expect(managedResource.Status.SecretsDataChecksum).NotTo(BeNil())
+				Expect(managedResource.Status.Resources).To(ConsistOf(HaveField("ObjectReference", corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "webhook", Namespace: "default"})))
+				Expect(targetClient.Get(ctx, client.ObjectKey{Name: "config", Namespace: "default"}, &corev1.ConfigMap{})).To(BeNotFoundError())
+
+				By("Report health of the applied resources")
+				healthy := v1beta1helper.GetOrInitConditionWithClock(fakeClock, managedResource.Status.Conditions, resourcesv1alpha1.ResourcesHealthy)
+				healthy = v1beta1helper.UpdatedConditionWithClock(fakeClock, healthy, gardencorev1beta1.ConditionFalse, "DeploymentUnhealthy", "Deployment is unhealthy")
+				progressing := v1beta1helper.GetOrInitConditionWithClock(fakeClock, managedResource.Status.Conditions, resourcesv1alpha1.ResourcesProgressing)
+				progressing = v1beta1helper.UpdatedConditionWithClock(fakeClock, progressing, gardencorev1beta1.ConditionTrue, "DeploymentProgressing", "Deployment is progressing")
+				managedResource.Status.Conditions = v1beta1helper.MergeConditions(managedResource.Status.Conditions, healthy, progressing)
+				Expect(sourceClient.Status().Update(ctx, managedResource)).To(Succeed())
+
+				By("Requeue")
+				fakeClock.Step(5 * time.Second)
+				result, err = reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(5 * time.Second))
+
+				Expect(sourceClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+				Expect(managedResource.Status.Conditions).To(And(
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesHealthy), WithStatus(gardencorev1beta1.ConditionFalse), WithReason("DeploymentUnhealthy")),
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesProgressing), WithStatus(gardencorev1beta1.ConditionTrue), WithReason("DeploymentProgressing")),
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesApplied), WithStatus(gardencorev1beta1.ConditionProgressing), WithReason(resourcesv1alpha1.ConditionApplyPhasePending)),
+				))
+				Expect(managedResource.Status.Resources).To(HaveLen(1))
+			})
+
+			var (
+				reportHealthy = func() {
+					Expect(sourceClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					healthy := v1beta1helper.GetOrInitConditionWithClock(fakeClock, managedResource.Status.Conditions, resourcesv1alpha1.ResourcesHealthy)
+					healthy = v1beta1helper.UpdatedConditionWithClock(fakeClock, healthy, gardencorev1beta1.ConditionTrue, "ResourcesHealthy", "All resources are healthy")
+					progressing := v1beta1helper.GetOrInitConditionWithClock(fakeClock, managedResource.Status.Conditions, resourcesv1alpha1.ResourcesProgressing)
+					progressing = v1beta1helper.UpdatedConditionWithClock(fakeClock, progressing, gardencorev1beta1.ConditionFalse, "ResourcesRolledOut", "All resources are rolled out")
+					managedResource.Status.Conditions = v1beta1helper.MergeConditions(managedResource.Status.Conditions, healthy, progressing)
+					Expect(sourceClient.Status().Update(ctx, managedResource)).To(Succeed())
+				}
+
+				completeRollout = func(name string) {
+					deployment := &appsv1.Deployment{}
+					Expect(targetClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, deployment)).To(Succeed())
+					deployment.Status = appsv1.DeploymentStatus{
+						ObservedGeneration: deployment.Generation,
+						Replicas:           1,
+						UpdatedReplicas:    1,
+						ReadyReplicas:      1,
+						AvailableReplicas:  1,
+						Conditions: []appsv1.DeploymentCondition{
+							{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+							{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "NewReplicaSetAvailable"},
+						},
+					}
+					Expect(targetClient.Status().Update(ctx, deployment)).To(Succeed())
+				}
+			)
+
+			It("should invalidate the conditions when the resources of the last phase are applied", func() {
+				request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(managedResource)}
+
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Report health of the applied resources")
+				reportHealthy()
+
+				By("Complete the rollout of the first phase")
+				completeRollout("webhook")
+
+				By("Requeue")
+				fakeClock.Step(5 * time.Second)
+				_, err = reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(targetClient.Get(ctx, client.ObjectKey{Name: "config", Namespace: "default"}, &corev1.ConfigMap{})).To(Succeed())
+				Expect(sourceClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+				Expect(managedResource.Status.Conditions).To(And(
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesHealthy), WithStatus(gardencorev1beta1.ConditionUnknown), WithReason(resourcesv1alpha1.ConditionChecksPending)),
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesProgressing), WithStatus(gardencorev1beta1.ConditionUnknown), WithReason(resourcesv1alpha1.ConditionChecksPending)),
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesApplied), WithStatus(gardencorev1beta1.ConditionTrue), WithReason(resourcesv1alpha1.ConditionApplySucceeded)),
+				))
+				Expect(managedResource.Status.Resources).To(HaveLen(2))
+			})
+
+			Context("with multiple pending phases", func() {
+				BeforeEach(func() {
+					secret.Data["configmap.yaml"] = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+  annotations:
+    resources.gardener.cloud/apply-phase: "2"
+`)
+					secret.Data["deployment-server.yaml"] = []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: server
+  namespace: default
+  annotations:
+    resources.gardener.cloud/apply-phase: "1"
+spec:
+  replicas: 1
+`)
+				})
+
+				It("should invalidate the conditions when the resources of the next pending phase are applied", func() {
+					request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(managedResource)}
+
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+
+					By("Report health of the applied resources")
+					reportHealthy()
+
+					By("Complete the rollout of the first phase")
+					completeRollout("webhook")
+
+					By("Requeue")
+					fakeClock.Step(5 * time.Second)
+					result, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(5 * time.Second))
+
+					Expect(targetClient.Get(ctx, client.ObjectKey{Name: "config", Namespace: "default"}, &corev1.ConfigMap{})).To(BeNotFoundError())
+					Expect(sourceClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					Expect(managedResource.Status.Conditions).To(And(
+						ContainCondition(OfType(resourcesv1alpha1.ResourcesHealthy), WithStatus(gardencorev1beta1.ConditionUnknown), WithReason(resourcesv1alpha1.ConditionChecksPending)),
+						ContainCondition(OfType(resourcesv1alpha1.ResourcesProgressing), WithStatus(gardencorev1beta1.ConditionUnknown), WithReason(resourcesv1alpha1.ConditionChecksPending)),
+						ContainCondition(OfType(resourcesv1alpha1.ResourcesApplied), WithStatus(gardencorev1beta1.ConditionProgressing), WithReason(resourcesv1alpha1.ConditionApplyPhasePending)),
+					))
+					Expect(managedResource.Status.Resources).To(HaveLen(2))
+				})
+			})
+		})
+	})
+
 	Describe("#injectLabels", func() {
 		var (
 			obj, expected *unstructured.Unstructured
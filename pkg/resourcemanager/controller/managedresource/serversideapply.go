@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+)
+
+// fieldManager is the name of the field manager used for applying resources with server-side apply.
+const fieldManager = "gardener-resource-manager"
+
+// podSpecPaths are the paths of the pod specs in the workload resources whose container resources might be preserved.
+var podSpecPaths = [][]string{
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+func serverSideApplyMode(meta metav1.Object) bool {
+	return meta.GetAnnotations()[resourcesv1alpha1.Mode] == resourcesv1alpha1.ModeServerSideApply
+}
+
+// fieldOwnershipConflictError is returned if a resource cannot be applied with server-side apply because some of its
+// fields are owned by other managers.
+type fieldOwnershipConflictError struct {
+	resource  string
+	conflicts []string
+}
+
+func (e *fieldOwnershipConflictError) Error() string {
+	return fmt.Sprintf("field ownership conflicts during server-side apply of object %q: %s", e.resource, strings.Join(e.conflicts, "; "))
+}
+
+// applyServerSide applies the given object with server-side apply. Fields which should be preserved (e.g., the
+// replicas of horizontally scaled workload resources) are not part of the applied configuration, i.e., they are left
+// to other managers. If the object was already applied with server-side apply before, conflicts with fields owned by
+// other managers are reported. Otherwise, the ownership of the fields is forced to take over the object, e.g., after
+// it was updated with the default mode before.
+func (r *Reconciler) applyServerSide(ctx context.Context, log logr.Logger, origin string, obj object, labelsToInject map[string]string, scaledHorizontally bool) (controllerutil.OperationResult, error) {
+	desired, err := desiredForServerSideApply(origin, obj, labelsToInject)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	current, err := r.getCurrent(ctx, desired)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	applied, err := patchServerSide(ctx, log, r.TargetClient, desired, current, scaledHorizontally)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	switch {
+	case current == nil:
+		return controllerutil.OperationResultCreated, nil
+	case applied.GetResourceVersion() != current.GetResourceVersion():
+		return controllerutil.OperationResultUpdated, nil
+	default:
+		return controllerutil.OperationResultNone, nil
+	}
+}
+
+// desiredForServerSideApply returns the configuration of the given object which is applied with server-side apply.
+func desiredForServerSideApply(origin string, obj object, labelsToInject map[string]string) (*unstructured.Unstructured, error) {
+	desired := obj.obj.DeepCopy()
+	if err := injectLabels(desired, labelsToInject); err != nil {
+		return nil, fmt.Errorf("error injecting labels into object %q: %s", unstructuredToString(obj.obj), err)
+	}
+	prepareForServerSideApply(desired, origin)
+	return desired, nil
+}
+
+// getCurrent returns the current state of the given object in the target cluster, or nil if it does not exist.
+func (r *Reconciler) getCurrent(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := r.TargetClient.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting object %q: %w", unstructuredToString(obj), err)
+	}
+	return current, nil
+}
+
+// patchServerSide applies the desired configuration with the given client and returns the resulting object. The current
+// object is nil if it does not exist yet.
+func patchServerSide(ctx context.Context, log logr.Logger, c client.Client, desired, current *unstructured.Unstructured, scaledHorizontally bool) (*unstructured.Unstructured, error) {
+	resource := unstructuredToString(desired)
+
+	ownedFields, appliedBefore, err := appliedFields(current)
+	if err != nil {
+		return nil, fmt.Errorf("error determining fields of object %q owned by %s: %s", resource, fieldManager, err)
+	}
+
+	desired = desired.DeepCopy()
+	annotations := desired.GetAnnotations()
+	if err := dropPreservedFields(desired, current, ownedFields,
+		scaledHorizontally || annotations[resourcesv1alpha1.PreserveReplicas] == "true",
+		annotations[resourcesv1alpha1.PreserveResources] == "true",
+	); err != nil {
+		return nil, fmt.Errorf("error dropping preserved fields of object %q: %s", resource, err)
+	}
+
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if !appliedBefore {
+		log.V(1).Info("Forcing ownership since object was not applied with server-side apply before")
+		opts = append(opts, client.ForceOwnership)
+	}
+
+	applied := desired.DeepCopy()
+	if err := c.Patch(ctx, applied, client.Apply, opts...); err != nil {
+		if conflicts := fieldManagerConflicts(err); len(conflicts) > 0 {
+			return nil, &fieldOwnershipConflictError{resource: resource, conflicts: conflicts}
+		}
+
+		if apierrors.IsInvalid(err) && current != nil && deleteOnInvalidUpdate(desired, err) {
+			if deleteErr := c.Delete(ctx, current); client.IgnoreNotFound(deleteErr) != nil {
+				return nil, fmt.Errorf("error deleting object %q after 'invalid' update error: %s", resource, deleteErr)
+			}
+			// return error directly, so that the create after delete will be retried
+			return nil, fmt.Errorf("deleted object %q because of 'invalid' update error, and 'delete-on-invalid-update' annotation on object or the resource is an immutable ConfigMap/Secret: %s", resource, err)
+		}
+
+		return nil, fmt.Errorf("error during server-side apply of object %q: %s", resource, err)
+	}
+
+	return applied, nil
+}
+
+// prepareForServerSideApply removes the fields from the given object which must not be part of an applied
+// configuration and adds the annotations added by the controller.
+func prepareForServerSideApply(obj *unstructured.Unstructured, origin string) {
+	for _, field := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "uid"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[descriptionAnnotation] = descriptionAnnotationText
+	annotations[resourcesv1alpha1.OriginAnnotation] = origin
+	obj.SetAnnotations(annotations)
+}
+
+// appliedFields returns the fields of the given object which are owned by the controller's server-side apply field
+// manager, and whether the object was applied by it before.
+func appliedFields(obj *unstructured.Unstructured) (*fieldpath.Set, bool, error) {
+	set := fieldpath.NewSet()
+	if obj == nil {
+		return set, false, nil
+	}
+
+	appliedBefore := false
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.Subresource != "" {
+			continue
+		}
+		appliedBefore = true
+
+		if entry.FieldsV1 == nil {
+			continue
+		}
+
+		entrySet := &fieldpath.Set{}
+		if err := entrySet.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, false, err
+		}
+		set = set.Union(entrySet)
+	}
+
+	return set, appliedBefore, nil
+}
+
+// dropPreservedFields removes the fields which should be preserved from the desired object, so that they are not owned
+// by the controller. Since removing a field from the applied configuration removes it from the object if no other
+// manager owns it, the current value is kept in the applied configuration as long as the controller still owns the
+// field. As soon as another manager changes the field, it takes over the ownership and the field is dropped.
+func dropPreservedFields(desired, current *unstructured.Unstructured, ownedFields *fieldpath.Set, preserveReplicas, preserveResources bool) error {
+	// Objects which do not exist yet are created with the desired values, otherwise they would lack e.g. the replicas or
+	// the resources of their containers.
+	if current == nil {
+		return nil
+	}
+
+	if preserveReplicas {
+		if err := dropOrKeepField(desired.Object, current.Object, ownedFields, fieldpath.MakePathOrDie("spec", "replicas"), "spec", "replicas"); err != nil {
+			return err
+		}
+	}
+
+	if !preserveResources {
+		return nil
+	}
+
+	for _, podSpecPath := range podSpecPaths {
+		for _, containersField := range []string{"containers", "initContainers"} {
+			containersPath := append(append([]string{}, podSpecPath...), containersField)
+
+			containers, found, err := unstructured.NestedSlice(desired.Object, containersPath...)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+
+			currentContainers, _, err := unstructured.NestedSlice(current.Object, containersPath...)
+			if err != nil {
+				return err
+			}
+
+			for i, c := range containers {
+				container, ok := c.(map[string]any)
+				if !ok {
+					return fmt.Errorf("%s[%d] is not a map[string]any", strings.Join(containersPath, "."), i)
+				}
+				name, _, err := unstructured.NestedString(container, "name")
+				if err != nil {
+					return err
+				}
+
+				path := make([]any, 0, len(containersPath)+2)
+				for _, element := range containersPath {
+					path = append(path, element)
+				}
+				path = append(path, fieldpath.KeyByFields("name", name), "resources")
+
+				if err := dropOrKeepField(container, containerWithName(currentContainers, name), ownedFields, fieldpath.MakePathOrDie(path...), "resources"); err != nil {
+					return err
+				}
+				containers[i] = container
+			}
+
+			if err := unstructured.SetNestedSlice(desired.Object, containers, containersPath...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// dropOrKeepField removes the field with the given path from the desired object if it is not owned by the controller.
+// Otherwise, it sets it to its current value.
+func dropOrKeepField(desired, current map[string]any, ownedFields *fieldpath.Set, path fieldpath.Path, fields ...string) error {
+	unstructured.RemoveNestedField(desired, fields...)
+
+	if !ownsField(ownedFields, path) {
+		return nil
+	}
+
+	value, found, err := unstructured.NestedFieldCopy(current, fields...)
+	if err != nil || !found {
+		return err
+	}
+	return unstructured.SetNestedField(desired, value, fields...)
+}
+
+// ownsField returns true if the given set contains the given path or any path below it.
+func ownsField(set *fieldpath.Set, path fieldpath.Path) bool {
+	if set.Has(path) {
+		return true
+	}
+
+	for _, element := range path {
+		set = set.WithPrefix(element)
+	}
+	return !set.Empty()
+}
+
+func containerWithName(containers []any, name string) map[string]any {
+	for _, c := range containers {
+		if container, ok := c.(map[string]any); ok && container["name"] == name {
+			return container
+		}
+	}
+	return nil
+}
+
+// fieldManagerConflicts returns the field ownership conflicts contained in the given error of a server-side apply
+// request.
+func fieldManagerConflicts(err error) []string {
+	var statusErr apierrors.APIStatus
+	if !apierrors.IsConflict(err) || !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil
+	}
+
+	var conflicts []string
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+)
+
+var _ = Describe("server-side apply", func() {
+	Describe("#applyServerSide", func() {
+		var (
+			ctx = context.Background()
+
+			fakeClient   client.Client
+			reconciler   *Reconciler
+			obj          object
+			applied      *unstructured.Unstructured
+			forced       bool
+			applyErr     error
+			existingObjs []client.Object
+		)
+
+		BeforeEach(func() {
+			applied, forced, applyErr, existingObjs = nil, false, nil, nil
+
+			obj = object{obj: &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]any{
+					"name":              "foo",
+					"namespace":         "default",
+					"creationTimestamp": nil,
+					"annotations":       map[string]any{resourcesv1alpha1.Mode: resourcesv1alpha1.ModeServerSideApply},
+				},
+				"spec": map[string]any{
+					"replicas": int64(1),
+					"template": map[string]any{"spec": map[string]any{"containers": []any{map[string]any{"name": "foo", "image": "foo"}}}},
+				},
+				"status": map[string]any{},
+			}}}
+		})
+
+		JustBeforeEach(func() {
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existingObjs...).WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					Expect(patch).To(Equal(client.Apply))
+
+					patchOptions := &client.PatchOptions{}
+					patchOptions.ApplyOptions(opts)
+					Expect(patchOptions.FieldManager).To(Equal(fieldManager))
+					forced = ptr.Deref(patchOptions.Force, false)
+
+					applied = obj.(*unstructured.Unstructured).DeepCopy()
+					if applyErr != nil {
+						return applyErr
+					}
+
+					// The fake client does not support server-side apply, hence the response of an unchanged object is
+					// emulated by returning the current resource version.
+					current := applied.DeepCopy()
+					if err := c.Get(ctx, client.ObjectKeyFromObject(current), current); err == nil {
+						obj.SetResourceVersion(current.GetResourceVersion())
+					}
+					return nil
+				},
+			}).Build()
+
+			reconciler = &Reconciler{TargetClient: fakeClient}
+		})
+
+		It("should apply the object with forced ownership if it does not exist", func() {
+			result, err := reconciler.applyServerSide(ctx, logr.Discard(), "origin", obj, map[string]string{"foo": "bar"}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(controllerutil.OperationResultCreated))
+
+			Expect(forced).To(BeTrue())
+			Expect(applied.GetLabels()).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(applied.GetAnnotations()).To(HaveKeyWithValue(resourcesv1alpha1.OriginAnnotation, "origin"))
+			Expect(applied.GetAnnotations()).To(HaveKey(descriptionAnnotation))
+			Expect(applied.Object).NotTo(HaveKey("status"))
+			Expect(applied.Object["metadata"]).NotTo(HaveKey("creationTimestamp"))
+			Expect(applied.Object["spec"]).To(HaveKeyWithValue("replicas", int64(1)))
+		})
+
+		It("should create horizontally scaled objects with the desired replicas and resources", func() {
+			obj.obj.SetAnnotations(map[string]string{resourcesv1alpha1.Mode: resourcesv1alpha1.ModeServerSideApply, resourcesv1alpha1.PreserveResources: "true"})
+			Expect(unstructured.SetNestedSlice(obj.obj.Object, []any{
+				map[string]any{"name": "foo", "image": "foo", "resources": map[string]any{"limits": map[string]any{"cpu": "1"}}},
+			}, "spec", "template", "spec", "containers")).To(Succeed())
+
+			result, err := reconciler.applyServerSide(ctx, logr.Discard(), "origin", obj, nil, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(controllerutil.OperationResultCreated))
+
+			Expect(applied.Object["spec"]).To(HaveKeyWithValue("replicas", int64(1)))
+			containers, _, err := unstructured.NestedSlice(applied.Object, "spec", "template", "spec", "containers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(ConsistOf(HaveKeyWithValue("resources", map[string]any{"limits": map[string]any{"cpu": "1"}})))
+		})
+
+		Context("object was applied before", func() {
+			BeforeEach(func() {
+				existing := obj.obj.DeepCopy()
+				existing.SetManagedFields([]metav1.ManagedFieldsEntry{{
+					Manager:    fieldManager,
+					Operation:  metav1.ManagedFieldsOperationApply,
+					APIVersion: "apps/v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+				}})
+				Expect(unstructured.SetNestedField(existing.Object, int64(3), "spec", "replicas")).To(Succeed())
+				existingObjs = append(existingObjs, existing)
+			})
+
+			It("should not force the ownership", func() {
+				result, err := reconciler.applyServerSide(ctx, logr.Discard(), "origin", obj, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(controllerutil.OperationResultNone))
+
+				Expect(forced).To(BeFalse())
+			})
+
+			It("should keep the current replicas as long as they are owned by the controller", func() {
+				_, err := reconciler.applyServerSide(ctx, logr.Discard(), "origin", obj, nil, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(applied.Object["spec"]).To(HaveKeyWithValue("replicas", int64(3)))
+			})
+
+			Context("replicas were taken over by another manager", func() {
+				BeforeEach(func() {
+					existingObjs[0].SetManagedFields([]metav1.ManagedFieldsEntry{{
+						Manager:    fieldManager,
+						Operation:  metav1.ManagedFieldsOperationApply,
+						APIVersion: "apps/v1",
+						FieldsType: "FieldsV1",
+						FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{}}}`)},
+					}})
+				})
+
+				It("should not own the replicas of horizontally scaled objects", func() {
+					_, err := reconciler.applyServerSide(ctx, logr.Discard(), "origin", obj, nil, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(applied.Object["spec"]).NotTo(HaveKey("replicas"))
+				})
+			})
+
+			It("should apply fields which were changed by other managers if they are not preserved", func() {
+				existingObjs[0].SetManagedFields(append(existingObjs[0].GetManagedFields(), metav1.ManagedFieldsEntry{
+					Manager:    "kubectl-edit",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "apps/v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"foo\"}":{"f:image":{}}}}}}}`)},
+				}))
+
+				_, err := reconciler.applyServerSide(ctx, logr.Discard(), "origin", obj, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(applied.Object["spec"]).To(HaveKeyWithValue("replicas", int64(1)))
+				containers, _, err := unstructured.NestedSlice(applied.Object, "spec", "template", "spec", "containers")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(ConsistOf(HaveKeyWithValue("image", "foo")))
+			})
+
+			It("should report field ownership conflicts", func() {
+				applyErr = apierrors.NewApplyConflict([]metav1.StatusCause{{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl-edit" using apps/v1`,
+					Field:   ".spec.replicas",
+				}}, "Apply failed with 1 conflict")
+
+				_, err := reconciler.applyServerSide(ctx, logr.Discard(), "origin", obj, nil, false)
+
+				conflictErr := &fieldOwnershipConflictError{}
+				Expect(errors.As(err, &conflictErr)).To(BeTrue())
+				Expect(err).To(MatchError(`field ownership conflicts during server-side apply of object "apps/v1/Deployment/default/foo": .spec.replicas: conflict with "kubectl-edit" using apps/v1`))
+			})
+		})
+	})
+
+	Describe("#dropPreservedFields", func() {
+		var desired, current *unstructured.Unstructured
+
+		BeforeEach(func() {
+			desired = &unstructured.Unstructured{Object: map[string]any{
+				"spec": map[string]any{"jobTemplate": map[string]any{"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "foo", "resources": map[string]any{"limits": map[string]any{"cpu": "1"}}},
+						map[string]any{"name": "bar", "resources": map[string]any{"limits": map[string]any{"cpu": "1"}}},
+					},
+				}}}}},
+			}}
+			current = desired.DeepCopy()
+			Expect(unstructured.SetNestedSlice(current.Object, []any{
+				map[string]any{"name": "foo", "resources": map[string]any{"limits": map[string]any{"cpu": "2"}}},
+				map[string]any{"name": "bar", "resources": map[string]any{"limits": map[string]any{"cpu": "2"}}},
+			}, "spec", "jobTemplate", "spec", "template", "spec", "containers")).To(Succeed())
+		})
+
+		It("should drop the resources of containers not owned by the controller and keep the current ones otherwise", func() {
+			ownedFields, _, err := appliedFields(withManagedFields(current, `{"f:spec":{"f:jobTemplate":{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"foo\"}":{"f:resources":{"f:limits":{"f:cpu":{}}}}}}}}}}}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dropPreservedFields(desired, current, ownedFields, false, true)).To(Succeed())
+
+			containers, _, err := unstructured.NestedSlice(desired.Object, "spec", "jobTemplate", "spec", "template", "spec", "containers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(Equal([]any{
+				map[string]any{"name": "foo", "resources": map[string]any{"limits": map[string]any{"cpu": "2"}}},
+				map[string]any{"name": "bar"},
+			}))
+		})
+
+		It("should not drop any fields if the object does not exist yet", func() {
+			expected := desired.DeepCopy()
+			Expect(dropPreservedFields(desired, nil, nil, true, true)).To(Succeed())
+			Expect(desired).To(Equal(expected))
+		})
+
+		It("should not change the resources if they should not be preserved", func() {
+			expected := desired.DeepCopy()
+			Expect(dropPreservedFields(desired, current, nil, false, false)).To(Succeed())
+			Expect(desired).To(Equal(expected))
+		})
+	})
+})
+
+func withManagedFields(obj *unstructured.Unstructured, fieldsV1 string) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:   fieldManager,
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(fieldsV1)},
+	}})
+	return obj
+}
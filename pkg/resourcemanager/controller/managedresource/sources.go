@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+)
+
+// ociArtifactDataKey is the key under which the manifests of an OCI artifact are decoded.
+const ociArtifactDataKey = "manifests.yaml"
+
+// manifestSource is a source of resources referenced by a ManagedResource, i.e., a secret, a config map or an OCI
+// artifact.
+type manifestSource struct {
+	// description identifies the source in messages, e.g. `secret 'garden/foo'`.
+	description string
+	// logKeysAndValues identify the source in log messages.
+	logKeysAndValues []any
+	// data maps keys to the manifests stored under the respective key.
+	data map[string][]byte
+}
+
+// sourceReadError is returned if a source of a ManagedResource cannot be read.
+type sourceReadError struct {
+	reason string
+	source string
+	err    error
+}
+
+func (e *sourceReadError) Error() string {
+	return fmt.Sprintf("could not read %s: %+v", e.source, e.err)
+}
+
+func (e *sourceReadError) Unwrap() error {
+	return e.err
+}
+
+// readManifestSources reads all sources referenced by the given ManagedResource. Secrets are read first, followed by
+// config maps and OCI artifacts.
+func (r *Reconciler) readManifestSources(ctx context.Context, mr *resourcesv1alpha1.ManagedResource) ([]manifestSource, error) {
+	var sources []manifestSource
+
+	for _, ref := range mr.Spec.SecretRefs {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: mr.Namespace}}
+		if err := r.SourceClient.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+			return nil, &sourceReadError{reason: "CannotReadSecret", source: fmt.Sprintf("secret '%s'", secret.Name), err: err}
+		}
+
+		sources = append(sources, manifestSource{
+			description:      fmt.Sprintf("secret '%s'", client.ObjectKeyFromObject(secret)),
+			logKeysAndValues: []any{"secret", client.ObjectKeyFromObject(secret)},
+			data:             secret.Data,
+		})
+	}
+
+	for _, ref := range mr.Spec.ConfigMapRefs {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: mr.Namespace}}
+		if err := r.ConfigMapReader.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+			return nil, &sourceReadError{reason: "CannotReadConfigMap", source: fmt.Sprintf("config map '%s'", configMap.Name), err: err}
+		}
+
+		data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+		for key, value := range configMap.Data {
+			data[key] = []byte(value)
+		}
+		for key, value := range configMap.BinaryData {
+			data[key] = value
+		}
+
+		sources = append(sources, manifestSource{
+			description:      fmt.Sprintf("config map '%s'", client.ObjectKeyFromObject(configMap)),
+			logKeysAndValues: []any{"configMap", client.ObjectKeyFromObject(configMap)},
+			data:             data,
+		})
+	}
+
+	for _, ref := range mr.Spec.OCIArtifacts {
+		artifact := ref.Repository + "@" + ref.Digest
+		if ref.Digest == "" {
+			return nil, &sourceReadError{reason: "CannotPullOCIArtifact", source: fmt.Sprintf("OCI artifact '%s'", ref.Repository), err: fmt.Errorf("artifact is not pinned by its digest")}
+		}
+
+		manifests, err := r.Registry.Pull(ctx, &gardencorev1.OCIRepository{Repository: &ref.Repository, Digest: &ref.Digest})
+		if err != nil {
+			return nil, &sourceReadError{reason: "CannotPullOCIArtifact", source: fmt.Sprintf("OCI artifact '%s'", artifact), err: err}
+		}
+
+		sources = append(sources, manifestSource{
+			description:      fmt.Sprintf("OCI artifact '%s'", artifact),
+			logKeysAndValues: []any{"ociArtifact", artifact},
+			data:             map[string][]byte{ociArtifactDataKey: manifests},
+		})
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package managedresource
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	fakeoci "github.com/gardener/gardener/pkg/utils/oci/fake"
+)
+
+var _ = Describe("sources", func() {
+	const (
+		repository = "example.com/manifests/dashboards"
+		digest     = "sha256:7a855a6d69033dd3240d9648e8bd46a67a528059158e098c7794ac9227735b4a"
+	)
+
+	var (
+		ctx = context.Background()
+
+		sourceClient client.Client
+		registry     *fakeoci.Registry
+		reconciler   *Reconciler
+
+		mr        *resourcesv1alpha1.ManagedResource
+		secret    *corev1.Secret
+		configMap *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "garden"},
+			Data:       map[string][]byte{"secret.yaml": []byte("secret-data")},
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "garden"},
+			Data:       map[string]string{"config.yaml": "config-data"},
+			BinaryData: map[string][]byte{"compressed.yaml.br": []byte("binary-data")},
+		}
+		mr = &resourcesv1alpha1.ManagedResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "mr", Namespace: "garden"},
+			Spec: resourcesv1alpha1.ManagedResourceSpec{
+				SecretRefs:    []corev1.LocalObjectReference{{Name: secret.Name}},
+				ConfigMapRefs: []corev1.LocalObjectReference{{Name: configMap.Name}},
+				OCIArtifacts:  []resourcesv1alpha1.OCIArtifactReference{{Repository: repository, Digest: digest}},
+			},
+		}
+
+		sourceClient = fake.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(secret, configMap).Build()
+		registry = fakeoci.NewRegistry()
+		reconciler = &Reconciler{SourceClient: sourceClient, ConfigMapReader: sourceClient, Registry: registry}
+	})
+
+	Describe("#readManifestSources", func() {
+		It("should read all sources in order", func() {
+			registry.AddArtifact(&gardencorev1.OCIRepository{Repository: ptr.To(repository), Digest: ptr.To(digest)}, []byte("artifact-data"))
+
+			sources, err := reconciler.readManifestSources(ctx, mr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sources).To(Equal([]manifestSource{
+				{
+					description:      "secret 'garden/secret'",
+					logKeysAndValues: []any{"secret", client.ObjectKeyFromObject(secret)},
+					data:             map[string][]byte{"secret.yaml": []byte("secret-data")},
+				},
+				{
+					description:      "config map 'garden/config'",
+					logKeysAndValues: []any{"configMap", client.ObjectKeyFromObject(configMap)},
+					data:             map[string][]byte{"config.yaml": []byte("config-data"), "compressed.yaml.br": []byte("binary-data")},
+				},
+				{
+					description:      "OCI artifact '" + repository + "@" + digest + "'",
+					logKeysAndValues: []any{"ociArtifact", repository + "@" + digest},
+					data:             map[string][]byte{"manifests.yaml": []byte("artifact-data")},
+				},
+			}))
+		})
+
+		It("should fail if a config map cannot be read", func() {
+			mr.Spec.ConfigMapRefs = append(mr.Spec.ConfigMapRefs, corev1.LocalObjectReference{Name: "missing"})
+
+			_, err := reconciler.readManifestSources(ctx, mr)
+			Expect(err).To(MatchError(ContainSubstring(`could not read config map 'missing'`)))
+
+			readErr := &sourceReadError{}
+			Expect(errors.As(err, &readErr)).To(BeTrue())
+			Expect(readErr.reason).To(Equal("CannotReadConfigMap"))
+		})
+
+		It("should fail if an OCI artifact cannot be pulled", func() {
+			_, err := reconciler.readManifestSources(ctx, mr)
+			Expect(err).To(MatchError(ContainSubstring(`could not read OCI artifact '` + repository + "@" + digest + `'`)))
+
+			readErr := &sourceReadError{}
+			Expect(errors.As(err, &readErr)).To(BeTrue())
+			Expect(readErr.reason).To(Equal("CannotPullOCIArtifact"))
+		})
+
+		It("should fail if an OCI artifact is not pinned by its digest", func() {
+			mr.Spec.OCIArtifacts[0].Digest = ""
+
+			_, err := reconciler.readManifestSources(ctx, mr)
+			Expect(err).To(MatchError(`could not read OCI artifact '` + repository + `': artifact is not pinned by its digest`))
+		})
+	})
+})
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package predicate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	. "github.com/gardener/gardener/pkg/resourcemanager/predicate"
+)
+
+var _ = Describe("preview", func() {
+	var managedResource *resourcesv1alpha1.ManagedResource
+
+	BeforeEach(func() {
+		managedResource = &resourcesv1alpha1.ManagedResource{}
+	})
+
+	Describe("#PreviewChanged", func() {
+		var p predicate.Predicate
+
+		BeforeEach(func() {
+			p = PreviewChanged()
+		})
+
+		It("should match on create, delete and generic events", func() {
+			Expect(p.Create(event.CreateEvent{Object: managedResource})).To(BeTrue())
+			Expect(p.Delete(event.DeleteEvent{Object: managedResource})).To(BeTrue())
+			Expect(p.Generic(event.GenericEvent{Object: managedResource})).To(BeTrue())
+		})
+
+		It("should not match on update if the preview annotation did not change", func() {
+			Expect(p.Update(event.UpdateEvent{ObjectOld: managedResource, ObjectNew: managedResource})).To(BeFalse())
+		})
+
+		It("should match on update if the preview annotation was added", func() {
+			oldManagedResource := managedResource.DeepCopy()
+			metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, "resources.gardener.cloud/preview", "true")
+
+			Expect(p.Update(event.UpdateEvent{ObjectOld: oldManagedResource, ObjectNew: managedResource})).To(BeTrue())
+		})
+
+		It("should match on update if the preview annotation was removed", func() {
+			metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, "resources.gardener.cloud/preview", "true")
+			newManagedResource := managedResource.DeepCopy()
+			delete(newManagedResource.Annotations, "resources.gardener.cloud/preview")
+
+			Expect(p.Update(event.UpdateEvent{ObjectOld: managedResource, ObjectNew: newManagedResource})).To(BeTrue())
+		})
+	})
+
+	Describe("#IsPreview", func() {
+		It("should return false if the annotation is not present", func() {
+			Expect(IsPreview(managedResource)).To(BeFalse())
+		})
+
+		It("should return false if the annotation is not truthy", func() {
+			metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, "resources.gardener.cloud/preview", "false")
+			Expect(IsPreview(managedResource)).To(BeFalse())
+		})
+
+		It("should return true if the annotation is truthy", func() {
+			metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, "resources.gardener.cloud/preview", "TRUE")
+			Expect(IsPreview(managedResource)).To(BeTrue())
+		})
+	})
+})
@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/go-logr/logr"
@@ -17,11 +19,13 @@ import (
 	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	auth "k8s.io/apiserver/pkg/authorization/authorizer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
 )
 
 // NewAuthorizer returns a new authorizer for requests from gardener-node-agents. It never has an opinion on the request.
@@ -42,6 +46,7 @@ var (
 	eventResource                     = eventsv1.Resource("events")
 	leaseResource                     = coordinationv1.Resource("leases")
 	nodeResource                      = corev1.Resource("nodes")
+	podResource                       = corev1.Resource("pods")
 	secretsResource                   = corev1.Resource("secrets")
 )
 
@@ -81,6 +86,8 @@ func (a *authorizer) Authorize(ctx context.Context, attrs auth.Attributes) (auth
 			return a.authorizeLease(ctx, requestLog, machineName, attrs)
 		case nodeResource:
 			return a.authorizeNode(ctx, requestLog, machineName, attrs)
+		case podResource:
+			return a.authorizePod(ctx, requestLog, machineName, attrs)
 		case secretsResource:
 			return a.authorizeSecret(ctx, requestLog, machineName, attrs)
 		}
@@ -148,6 +155,12 @@ func (a *authorizer) authorizeLease(ctx context.Context, log logr.Logger, machin
 		return auth.DecisionDeny, fmt.Sprintf(`expecting "node" label on machine %q`, machineName), nil
 	}
 
+	// gardener-node-agent coordinates reboots of the nodes of its worker pool via leases.
+	if workerPoolName := machine.Spec.NodeTemplateSpec.Labels[v1beta1constants.LabelWorkerPool]; workerPoolName != "" &&
+		attrs.GetNamespace() == metav1.NamespaceSystem && isRebootLeaseOfWorkerPool(attrs.GetName(), workerPoolName) {
+		return auth.DecisionAllow, "", nil
+	}
+
 	allowedLease := "gardener-node-agent-" + node
 	if (attrs.GetVerb() != "create" && attrs.GetName() != allowedLease) || attrs.GetNamespace() != metav1.NamespaceSystem {
 		log.Info("Denying authorization because gardener-node-agent is not allowed to access the lease", "nodeName", node, "machineName", machineName, "leaseName", attrs.GetName())
@@ -157,6 +170,19 @@ func (a *authorizer) authorizeLease(ctx context.Context, log logr.Logger, machin
 	return auth.DecisionAllow, "", nil
 }
 
+// isRebootLeaseOfWorkerPool returns true if the given lease name is '<prefix><slot>' for the reboot lease prefix of the
+// given worker pool. Checking the numeric slot is required since the prefix of one worker pool can also be the prefix of
+// the leases of another worker pool, e.g., 'a' and 'a-b'.
+func isRebootLeaseOfWorkerPool(leaseName, workerPoolName string) bool {
+	slot, ok := strings.CutPrefix(leaseName, gardenerutils.NodeAgentRebootLeasePrefix(workerPoolName))
+	if !ok {
+		return false
+	}
+
+	n, err := strconv.ParseInt(slot, 10, 32)
+	return err == nil && n >= 0 && strconv.FormatInt(n, 10) == slot
+}
+
 func (a *authorizer) authorizeNode(ctx context.Context, log logr.Logger, machineName string, attrs auth.Attributes) (auth.Decision, string, error) {
 	if ok, reason := a.checkSubresource(log, attrs, "status"); !ok {
 		return auth.DecisionDeny, reason, nil
@@ -186,6 +212,64 @@ func (a *authorizer) authorizeNode(ctx context.Context, log logr.Logger, machine
 	return auth.DecisionAllow, "", nil
 }
 
+func (a *authorizer) authorizePod(ctx context.Context, log logr.Logger, machineName string, attrs auth.Attributes) (auth.Decision, string, error) {
+	if ok, reason := a.checkSubresource(log, attrs, "eviction"); !ok {
+		return auth.DecisionDeny, reason, nil
+	}
+
+	allowedVerbs := []string{"create"}
+	if attrs.GetSubresource() == "" {
+		allowedVerbs = []string{"list", "watch"}
+	}
+
+	if allowed, reason := a.checkVerb(log, attrs, allowedVerbs...); !allowed {
+		return auth.DecisionDeny, reason, nil
+	}
+
+	machine := &machinev1alpha1.Machine{}
+	if err := a.sourceClient.Get(ctx, client.ObjectKey{Name: machineName, Namespace: a.machineNamespace}, machine); err != nil {
+		return auth.DecisionDeny, "", fmt.Errorf("error getting machine %q: %w", machineName, err)
+	}
+
+	// Similar to kubelets, gardener-node-agents may only list and watch the pods running on their own node.
+	if attrs.GetSubresource() == "" {
+		if node := machine.Labels[machinev1alpha1.NodeLabelKey]; node == "" || !selectsPodsOfNode(attrs, node) {
+			log.Info("Denying authorization because pods are not selected by the node of the machine", "machineName", machineName)
+			return auth.DecisionDeny, fmt.Sprintf("gardener-node-agent can only list and watch pods with field selector 'spec.nodeName' for the node of machine %q", machineName), nil
+		}
+		return auth.DecisionAllow, "", nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := a.targetClient.Get(ctx, client.ObjectKey{Name: attrs.GetName(), Namespace: attrs.GetNamespace()}, pod); err != nil {
+		return auth.DecisionDeny, "", fmt.Errorf("error getting pod %q: %w", client.ObjectKey{Name: attrs.GetName(), Namespace: attrs.GetNamespace()}, err)
+	}
+
+	if node := machine.Labels[machinev1alpha1.NodeLabelKey]; node == "" || pod.Spec.NodeName != node {
+		log.Info("Denying authorization because pod is not running on the node of the machine", "podNodeName", pod.Spec.NodeName, "machineName", machineName)
+		return auth.DecisionDeny, fmt.Sprintf("gardener-node-agent can only evict pods running on the node of machine %q", machineName), nil
+	}
+
+	return auth.DecisionAllow, "", nil
+}
+
+// selectsPodsOfNode returns true if the request is restricted to the pods of the given node by a field selector.
+func selectsPodsOfNode(attrs auth.Attributes, nodeName string) bool {
+	requirements, err := attrs.GetFieldSelector()
+	if err != nil {
+		return false
+	}
+
+	for _, requirement := range requirements {
+		if requirement.Field == "spec.nodeName" && requirement.Value == nodeName &&
+			(requirement.Operator == selection.Equals || requirement.Operator == selection.DoubleEquals) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (a *authorizer) authorizeSecret(ctx context.Context, log logr.Logger, machineName string, attrs auth.Attributes) (auth.Decision, string, error) {
 	if ok, reason := a.checkSubresource(log, attrs); !ok {
 		return auth.DecisionDeny, reason, nil
@@ -6,6 +6,7 @@ package nodeagentauthorizer_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
@@ -15,6 +16,8 @@ import (
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apiserver/pkg/authentication/user"
 	auth "k8s.io/apiserver/pkg/authorization/authorizer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,7 +84,10 @@ var _ = Describe("Authorizer", func() {
 			Spec: machinev1alpha1.MachineSpec{
 				NodeTemplateSpec: machinev1alpha1.NodeTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
-						Labels: map[string]string{v1beta1constants.LabelWorkerPoolGardenerNodeAgentSecretName: machineSecretName},
+						Labels: map[string]string{
+							v1beta1constants.LabelWorkerPoolGardenerNodeAgentSecretName: machineSecretName,
+							v1beta1constants.LabelWorkerPool:                            "worker-a",
+						},
 					},
 				},
 			},
@@ -436,6 +442,233 @@ var _ = Describe("Authorizer", func() {
 				Entry("delete", "delete"),
 				Entry("deletecollection", "deletecollection"),
 			)
+
+			DescribeTable("should allow accessing the reboot leases of the worker pool", func(verb string) {
+				attrs := &auth.AttributesRecord{
+					User:            nodeAgentUser,
+					Name:            "gardener-node-agent-reboot-worker-a-1",
+					Namespace:       "kube-system",
+					APIGroup:        "coordination.k8s.io",
+					Resource:        "leases",
+					ResourceRequest: true,
+					Verb:            verb,
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionAllow))
+				Expect(reason).To(BeEmpty())
+			},
+				Entry("get", "get"),
+				Entry("update", "update"),
+			)
+
+			DescribeTable("should deny accessing the reboot leases of a different worker pool", func(leaseName string) {
+				attrs := &auth.AttributesRecord{
+					User:            nodeAgentUser,
+					Name:            leaseName,
+					Namespace:       "kube-system",
+					APIGroup:        "coordination.k8s.io",
+					Resource:        "leases",
+					ResourceRequest: true,
+					Verb:            "get",
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionDeny))
+				Expect(reason).To(Equal(fmt.Sprintf("this gardener-node-agent can only access lease \"gardener-node-agent-%s\" in \"kube-system\" namespace", nodeName)))
+			},
+				Entry("other worker pool", "gardener-node-agent-reboot-worker-b-0"),
+				Entry("worker pool with the same prefix", "gardener-node-agent-reboot-worker-a-b-0"),
+				Entry("no slot", "gardener-node-agent-reboot-worker-a-"),
+				Entry("non-numeric slot", "gardener-node-agent-reboot-worker-a-b"),
+				Entry("negative slot", "gardener-node-agent-reboot-worker-a--1"),
+				Entry("non-canonical slot", "gardener-node-agent-reboot-worker-a-01"),
+			)
+		})
+
+		Context("#Pods", func() {
+			var pod *corev1.Pod
+
+			BeforeEach(func() {
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+					Spec:       corev1.PodSpec{NodeName: nodeName},
+				}
+				Expect(targetClient.Create(ctx, pod)).To(Succeed())
+			})
+
+			DescribeTable("should allow reading the pods of the node of the machine", func(verb, fieldSelector string) {
+				fieldSelectorRequirements, err := fields.ParseSelector(fieldSelector)
+				Expect(err).NotTo(HaveOccurred())
+
+				attrs := &auth.AttributesRecord{
+					User:                      nodeAgentUser,
+					Name:                      "",
+					APIGroup:                  "",
+					Resource:                  "pods",
+					ResourceRequest:           true,
+					Verb:                      verb,
+					FieldSelectorRequirements: fieldSelectorRequirements.Requirements(),
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionAllow))
+				Expect(reason).To(BeEmpty())
+			},
+				Entry("list", "list", "spec.nodeName=foo-node"),
+				Entry("watch", "watch", "spec.nodeName=foo-node"),
+				Entry("list with double equals", "list", "spec.nodeName==foo-node"),
+				Entry("list with additional requirements", "list", "metadata.namespace=default,spec.nodeName=foo-node"),
+			)
+
+			DescribeTable("should deny reading pods which are not selected by the node of the machine", func(fieldSelector string, fieldSelectorParsingErr error) {
+				var fieldSelectorRequirements fields.Requirements
+				if fieldSelector != "" {
+					selector, err := fields.ParseSelector(fieldSelector)
+					Expect(err).NotTo(HaveOccurred())
+					fieldSelectorRequirements = selector.Requirements()
+				}
+
+				attrs := &auth.AttributesRecord{
+					User:                      nodeAgentUser,
+					Name:                      "",
+					APIGroup:                  "",
+					Resource:                  "pods",
+					ResourceRequest:           true,
+					Verb:                      "list",
+					FieldSelectorRequirements: fieldSelectorRequirements,
+					FieldSelectorParsingErr:   fieldSelectorParsingErr,
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionDeny))
+				Expect(reason).To(Equal(fmt.Sprintf("gardener-node-agent can only list and watch pods with field selector 'spec.nodeName' for the node of machine %q", machineName)))
+			},
+				Entry("no field selector", "", nil),
+				Entry("different node", "spec.nodeName=other-node", nil),
+				Entry("negated node", "spec.nodeName!=foo-node", nil),
+				Entry("different field", "metadata.name=foo-node", nil),
+				Entry("parsing error", "spec.nodeName=foo-node", errors.New("fake")),
+			)
+
+			It("should deny reading pods for a machine without a node label", func() {
+				attrs := &auth.AttributesRecord{
+					User:                      newNodeAgentUser,
+					Name:                      "",
+					APIGroup:                  "",
+					Resource:                  "pods",
+					ResourceRequest:           true,
+					Verb:                      "list",
+					FieldSelectorRequirements: fields.Requirements{{Operator: selection.Equals, Field: "spec.nodeName", Value: ""}},
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionDeny))
+				Expect(reason).To(Equal(fmt.Sprintf("gardener-node-agent can only list and watch pods with field selector 'spec.nodeName' for the node of machine %q", newMachineName)))
+			})
+
+			DescribeTable("should deny because no allowed verb", func(verb string) {
+				attrs := &auth.AttributesRecord{
+					User:            nodeAgentUser,
+					Name:            pod.Name,
+					Namespace:       pod.Namespace,
+					APIGroup:        "",
+					Resource:        "pods",
+					ResourceRequest: true,
+					Verb:            verb,
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionDeny))
+				Expect(reason).To(ContainSubstring("only the following verbs are allowed for this resource type: [list watch]"))
+			},
+				Entry("get", "get"),
+				Entry("create", "create"),
+				Entry("update", "update"),
+				Entry("patch", "patch"),
+				Entry("delete", "delete"),
+			)
+
+			It("should allow evicting a pod running on the node of the machine", func() {
+				attrs := &auth.AttributesRecord{
+					User:            nodeAgentUser,
+					Name:            pod.Name,
+					Namespace:       pod.Namespace,
+					APIGroup:        "",
+					Resource:        "pods",
+					Subresource:     "eviction",
+					ResourceRequest: true,
+					Verb:            "create",
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionAllow))
+				Expect(reason).To(BeEmpty())
+			})
+
+			It("should deny evicting a pod running on a different node", func() {
+				pod.Spec.NodeName = "other-node"
+				Expect(targetClient.Update(ctx, pod)).To(Succeed())
+
+				attrs := &auth.AttributesRecord{
+					User:            nodeAgentUser,
+					Name:            pod.Name,
+					Namespace:       pod.Namespace,
+					APIGroup:        "",
+					Resource:        "pods",
+					Subresource:     "eviction",
+					ResourceRequest: true,
+					Verb:            "create",
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionDeny))
+				Expect(reason).To(Equal(fmt.Sprintf("gardener-node-agent can only evict pods running on the node of machine %q", machineName)))
+			})
+
+			It("should deny evicting pods for a machine without a node label", func() {
+				attrs := &auth.AttributesRecord{
+					User:            newNodeAgentUser,
+					Name:            pod.Name,
+					Namespace:       pod.Namespace,
+					APIGroup:        "",
+					Resource:        "pods",
+					Subresource:     "eviction",
+					ResourceRequest: true,
+					Verb:            "create",
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionDeny))
+				Expect(reason).To(Equal(fmt.Sprintf("gardener-node-agent can only evict pods running on the node of machine %q", newMachineName)))
+			})
+
+			It("should deny other subresources", func() {
+				attrs := &auth.AttributesRecord{
+					User:            nodeAgentUser,
+					Name:            pod.Name,
+					Namespace:       pod.Namespace,
+					APIGroup:        "",
+					Resource:        "pods",
+					Subresource:     "exec",
+					ResourceRequest: true,
+					Verb:            "create",
+				}
+				decision, reason, err := authorizer.Authorize(ctx, attrs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision).To(Equal(auth.DecisionDeny))
+				Expect(reason).To(ContainSubstring("only the following subresources are allowed for this resource type: [eviction]"))
+			})
 		})
 
 		Context("#Nodes", func() {
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow
+
+import (
+	"context"
+)
+
+// Checkpoint is the persisted progress of a flow execution.
+type Checkpoint struct {
+	// SpecHash is the hash of the inputs of the flow execution which created the checkpoint.
+	SpecHash string `json:"specHash"`
+	// SucceededTasks are the IDs of the tasks which succeeded in the flow execution.
+	SucceededTasks []string `json:"succeededTasks,omitempty"`
+}
+
+// CheckpointStore is used to persist the progress of flow executions so that an interrupted execution can be resumed.
+type CheckpointStore interface {
+	// Load returns the checkpoint of the flow with the given name. It returns nil if there is no checkpoint.
+	Load(ctx context.Context, flowName string) (*Checkpoint, error)
+	// Save persists the checkpoint of the flow with the given name.
+	Save(ctx context.Context, flowName string, checkpoint *Checkpoint) error
+	// Delete removes the checkpoint of the flow with the given name. It does not return an error if there is no
+	// checkpoint.
+	Delete(ctx context.Context, flowName string) error
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// invalidConfigMapKeyChars matches all characters which are not allowed in ConfigMap keys.
+var invalidConfigMapKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+type checkpointStoreConfigMap struct {
+	client    client.Client
+	apiReader client.Reader
+	namespace string
+	name      string
+}
+
+// NewConfigMapCheckpointStore returns a new checkpoint store which persists the checkpoints in the ConfigMap with the
+// given name and namespace. The checkpoint of each flow is stored as JSON in a separate data key derived from the flow
+// name. The ConfigMap is created on the first save and deleted once the last checkpoint was removed. Since checkpoints
+// are saved in quick succession, the ConfigMap is read with the given API reader instead of a cached client.
+func NewConfigMapCheckpointStore(c client.Client, apiReader client.Reader, namespace, name string) CheckpointStore {
+	return &checkpointStoreConfigMap{
+		client:    c,
+		apiReader: apiReader,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (c *checkpointStoreConfigMap) Load(ctx context.Context, flowName string) (*Checkpoint, error) {
+	configMap := c.emptyConfigMap()
+	if err := c.apiReader.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed reading checkpoint ConfigMap %s: %w", client.ObjectKeyFromObject(configMap), err)
+	}
+
+	data, ok := configMap.Data[configMapKey(flowName)]
+	if !ok {
+		return nil, nil
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal([]byte(data), checkpoint); err != nil {
+		return nil, fmt.Errorf("failed decoding checkpoint of flow %q: %w", flowName, err)
+	}
+	return checkpoint, nil
+}
+
+func (c *checkpointStoreConfigMap) Save(ctx context.Context, flowName string, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed encoding checkpoint of flow %q: %w", flowName, err)
+	}
+
+	configMap := c.emptyConfigMap()
+	if err := c.apiReader.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed reading checkpoint ConfigMap %s: %w", client.ObjectKeyFromObject(configMap), err)
+		}
+
+		configMap.Data = map[string]string{configMapKey(flowName): string(data)}
+		return c.client.Create(ctx, configMap)
+	}
+
+	patch := client.MergeFrom(configMap.DeepCopy())
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string, 1)
+	}
+	configMap.Data[configMapKey(flowName)] = string(data)
+	return c.client.Patch(ctx, configMap, patch)
+}
+
+func (c *checkpointStoreConfigMap) Delete(ctx context.Context, flowName string) error {
+	configMap := c.emptyConfigMap()
+	if err := c.apiReader.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed reading checkpoint ConfigMap %s: %w", client.ObjectKeyFromObject(configMap), err)
+	}
+
+	if _, ok := configMap.Data[configMapKey(flowName)]; !ok {
+		return nil
+	}
+
+	if len(configMap.Data) == 1 {
+		return client.IgnoreNotFound(c.client.Delete(ctx, configMap, client.Preconditions{ResourceVersion: &configMap.ResourceVersion}))
+	}
+
+	patch := client.MergeFrom(configMap.DeepCopy())
+	delete(configMap.Data, configMapKey(flowName))
+	return c.client.Patch(ctx, configMap, patch)
+}
+
+func (c *checkpointStoreConfigMap) emptyConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: c.name, Namespace: c.namespace}}
+}
+
+func configMapKey(flowName string) string {
+	return invalidConfigMapKeyChars.ReplaceAllString(flowName, "-")
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener/pkg/utils/flow"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+)
+
+var _ = Describe("CheckpointStoreConfigMap", func() {
+	var (
+		ctx        = context.Background()
+		fakeClient client.Client
+		store      flow.CheckpointStore
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().Build()
+		store = flow.NewConfigMapCheckpointStore(fakeClient, fakeClient, "shoot--foo--bar", "flow-checkpoints")
+	})
+
+	It("should return nil if the ConfigMap does not exist", func() {
+		Expect(store.Load(ctx, "Shoot cluster reconciliation")).To(BeNil())
+	})
+
+	It("should succeed deleting if the ConfigMap does not exist", func() {
+		Expect(store.Delete(ctx, "Shoot cluster reconciliation")).To(Succeed())
+	})
+
+	It("should save, load and delete checkpoints", func() {
+		Expect(store.Save(ctx, "Shoot cluster reconciliation", &flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"x"}})).To(Succeed())
+		Expect(store.Save(ctx, "Shoot cluster deletion", &flow.Checkpoint{SpecHash: "other-hash"})).To(Succeed())
+
+		configMap := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "shoot--foo--bar", Name: "flow-checkpoints"}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(Equal(map[string]string{
+			"Shoot-cluster-reconciliation": `{"specHash":"hash","succeededTasks":["x"]}`,
+			"Shoot-cluster-deletion":       `{"specHash":"other-hash"}`,
+		}))
+
+		Expect(store.Load(ctx, "Shoot cluster reconciliation")).To(Equal(&flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"x"}}))
+
+		Expect(store.Delete(ctx, "Shoot cluster reconciliation")).To(Succeed())
+		Expect(store.Load(ctx, "Shoot cluster reconciliation")).To(BeNil())
+		Expect(store.Load(ctx, "Shoot cluster deletion")).To(Equal(&flow.Checkpoint{SpecHash: "other-hash"}))
+
+		Expect(store.Delete(ctx, "Shoot cluster deletion")).To(Succeed())
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "shoot--foo--bar", Name: "flow-checkpoints"}, configMap)).To(BeNotFoundError())
+	})
+
+	It("should fail loading an invalid checkpoint", func() {
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "flow-checkpoints"},
+			Data:       map[string]string{"foo": "{"},
+		})).To(Succeed())
+
+		_, err := store.Load(ctx, "foo")
+		Expect(err).To(MatchError(ContainSubstring(`failed decoding checkpoint of flow "foo"`)))
+	})
+})
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type checkpointStoreInMemory struct {
+	lock        sync.RWMutex
+	checkpoints map[string]*Checkpoint
+}
+
+// NewInMemoryCheckpointStore returns a new checkpoint store which keeps the checkpoints in memory. Hence, the
+// checkpoints do not survive process restarts.
+func NewInMemoryCheckpointStore() CheckpointStore {
+	return &checkpointStoreInMemory{
+		checkpoints: make(map[string]*Checkpoint),
+	}
+}
+
+func (c *checkpointStoreInMemory) Load(_ context.Context, flowName string) (*Checkpoint, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	checkpoint, ok := c.checkpoints[flowName]
+	if !ok {
+		return nil, nil
+	}
+	return copyCheckpoint(checkpoint), nil
+}
+
+func (c *checkpointStoreInMemory) Save(_ context.Context, flowName string, checkpoint *Checkpoint) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.checkpoints[flowName] = copyCheckpoint(checkpoint)
+	return nil
+}
+
+func (c *checkpointStoreInMemory) Delete(_ context.Context, flowName string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.checkpoints, flowName)
+	return nil
+}
+
+func copyCheckpoint(checkpoint *Checkpoint) *Checkpoint {
+	return &Checkpoint{
+		SpecHash:       checkpoint.SpecHash,
+		SucceededTasks: slices.Clone(checkpoint.SucceededTasks),
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardener/pkg/utils/flow"
+)
+
+var _ = Describe("CheckpointStoreInMemory", func() {
+	var (
+		ctx   = context.Background()
+		store flow.CheckpointStore
+	)
+
+	BeforeEach(func() {
+		store = flow.NewInMemoryCheckpointStore()
+	})
+
+	It("should return nil if there is no checkpoint", func() {
+		Expect(store.Load(ctx, "foo")).To(BeNil())
+	})
+
+	It("should save, load and delete checkpoints", func() {
+		checkpoint := &flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"x"}}
+		Expect(store.Save(ctx, "foo", checkpoint)).To(Succeed())
+
+		checkpoint.SucceededTasks[0] = "modified"
+		Expect(store.Load(ctx, "foo")).To(Equal(&flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"x"}}))
+		Expect(store.Load(ctx, "bar")).To(BeNil())
+
+		Expect(store.Delete(ctx, "foo")).To(Succeed())
+		Expect(store.Load(ctx, "foo")).To(BeNil())
+		Expect(store.Delete(ctx, "foo")).To(Succeed())
+	})
+})
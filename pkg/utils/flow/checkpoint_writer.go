@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// checkpointSaveTimeout is the maximum duration of saving a checkpoint.
+const checkpointSaveTimeout = 30 * time.Second
+
+// checkpointWriter saves the checkpoints of a flow execution asynchronously so that the execution is not blocked by
+// round trips to the checkpoint store. Checkpoints requested while a save is in progress are coalesced, i.e., only the
+// latest one is saved afterwards.
+type checkpointWriter struct {
+	store    CheckpointStore
+	flowName string
+	log      logr.Logger
+
+	lock    sync.Mutex
+	pending *Checkpoint
+	discard bool
+
+	notify chan struct{}
+	done   chan struct{}
+}
+
+// newCheckpointWriter starts a new checkpointWriter. It must be stopped with stop once the flow execution has finished.
+// The checkpoints are saved independently of the cancellation of the given context so that the progress of a canceled
+// execution is persisted as well.
+func newCheckpointWriter(ctx context.Context, store CheckpointStore, flowName string, log logr.Logger) *checkpointWriter {
+	w := &checkpointWriter{
+		store:    store,
+		flowName: flowName,
+		log:      log,
+		notify:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	go w.run(context.WithoutCancel(ctx))
+
+	return w
+}
+
+// save requests saving the given checkpoint. It does not block.
+func (w *checkpointWriter) save(checkpoint *Checkpoint) {
+	w.lock.Lock()
+	w.pending = checkpoint
+	w.lock.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// stop waits until the running save has finished and stops the writer. If flush is true, the latest requested
+// checkpoint is saved before, otherwise it is discarded.
+func (w *checkpointWriter) stop(flush bool) {
+	if !flush {
+		w.lock.Lock()
+		w.pending = nil
+		w.discard = true
+		w.lock.Unlock()
+	}
+
+	close(w.notify)
+	<-w.done
+}
+
+func (w *checkpointWriter) run(ctx context.Context) {
+	defer close(w.done)
+
+	for {
+		_, ok := <-w.notify
+		w.write(ctx)
+
+		if !ok {
+			return
+		}
+	}
+}
+
+func (w *checkpointWriter) write(ctx context.Context) {
+	w.lock.Lock()
+	checkpoint := w.pending
+	w.pending = nil
+	w.lock.Unlock()
+
+	if checkpoint == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkpointSaveTimeout)
+	defer cancel()
+
+	if err := w.store.Save(ctx, w.flowName, checkpoint); err != nil {
+		// Errors are only logged since the checkpoint is merely an optimization. The checkpoint is retried with the next
+		// request unless a newer one has been requested in the meantime.
+		w.log.Error(err, "Failed saving checkpoint")
+
+		w.lock.Lock()
+		if w.pending == nil && !w.discard {
+			w.pending = checkpoint
+		}
+		w.lock.Unlock()
+	}
+}
@@ -7,8 +7,10 @@
 package flow
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -40,6 +42,23 @@ func (ns nodes) rootIDs() TaskIDs {
 	return roots
 }
 
+// computePriority computes the priority of the node with the given ID, i.e., the number of tasks on the longest path
+// starting at the node. Tasks on longer paths are started first when the parallelism is limited.
+func (ns nodes) computePriority(id TaskID) int {
+	n := ns[id]
+	if n.priority > 0 {
+		return n.priority
+	}
+
+	var longestTargetPath int
+	for target := range n.targetIDs {
+		longestTargetPath = max(longestTargetPath, ns.computePriority(target))
+	}
+
+	n.priority = longestTargetPath + 1
+	return n.priority
+}
+
 func (ns nodes) getOrCreate(id TaskID) *node {
 	n, ok := ns[id]
 	if !ok {
@@ -75,6 +94,9 @@ type node struct {
 	required  int
 	fn        TaskFn
 	skip      bool
+
+	concurrencyGroup string
+	priority         int
 }
 
 func (n *node) String() string {
@@ -102,6 +124,23 @@ type Opts struct {
 	ErrorCleaner func(ctx context.Context, taskID string)
 	// ErrorContext is used to store any error related context.
 	ErrorContext *errorsutils.ErrorContext
+	// Checkpoint is used to persist the IDs of succeeded tasks together with the SpecHash. If the flow execution is
+	// interrupted, the next execution with the same SpecHash does not run the succeeded tasks again. The checkpoint is
+	// deleted once the flow has finished successfully. The flow fails if the checkpoint cannot be deleted.
+	// Only flows whose tasks solely depend on the state persisted in the clusters may be resumed from a checkpoint,
+	// i.e., tasks must not rely on in-memory side effects of other tasks.
+	Checkpoint CheckpointStore
+	// SpecHash is the hash of the inputs of the flow execution. A checkpoint is only considered if it was created with
+	// the same SpecHash.
+	SpecHash string
+	// Trace is used to record the start and end time, the delay and the error of each task.
+	Trace *Trace
+	// MaxParallelism is the maximum number of tasks running concurrently. If it is not positive, the number of
+	// concurrently running tasks is not limited. Ready tasks on the longest remaining path are started first.
+	MaxParallelism int
+	// ConcurrencyLimits maps the names of concurrency groups (see Task.ConcurrencyGroup) to the maximum number of tasks
+	// of the group running concurrently. Groups without a positive limit are not limited.
+	ConcurrencyLimits map[string]int
 }
 
 // Run starts an execution of a Flow.
@@ -111,9 +150,10 @@ func (f *Flow) Run(ctx context.Context, opts Opts) error {
 }
 
 type nodeResult struct {
-	TaskID  TaskID
-	Error   error
-	skipped bool
+	TaskID   TaskID
+	Error    error
+	skipped  bool
+	restored bool
 
 	delay    time.Duration
 	duration time.Duration
@@ -185,6 +225,15 @@ func newExecution(flow *Flow, opts Opts) *execution {
 		opts.ProgressReporter,
 		opts.ErrorCleaner,
 		opts.ErrorContext,
+		opts.Checkpoint,
+		nil,
+		opts.SpecHash,
+		NewTaskIDs(),
+		opts.Trace,
+		opts.MaxParallelism,
+		opts.ConcurrencyLimits,
+		nil,
+		make(map[string]int),
 		make(chan *nodeResult),
 		make(map[TaskID]int),
 	}
@@ -200,11 +249,25 @@ type execution struct {
 	progressReporter ProgressReporter
 	errorCleaner     ErrorCleaner
 	errorContext     *errorsutils.ErrorContext
+	checkpoint       CheckpointStore
+	checkpointWriter *checkpointWriter
+	specHash         string
+	restoredTaskIDs  TaskIDs
+	trace            *Trace
+
+	maxParallelism    int
+	concurrencyLimits map[string]int
+	// ready contains the IDs of the tasks whose dependencies have completed but which have not been started yet.
+	ready []TaskID
+	// runningPerGroup contains the number of running tasks per concurrency group.
+	runningPerGroup map[string]int
 
 	done          chan *nodeResult
 	triggerCounts map[TaskID]int
 }
 
+// runNode runs the node with the given ID. Skipped nodes and nodes restored from the checkpoint complete immediately.
+// Other nodes are queued and started by startReadyNodes according to the parallelism limits.
 func (e *execution) runNode(ctx context.Context, id TaskID) {
 	log := e.log.WithValues(logKeyTask, id)
 	taskStartDelay := e.flow.clock.Now().UTC().Sub(e.flow.start.UTC())
@@ -221,12 +284,68 @@ func (e *execution) runNode(ctx context.Context, id TaskID) {
 		return
 	}
 
+	if e.restoredTaskIDs.Has(id) {
+		log.V(1).Info("Succeeded in previous execution, not running again")
+		e.stats.Pending.Delete(id)
+		e.stats.Running.Insert(id)
+
+		go func() {
+			e.done <- &nodeResult{TaskID: id, Error: nil, restored: true, delay: taskStartDelay}
+		}()
+
+		return
+	}
+
+	e.ready = append(e.ready, id)
+}
+
+// startReadyNodes starts the queued nodes as long as the parallelism limits allow it. Nodes with a higher priority,
+// i.e., nodes on a longer remaining path, are started first.
+func (e *execution) startReadyNodes(ctx context.Context) {
+	slices.SortFunc(e.ready, func(a, b TaskID) int {
+		if c := cmp.Compare(e.flow.nodes[b].priority, e.flow.nodes[a].priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	var deferred []TaskID
+	for _, id := range e.ready {
+		if ctx.Err() != nil || !e.canStart(e.flow.nodes[id]) {
+			deferred = append(deferred, id)
+			continue
+		}
+		e.startNode(ctx, id)
+	}
+	e.ready = deferred
+}
+
+func (e *execution) canStart(n *node) bool {
+	if e.maxParallelism > 0 && e.stats.Running.Len() >= e.maxParallelism {
+		return false
+	}
+
+	if limit := e.concurrencyLimits[n.concurrencyGroup]; n.concurrencyGroup != "" && limit > 0 && e.runningPerGroup[n.concurrencyGroup] >= limit {
+		return false
+	}
+
+	return true
+}
+
+func (e *execution) startNode(ctx context.Context, id TaskID) {
+	log := e.log.WithValues(logKeyTask, id)
+	taskStartDelay := e.flow.clock.Now().UTC().Sub(e.flow.start.UTC())
+	node := e.flow.nodes[id]
+
 	if e.errorContext != nil {
 		e.errorContext.AddErrorID(string(id))
 	}
 
 	e.stats.Pending.Delete(id)
 	e.stats.Running.Insert(id)
+	if node.concurrencyGroup != "" {
+		e.runningPerGroup[node.concurrencyGroup]++
+	}
 
 	go func() {
 		start := e.flow.clock.Now().UTC()
@@ -247,11 +366,19 @@ func (e *execution) runNode(ctx context.Context, id TaskID) {
 }
 
 func (e *execution) updateSuccess(id TaskID) {
+	e.finishNode(id)
 	e.stats.Running.Delete(id)
 	e.stats.Succeeded.Insert(id)
 }
 
+func (e *execution) finishNode(id TaskID) {
+	if group := e.flow.nodes[id].concurrencyGroup; group != "" && !e.restoredTaskIDs.Has(id) {
+		e.runningPerGroup[group]--
+	}
+}
+
 func (e *execution) updateFailure(id TaskID) {
+	e.finishNode(id)
 	e.stats.Running.Delete(id)
 	e.stats.Failed.Insert(id)
 }
@@ -272,6 +399,67 @@ func (e *execution) cleanErrors(ctx context.Context, taskID TaskID) {
 	}
 }
 
+// restoreCheckpoint loads the checkpoint of the flow. The tasks which succeeded in the previous execution are not run
+// again if the checkpoint was created with the same spec hash. Otherwise, the checkpoint is discarded.
+func (e *execution) restoreCheckpoint(ctx context.Context) {
+	if e.checkpoint == nil {
+		return
+	}
+
+	checkpoint, err := e.checkpoint.Load(ctx, e.flow.name)
+	if err != nil {
+		e.log.Error(err, "Failed loading checkpoint, running all tasks")
+		return
+	}
+
+	if checkpoint == nil || checkpoint.SpecHash != e.specHash {
+		return
+	}
+
+	for _, id := range checkpoint.SucceededTasks {
+		if node, ok := e.flow.nodes[TaskID(id)]; ok && !node.skip {
+			e.restoredTaskIDs.Insert(TaskID(id))
+		}
+	}
+
+	if e.restoredTaskIDs.Len() > 0 {
+		e.log.Info("Resuming from checkpoint", "succeededTasks", e.restoredTaskIDs.Len())
+	}
+}
+
+// saveCheckpoint requests persisting the IDs of the tasks which succeeded so far. The checkpoint is saved
+// asynchronously, see checkpointWriter.
+func (e *execution) saveCheckpoint() {
+	if e.checkpointWriter == nil {
+		return
+	}
+
+	e.checkpointWriter.save(&Checkpoint{
+		SpecHash:       e.specHash,
+		SucceededTasks: e.stats.Succeeded.StringList(),
+	})
+}
+
+// finishCheckpoint stops saving checkpoints. If the flow has finished successfully, the checkpoint is removed so that
+// the next execution runs all tasks again. Otherwise, the latest checkpoint is saved.
+// An error is returned if the checkpoint cannot be removed since the next execution would skip the succeeded tasks
+// otherwise.
+func (e *execution) finishCheckpoint(ctx context.Context, succeeded bool) error {
+	if e.checkpointWriter == nil {
+		return nil
+	}
+
+	e.checkpointWriter.stop(!succeeded)
+	if !succeeded {
+		return nil
+	}
+
+	if err := e.checkpoint.Delete(ctx, e.flow.name); err != nil {
+		return fmt.Errorf("failed deleting checkpoint: %w", err)
+	}
+	return nil
+}
+
 func (e *execution) reportProgress(ctx context.Context) {
 	if e.progressReporter != nil {
 		e.progressReporter.Report(ctx, e.stats.Copy())
@@ -282,6 +470,11 @@ func (e *execution) run(ctx context.Context) error {
 	e.flow.start = e.flow.clock.Now()
 	defer close(e.done)
 
+	if e.trace != nil {
+		e.trace.begin(e.flow.name, e.flow.start.UTC())
+		defer func() { e.trace.finish(e.flow.clock.Now().UTC()) }()
+	}
+
 	if e.progressReporter != nil {
 		if err := e.progressReporter.Start(ctx); err != nil {
 			return err
@@ -290,6 +483,10 @@ func (e *execution) run(ctx context.Context) error {
 	}
 
 	e.log.Info("Starting")
+	e.restoreCheckpoint(ctx)
+	if e.checkpoint != nil {
+		e.checkpointWriter = newCheckpointWriter(ctx, e.checkpoint, e.flow.name, e.log)
+	}
 	e.reportProgress(ctx)
 
 	var (
@@ -301,12 +498,14 @@ func (e *execution) run(ctx context.Context) error {
 			e.runNode(ctx, name)
 		}
 	}
+	e.startReadyNodes(ctx)
 
 	e.reportProgress(ctx)
 
 	for e.stats.Running.Len() > 0 || e.stats.Skipped.Len() > 0 {
 		result := <-e.done
 		e.reportTaskMetrics(result)
+		e.recordTrace(result)
 		if result.skipped {
 			e.stats.Skipped.Delete(result.TaskID)
 			if cancelErr = ctx.Err(); cancelErr == nil {
@@ -318,6 +517,9 @@ func (e *execution) run(ctx context.Context) error {
 				e.updateFailure(result.TaskID)
 			} else {
 				e.updateSuccess(result.TaskID)
+				if !result.restored {
+					e.saveCheckpoint()
+				}
 				if e.errorContext != nil && e.errorContext.HasLastErrorWithID(string(result.TaskID)) {
 					e.cleanErrors(ctx, result.TaskID)
 				}
@@ -327,10 +529,19 @@ func (e *execution) run(ctx context.Context) error {
 			}
 		}
 
+		e.startReadyNodes(ctx)
 		e.reportProgress(ctx)
 	}
 
+	if len(e.ready) > 0 && cancelErr == nil {
+		// The context was canceled before the queued tasks could be started.
+		cancelErr = ctx.Err()
+	}
+
 	e.log.Info("Finished")
+	if err := e.finishCheckpoint(ctx, cancelErr == nil && len(e.taskErrors) == 0); err != nil {
+		e.taskErrors = append(e.taskErrors, err)
+	}
 	return e.result(cancelErr)
 }
 
@@ -356,10 +567,10 @@ func (e *execution) result(cancelErr error) error {
 func (e *execution) reportTaskMetrics(r *nodeResult) {
 	if flowTaskDelaySeconds != nil {
 		flowTaskDelaySeconds.
-			WithLabelValues(e.flow.name, string(r.TaskID), utils.IifString(r.skipped, "true", "false")).
+			WithLabelValues(e.flow.name, string(r.TaskID), utils.IifString(r.skipped || r.restored, "true", "false")).
 			Observe(r.delay.Seconds())
 	}
-	if flowTaskDurationSeconds != nil && !r.skipped {
+	if flowTaskDurationSeconds != nil && !r.skipped && !r.restored {
 		flowTaskDurationSeconds.WithLabelValues(e.flow.name, string(r.TaskID)).Observe(r.duration.Seconds())
 	}
 	if flowTaskResults != nil {
@@ -367,6 +578,23 @@ func (e *execution) reportTaskMetrics(r *nodeResult) {
 	}
 }
 
+func (e *execution) recordTrace(r *nodeResult) {
+	if e.trace == nil {
+		return
+	}
+
+	start := e.flow.start.UTC().Add(r.delay)
+	e.trace.record(TaskTrace{
+		TaskID:   r.TaskID,
+		Start:    start,
+		End:      start.Add(r.duration),
+		Delay:    r.delay,
+		Skipped:  r.skipped,
+		Restored: r.restored,
+		Error:    r.Error,
+	})
+}
+
 func (e *execution) reportFlowMetrics() {
 	if flowDurationSeconds != nil {
 		flowDurationSeconds.WithLabelValues(e.flow.name).Observe(e.flow.clock.Now().UTC().Sub(e.flow.start.UTC()).Seconds())
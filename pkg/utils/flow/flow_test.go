@@ -7,7 +7,9 @@ package flow_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -47,6 +49,35 @@ func (a *AtomicStringList) Values() []string {
 	return out
 }
 
+// blockingCheckpointStore blocks saving checkpoints until the release channel is closed.
+type blockingCheckpointStore struct {
+	flow.CheckpointStore
+	release chan struct{}
+}
+
+func (b *blockingCheckpointStore) Save(ctx context.Context, flowName string, checkpoint *flow.Checkpoint) error {
+	<-b.release
+	return b.CheckpointStore.Save(ctx, flowName, checkpoint)
+}
+
+// failingCheckpointStore fails saving checkpoints.
+type failingCheckpointStore struct {
+	flow.CheckpointStore
+}
+
+func (f *failingCheckpointStore) Save(context.Context, string, *flow.Checkpoint) error {
+	return errors.New("fake")
+}
+
+// undeletableCheckpointStore fails deleting checkpoints.
+type undeletableCheckpointStore struct {
+	flow.CheckpointStore
+}
+
+func (u *undeletableCheckpointStore) Delete(context.Context, string) error {
+	return errors.New("fake")
+}
+
 var _ = Describe("Flow", func() {
 	canceledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -169,6 +200,236 @@ var _ = Describe("Flow", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(flow.WasCanceled(err)).To(BeTrue())
 		})
+
+		Context("with parallelism limits", func() {
+			var (
+				lock             sync.Mutex
+				running, maxSeen map[string]int
+				mkConcurrentTask func(group string) flow.TaskFn
+			)
+
+			BeforeEach(func() {
+				running, maxSeen = map[string]int{}, map[string]int{}
+				mkConcurrentTask = func(group string) flow.TaskFn {
+					return func(_ context.Context) error {
+						lock.Lock()
+						running["total"]++
+						running[group]++
+						maxSeen["total"] = max(maxSeen["total"], running["total"])
+						maxSeen[group] = max(maxSeen[group], running[group])
+						lock.Unlock()
+
+						time.Sleep(10 * time.Millisecond)
+
+						lock.Lock()
+						running["total"]--
+						running[group]--
+						lock.Unlock()
+						return nil
+					}
+				}
+			})
+
+			It("should not run more tasks than the max parallelism concurrently", func() {
+				g := flow.NewGraph("foo")
+				for i := range 10 {
+					g.Add(flow.Task{Name: fmt.Sprintf("x%d", i), Fn: mkConcurrentTask("none")})
+				}
+
+				Expect(g.Compile().Run(ctx, flow.Opts{MaxParallelism: 3})).To(Succeed())
+				Expect(maxSeen["total"]).To(Equal(3))
+			})
+
+			It("should not run more tasks of a concurrency group than its limit concurrently", func() {
+				g := flow.NewGraph("foo")
+				for i := range 6 {
+					g.Add(flow.Task{Name: fmt.Sprintf("limited%d", i), Fn: mkConcurrentTask("limited"), ConcurrencyGroup: "limited"})
+					g.Add(flow.Task{Name: fmt.Sprintf("unlimited%d", i), Fn: mkConcurrentTask("unlimited"), ConcurrencyGroup: "unlimited"})
+				}
+
+				Expect(g.Compile().Run(ctx, flow.Opts{ConcurrencyLimits: map[string]int{"limited": 2}})).To(Succeed())
+				Expect(maxSeen["limited"]).To(Equal(2))
+				Expect(maxSeen["unlimited"]).To(Equal(6))
+			})
+
+			It("should start tasks on the longest remaining path first", func() {
+				var (
+					list           = NewAtomicStringList()
+					mkListAppender = func(value string) flow.TaskFn {
+						return func(_ context.Context) error {
+							list.Append(value)
+							return nil
+						}
+					}
+
+					g  = flow.NewGraph("foo")
+					_  = g.Add(flow.Task{Name: "a", Fn: mkListAppender("a")})
+					b1 = g.Add(flow.Task{Name: "b1", Fn: mkListAppender("b1")})
+					b2 = g.Add(flow.Task{Name: "b2", Fn: mkListAppender("b2"), Dependencies: flow.NewTaskIDs(b1)})
+					_  = g.Add(flow.Task{Name: "b3", Fn: mkListAppender("b3"), Dependencies: flow.NewTaskIDs(b2)})
+					c1 = g.Add(flow.Task{Name: "c1", Fn: mkListAppender("c1")})
+					_  = g.Add(flow.Task{Name: "c2", Fn: mkListAppender("c2"), Dependencies: flow.NewTaskIDs(c1)})
+				)
+
+				Expect(g.Compile().Run(ctx, flow.Opts{MaxParallelism: 1})).To(Succeed())
+				Expect(list.Values()).To(Equal([]string{"b1", "b2", "c1", "a", "b3", "c2"}))
+			})
+		})
+
+		Context("with checkpoint", func() {
+			var (
+				checkpoint flow.CheckpointStore
+				list       *AtomicStringList
+				failY      bool
+				f          *flow.Flow
+			)
+
+			BeforeEach(func() {
+				checkpoint = flow.NewInMemoryCheckpointStore()
+				list = NewAtomicStringList()
+				failY = true
+
+				var (
+					g = flow.NewGraph("foo")
+					x = g.Add(flow.Task{Name: "x", Fn: func(_ context.Context) error {
+						list.Append("x")
+						return nil
+					}})
+					y = g.Add(flow.Task{Name: "y", Fn: func(_ context.Context) error {
+						list.Append("y")
+						if failY {
+							return errors.New("err")
+						}
+						return nil
+					}, Dependencies: flow.NewTaskIDs(x)})
+					_ = g.Add(flow.Task{Name: "z", Fn: func(_ context.Context) error {
+						list.Append("z")
+						return nil
+					}, Dependencies: flow.NewTaskIDs(y)})
+				)
+				f = g.Compile()
+			})
+
+			It("should persist the succeeded tasks if the flow fails", func() {
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).NotTo(Succeed())
+
+				Expect(checkpoint.Load(ctx, "foo")).To(Equal(&flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"x"}}))
+				Expect(list.Values()).To(Equal([]string{"x", "y"}))
+			})
+
+			It("should not run succeeded tasks again if the spec hash is unchanged", func() {
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).NotTo(Succeed())
+
+				failY = false
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).To(Succeed())
+
+				Expect(list.Values()).To(Equal([]string{"x", "y", "y", "z"}))
+			})
+
+			It("should run all tasks again if the spec hash has changed", func() {
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).NotTo(Succeed())
+
+				failY = false
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "other-hash"})).To(Succeed())
+
+				Expect(list.Values()).To(Equal([]string{"x", "y", "x", "y", "z"}))
+			})
+
+			It("should report restored tasks as succeeded", func() {
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).NotTo(Succeed())
+
+				var lastStats *flow.Stats
+				failY = false
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash", ProgressReporter: flow.NewImmediateProgressReporter(func(_ context.Context, stats *flow.Stats) {
+					lastStats = stats
+				})})).To(Succeed())
+
+				Expect(lastStats.ProgressPercent()).To(Equal(int32(100)))
+			})
+
+			It("should delete the checkpoint once the flow has finished successfully", func() {
+				failY = false
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).To(Succeed())
+
+				Expect(checkpoint.Load(ctx, "foo")).To(BeNil())
+
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).To(Succeed())
+				Expect(list.Values()).To(Equal([]string{"x", "y", "z", "x", "y", "z"}))
+			})
+
+			It("should not block the flow while a checkpoint is saved", func() {
+				var (
+					release = make(chan struct{})
+					store   = &blockingCheckpointStore{CheckpointStore: checkpoint, release: release}
+
+					g = flow.NewGraph("bar")
+					a = g.Add(flow.Task{Name: "a", Fn: func(_ context.Context) error { return nil }})
+					_ = g.Add(flow.Task{Name: "b", Fn: func(_ context.Context) error {
+						// The checkpoint after task a can only be saved once this task is running.
+						close(release)
+						return errors.New("err")
+					}, Dependencies: flow.NewTaskIDs(a)})
+				)
+
+				Expect(g.Compile().Run(ctx, flow.Opts{Checkpoint: store, SpecHash: "hash"})).NotTo(Succeed())
+				Expect(checkpoint.Load(ctx, "bar")).To(Equal(&flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"a"}}))
+			})
+
+			It("should save the latest checkpoint once the flow has been canceled", func() {
+				cancelCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				var (
+					g = flow.NewGraph("bar")
+					a = g.Add(flow.Task{Name: "a", Fn: func(_ context.Context) error { return nil }})
+					b = g.Add(flow.Task{Name: "b", Fn: func(_ context.Context) error {
+						cancel()
+						return nil
+					}, Dependencies: flow.NewTaskIDs(a)})
+					_ = g.Add(flow.Task{Name: "c", Fn: func(_ context.Context) error { return nil }, Dependencies: flow.NewTaskIDs(b)})
+				)
+
+				Expect(g.Compile().Run(cancelCtx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).To(MatchError(ContainSubstring("canceled")))
+				Expect(checkpoint.Load(ctx, "bar")).To(Equal(&flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"a", "b"}}))
+			})
+
+			It("should not fail the flow if the checkpoint cannot be saved", func() {
+				failY = false
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: &failingCheckpointStore{CheckpointStore: checkpoint}, SpecHash: "hash"})).To(Succeed())
+				Expect(list.Values()).To(Equal([]string{"x", "y", "z"}))
+			})
+
+			It("should fail the flow if the checkpoint cannot be deleted", func() {
+				failY = false
+				Expect(checkpoint.Save(ctx, "foo", &flow.Checkpoint{SpecHash: "hash", SucceededTasks: []string{"x", "y"}})).To(Succeed())
+
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: &undeletableCheckpointStore{CheckpointStore: checkpoint}, SpecHash: "hash"})).To(MatchError(ContainSubstring("failed deleting checkpoint")))
+				Expect(checkpoint.Load(ctx, "foo")).NotTo(BeNil())
+
+				By("Retry deleting the checkpoint")
+				Expect(f.Run(ctx, flow.Opts{Checkpoint: checkpoint, SpecHash: "hash"})).To(Succeed())
+				Expect(checkpoint.Load(ctx, "foo")).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#DOT, #Mermaid", func() {
+		var graph *flow.Graph
+
+		BeforeEach(func() {
+			graph = flow.NewGraph("foo")
+			x := graph.Add(flow.Task{Name: "x"})
+			y := graph.Add(flow.Task{Name: "y", SkipIf: true})
+			graph.Add(flow.Task{Name: "z", Dependencies: flow.NewTaskIDs(x, y)})
+		})
+
+		It("should render the compiled flow in the DOT language like the graph", func() {
+			Expect(graph.Compile().DOT()).To(Equal(graph.DOT()))
+		})
+
+		It("should render the compiled flow as Mermaid flowchart like the graph", func() {
+			Expect(graph.Compile().Mermaid()).To(Equal(graph.Mermaid()))
+		})
 	})
 
 	Describe("#Sequential", func() {
@@ -17,6 +17,9 @@ type Task struct {
 	Fn           TaskFn
 	SkipIf       bool
 	Dependencies TaskIDs
+	// ConcurrencyGroup is the name of the group of tasks whose number of concurrently running tasks is limited by
+	// Opts.ConcurrencyLimits.
+	ConcurrencyGroup string
 }
 
 // Spec returns the TaskSpec of a task.
@@ -25,15 +28,17 @@ func (t *Task) Spec() *TaskSpec {
 		t.Fn,
 		t.SkipIf,
 		t.Dependencies.Copy(),
+		t.ConcurrencyGroup,
 	}
 }
 
 // TaskSpec is functional body of a Task, consisting only of the payload function and
 // the dependencies of the Task.
 type TaskSpec struct {
-	Fn           TaskFn
-	Skip         bool
-	Dependencies TaskIDs
+	Fn               TaskFn
+	Skip             bool
+	Dependencies     TaskIDs
+	ConcurrencyGroup string
 }
 
 // Tasks is a mapping from TaskID to TaskSpec.
@@ -92,6 +97,11 @@ func (g *Graph) Compile() *Flow {
 		node.fn = taskSpec.Fn
 		node.skip = taskSpec.Skip
 		node.required = taskSpec.Dependencies.Len()
+		node.concurrencyGroup = taskSpec.ConcurrencyGroup
+	}
+
+	for taskName := range nodes {
+		nodes.computePriority(taskName)
 	}
 
 	return &Flow{
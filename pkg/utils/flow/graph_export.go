@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DOT returns the graph in the DOT language of Graphviz. Tasks which are skipped are drawn with a dashed border.
+func (g *Graph) DOT() string {
+	return g.export().dot()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart. Tasks which are skipped are drawn with a dashed border.
+func (g *Graph) Mermaid() string {
+	return g.export().mermaid()
+}
+
+// DOT returns the compiled flow in the DOT language of Graphviz, see Graph.DOT.
+func (f *Flow) DOT() string {
+	return f.export().dot()
+}
+
+// Mermaid returns the compiled flow as a Mermaid flowchart, see Graph.Mermaid.
+func (f *Flow) Mermaid() string {
+	return f.export().mermaid()
+}
+
+// exportedGraph is the common representation of graphs and compiled flows for exporting them.
+type exportedGraph struct {
+	name string
+	// dependencies contains the dependencies of all tasks.
+	dependencies map[TaskID]TaskIDs
+	skipped      TaskIDs
+}
+
+func (g *Graph) export() *exportedGraph {
+	e := &exportedGraph{name: g.name, dependencies: make(map[TaskID]TaskIDs, len(g.tasks)), skipped: NewTaskIDs()}
+	for id, task := range g.tasks {
+		e.dependencies[id] = task.Dependencies
+		if task.Skip {
+			e.skipped.Insert(id)
+		}
+	}
+	return e
+}
+
+func (f *Flow) export() *exportedGraph {
+	e := &exportedGraph{name: f.name, dependencies: make(map[TaskID]TaskIDs, len(f.nodes)), skipped: NewTaskIDs()}
+	for id, node := range f.nodes {
+		if _, ok := e.dependencies[id]; !ok {
+			e.dependencies[id] = NewTaskIDs()
+		}
+		for targetID := range node.targetIDs {
+			if _, ok := e.dependencies[targetID]; !ok {
+				e.dependencies[targetID] = NewTaskIDs()
+			}
+			e.dependencies[targetID].Insert(id)
+		}
+		if node.skip {
+			e.skipped.Insert(id)
+		}
+	}
+	return e
+}
+
+func (e *exportedGraph) dot() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(e.name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	ids := e.sortedTaskIDs()
+	for _, id := range ids {
+		if e.skipped.Has(id) {
+			fmt.Fprintf(&b, "  %s [style=dashed];\n", strconv.Quote(string(id)))
+		} else {
+			fmt.Fprintf(&b, "  %s;\n", strconv.Quote(string(id)))
+		}
+	}
+
+	for _, id := range ids {
+		for _, dependencyID := range e.dependencies[id].List() {
+			fmt.Fprintf(&b, "  %s -> %s;\n", strconv.Quote(string(dependencyID)), strconv.Quote(string(id)))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaidEscaper replaces the characters which would break titles and labels of Mermaid flowcharts with entity codes.
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", `\`, "#92;", "\n", "#10;", "\r", "#13;")
+
+func (e *exportedGraph) mermaid() string {
+	var (
+		b          strings.Builder
+		ids        = e.sortedTaskIDs()
+		idToNode   = make(map[TaskID]string, len(ids))
+		anySkipped bool
+	)
+
+	fmt.Fprintf(&b, "---\ntitle: \"%s\"\n---\n", mermaidEscaper.Replace(e.name))
+	b.WriteString("flowchart LR\n")
+
+	for i, id := range ids {
+		idToNode[id] = fmt.Sprintf("t%d", i)
+
+		label := mermaidEscaper.Replace(string(id))
+		if e.skipped.Has(id) {
+			anySkipped = true
+			fmt.Fprintf(&b, "  %s[\"%s\"]:::skipped\n", idToNode[id], label)
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", idToNode[id], label)
+		}
+	}
+
+	for _, id := range ids {
+		for _, dependencyID := range e.dependencies[id].List() {
+			fmt.Fprintf(&b, "  %s --> %s\n", idToNode[dependencyID], idToNode[id])
+		}
+	}
+
+	if anySkipped {
+		b.WriteString("  classDef skipped stroke-dasharray: 5 5\n")
+	}
+
+	return b.String()
+}
+
+func (e *exportedGraph) sortedTaskIDs() []TaskID {
+	ids := make([]TaskID, 0, len(e.dependencies))
+	for id := range e.dependencies {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
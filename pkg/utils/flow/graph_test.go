@@ -28,4 +28,55 @@ var _ = Describe("Graph", func() {
 			}).To(Panic())
 		})
 	})
+
+	Describe("#DOT", func() {
+		It("should render the graph in the DOT language", func() {
+			graph := flow.NewGraph("foo")
+			x := graph.Add(flow.Task{Name: "x"})
+			y := graph.Add(flow.Task{Name: "y", SkipIf: true})
+			graph.Add(flow.Task{Name: `z "quoted"`, Dependencies: flow.NewTaskIDs(x, y)})
+
+			Expect(graph.DOT()).To(Equal(`digraph "foo" {
+  rankdir=LR;
+  node [shape=box];
+  "x";
+  "y" [style=dashed];
+  "z \"quoted\"";
+  "x" -> "z \"quoted\"";
+  "y" -> "z \"quoted\"";
+}
+`))
+		})
+	})
+
+	Describe("#Mermaid", func() {
+		It("should render the graph as Mermaid flowchart", func() {
+			graph := flow.NewGraph("foo")
+			x := graph.Add(flow.Task{Name: "x"})
+			y := graph.Add(flow.Task{Name: "y", SkipIf: true})
+			graph.Add(flow.Task{Name: `z "quoted"`, Dependencies: flow.NewTaskIDs(x, y)})
+
+			Expect(graph.Mermaid()).To(Equal(`---
+title: "foo"
+---
+flowchart LR
+  t0["x"]
+  t1["y"]:::skipped
+  t2["z #quot;quoted#quot;"]
+  t0 --> t2
+  t1 --> t2
+  classDef skipped stroke-dasharray: 5 5
+`))
+		})
+
+		It("should escape quotes and newlines in the title", func() {
+			graph := flow.NewGraph("foo \"bar\"\nbaz")
+
+			Expect(graph.Mermaid()).To(Equal(`---
+title: "foo #quot;bar#quot;#10;baz"
+---
+flowchart LR
+`))
+		})
+	})
 })
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TaskTrace is the trace of a single task of a flow execution.
+type TaskTrace struct {
+	// TaskID is the ID of the task.
+	TaskID TaskID
+	// Start is the time when the task was started.
+	Start time.Time
+	// End is the time when the task was finished.
+	End time.Time
+	// Delay is the duration between the start of the flow execution and the start of the task.
+	Delay time.Duration
+	// Skipped is true if the task was skipped.
+	Skipped bool
+	// Restored is true if the task was not run since it succeeded in a previous execution, see Opts.Checkpoint.
+	Restored bool
+	// Error is the error returned by the task, if any.
+	Error error
+}
+
+// Trace records the execution of a flow. It can be passed to Flow.Run via Opts.Trace.
+type Trace struct {
+	lock sync.RWMutex
+
+	flowName string
+	start    time.Time
+	end      time.Time
+	tasks    []TaskTrace
+}
+
+// NewTrace returns a new, empty Trace.
+func NewTrace() *Trace {
+	return &Trace{}
+}
+
+// FlowName returns the name of the traced flow.
+func (t *Trace) FlowName() string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.flowName
+}
+
+// Tasks returns the traces of the finished tasks ordered by their start time and ID.
+func (t *Trace) Tasks() []TaskTrace {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	tasks := slices.Clone(t.tasks)
+	slices.SortFunc(tasks, func(a, b TaskTrace) int {
+		if c := a.Start.Compare(b.Start); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.TaskID), string(b.TaskID))
+	})
+	return tasks
+}
+
+func (t *Trace) begin(flowName string, start time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.flowName = flowName
+	t.start = start
+	t.end = time.Time{}
+	t.tasks = nil
+}
+
+func (t *Trace) record(task TaskTrace) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.tasks = append(t.tasks, task)
+}
+
+func (t *Trace) finish(end time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.end = end
+}
+
+// chromeTraceEvent is a complete event of the Chrome trace event format, see
+// https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU.
+type chromeTraceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur"`
+	ProcessID int               `json:"pid"`
+	ThreadID  int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+type chromeTrace struct {
+	TraceEvents     []chromeTraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string             `json:"displayTimeUnit"`
+}
+
+// WriteChromeTraceEvents writes the trace in the Chrome trace event format to the given writer. The result can be
+// loaded into chrome://tracing or https://ui.perfetto.dev. The flow execution is shown in the first row, the tasks
+// are distributed to the following rows such that tasks running in parallel do not overlap.
+func (t *Trace) WriteChromeTraceEvents(w io.Writer) error {
+	var (
+		tasks  = t.Tasks()
+		events = make([]chromeTraceEvent, 0, len(tasks)+1)
+		// laneEnds contains the end time of the last task in each lane.
+		laneEnds []time.Time
+	)
+
+	t.lock.RLock()
+	flowName, start, end := t.flowName, t.start, t.end
+	t.lock.RUnlock()
+
+	if !end.IsZero() {
+		events = append(events, chromeTraceEvent{
+			Name:      flowName,
+			Category:  "flow",
+			Phase:     "X",
+			Timestamp: 0,
+			Duration:  end.Sub(start).Microseconds(),
+			ProcessID: 1,
+			ThreadID:  0,
+		})
+	}
+
+	for _, task := range tasks {
+		lane := slices.IndexFunc(laneEnds, func(laneEnd time.Time) bool { return !laneEnd.After(task.Start) })
+		if lane == -1 {
+			lane = len(laneEnds)
+			laneEnds = append(laneEnds, task.End)
+		} else {
+			laneEnds[lane] = task.End
+		}
+
+		args := map[string]string{"delay": task.Delay.String()}
+		switch {
+		case task.Skipped:
+			args["result"] = "skipped"
+		case task.Restored:
+			args["result"] = "restored"
+		case task.Error != nil:
+			args["result"] = "error"
+			args["error"] = task.Error.Error()
+		default:
+			args["result"] = "success"
+		}
+
+		events = append(events, chromeTraceEvent{
+			Name:      string(task.TaskID),
+			Category:  "task",
+			Phase:     "X",
+			Timestamp: task.Start.Sub(start).Microseconds(),
+			Duration:  task.End.Sub(task.Start).Microseconds(),
+			ProcessID: 1,
+			ThreadID:  lane + 1,
+			Args:      args,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(chromeTrace{TraceEvents: events, DisplayTimeUnit: "ms"})
+}
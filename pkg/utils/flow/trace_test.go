@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package flow_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/gardener/gardener/pkg/utils/flow"
+)
+
+var _ = Describe("Trace", func() {
+	var (
+		ctx       = context.Background()
+		fakeClock *testclock.FakeClock
+		start     time.Time
+		trace     *flow.Trace
+		f         *flow.Flow
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		fakeClock = testclock.NewFakeClock(start)
+		trace = flow.NewTrace()
+
+		g := flow.NewGraph("foo")
+		g.Clock = fakeClock
+		x := g.Add(flow.Task{Name: "x", Fn: func(_ context.Context) error {
+			fakeClock.Step(time.Second)
+			return nil
+		}})
+		_ = g.Add(flow.Task{Name: "y", SkipIf: true, Dependencies: flow.NewTaskIDs(x)})
+		_ = g.Add(flow.Task{Name: "z", Fn: func(_ context.Context) error {
+			fakeClock.Step(2 * time.Second)
+			return errors.New("err")
+		}, Dependencies: flow.NewTaskIDs(x)})
+		f = g.Compile()
+	})
+
+	It("should record the execution of the tasks", func() {
+		Expect(f.Run(ctx, flow.Opts{Trace: trace})).NotTo(Succeed())
+
+		Expect(trace.FlowName()).To(Equal("foo"))
+
+		tasks := trace.Tasks()
+		Expect(tasks).To(HaveLen(3))
+
+		Expect(tasks[0]).To(Equal(flow.TaskTrace{TaskID: "x", Start: start, End: start.Add(time.Second)}))
+		Expect(tasks[1:]).To(HaveExactElements(
+			flow.TaskTrace{TaskID: "y", Start: start.Add(time.Second), End: start.Add(time.Second), Delay: time.Second, Skipped: true},
+			MatchFields(IgnoreExtras, Fields{
+				"TaskID": Equal(flow.TaskID("z")),
+				"Start":  Equal(start.Add(time.Second)),
+				"End":    Equal(start.Add(3 * time.Second)),
+				"Delay":  Equal(time.Second),
+				"Error":  MatchError(ContainSubstring("err")),
+			}),
+		))
+	})
+
+	It("should write the trace in the Chrome trace event format", func() {
+		Expect(f.Run(ctx, flow.Opts{Trace: trace})).NotTo(Succeed())
+
+		var buf bytes.Buffer
+		Expect(trace.WriteChromeTraceEvents(&buf)).To(Succeed())
+
+		Expect(buf.String()).To(MatchJSON(`{
+  "displayTimeUnit": "ms",
+  "traceEvents": [
+    {"name": "foo", "cat": "flow", "ph": "X", "ts": 0, "dur": 3000000, "pid": 1, "tid": 0},
+    {"name": "x", "cat": "task", "ph": "X", "ts": 0, "dur": 1000000, "pid": 1, "tid": 1, "args": {"delay": "0s", "result": "success"}},
+    {"name": "y", "cat": "task", "ph": "X", "ts": 1000000, "dur": 0, "pid": 1, "tid": 1, "args": {"delay": "1s", "result": "skipped"}},
+    {"name": "z", "cat": "task", "ph": "X", "ts": 1000000, "dur": 2000000, "pid": 1, "tid": 1, "args": {"delay": "1s", "result": "error", "error": "task \"z\" failed: err"}}
+  ]
+}`))
+	})
+})
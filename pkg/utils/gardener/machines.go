@@ -7,6 +7,7 @@ package gardener
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -167,3 +168,14 @@ func WaitUntilMachineResourcesDeleted(ctx context.Context, log logr.Logger, read
 func NodeAgentLeaseName(nodeName string) string {
 	return NodeLeasePrefix + nodeName
 }
+
+// NodeAgentRebootLeasePrefix returns the prefix of the Lease objects used by gardener-node-agent for coordinating the
+// reboots of the nodes of the given worker pool.
+func NodeAgentRebootLeasePrefix(workerPoolName string) string {
+	return NodeLeasePrefix + "reboot-" + workerPoolName + "-"
+}
+
+// NodeAgentRebootLeaseName returns the name of the Lease object for the given reboot slot of the given worker pool.
+func NodeAgentRebootLeaseName(workerPoolName string, slot int32) string {
+	return NodeAgentRebootLeasePrefix(workerPoolName) + strconv.Itoa(int(slot))
+}
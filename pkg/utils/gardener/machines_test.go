@@ -173,4 +173,11 @@ var _ = Describe("Machines", func() {
 			Expect(WaitUntilMachineResourcesDeleted(ctx, log, fakeClient, namespace)).To(MatchError(ContainSubstring("waiting until the following machine resources have been deleted: 0 machines, 0 machine sets, 0 machine deployments, 0 machine classes, 1 machine class secrets")))
 		})
 	})
+
+	Describe("#NodeAgentRebootLeaseName", func() {
+		It("should return the name of the reboot lease", func() {
+			Expect(NodeAgentRebootLeasePrefix("worker-a")).To(Equal("gardener-node-agent-reboot-worker-a-"))
+			Expect(NodeAgentRebootLeaseName("worker-a", 2)).To(Equal("gardener-node-agent-reboot-worker-a-2"))
+		})
+	})
 })
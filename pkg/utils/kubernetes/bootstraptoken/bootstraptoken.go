@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	bootstraptokenapi "k8s.io/cluster-bootstrap/token/api"
 	bootstraptokenutil "k8s.io/cluster-bootstrap/token/util"
+	bootstraptokentokens "k8s.io/cluster-bootstrap/util/tokens"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/gardener/pkg/controllerutils"
@@ -48,14 +49,7 @@ func ComputeBootstrapToken(ctx context.Context, c client.Client, tokenID, descri
 		}
 	}
 
-	data := map[string][]byte{
-		bootstraptokenapi.BootstrapTokenDescriptionKey:      []byte(description),
-		bootstraptokenapi.BootstrapTokenIDKey:               []byte(tokenID),
-		bootstraptokenapi.BootstrapTokenSecretKey:           []byte(bootstrapTokenSecretKey),
-		bootstraptokenapi.BootstrapTokenExpirationKey:       []byte(metav1.Now().Add(validity).Format(time.RFC3339)),
-		bootstraptokenapi.BootstrapTokenUsageAuthentication: []byte("true"),
-		bootstraptokenapi.BootstrapTokenUsageSigningKey:     []byte("true"),
-	}
+	data := secretData(tokenID, bootstrapTokenSecretKey, description, validity)
 
 	_, err2 := controllerutils.GetAndCreateOrMergePatch(ctx, c, secret, func() error {
 		secret.Type = bootstraptokenapi.SecretTypeBootstrapToken
@@ -66,6 +60,37 @@ func ComputeBootstrapToken(ctx context.Context, c client.Client, tokenID, descri
 	return secret, err2
 }
 
+// CreateBootstrapToken creates a new bootstrap token secret for the given token (of the form
+// "[a-z0-9]{6}.[a-z0-9]{16}"), and returns it. It fails if a bootstrap token with the same ID already exists.
+func CreateBootstrapToken(ctx context.Context, c client.Client, token, description string, validity time.Duration) (*corev1.Secret, error) {
+	tokenID, tokenSecret, err := bootstraptokentokens.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bootstraptokenutil.BootstrapTokenSecretName(tokenID),
+			Namespace: metav1.NamespaceSystem,
+		},
+		Type: bootstraptokenapi.SecretTypeBootstrapToken,
+		Data: secretData(tokenID, tokenSecret, description, validity),
+	}
+
+	return secret, c.Create(ctx, secret)
+}
+
+func secretData(tokenID, tokenSecret, description string, validity time.Duration) map[string][]byte {
+	return map[string][]byte{
+		bootstraptokenapi.BootstrapTokenDescriptionKey:      []byte(description),
+		bootstraptokenapi.BootstrapTokenIDKey:               []byte(tokenID),
+		bootstraptokenapi.BootstrapTokenSecretKey:           []byte(tokenSecret),
+		bootstraptokenapi.BootstrapTokenExpirationKey:       []byte(metav1.Now().Add(validity).Format(time.RFC3339)),
+		bootstraptokenapi.BootstrapTokenUsageAuthentication: []byte("true"),
+		bootstraptokenapi.BootstrapTokenUsageSigningKey:     []byte("true"),
+	}
+}
+
 // FromSecretData returns the bootstrap token based on the secret data.
 func FromSecretData(data map[string][]byte) string {
 	return bootstraptokenutil.TokenFromIDAndSecret(string(data[bootstraptokenapi.BootstrapTokenIDKey]), string(data[bootstraptokenapi.BootstrapTokenSecretKey]))
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstraptoken_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstraptokenapi "k8s.io/cluster-bootstrap/token/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/gardener/gardener/pkg/utils/kubernetes/bootstraptoken"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+)
+
+var _ = Describe("BootstrapToken", func() {
+	var (
+		ctx        = context.Background()
+		fakeClient client.Client
+
+		description = "some description"
+		validity    = time.Hour
+	)
+
+	BeforeEach(func() {
+		fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).Build()
+	})
+
+	expectSecretData := func(data map[string][]byte, tokenID string) {
+		GinkgoHelper()
+
+		Expect(data).To(HaveKeyWithValue(bootstraptokenapi.BootstrapTokenIDKey, []byte(tokenID)))
+		Expect(string(data[bootstraptokenapi.BootstrapTokenSecretKey])).To(MatchRegexp(`^[a-z0-9]{16}$`))
+		Expect(data).To(HaveKeyWithValue(bootstraptokenapi.BootstrapTokenDescriptionKey, []byte(description)))
+		Expect(data).To(HaveKeyWithValue(bootstraptokenapi.BootstrapTokenUsageAuthentication, []byte("true")))
+		Expect(data).To(HaveKeyWithValue(bootstraptokenapi.BootstrapTokenUsageSigningKey, []byte("true")))
+		Expect(data).NotTo(HaveKey(bootstraptokenapi.BootstrapTokenExtraGroupsKey))
+
+		expiration, err := time.Parse(time.RFC3339, string(data[bootstraptokenapi.BootstrapTokenExpirationKey]))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expiration).To(BeTemporally("~", time.Now().Add(validity), time.Minute))
+	}
+
+	Describe("#ComputeBootstrapToken", func() {
+		It("should create a new bootstrap token secret", func() {
+			secret, err := ComputeBootstrapToken(ctx, fakeClient, "abcdef", description, validity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			Expect(secret.Name).To(Equal("bootstrap-token-abcdef"))
+			Expect(secret.Namespace).To(Equal(metav1.NamespaceSystem))
+			Expect(secret.Type).To(Equal(bootstraptokenapi.SecretTypeBootstrapToken))
+			expectSecretData(secret.Data, "abcdef")
+		})
+
+		It("should keep the token secret of an existing bootstrap token secret and renew its expiration", func() {
+			existingSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-abcdef", Namespace: metav1.NamespaceSystem},
+				Type:       bootstraptokenapi.SecretTypeBootstrapToken,
+				Data: map[string][]byte{
+					bootstraptokenapi.BootstrapTokenIDKey:         []byte("abcdef"),
+					bootstraptokenapi.BootstrapTokenSecretKey:     []byte("0123456789abcdef"),
+					bootstraptokenapi.BootstrapTokenExpirationKey: []byte(time.Now().Add(time.Minute).Format(time.RFC3339)),
+				},
+			}
+			Expect(fakeClient.Create(ctx, existingSecret)).To(Succeed())
+
+			secret, err := ComputeBootstrapToken(ctx, fakeClient, "abcdef", description, validity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue(bootstraptokenapi.BootstrapTokenSecretKey, []byte("0123456789abcdef")))
+			expectSecretData(secret.Data, "abcdef")
+			Expect(FromSecretData(secret.Data)).To(Equal("abcdef.0123456789abcdef"))
+		})
+
+		It("should generate a new token secret if the existing one is invalid", func() {
+			existingSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-abcdef", Namespace: metav1.NamespaceSystem},
+				Type:       bootstraptokenapi.SecretTypeBootstrapToken,
+				Data:       map[string][]byte{bootstraptokenapi.BootstrapTokenSecretKey: []byte("invalid")},
+			}
+			Expect(fakeClient.Create(ctx, existingSecret)).To(Succeed())
+
+			secret, err := ComputeBootstrapToken(ctx, fakeClient, "abcdef", description, validity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(secret.Data[bootstraptokenapi.BootstrapTokenSecretKey]).NotTo(Equal([]byte("invalid")))
+			expectSecretData(secret.Data, "abcdef")
+		})
+	})
+
+	Describe("#CreateBootstrapToken", func() {
+		It("should create a bootstrap token secret for the given token", func() {
+			secret, err := CreateBootstrapToken(ctx, fakeClient, "abcdef.0123456789abcdef", description, validity)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			Expect(secret.Name).To(Equal("bootstrap-token-abcdef"))
+			Expect(secret.Namespace).To(Equal(metav1.NamespaceSystem))
+			Expect(secret.Type).To(Equal(bootstraptokenapi.SecretTypeBootstrapToken))
+			expectSecretData(secret.Data, "abcdef")
+			Expect(FromSecretData(secret.Data)).To(Equal("abcdef.0123456789abcdef"))
+		})
+
+		DescribeTable("should fail for tokens with an invalid format",
+			func(token string) {
+				_, err := CreateBootstrapToken(ctx, fakeClient, token, description, validity)
+				Expect(err).To(HaveOccurred())
+			},
+
+			Entry("missing separator", "abcdef0123456789abcdef"),
+			Entry("token ID too short", "abcde.0123456789abcdef"),
+			Entry("token secret too short", "abcdef.0123456789abcde"),
+			Entry("upper case characters", "ABCDEF.0123456789abcdef"),
+		)
+
+		It("should fail if a bootstrap token secret with the same ID already exists", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-abcdef", Namespace: metav1.NamespaceSystem}})).To(Succeed())
+
+			_, err := CreateBootstrapToken(ctx, fakeClient, "abcdef.0123456789abcdef", description, validity)
+			Expect(err).To(BeAlreadyExistsError())
+
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "bootstrap-token-abcdef", Namespace: metav1.NamespaceSystem}, secret)).To(Succeed())
+			Expect(secret.Data).To(BeEmpty())
+		})
+	})
+})
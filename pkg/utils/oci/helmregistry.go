@@ -44,6 +44,10 @@ func NewHelmRegistry() (*HelmRegistry, error) {
 
 // Pull from the repository and return the compressed archive.
 func (r *HelmRegistry) Pull(ctx context.Context, oci *gardencorev1.OCIRepository) ([]byte, error) {
+	return pull(ctx, r.cache, oci, extractHelmLayer)
+}
+
+func pull(ctx context.Context, cache cacher, oci *gardencorev1.OCIRepository, extract func(gcrv1.Image) ([]byte, error)) ([]byte, error) {
 	ref, err := buildRef(oci)
 	if err != nil {
 		return nil, err
@@ -57,7 +61,7 @@ func (r *HelmRegistry) Pull(ctx context.Context, oci *gardencorev1.OCIRepository
 		return nil, err
 	}
 	if key != "" {
-		if blob, found := r.cache.Get(key); found {
+		if blob, found := cache.Get(key); found {
 			return blob, nil
 		}
 	}
@@ -66,7 +70,7 @@ func (r *HelmRegistry) Pull(ctx context.Context, oci *gardencorev1.OCIRepository
 	if err != nil {
 		return nil, fmt.Errorf("failed to pull artifact %s: %w", ref, err)
 	}
-	blob, err := extractHelmLayer(img)
+	blob, err := extract(img)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +81,7 @@ func (r *HelmRegistry) Pull(ctx context.Context, oci *gardencorev1.OCIRepository
 		return nil, err
 	}
 	key = ref.Context().Digest(digest.String()).Name()
-	r.cache.Set(key, blob)
+	cache.Set(key, blob)
 
 	return blob, nil
 }
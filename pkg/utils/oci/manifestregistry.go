@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
+)
+
+// MediaTypeManifests is the media type of artifact layers containing Kubernetes manifests in YAML or JSON format.
+const MediaTypeManifests = "application/vnd.gardener.cloud.manifests.v1+yaml"
+
+var defaultManifestCache = newCache()
+
+// ManifestRegistry can pull OCI artifacts containing Kubernetes manifests.
+type ManifestRegistry struct {
+	cache cacher
+}
+
+// NewManifestRegistry creates a new ManifestRegistry.
+func NewManifestRegistry() (*ManifestRegistry, error) {
+	return &ManifestRegistry{
+		cache: defaultManifestCache,
+	}, nil
+}
+
+// Pull from the repository and return the content of all manifest layers of the artifact as multi-document YAML.
+func (r *ManifestRegistry) Pull(ctx context.Context, oci *gardencorev1.OCIRepository) ([]byte, error) {
+	return pull(ctx, r.cache, oci, extractManifestLayers)
+}
+
+func extractManifestLayers(image gcrv1.Image) ([]byte, error) {
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse layers: %w", err)
+	}
+
+	var (
+		out   bytes.Buffer
+		found bool
+	)
+
+	for _, l := range layers {
+		mt, err := l.MediaType()
+		if err != nil {
+			return nil, err
+		}
+		if string(mt) != MediaTypeManifests {
+			continue
+		}
+
+		blob, err := l.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract layer from artifact: %w", err)
+		}
+		raw, err := io.ReadAll(blob)
+		_ = blob.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read content of manifest layer: %w", err)
+		}
+
+		if found {
+			out.WriteString("\n---\n")
+		}
+		out.Write(raw)
+		found = true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no manifest layer found in artifact")
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
+)
+
+var _ = Describe("manifestregistry", func() {
+	var (
+		mr  *ManifestRegistry
+		rc  *recordingCache
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		rc = &recordingCache{cache: newCache()}
+		mr = &ManifestRegistry{cache: rc}
+	})
+
+	It("should return the content of all manifest layers", func() {
+		out, err := mr.Pull(ctx, &gardencorev1.OCIRepository{
+			Repository: ptr.To(registryAddress + "/manifests/example"),
+			Digest:     ptr.To(exampleManifestsDigest),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal(exampleConfigMap("foo") + "\n---\n" + exampleConfigMap("bar")))
+	})
+
+	It("should use the cache", func() {
+		oci := &gardencorev1.OCIRepository{
+			Repository: ptr.To(registryAddress + "/manifests/example"),
+			Digest:     ptr.To(exampleManifestsDigest),
+		}
+		_, err := mr.Pull(ctx, oci)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rc.cacheHits).To(Equal(0))
+
+		_, err = mr.Pull(ctx, oci)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rc.cacheHits).To(Equal(1))
+	})
+
+	It("should return an error if the artifact does not contain manifest layers", func() {
+		_, err := mr.Pull(ctx, &gardencorev1.OCIRepository{
+			Repository: ptr.To(registryAddress + "/charts/example"),
+			Digest:     ptr.To(exampleChartDigest),
+		})
+		Expect(err).To(MatchError("no manifest layer found in artifact"))
+	})
+})
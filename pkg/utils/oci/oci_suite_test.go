@@ -12,6 +12,11 @@ import (
 
 	"github.com/distribution/distribution/v3/configuration"
 	"github.com/distribution/distribution/v3/registry"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	helmregistry "helm.sh/helm/v3/pkg/registry"
@@ -25,9 +30,10 @@ func TestOCI(t *testing.T) {
 }
 
 var (
-	registryAddress    string
-	exampleChartDigest string
-	rawChart           []byte
+	registryAddress        string
+	exampleChartDigest     string
+	rawChart               []byte
+	exampleManifestsDigest string
 )
 
 var _ = BeforeSuite(func() {
@@ -46,8 +52,30 @@ var _ = BeforeSuite(func() {
 	res, err := c.Push(rawChart, fmt.Sprintf("%s/charts/example:0.1.0", registryAddress))
 	Expect(err).NotTo(HaveOccurred())
 	exampleChartDigest = res.Manifest.Digest
+
+	img, err := mutate.Append(empty.Image,
+		mutate.Addendum{Layer: static.NewLayer([]byte(exampleConfigMap("foo")), MediaTypeManifests)},
+		mutate.Addendum{Layer: static.NewLayer([]byte("some-readme"), "text/markdown")},
+		mutate.Addendum{Layer: static.NewLayer([]byte(exampleConfigMap("bar")), MediaTypeManifests)},
+	)
+	Expect(err).NotTo(HaveOccurred())
+	ref, err := name.ParseReference(registryAddress + "/manifests/example:0.1.0")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(remote.Write(ref, img, remote.WithContext(ctx))).To(Succeed())
+	digest, err := img.Digest()
+	Expect(err).NotTo(HaveOccurred())
+	exampleManifestsDigest = digest.String()
 })
 
+func exampleConfigMap(name string) string {
+	return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ` + name + `
+  namespace: default
+`
+}
+
 func startTestRegistry(ctx context.Context) (string, error) {
 	config := &configuration.Configuration{}
 	config.Storage = map[string]configuration.Parameters{"inmemory": map[string]interface{}{}}
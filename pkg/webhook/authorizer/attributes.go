@@ -22,7 +22,12 @@ Modifications Copyright 2024 SAP SE or an SAP affiliate company and Gardener con
 package authorizer
 
 import (
+	"fmt"
+
 	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apiserver/pkg/authentication/user"
 	auth "k8s.io/apiserver/pkg/authorization/authorizer"
 )
@@ -30,7 +35,7 @@ import (
 // ResourceAttributesFrom combines the API object information and the user.Info from the context to build a full
 // auth.AttributesRecord for resource access.
 func ResourceAttributesFrom(user user.Info, in authorizationv1.ResourceAttributes) auth.AttributesRecord {
-	return auth.AttributesRecord{
+	ret := auth.AttributesRecord{
 		User:            user,
 		Verb:            in.Verb,
 		Namespace:       in.Namespace,
@@ -41,6 +46,42 @@ func ResourceAttributesFrom(user user.Info, in authorizationv1.ResourceAttribute
 		Name:            in.Name,
 		ResourceRequest: true,
 	}
+
+	if in.FieldSelector != nil {
+		ret.FieldSelectorRequirements, ret.FieldSelectorParsingErr = fieldSelectorRequirementsFrom(*in.FieldSelector)
+	}
+
+	return ret
+}
+
+// fieldSelectorRequirementsFrom converts the field selector of a SubjectAccessReview to field requirements. Only the
+// operators which can be expressed by field selectors are supported, i.e., 'In' and 'NotIn' with exactly one value.
+func fieldSelectorRequirementsFrom(in authorizationv1.FieldSelectorAttributes) (fields.Requirements, error) {
+	if len(in.RawSelector) > 0 {
+		selector, err := fields.ParseSelector(in.RawSelector)
+		if err != nil {
+			return nil, err
+		}
+		return selector.Requirements(), nil
+	}
+
+	var requirements fields.Requirements
+	for _, requirement := range in.Requirements {
+		if len(requirement.Values) != 1 {
+			return nil, fmt.Errorf("fieldSelectors in %q and %q operations must have exactly one value", metav1.FieldSelectorOpIn, metav1.FieldSelectorOpNotIn)
+		}
+
+		switch requirement.Operator {
+		case metav1.FieldSelectorOpIn:
+			requirements = append(requirements, fields.Requirement{Operator: selection.Equals, Field: requirement.Key, Value: requirement.Values[0]})
+		case metav1.FieldSelectorOpNotIn:
+			requirements = append(requirements, fields.Requirement{Operator: selection.NotEquals, Field: requirement.Key, Value: requirement.Values[0]})
+		default:
+			return nil, fmt.Errorf("fieldSelector operator %q is not supported", requirement.Operator)
+		}
+	}
+
+	return requirements, nil
 }
 
 // NonResourceAttributesFrom combines the API object information and the user.Info from the context to build a full
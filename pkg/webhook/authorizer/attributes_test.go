@@ -8,6 +8,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/selection"
 	userpkg "k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 
@@ -98,6 +101,42 @@ var _ = Describe("Attributes", func() {
 			Expect(result).To(Equal(expectedResourceAttributesRecord))
 			expectUserToBeCorrect(result.User)
 		})
+
+		It("should return the expected attributes record (raw field selector)", func() {
+			resourceAttributes.FieldSelector = &authorizationv1.FieldSelectorAttributes{RawSelector: "spec.nodeName=foo,metadata.name!=bar"}
+
+			result := ResourceAttributesFrom(user, resourceAttributes)
+
+			Expect(result.GetFieldSelector()).To(ConsistOf(
+				fields.Requirement{Operator: selection.Equals, Field: "spec.nodeName", Value: "foo"},
+				fields.Requirement{Operator: selection.NotEquals, Field: "metadata.name", Value: "bar"},
+			))
+		})
+
+		It("should return the expected attributes record (field selector requirements)", func() {
+			resourceAttributes.FieldSelector = &authorizationv1.FieldSelectorAttributes{Requirements: []metav1.FieldSelectorRequirement{
+				{Key: "spec.nodeName", Operator: metav1.FieldSelectorOpIn, Values: []string{"foo"}},
+				{Key: "metadata.name", Operator: metav1.FieldSelectorOpNotIn, Values: []string{"bar"}},
+			}}
+
+			result := ResourceAttributesFrom(user, resourceAttributes)
+
+			Expect(result.GetFieldSelector()).To(ConsistOf(
+				fields.Requirement{Operator: selection.Equals, Field: "spec.nodeName", Value: "foo"},
+				fields.Requirement{Operator: selection.NotEquals, Field: "metadata.name", Value: "bar"},
+			))
+		})
+
+		It("should return a parsing error for unsupported field selector requirements", func() {
+			resourceAttributes.FieldSelector = &authorizationv1.FieldSelectorAttributes{Requirements: []metav1.FieldSelectorRequirement{
+				{Key: "spec.nodeName", Operator: metav1.FieldSelectorOpExists},
+			}}
+
+			result := ResourceAttributesFrom(user, resourceAttributes)
+
+			_, err := result.GetFieldSelector()
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("#NonResourceAttributesFrom", func() {
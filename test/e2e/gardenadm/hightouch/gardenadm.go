@@ -7,6 +7,7 @@ package hightouch
 import (
 	"context"
 	"io"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -14,12 +15,18 @@ import (
 	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/gardenadm/botanist"
 	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
 	. "github.com/gardener/gardener/test/e2e/gardenadm/common"
 )
 
@@ -45,29 +52,147 @@ var _ = Describe("gardenadm high-touch scenario tests", Label("gardenadm", "high
 
 	Describe("Single-node control plane", Ordered, Label("single"), func() {
 		It("should initialize as control plane node", func(ctx SpecContext) {
-			stdOut, _ := execute(ctx, 0,
-				"gardenadm", "init",
+			stdOut, _, err := execute(ctx, 0,
+				"gardenadm", "init", "--config-dir", configDir,
 			)
+			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(ctx, stdOut).Should(gbytes.Say("not implemented"))
-		}, SpecTimeout(time.Minute))
+			Eventually(ctx, stdOut).Should(gbytes.Say("Your autonomous shoot cluster control plane has initialized successfully!"))
+
+			By("Ensure operating system config of worker pool is available for joining nodes")
+			stdOut, _, err = execute(ctx, 0,
+				"kubectl", "--kubeconfig", botanist.PathAdminKubeconfig, "-n", "kube-system", "get", "secrets",
+				"-l", "gardener.cloud/role=operating-system-config,worker.gardener.cloud/pool=worker", "-o", "name",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdOut).To(gbytes.Say("secret/gardener-node-agent-worker"))
+		}, SpecTimeout(10*time.Minute))
 
 		It("should join as worker node", func(ctx SpecContext) {
-			stdOut, _ := execute(ctx, 1,
+			By("Determine address of control plane machine")
+			controlPlaneMachine := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: machinePodName(0), Namespace: namespace}}
+			Expect(RuntimeClient.Client().Get(ctx, client.ObjectKeyFromObject(controlPlaneMachine), controlPlaneMachine)).To(Succeed())
+			Expect(controlPlaneMachine.Status.PodIP).NotTo(BeEmpty())
+			controlPlaneAddress := "https://" + controlPlaneMachine.Status.PodIP
+
+			By("Create bootstrap token and print join command")
+			stdOut, _, err := execute(ctx, 0,
+				"gardenadm", "token", "create", "--print-join-command",
+				"--kubeconfig", botanist.PathAdminKubeconfig,
+				"--worker-pool-name", "worker",
+				"--control-plane-address", controlPlaneAddress,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			joinCommand := strings.Fields(string(stdOut.Contents()))
+			Expect(joinCommand).To(HaveExactElements(
 				"gardenadm", "join",
+				"--bootstrap-token", MatchRegexp(`^[a-z0-9]{6}\.[a-z0-9]{16}$`),
+				"--ca-certificate-hash", MatchRegexp(`^sha256:[0-9a-f]{64}$`),
+				"--gardener-node-agent-secret-name", "gardener-node-agent-worker",
+				controlPlaneAddress,
+			))
+
+			By("Join worker node")
+			var (
+				joinStdOut *gbytes.Buffer
+				joinErr    error
+				joined     = make(chan struct{})
+			)
+
+			go func() {
+				defer GinkgoRecover()
+				defer close(joined)
+				joinStdOut, _, joinErr = execute(ctx, 1, joinCommand...)
+			}()
+
+			By("Approve certificate signing request of gardener-node-agent")
+			Eventually(ctx, func(g Gomega) {
+				stdOut, _, err := execute(ctx, 0,
+					"kubectl", "--kubeconfig", botanist.PathAdminKubeconfig, "get", "certificatesigningrequests", "-o", "name",
+				)
+				g.Expect(err).NotTo(HaveOccurred())
+
+				var csrNames []string
+				for _, name := range strings.Fields(string(stdOut.Contents())) {
+					if strings.HasPrefix(name, "certificatesigningrequest.certificates.k8s.io/node-agent-csr-") {
+						csrNames = append(csrNames, name)
+					}
+				}
+				g.Expect(csrNames).NotTo(BeEmpty())
+
+				_, _, err = execute(ctx, 0,
+					append([]string{"kubectl", "--kubeconfig", botanist.PathAdminKubeconfig, "certificate", "approve"}, csrNames...)...,
+				)
+				g.Expect(err).NotTo(HaveOccurred())
+			}).Should(Succeed())
+
+			Eventually(ctx, joined).Should(BeClosed())
+			Expect(joinErr).NotTo(HaveOccurred())
+			Expect(joinStdOut).To(gbytes.Say("This node has joined the cluster!"))
+
+			By("Wait for worker node to register")
+			Eventually(ctx, func(g Gomega) {
+				stdOut, _, err := execute(ctx, 0,
+					"kubectl", "--kubeconfig", botanist.PathAdminKubeconfig, "get", "nodes", "-o", "name",
+				)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(strings.Fields(string(stdOut.Contents()))).To(ContainElement("node/" + machinePodName(1)))
+			}).Should(Succeed())
+		}, SpecTimeout(10*time.Minute))
+
+		It("should connect to the garden cluster", func(ctx SpecContext) {
+			gardenClient, err := kubernetes.NewWithConfig(
+				kubernetes.WithRESTConfig(RuntimeClient.RESTConfig()),
+				kubernetes.WithClientOptions(client.Options{Scheme: kubernetes.GardenScheme}),
+				kubernetes.WithDisabledCachedClient(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Prepare kubeconfig for the garden cluster")
+			kubeconfig, err := gardenKubeconfig(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func(ctx SpecContext) {
+				Expect(client.IgnoreNotFound(RuntimeClient.Client().Delete(ctx, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: gardenAccessName}}))).To(Succeed())
+			}, NodeTimeout(time.Minute))
+
+			By("Prepare config directory containing the Gardenlet")
+			_, _, err = execute(ctx, 0, "sh", "-c", strings.Join([]string{
+				"mkdir -p " + connectConfigDir,
+				"cp " + configDir + "/* " + connectConfigDir,
+				writeFileCommand(connectConfigDir+"/gardenlet.yaml", []byte(gardenletManifest)),
+				writeFileCommand(gardenKubeconfigPath, kubeconfig),
+			}, " && "))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Connect autonomous shoot cluster to garden cluster")
+			stdOut, _, err := execute(ctx, 0,
+				"gardenadm", "connect",
+				"--kubeconfig", gardenKubeconfigPath,
+				"--config-dir", connectConfigDir,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdOut).To(gbytes.Say("Your autonomous shoot cluster has been connected to the garden cluster successfully!"))
+
+			By("Ensure gardenlet is running in the autonomous shoot cluster")
+			stdOut, _, err = execute(ctx, 0,
+				"kubectl", "--kubeconfig", botanist.PathAdminKubeconfig, "-n", "garden", "get", "deployments", "-o", "name",
 			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdOut).To(gbytes.Say("deployment.apps/gardenlet"))
 
-			Eventually(ctx, stdOut).Should(gbytes.Say("not implemented either"))
-		}, SpecTimeout(time.Minute))
+			By("Ensure Seed is registered and Shoot is not created in the garden cluster")
+			Expect(gardenClient.Client().Get(ctx, client.ObjectKey{Name: seedName}, &gardencorev1beta1.Seed{})).To(Succeed())
+			Expect(gardenClient.Client().Get(ctx, client.ObjectKey{Name: "root", Namespace: "garden"}, &gardencorev1beta1.Shoot{})).To(BeNotFoundError())
+		}, SpecTimeout(15*time.Minute))
 	})
 })
 
-// nolint:unparam
-func execute(ctx context.Context, ordinal int, command ...string) (*gbytes.Buffer, *gbytes.Buffer) {
+func execute(ctx context.Context, ordinal int, command ...string) (*gbytes.Buffer, *gbytes.Buffer, error) {
 	GinkgoHelper()
 	var stdOutBuffer, stdErrBuffer = gbytes.NewBuffer(), gbytes.NewBuffer()
 
-	Expect(RuntimeClient.PodExecutor().ExecuteWithStreams(
+	err := RuntimeClient.PodExecutor().ExecuteWithStreams(
 		ctx,
 		namespace,
 		machinePodName(ordinal),
@@ -76,7 +201,7 @@ func execute(ctx context.Context, ordinal int, command ...string) (*gbytes.Buffe
 		io.MultiWriter(stdOutBuffer, gexec.NewPrefixedWriter("[out] ", GinkgoWriter)),
 		io.MultiWriter(stdErrBuffer, gexec.NewPrefixedWriter("[err] ", GinkgoWriter)),
 		command...,
-	)).To(Succeed())
+	)
 
-	return stdOutBuffer, stdErrBuffer
+	return stdOutBuffer, stdErrBuffer, err
 }
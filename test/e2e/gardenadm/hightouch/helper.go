@@ -5,14 +5,125 @@
 package hightouch
 
 import (
+	"context"
+	"encoding/base64"
+	"fmt"
 	"strconv"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener/pkg/controllerutils"
+	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
+	. "github.com/gardener/gardener/test/e2e/gardenadm/common"
 )
 
 const (
 	namespace       = "gardenadm-high-touch"
 	statefulSetName = "machine"
+	// configDir is the directory on the machines containing the Gardener configuration resources, see the 'machine'
+	// config in skaffold-gardenadm.yaml.
+	configDir = "/gardenadm/resources"
+
+	// connectConfigDir is the directory on the machines containing the Gardener configuration resources extended by
+	// the Gardenlet for `gardenadm connect`.
+	connectConfigDir = "/tmp/gardenadm-connect"
+	// gardenKubeconfigPath is the path of the kubeconfig for the garden cluster on the machines.
+	gardenKubeconfigPath = "/tmp/garden-kubeconfig"
+	// gardenAccessName is the name of the ServiceAccount and ClusterRoleBinding used by `gardenadm connect` for
+	// accessing the garden cluster.
+	gardenAccessName = "gardenadm-connect"
+
+	// seedName is the name of the Seed registered by the gardenlet deployed with `gardenadm connect`.
+	seedName = "root"
+	// gardenletManifest is the Gardenlet used for `gardenadm connect`. The Seed is not visible for scheduling since
+	// the autonomous shoot cluster is not meant to host control planes of other shoots in the e2e tests.
+	gardenletManifest = `apiVersion: seedmanagement.gardener.cloud/v1alpha1
+kind: Gardenlet
+metadata:
+  name: ` + seedName + `
+  namespace: garden
+spec:
+  config:
+    apiVersion: gardenlet.config.gardener.cloud/v1alpha1
+    kind: GardenletConfiguration
+    seedConfig:
+      spec:
+        provider:
+          type: local
+          region: local
+        networks:
+          nodes: 10.10.0.0/16
+          pods: 100.96.0.0/11
+          services: 100.64.0.0/13
+        settings:
+          scheduling:
+            visible: false
+          verticalPodAutoscaler:
+            enabled: false
+`
 )
 
 func machinePodName(ordinal int) string {
 	return statefulSetName + "-" + strconv.Itoa(ordinal)
 }
+
+// gardenKubeconfig returns a kubeconfig for the garden cluster which can be used from within the machine pods. In the
+// local setup, the garden cluster is the runtime cluster, hence it is reachable via the cluster IP of the 'kubernetes'
+// service. The kubeconfig uses a token of a ServiceAccount bound to the 'cluster-admin' ClusterRole.
+func gardenKubeconfig(ctx context.Context) ([]byte, error) {
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: gardenAccessName, Namespace: namespace}}
+	if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, RuntimeClient.Client(), serviceAccount, func() error {
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: gardenAccessName}}
+	if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, RuntimeClient.Client(), clusterRoleBinding, func() error {
+		clusterRoleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "cluster-admin",
+		}
+		clusterRoleBinding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      serviceAccount.Name,
+			Namespace: serviceAccount.Namespace,
+		}}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	tokenRequest := &authenticationv1.TokenRequest{Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: ptr.To[int64](3600)}}
+	if err := RuntimeClient.Client().SubResource("token").Create(ctx, serviceAccount, tokenRequest); err != nil {
+		return nil, err
+	}
+
+	service := &corev1.Service{}
+	if err := RuntimeClient.Client().Get(ctx, client.ObjectKey{Name: "kubernetes", Namespace: metav1.NamespaceDefault}, service); err != nil {
+		return nil, err
+	}
+
+	return runtime.Encode(clientcmdlatest.Codec, kubernetesutils.NewKubeconfig(
+		"garden",
+		clientcmdv1.Cluster{
+			Server:                   service.Spec.ClusterIP,
+			CertificateAuthorityData: RuntimeClient.RESTConfig().CAData,
+		},
+		clientcmdv1.AuthInfo{Token: tokenRequest.Status.Token},
+	))
+}
+
+// writeFileCommand returns a shell command writing the given content to the given path on the machines.
+func writeFileCommand(path string, content []byte) string {
+	return fmt.Sprintf("echo %s | base64 -d > %s", base64.StdEncoding.EncodeToString(content), path)
+}
@@ -5,11 +5,22 @@
 package mediumtouch
 
 import (
+	"os"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+const (
+	// configDir is the directory containing the Gardener configuration resources rendered by
+	// 'make gardenadm-medium-touch-up'.
+	configDir = "../../../example/gardenadm-local/medium-touch"
+	// skaffoldImageFile is the file containing the reference of the gardenadm image built by skaffold.
+	skaffoldImageFile = "../../../example/gardenadm-local/.skaffold-image"
 )
 
 var _ = Describe("gardenadm medium-touch scenario tests", Label("gardenadm", "medium-touch"), func() {
@@ -18,8 +29,22 @@ var _ = Describe("gardenadm medium-touch scenario tests", Label("gardenadm", "me
 	}, NodeTimeout(time.Minute))
 
 	Describe("Prepare infrastructure and machines", Ordered, func() {
-		It("should bootstrap the machine pods", func(SpecContext) {
-			Eventually(RunAndWait("bootstrap")).Should(gbytes.Say("not implemented as well"))
-		}, SpecTimeout(time.Minute))
+		It("should bootstrap the machine pods", func(ctx SpecContext) {
+			gardenadmImage, err := os.ReadFile(skaffoldImageFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			// The KinD cluster of the local setup serves as bootstrap cluster since its nodes are configured to pull the
+			// images built by skaffold from the local registry.
+			session := Run("bootstrap",
+				"--kubeconfig", os.Getenv("KUBECONFIG"),
+				"--config-dir", configDir,
+				"--gardenadm-image", strings.TrimSpace(string(gardenadmImage)),
+			)
+
+			Eventually(ctx, session.Err).Should(gbytes.Say("Deploying extension controllers"))
+			Eventually(ctx, session.Err).Should(gbytes.Say("Waiting until machines have been created"))
+			Eventually(ctx, session).Should(gexec.Exit(0))
+			Expect(session.Out).To(gbytes.Say("Your autonomous shoot cluster has been bootstrapped successfully!"))
+		}, SpecTimeout(15*time.Minute))
 	})
 })
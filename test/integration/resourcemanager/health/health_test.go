@@ -8,6 +8,7 @@ import (
 	certv1alpha1 "github.com/gardener/cert-management/pkg/apis/cert/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -244,6 +245,43 @@ var _ = Describe("Health controller tests", func() {
 			)
 		})
 
+		It("reports the health of every resource", func() {
+			By("Add resources to ManagedResource status")
+			patch := client.MergeFrom(managedResource.DeepCopy())
+			managedResource.Status.Resources = []resourcesv1alpha1.ObjectReference{
+				{
+					ObjectReference: corev1.ObjectReference{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Namespace:  testNamespace.Name,
+						Name:       "non-existing-1",
+					},
+				},
+				{
+					ObjectReference: corev1.ObjectReference{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Namespace:  testNamespace.Name,
+						Name:       "non-existing-2",
+					},
+				},
+			}
+			Expect(testClient.Status().Patch(ctx, managedResource, patch)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+				g.Expect(managedResource.Status.Conditions).To(ContainCondition(OfType(resourcesv1alpha1.ResourcesHealthy), WithStatus(gardencorev1beta1.ConditionFalse), WithReason("ConfigMapMissing"),
+					WithMessageSubstrings("1 more resources are unhealthy")))
+				g.Expect(managedResource.Status.HealthSummary).To(Equal("0/2 healthy"))
+				for _, ref := range managedResource.Status.Resources {
+					g.Expect(ref.Healthy).NotTo(BeNil())
+					g.Expect(ref.Healthy.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+					g.Expect(ref.Healthy.Reason).To(Equal("ConfigMapMissing"))
+					g.Expect(ref.Healthy.Message).To(ContainSubstring(ref.Name))
+				}
+			}).Should(Succeed())
+		})
+
 		Context("with existing resource", func() {
 			var pod *corev1.Pod
 
@@ -517,6 +555,15 @@ var _ = Describe("Health controller tests", func() {
 				}).Should(
 					ContainCondition(OfType(resourcesv1alpha1.ResourcesProgressing), WithStatus(gardencorev1beta1.ConditionTrue), WithReason("DeploymentProgressing")),
 				)
+
+				Expect(managedResource.Status.Resources[0].Progressing).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Status": Equal(gardencorev1beta1.ConditionTrue),
+					"Reason": Equal("DeploymentProgressing"),
+				})))
+				Expect(managedResource.Status.Resources[1].Progressing).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Status": Equal(gardencorev1beta1.ConditionFalse),
+				})))
+				Expect(managedResource.Status.HealthSummary).To(ContainSubstring("1 progressing"))
 			})
 
 			It("sets Progressing to true as Deployment still has non-terminated pods", func() {
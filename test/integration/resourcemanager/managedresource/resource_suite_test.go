@@ -33,6 +33,7 @@ import (
 	resourcemanagerclient "github.com/gardener/gardener/pkg/resourcemanager/client"
 	"github.com/gardener/gardener/pkg/resourcemanager/controller/managedresource"
 	resourcemanagerpredicate "github.com/gardener/gardener/pkg/resourcemanager/predicate"
+	fakeoci "github.com/gardener/gardener/pkg/utils/oci/fake"
 	. "github.com/gardener/gardener/pkg/utils/test/matchers"
 )
 
@@ -54,7 +55,8 @@ var (
 
 	testNamespace *corev1.Namespace
 
-	filter *resourcemanagerpredicate.ClassFilter
+	filter   *resourcemanagerpredicate.ClassFilter
+	registry *fakeoci.Registry
 )
 
 var _ = BeforeSuite(func() {
@@ -116,6 +118,7 @@ var _ = BeforeSuite(func() {
 	By("Register controller")
 	fakeClock = testclock.NewFakeClock(time.Now())
 	filter = resourcemanagerpredicate.NewClassFilter(resourcemanagerconfigv1alpha1.DefaultResourceClass)
+	registry = fakeoci.NewRegistry()
 
 	Expect((&managedresource.Reconciler{
 		Config: config.ManagedResourceControllerConfig{
@@ -125,10 +128,13 @@ var _ = BeforeSuite(func() {
 			SyncPeriod:          &metav1.Duration{Duration: time.Minute},
 			ManagedByLabelValue: ptr.To("gardener"),
 		},
-		Clock:                         fakeClock,
-		ClassFilter:                   filter,
-		RequeueAfterOnDeletionPending: ptr.To(50 * time.Millisecond),
-		GarbageCollectorActivated:     true,
+		Clock:                           fakeClock,
+		ClassFilter:                     filter,
+		RequeueAfterOnDeletionPending:   ptr.To(50 * time.Millisecond),
+		RequeueAfterOnApplyPhasePending: ptr.To(50 * time.Millisecond),
+		GarbageCollectorActivated:       true,
+		Registry:                        registry,
+		SourceNamespaces:                []string{testNamespace.Name},
 	}).AddToManager(mgr, mgr, mgr)).To(Succeed())
 
 	By("Start manager")
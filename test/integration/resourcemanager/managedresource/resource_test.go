@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	gardencorev1 "github.com/gardener/gardener/pkg/apis/core/v1"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
@@ -162,6 +163,47 @@ var _ = Describe("ManagedResource controller tests", func() {
 					test()
 				})
 			})
+
+			Context("with data from a config map", func() {
+				BeforeEach(func() {
+					configMapForManagedResource := &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      resourceName + "-manifests",
+							Namespace: testNamespace.Name,
+							Labels:    map[string]string{resourcesv1alpha1.ManagedResourceSource: "true"},
+						},
+						Data: map[string]string{dataKey: string(jsonDataForObject(configMap))},
+					}
+					Expect(testClient.Create(ctx, configMapForManagedResource)).To(Succeed())
+					DeferCleanup(func() {
+						Expect(testClient.Delete(ctx, configMapForManagedResource)).To(Or(Succeed(), BeNotFoundError()))
+					})
+
+					managedResource.Spec.SecretRefs = nil
+					managedResource.Spec.ConfigMapRefs = []corev1.LocalObjectReference{{Name: configMapForManagedResource.Name}}
+				})
+
+				It("should successfully create the resources and maintain proper status conditions", func() {
+					test()
+				})
+			})
+
+			Context("with data from an OCI artifact", func() {
+				BeforeEach(func() {
+					artifact := resourcesv1alpha1.OCIArtifactReference{
+						Repository: "example.com/manifests/" + resourceName,
+						Digest:     "sha256:" + utils.ComputeSHA256Hex([]byte(resourceName)),
+					}
+					registry.AddArtifact(&gardencorev1.OCIRepository{Repository: &artifact.Repository, Digest: &artifact.Digest}, jsonDataForObject(configMap))
+
+					managedResource.Spec.SecretRefs = nil
+					managedResource.Spec.OCIArtifacts = []resourcesv1alpha1.OCIArtifactReference{artifact}
+				})
+
+				It("should successfully create the resources and maintain proper status conditions", func() {
+					test()
+				})
+			})
 		})
 
 		Context("missing secret", func() {
@@ -744,6 +786,187 @@ var _ = Describe("ManagedResource controller tests", func() {
 			})
 		})
 
+		Describe("Server-Side Apply Mode", func() {
+			var triggerReconciliation func()
+
+			BeforeEach(func() {
+				configMap.SetAnnotations(map[string]string{resourcesv1alpha1.Mode: resourcesv1alpha1.ModeServerSideApply})
+				secretForManagedResource.Data = secretDataForObject(configMap, dataKey)
+
+				triggerReconciliation = func() {
+					patch := client.MergeFrom(managedResource.DeepCopy())
+					metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, "gardener.cloud/operation", "reconcile")
+					Expect(testClient.Patch(ctx, managedResource, patch)).To(Succeed())
+				}
+			})
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) []gardencorev1beta1.Condition {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					return managedResource.Status.Conditions
+				}).Should(
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesApplied), WithStatus(gardencorev1beta1.ConditionTrue), WithReason(resourcesv1alpha1.ConditionApplySucceeded)),
+				)
+			})
+
+			It("should apply the resource with server-side apply and keep fields owned by other managers", func() {
+				cm := &corev1.ConfigMap{}
+				Expect(testClient.Get(ctx, client.ObjectKeyFromObject(configMap), cm)).To(Succeed())
+				Expect(cm.ManagedFields).To(ContainElement(And(
+					HaveField("Manager", "gardener-resource-manager"),
+					HaveField("Operation", metav1.ManagedFieldsOperationApply),
+				)))
+
+				patch := client.MergeFrom(cm.DeepCopy())
+				cm.Data["foo"] = "bar"
+				Expect(testClient.Patch(ctx, cm, patch)).To(Succeed())
+
+				triggerReconciliation()
+
+				Consistently(func(g Gomega) map[string]string {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+					return cm.Data
+				}).Should(And(HaveKeyWithValue("abc", "xyz"), HaveKeyWithValue("foo", "bar")))
+			})
+
+			It("should report conflicts with fields owned by other managers", func() {
+				cm := &corev1.ConfigMap{}
+				Expect(testClient.Get(ctx, client.ObjectKeyFromObject(configMap), cm)).To(Succeed())
+
+				patch := client.MergeFrom(cm.DeepCopy())
+				cm.Data["abc"] = "changed"
+				Expect(testClient.Patch(ctx, cm, patch)).To(Succeed())
+
+				triggerReconciliation()
+
+				Eventually(func(g Gomega) []gardencorev1beta1.Condition {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					return managedResource.Status.Conditions
+				}).Should(
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesApplied), WithStatus(gardencorev1beta1.ConditionFalse), WithReason(resourcesv1alpha1.ConditionApplyConflict), WithMessageSubstrings(".data.abc")),
+				)
+
+				Expect(testClient.Get(ctx, client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+				Expect(cm.Data).To(HaveKeyWithValue("abc", "changed"))
+			})
+		})
+
+		Describe("Preview", func() {
+			BeforeEach(func() {
+				metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, resourcesv1alpha1.Preview, "true")
+			})
+
+			It("should write the changes to the status without applying the resources", func() {
+				Eventually(func(g Gomega) *resourcesv1alpha1.ManagedResourcePreview {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					return managedResource.Status.Preview
+				}).Should(And(
+					HaveField("Summary", "1 to create, 0 to update, 0 to delete, 0 unchanged"),
+					HaveField("Changes", ConsistOf(resourcesv1alpha1.ObjectChange{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Namespace:  configMap.Namespace,
+						Name:       configMap.Name,
+						Operation:  resourcesv1alpha1.ObjectChangeOperationCreate,
+					})),
+				))
+
+				Consistently(func() error {
+					return testClient.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})
+				}).Should(BeNotFoundError())
+
+				By("Remove preview annotation")
+				patch := client.MergeFrom(managedResource.DeepCopy())
+				delete(managedResource.Annotations, resourcesv1alpha1.Preview)
+				Expect(testClient.Patch(ctx, managedResource, patch)).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})).To(Succeed())
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					g.Expect(managedResource.Status.Preview).To(BeNil())
+				}).Should(Succeed())
+			})
+
+			It("should report the changed fields of updated resources", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					g.Expect(managedResource.Status.Preview).NotTo(BeNil())
+				}).Should(Succeed())
+
+				Expect(testClient.Create(ctx, configMap.DeepCopy())).To(Succeed())
+
+				patch := client.MergeFrom(managedResource.DeepCopy())
+				metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, "gardener.cloud/operation", "reconcile")
+				Expect(testClient.Patch(ctx, managedResource, patch)).To(Succeed())
+
+				Eventually(func(g Gomega) []resourcesv1alpha1.ObjectChange {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					return managedResource.Status.Preview.Changes
+				}).Should(ConsistOf(And(
+					HaveField("Operation", resourcesv1alpha1.ObjectChangeOperationUpdate),
+					HaveField("Fields", ContainElements(".metadata.annotations", ".metadata.labels")),
+				)))
+
+				cm := &corev1.ConfigMap{}
+				Expect(testClient.Get(ctx, client.ObjectKeyFromObject(configMap), cm)).To(Succeed())
+				Expect(cm.Labels).To(BeEmpty())
+
+				// The ConfigMap is not part of the status of the ManagedResource, hence it is not deleted together with it.
+				Expect(testClient.Delete(ctx, cm)).To(Succeed())
+			})
+		})
+
+		Describe("Apply Phases", func() {
+			var service *corev1.Service
+
+			BeforeEach(func() {
+				service = &corev1.Service{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: corev1.SchemeGroupVersion.String(),
+						Kind:       "Service",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: testNamespace.Name,
+					},
+					Spec: corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeLoadBalancer,
+						Ports: []corev1.ServicePort{{Name: "foo", Port: 1234}},
+					},
+				}
+
+				configMap.SetAnnotations(map[string]string{resourcesv1alpha1.ApplyPhase: "1"})
+				secretForManagedResource.Data = secretDataForObject(configMap, dataKey)
+				secretForManagedResource.Data["service.yaml"] = jsonDataForObject(service)
+			})
+
+			It("should apply the next phase only after the previous phase is ready", func() {
+				Eventually(func(g Gomega) []gardencorev1beta1.Condition {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					return managedResource.Status.Conditions
+				}).Should(
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesApplied), WithStatus(gardencorev1beta1.ConditionProgressing), WithReason(resourcesv1alpha1.ConditionApplyPhasePending), WithMessageSubstrings("waiting for apply phase 0 to become ready before applying phase 1")),
+				)
+
+				Expect(testClient.Get(ctx, client.ObjectKeyFromObject(service), service)).To(Succeed())
+				Expect(testClient.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})).To(BeNotFoundError())
+
+				By("Set ingress status of Service")
+				patch := client.MergeFrom(service.DeepCopy())
+				service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}
+				Expect(testClient.Status().Patch(ctx, service, patch)).To(Succeed())
+
+				Eventually(func(g Gomega) []gardencorev1beta1.Condition {
+					g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+					return managedResource.Status.Conditions
+				}).Should(
+					ContainCondition(OfType(resourcesv1alpha1.ResourcesApplied), WithStatus(gardencorev1beta1.ConditionTrue), WithReason(resourcesv1alpha1.ConditionApplySucceeded)),
+				)
+
+				Expect(testClient.Get(ctx, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})).To(Succeed())
+			})
+		})
+
 		Describe("Ensure resources.gardener.cloud/managed-by label", func() {
 			var (
 				defaultPodTemplateSpec *corev1.PodTemplateSpec